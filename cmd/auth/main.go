@@ -12,6 +12,7 @@ import (
 
 	proto "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/auth/delivery/grpc/proto"
 	serv "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/auth/delivery/grpc/server"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/auth/limiter"
 	rep "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/auth/repository/postgres"
 	pkgDb "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/db"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/middleware"
@@ -58,7 +59,8 @@ func main() {
 		os.Exit(1)
 	}
 
-	authRepo := rep.NewRepository(db, rdb, ctx, logger)
+	loginLimiter := limiter.NewLimiter(rdb, ctx, logger, limiter.ParamsFromConfig())
+	authRepo := rep.NewRepository(db, rdb, ctx, logger, loginLimiter)
 	authServ := serv.NewAuthServer(authRepo)
 
 	// setting up metrics
@@ -68,6 +70,11 @@ func main() {
 		logger.Error("Failed to setup metrics", zap.Error(err))
 		os.Exit(1)
 	}
+	err = loginLimiter.SetupMetrics()
+	if err != nil {
+		logger.Error("Failed to setup login limiter metrics", zap.Error(err))
+		os.Exit(1)
+	}
 	mw := middleware.NewGRPCMetricsMiddleware(ms)
 
 	// registration in consul
@@ -1,20 +1,27 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	proto "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/proto"
-	rep "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server/repository/s3"
+	images "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server"
+	delHTTP "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server/delivery/http"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server/repository/fs"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server/repository/s3"
 	serv "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server/service/grpc"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/middleware"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/config"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/consul"
 	zaplogger "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/log/zap"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/metrics"
+	"github.com/julienschmidt/httprouter"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -41,11 +48,31 @@ func main() {
 	}
 
 	// creating image service
-	bucket, err := rep.NewS3Repository(logger)
+	var storage images.Repository
+	var signer *images.URLSigner
+	backend := viper.GetString(config.ImageStorageConfig.Backend)
+	switch backend {
+	case images.S3Storage:
+		storage, err = s3.NewS3Repository(logger)
+	case images.FSStorage:
+		signer, err = images.NewURLSigner(viper.GetString(config.ImageStorageConfig.SigningKey))
+		if err != nil {
+			logger.Error("Failed to create image url signer", zap.Error(err),
+				zap.String("env", config.ImageStorageConfig.SigningKey))
+			os.Exit(1)
+		}
+		storage, err = fs.NewFSRepository(logger, signer)
+	default:
+		logger.Error("Unknown image storage backend", zap.String("backend", backend))
+		os.Exit(1)
+	}
 	if err != nil {
 		os.Exit(1)
 	}
-	imagesServ := serv.NewS3Service(bucket)
+	storage = images.NewContentRepository(storage, logger)
+	frames := images.NewFFmpeg(viper.GetString(config.FFmpegConfig.Path))
+	imagesServ := serv.NewS3Service(storage, images.LimitsFromConfig(),
+		viper.GetDuration(config.ImageStorageConfig.SignedURLTTL), frames)
 
 	// setting up metrics
 	ms := metrics.NewPrometheusMetrics("images")
@@ -88,6 +115,26 @@ func main() {
 		os.Exit(1)
 	}
 
+	// serving stored files, S3 serves them by itself
+	var httpServer *http.Server
+	if backend == images.FSStorage {
+		mux := httprouter.New()
+		delHTTP.RegisterHandlers(mux, logger, viper.GetString(config.ImageStorageConfig.Root), signer,
+			middleware.NewHttpMetricsMiddleware(ms))
+		httpServer = &http.Server{
+			Addr:    viper.GetString(config.HttpConfig.Addr),
+			Handler: mux,
+		}
+
+		logger.Info("Starting images http server...")
+		go func() {
+			err := httpServer.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
+				logger.Error("Failed to start images http server", zap.Error(err))
+			}
+		}()
+	}
+
 	// starting server
 	go metrics.ServePrometheusHTTP(viper.GetString(config.MetricsConfig.Addr))
 
@@ -105,4 +152,12 @@ func main() {
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 	<-stop
 	server.GracefulStop()
+	if httpServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		err = httpServer.Shutdown(ctx)
+		if err != nil {
+			logger.Error("Failed to shutdown images http server", zap.Error(err))
+		}
+	}
 }
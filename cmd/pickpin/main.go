@@ -33,6 +33,8 @@ import (
 	boardsService "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/boards/service"
 
 	imagesService "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/client"
+	imagesGC "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/gc"
+	imagesGCRepository "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/gc/repository/postgres"
 
 	usersDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/users/delivery/http"
 	usersRepository "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/users/repository/postgres"
@@ -57,6 +59,10 @@ import (
 	commentsRepository "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/comments/repository/postgres"
 	commentsService "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/comments/service"
 
+	accessTokensDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/accesstokens/delivery/http"
+	accessTokensRepository "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/accesstokens/repository/postgres"
+	accessTokensService "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/accesstokens/service"
+
 	pkgDb "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/db"
 	shortenerService "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/shortener/delivery/grpc/client"
 	shortenerDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/shortener/delivery/http"
@@ -103,7 +109,7 @@ func main() {
 	if err != nil {
 		os.Exit(1)
 	}
-	imagesServ := imagesService.NewImageUploaderClient(imagesConn)
+	imagesClient := imagesService.NewImageUploaderClient(imagesConn)
 
 	authConn, err := resolvers.NewGRPCConnWithResolver(ctx, cnsl, "auth", logger)
 	if err != nil {
@@ -140,6 +146,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	imagesGCRepo := imagesGCRepository.NewRepository(db, logger)
+	imagesServ := imagesGC.NewTrackingClient(imagesClient, imagesGCRepo, logger)
+	imagesCollector := imagesGC.NewCollector(imagesGCRepo, imagesClient,
+		viper.GetDuration(config.ImageGCConfig.GracePeriod), viper.GetBool(config.ImageGCConfig.DryRun), logger)
+	imagesGC.StartCollector(ctx, imagesCollector, viper.GetDuration(config.ImageGCConfig.Interval), logger)
+
 	notificationsRepo := notificationsRepository.NewRepository(db, logger)
 	notificationsServ := notificationsService.NewService(notificationsRepo, logger)
 
@@ -148,15 +160,20 @@ func main() {
 
 	pinsRepo := pinsRepository.NewRepository(db, imagesServ, logger)
 	pinsServ := pinsService.NewService(pinsRepo, notificationsServ, followingsRepo)
+	pinsService.StartMediaPrivacyBackfill(pinsServ, logger)
 
 	likesRepo := likesRepository.NewRepository(db, logger)
 	likesServ := likesService.NewService(likesRepo, notificationsServ, pinsRepo, logger)
 
 	token := tokens.NewHMACHashToken(viper.GetString(config.CSRFConfig.Token))
 	CSRFMiddleware := middleware.NewCSRFMiddleware(token, logger)
-	authorizer := middleware.NewAuthorizer(authServ, logger)
+	accessTokensRepo := accessTokensRepository.NewRepository(db, logger)
+	accessTokensServ := accessTokensService.NewService(accessTokensRepo)
+
+	authorizer := middleware.NewAuthorizer(authServ, accessTokensServ, logger)
 
-	searchServ := searchService.NewSearchClient(searchConn, pinsServ)
+	searchServ := searchService.NewSearchClient(searchConn, pinsServ,
+		viper.GetDuration(config.SearchSuggestConfig.Timeout))
 
 	boardsRepo := boardsRepository.NewPostgresRepository(db, logger)
 	boardsServ := boardsService.NewBoardsService(boardsRepo, pinsServ)
@@ -166,7 +183,9 @@ func main() {
 	usersServ := usersService.NewService(usersRepo)
 
 	profileRepo := profileRepository.NewPostgresRepository(db, imagesServ, logger)
-	profileServ := profileService.NewProfileService(profileRepo)
+	profileServ := profileService.NewProfileService(profileRepo, authServ,
+		viper.GetDuration(config.AccountDeletionConfig.GracePeriod))
+	profileService.StartPurger(ctx, profileServ, viper.GetDuration(config.AccountDeletionConfig.PurgeInterval), logger)
 
 	chatsRepo := chatsRepository.NewRepository(db, logger)
 	chatsServ := chatsService.NewService(chatsRepo)
@@ -185,8 +204,9 @@ func main() {
 	pinsDelivery.RegisterHandlers(mux, logger, authorizer, CSRFMiddleware, middleware.NewAccessChecker(pinsServ), pinsServ, metricsMiddleware)
 	chatsDelivery.RegisterHandlers(mux, logger, authorizer, CSRFMiddleware, chatsServ)
 	commentsDelivery.RegisterHandlers(mux, logger, authorizer, CSRFMiddleware, commentsServ, metricsMiddleware)
+	accessTokensDelivery.RegisterHandlers(mux, logger, authorizer, CSRFMiddleware, accessTokensServ, metricsMiddleware)
 	ping.RegisterHandlers(mux, logger)
-	searchDelivery.RegisterHandlers(mux, logger, authorizer, searchServ)
+	searchDelivery.RegisterHandlers(mux, logger, authorizer, CSRFMiddleware, searchServ)
 	shortenerDelivery.RegisterPostHandler(mux, logger, authorizer, CSRFMiddleware, shortServ, metricsMiddleware)
 	notificationsDelivery.RegisterHandlers(mux, logger, authorizer, CSRFMiddleware, notificationsServ,
 		metricsMiddleware)
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+
+	accessTokensDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/accesstokens/delivery/http"
+	authDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/auth/delivery/http"
+	boardsDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/boards/delivery/http"
+	chatsDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/chats/delivery/http"
+	commentsDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/comments/delivery/http"
+	followingsDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/followings/delivery/http"
+	likesDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/likes/delivery/http"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/middleware"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	notificationsDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/notifications/delivery/http"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/ping"
+	pinsDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pins/delivery/http"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/metrics"
+	profileDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/profile/delivery/http"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/router"
+	searchDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/delivery/http"
+	shortenerDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/shortener/delivery/http"
+	usersDelivery "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/users/delivery/http"
+)
+
+// routeScopes are the access token scopes of all authorized routes, empty for the routes
+// that are available with session cookies only.
+var routeScopes = map[string]string{
+	"GET /users/1": "",
+
+	"POST /pins":               models.ScopePinsWrite,
+	"GET /users/1/pins":        models.ScopePinsRead,
+	"PUT /pins/1":              models.ScopePinsWrite,
+	"DELETE /pins/1":           models.ScopePinsWrite,
+	"PUT /pins/1/promotion":    "",
+	"GET /pins/1/analytics":    "",
+	"POST /pins/1/like":        models.ScopePinsWrite,
+	"DELETE /pins/1/like":      models.ScopePinsWrite,
+	"GET /pins/1/likes":        models.ScopePinsRead,
+	"GET /users/1/likes":       "",
+	"POST /pins/1/comments":    models.ScopePinsWrite,
+	"GET /pins/1/comments":     models.ScopePinsRead,
+	"POST /share/pin/1":        "",
+	"GET /share/stats/abcdefg": "",
+
+	"POST /boards":            models.ScopeBoards,
+	"GET /boards":             models.ScopeBoards,
+	"GET /boards/1":           models.ScopeBoards,
+	"PUT /boards/1":           models.ScopeBoards,
+	"PATCH /boards/1":         models.ScopeBoards,
+	"DELETE /boards/1":        models.ScopeBoards,
+	"POST /boards/1/pins/2":   models.ScopeBoards,
+	"GET /boards/1/pins":      models.ScopeBoards,
+	"DELETE /boards/1/pins/2": models.ScopeBoards,
+
+	"GET /chats":            models.ScopeChats,
+	"GET /chats/1":          models.ScopeChats,
+	"GET /chats/1/messages": models.ScopeChats,
+	"GET /messages":         models.ScopeChats,
+	"GET /chat":             models.ScopeChats,
+
+	"POST /users/1/following":   "",
+	"DELETE /users/1/following": "",
+	"GET /users/1/followers":    "",
+	"GET /users/1/followees":    "",
+
+	"GET /users/1/profile":          "",
+	"PUT /profile":                  "",
+	"PATCH /profile":                "",
+	"DELETE /profile":               "",
+	"GET /profile/export":           "",
+	"GET /profile/business":         "",
+	"POST /profile/business":        "",
+	"PUT /profile/business":         "",
+	"POST /profile/business/verify": "",
+	"POST /tokens":                  "",
+	"GET /tokens":                   "",
+	"DELETE /tokens/1":              "",
+	"GET /search":                   "",
+	"GET /search/fox":               "",
+	"DELETE /search/recent":         "",
+	"GET /notifications":            "",
+	"GET /ws/notifications":         "",
+}
+
+func TestRouteScopes(t *testing.T) {
+	// the authorizer records the scopes of the requests instead of authorizing them,
+	// the handlers are never reached
+	var registered int
+	var scope string
+	var authorized bool
+	authorizer := middleware.Authorizer(func(h router.Handler) router.Handler {
+		registered++
+		return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+			authorized = true
+			scope = middleware.RequiredScope(r)
+			return nil
+		}
+	})
+	csrf := middleware.CSRFMiddleware(func(h router.Handler) router.Handler { return h })
+	m := middleware.NewHttpMetricsMiddleware(metrics.NewPrometheusMetrics("test"))
+	logger := zap.NewNop()
+
+	mux := httprouter.New()
+	authDelivery.RegisterHandlers(mux, logger, nil, nil, m)
+	likesDelivery.RegisterHandlers(mux, logger, authorizer, csrf, nil, m)
+	usersDelivery.RegisterHandlers(mux, logger, authorizer, csrf, nil, m)
+	profileDelivery.RegisterHandlers(mux, logger, authorizer, csrf, nil, m)
+	followingsDelivery.RegisterHandlers(mux, logger, authorizer, csrf, nil, m)
+	boardsDelivery.RegisterHandlers(mux, logger, authorizer, csrf, middleware.NewAccessChecker(nil), nil, m)
+	pinsDelivery.RegisterHandlers(mux, logger, authorizer, csrf, middleware.NewAccessChecker(nil), nil, m)
+	chatsDelivery.RegisterHandlers(mux, logger, authorizer, csrf, nil)
+	commentsDelivery.RegisterHandlers(mux, logger, authorizer, csrf, nil, m)
+	accessTokensDelivery.RegisterHandlers(mux, logger, authorizer, csrf, nil, m)
+	ping.RegisterHandlers(mux, logger)
+	searchDelivery.RegisterHandlers(mux, logger, authorizer, csrf, nil)
+	shortenerDelivery.RegisterPostHandler(mux, logger, authorizer, csrf, nil, m)
+	notificationsDelivery.RegisterHandlers(mux, logger, authorizer, csrf, nil, m)
+
+	if registered != len(routeScopes) {
+		t.Fatalf("%d authorized routes are registered, %d are listed in routeScopes: list the scopes of new routes",
+			registered, len(routeScopes))
+	}
+
+	for route, expected := range routeScopes {
+		method, path, _ := strings.Cut(route, " ")
+
+		scope, authorized = "", false
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, path, nil))
+		if !authorized {
+			t.Errorf("%s is not authorized", route)
+			continue
+		}
+		if scope != expected {
+			t.Errorf("%s requires scope %q, expected %q", route, scope, expected)
+		}
+	}
+}
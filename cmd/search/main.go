@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 
 	pkgDb "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/db"
@@ -15,8 +17,10 @@ import (
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/consul"
 	zaplogger "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/log/zap"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/metrics"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
 	proto "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/delivery/grpc/proto"
 	serv "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/delivery/grpc/server"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/repository/index"
 	rep "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/repository/postgres"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
@@ -48,8 +52,49 @@ func main() {
 		os.Exit(1)
 	}
 
-	searchRepo := rep.NewRepository(db, logger)
-	searchServ := serv.NewSearchServer(searchRepo)
+	historyParams, err := search.HistoryParamsFromConfig()
+	if err != nil {
+		logger.Error("Invalid search history configuration", zap.Error(err))
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var searchRepo search.Repository
+	backend := viper.GetString(config.SearchIndexConfig.Backend)
+	switch backend {
+	case search.PostgresBackend:
+		searchRepo = rep.NewRepository(db, logger)
+		rep.StartWordsRefresher(ctx, db, viper.GetDuration(config.SearchIndexConfig.WordsRefreshInterval), logger)
+	case search.IndexBackend:
+		searchIndex := index.NewIndex()
+		listener := pq.NewListener(pkgDb.ConnString(),
+			viper.GetDuration(config.SearchIndexConfig.MinReconnectInterval),
+			viper.GetDuration(config.SearchIndexConfig.MaxReconnectInterval),
+			func(event pq.ListenerEventType, err error) {
+				if err != nil {
+					logger.Error("Search changes listener failed", zap.Error(err))
+				}
+			})
+		err = rep.NewSyncer(db, searchIndex, logger).Start(ctx, listener,
+			viper.GetDuration(config.SearchIndexConfig.PingInterval))
+		if err != nil {
+			logger.Error("Failed to load search index", zap.Error(err))
+			os.Exit(1)
+		}
+		searchRepo = searchIndex
+	default:
+		logger.Error("Unknown search backend", zap.String("backend", backend))
+		os.Exit(1)
+	}
+
+	queriesRepo := rep.NewQueriesRepository(db, logger)
+	suggester := search.NewSuggester(searchRepo, queriesRepo, search.SuggestParamsFromConfig())
+	history := search.NewHistory(queriesRepo, historyParams)
+	searchServ := serv.NewSearchServer(searchRepo, suggester, history, logger)
+
+	search.StartPurger(ctx, history, viper.GetDuration(config.SearchHistoryConfig.PurgeInterval), logger)
 
 	// setting up metrics
 	ms := metrics.NewPrometheusMetrics("search")
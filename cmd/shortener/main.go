@@ -54,8 +54,13 @@ func main() {
 	}
 	defer client.Disconnect(context.Background()) //nolint
 
-	shortenerRep := rep.NewShortenerRepository(client.Database("url_shortener"), logger)
-	shortenerServ := serv.NewShortenerServer(shortenerRep)
+	db := client.Database("url_shortener")
+	if err = rep.CreateIndexes(db); err != nil {
+		logger.Error("Failed to create shortener indexes", zap.Error(err))
+	}
+
+	shortenerRep := rep.NewShortenerRepository(db, logger)
+	shortenerServ := serv.NewShortenerServer(shortenerRep, logger)
 
 	// setting up metrics
 	ms := metrics.NewPrometheusMetrics("shortener")
@@ -109,7 +114,7 @@ func main() {
 	mux := httprouter.New()
 	mux.GlobalOPTIONS = middleware.HandlerFuncLogger(middleware.OptionsHandler, logger)
 
-	shortenerService := service.NewShortenerService(shortenerRep)
+	shortenerService := service.NewShortenerService(shortenerRep, logger)
 
 	metricsMiddleware := middleware.NewHttpMetricsMiddleware(ms)
 	delHTTP.RegisterGetHandler(mux, logger, shortenerService, metricsMiddleware)
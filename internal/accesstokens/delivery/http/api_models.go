@@ -0,0 +1,49 @@
+package http
+
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/xss"
+)
+
+//go:generate easyjson -all -snake_case api_models.go
+
+// API requests
+type createRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// API responses
+type createResponse struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+	Token     string    `json:"token"`
+}
+
+func newCreateResponse(token *models.AccessToken, plain string) *createResponse {
+	return &createResponse{
+		ID:        token.ID,
+		Name:      xss.Sanitize(token.Name),
+		Scopes:    token.Scopes,
+		CreatedAt: token.CreatedAt,
+		Token:     plain,
+	}
+}
+
+type listResponse struct {
+	Items []models.AccessToken `json:"items"`
+}
+
+func newListResponse(tokens []models.AccessToken) *listResponse {
+	for i := range tokens {
+		tokens[i].Name = xss.Sanitize(tokens[i].Name)
+	}
+
+	return &listResponse{
+		Items: tokens,
+	}
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	pkgAccessTokens "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/accesstokens"
+	mw "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/middleware"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/utils"
+)
+
+const (
+	tokensUrl = "/tokens"
+	tokenUrl  = "/tokens/:id"
+)
+
+type delivery struct {
+	serv pkgAccessTokens.Service
+	log  *zap.Logger
+}
+
+func RegisterHandlers(mux *httprouter.Router, logger *zap.Logger, authorizer mw.Authorizer, csrf mw.CSRFMiddleware, serv pkgAccessTokens.Service, m *mw.HttpMetricsMiddleware) {
+	del := delivery{serv, logger}
+
+	mux.POST(tokensUrl, mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer(mw.Cors(csrf(del.Create))), logger), logger), logger))
+	mux.GET(tokensUrl, mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer(mw.Cors(csrf(del.List))), logger), logger), logger))
+	mux.DELETE(tokenUrl, mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer(mw.Cors(csrf(del.Revoke))), logger), logger), logger))
+}
+
+func (del delivery) Create(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+	userID, err := strconv.Atoi(p.ByName("user-id"))
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrInvalidUserIdParam, err.Error())
+	}
+
+	body, err := utils.ReadBody(r, del.log)
+	if err != nil {
+		return err
+	}
+
+	var request createRequest
+	err = request.UnmarshalJSON(body)
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrParseJson, err.Error())
+	}
+
+	token, plain, err := del.serv.Create(userID, request.Name, request.Scopes)
+	if err != nil {
+		return err
+	}
+
+	response := newCreateResponse(&token, plain)
+	data, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+	return nil
+}
+
+func (del delivery) List(w http.ResponseWriter, _ *http.Request, p httprouter.Params) error {
+	userID, err := strconv.Atoi(p.ByName("user-id"))
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrInvalidUserIdParam, err.Error())
+	}
+
+	tokens, err := del.serv.List(userID)
+	if err != nil {
+		return err
+	}
+
+	response := newListResponse(tokens)
+	data, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+	return nil
+}
+
+func (del delivery) Revoke(_ http.ResponseWriter, _ *http.Request, p httprouter.Params) error {
+	userID, err := strconv.Atoi(p.ByName("user-id"))
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrInvalidUserIdParam, err.Error())
+	}
+
+	tokenID, err := strconv.Atoi(p.ByName("id"))
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrInvalidTokenIDParam, err.Error())
+	}
+
+	err = del.serv.Revoke(userID, tokenID)
+	if err != nil {
+		return err
+	}
+	return pkgErrors.ErrNoContent
+}
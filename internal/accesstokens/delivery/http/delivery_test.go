@@ -0,0 +1,233 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/accesstokens/mocks"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+func TestDelivery_Create(t *testing.T) {
+	type fields struct {
+		serv *mocks.MockService
+	}
+
+	type testCase struct {
+		prepare  func(f *fields)
+		params   httprouter.Params
+		request  string
+		response string
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				f.serv.EXPECT().Create(3, "ci", []string{models.ScopePinsRead}).
+					Return(models.AccessToken{ID: 1, UserID: 3, Name: "ci",
+						Scopes: []string{models.ScopePinsRead}}, "pat_secret", nil)
+			},
+			params:   []httprouter.Param{{Key: "user-id", Value: "3"}},
+			request:  `{"name":"ci","scopes":["pins:read"]}`,
+			response: `{"id":1,"name":"ci","scopes":["pins:read"],"created_at":"0001-01-01T00:00:00Z","token":"pat_secret"}`,
+			err:      nil,
+		},
+		"invalid scope": {
+			prepare: func(f *fields) {
+				f.serv.EXPECT().Create(3, "ci", []string{"admin"}).
+					Return(models.AccessToken{}, "", pkgErrors.ErrInvalidTokenScope)
+			},
+			params:   []httprouter.Param{{Key: "user-id", Value: "3"}},
+			request:  `{"name":"ci","scopes":["admin"]}`,
+			response: ``,
+			err:      pkgErrors.ErrInvalidTokenScope,
+		},
+		"missing user id param": {
+			prepare:  func(f *fields) {},
+			params:   []httprouter.Param{},
+			request:  `{"name":"ci","scopes":["pins:read"]}`,
+			response: ``,
+			err:      pkgErrors.ErrInvalidUserIdParam,
+		},
+		"invalid json": {
+			prepare:  func(f *fields) {},
+			params:   []httprouter.Param{{Key: "user-id", Value: "3"}},
+			request:  `{"name":`,
+			response: ``,
+			err:      pkgErrors.ErrParseJson,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, err := zap.NewDevelopment()
+			if err != nil {
+				t.Fatalf("can't create logger: %s", err)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{serv: mocks.NewMockService(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			del := delivery{serv: f.serv, log: logger}
+
+			req := httptest.NewRequest(http.MethodPost, "/tokens", strings.NewReader(test.request))
+			rec := httptest.NewRecorder()
+			err = del.Create(rec, req, test.params)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			body, _ := io.ReadAll(rec.Body)
+			if strings.Trim(string(body), "\n") != test.response {
+				t.Errorf("\nExpected: %s\nGot: %s", test.response, string(body))
+			}
+		})
+	}
+}
+
+func TestDelivery_List(t *testing.T) {
+	type fields struct {
+		serv *mocks.MockService
+	}
+
+	type testCase struct {
+		prepare  func(f *fields)
+		params   httprouter.Params
+		response string
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				f.serv.EXPECT().List(3).Return([]models.AccessToken{
+					{ID: 1, UserID: 3, Name: "ci", Scopes: []string{models.ScopeBoards}},
+				}, nil)
+			},
+			params:   []httprouter.Param{{Key: "user-id", Value: "3"}},
+			response: `{"items":[{"id":1,"user_id":3,"name":"ci","scopes":["boards"],"created_at":"0001-01-01T00:00:00Z","last_used_at":null}]}`,
+			err:      nil,
+		},
+		"service error": {
+			prepare: func(f *fields) {
+				f.serv.EXPECT().List(3).Return(nil, pkgErrors.ErrDb)
+			},
+			params:   []httprouter.Param{{Key: "user-id", Value: "3"}},
+			response: ``,
+			err:      pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, err := zap.NewDevelopment()
+			if err != nil {
+				t.Fatalf("can't create logger: %s", err)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{serv: mocks.NewMockService(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			del := delivery{serv: f.serv, log: logger}
+
+			req := httptest.NewRequest(http.MethodGet, "/tokens", nil)
+			rec := httptest.NewRecorder()
+			err = del.List(rec, req, test.params)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			body, _ := io.ReadAll(rec.Body)
+			if strings.Trim(string(body), "\n") != test.response {
+				t.Errorf("\nExpected: %s\nGot: %s", test.response, string(body))
+			}
+		})
+	}
+}
+
+func TestDelivery_Revoke(t *testing.T) {
+	type fields struct {
+		serv *mocks.MockService
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		params  httprouter.Params
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				f.serv.EXPECT().Revoke(3, 1).Return(nil)
+			},
+			params: []httprouter.Param{{Key: "user-id", Value: "3"}, {Key: "id", Value: "1"}},
+			err:    pkgErrors.ErrNoContent,
+		},
+		"token not found": {
+			prepare: func(f *fields) {
+				f.serv.EXPECT().Revoke(3, 1).Return(pkgErrors.ErrAccessTokenNotFound)
+			},
+			params: []httprouter.Param{{Key: "user-id", Value: "3"}, {Key: "id", Value: "1"}},
+			err:    pkgErrors.ErrAccessTokenNotFound,
+		},
+		"invalid token id param": {
+			prepare: func(f *fields) {},
+			params:  []httprouter.Param{{Key: "user-id", Value: "3"}, {Key: "id", Value: "a"}},
+			err:     pkgErrors.ErrInvalidTokenIDParam,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, err := zap.NewDevelopment()
+			if err != nil {
+				t.Fatalf("can't create logger: %s", err)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{serv: mocks.NewMockService(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			del := delivery{serv: f.serv, log: logger}
+
+			req := httptest.NewRequest(http.MethodDelete, "/tokens/1", nil)
+			rec := httptest.NewRecorder()
+			err = del.Revoke(rec, req, test.params)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+		})
+	}
+}
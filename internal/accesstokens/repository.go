@@ -0,0 +1,20 @@
+package accesstokens
+
+import (
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+)
+
+type CreateParams struct {
+	UserID    int
+	Name      string
+	TokenHash string
+	Scopes    []string
+}
+
+type Repository interface {
+	Create(params *CreateParams) (models.AccessToken, error)
+	List(userID int) ([]models.AccessToken, error)
+	Delete(userID, tokenID int) error
+	GetByHash(tokenHash string) (models.AccessToken, error)
+	UpdateLastUsed(tokenID int) error
+}
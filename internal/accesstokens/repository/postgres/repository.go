@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	pkgAccessTokens "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/accesstokens"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+type repository struct {
+	db  *sql.DB
+	log *zap.Logger
+}
+
+func NewRepository(db *sql.DB, log *zap.Logger) pkgAccessTokens.Repository {
+	return &repository{db, log}
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanToken(token *models.AccessToken, row scanner) error {
+	var lastUsedAt sql.NullTime
+	err := row.Scan(&token.ID, &token.UserID, &token.Name, pq.Array(&token.Scopes), &token.CreatedAt, &lastUsedAt)
+	if lastUsedAt.Valid {
+		token.LastUsedAt = &lastUsedAt.Time
+	}
+	return err
+}
+
+const createCmd = `
+		INSERT INTO access_tokens (user_id, name, token_hash, scopes)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, user_id, name, scopes, created_at, last_used_at;`
+
+func (rep *repository) Create(params *pkgAccessTokens.CreateParams) (models.AccessToken, error) {
+	row := rep.db.QueryRow(createCmd, params.UserID, params.Name, params.TokenHash, pq.Array(params.Scopes))
+
+	token := models.AccessToken{}
+	err := scanToken(&token, row)
+	if err != nil {
+		rep.log.Error(constants.DBScanError, zap.String("sql_query", createCmd),
+			zap.Int("user_id", params.UserID), zap.String("name", params.Name), zap.Error(err))
+		return models.AccessToken{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+
+	return token, nil
+}
+
+const listCmd = `
+		SELECT id, user_id, name, scopes, created_at, last_used_at
+		FROM access_tokens
+		WHERE user_id = $1
+		ORDER BY created_at;`
+
+func (rep *repository) List(userID int) ([]models.AccessToken, error) {
+	rows, err := rep.db.Query(listCmd, userID)
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", listCmd),
+			zap.Int("user_id", userID), zap.Error(err))
+		return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer rows.Close()
+
+	tokens := []models.AccessToken{}
+	for rows.Next() {
+		token := models.AccessToken{}
+		err = scanToken(&token, rows)
+		if err != nil {
+			rep.log.Error(constants.DBScanError, zap.String("sql_query", listCmd),
+				zap.Int("user_id", userID), zap.Error(err))
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+const deleteCmd = `
+		DELETE FROM access_tokens
+		WHERE id = $1 AND user_id = $2;`
+
+func (rep *repository) Delete(userID, tokenID int) error {
+	res, err := rep.db.Exec(deleteCmd, tokenID, userID)
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", deleteCmd),
+			zap.Int("user_id", userID), zap.Int("token_id", tokenID), zap.Error(err))
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	if affected == 0 {
+		return pkgErrors.ErrAccessTokenNotFound
+	}
+
+	return nil
+}
+
+const getByHashCmd = `
+		SELECT id, user_id, name, scopes, created_at, last_used_at
+		FROM access_tokens
+		WHERE token_hash = $1;`
+
+func (rep *repository) GetByHash(tokenHash string) (models.AccessToken, error) {
+	row := rep.db.QueryRow(getByHashCmd, tokenHash)
+
+	token := models.AccessToken{}
+	err := scanToken(&token, row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.AccessToken{}, pkgErrors.ErrAccessTokenNotFound
+		}
+		rep.log.Error(constants.DBScanError, zap.String("sql_query", getByHashCmd), zap.Error(err))
+		return models.AccessToken{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+
+	return token, nil
+}
+
+const updateLastUsedCmd = `
+		UPDATE access_tokens
+		SET last_used_at = now()
+		WHERE id = $1;`
+
+func (rep *repository) UpdateLastUsed(tokenID int) error {
+	_, err := rep.db.Exec(updateLastUsedCmd, tokenID)
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", updateLastUsedCmd),
+			zap.Int("token_id", tokenID), zap.Error(err))
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	pkgAccessTokens "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/accesstokens"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+var tokenColumns = []string{"id", "user_id", "name", "scopes", "created_at", "last_used_at"}
+
+func tokenRow(token *models.AccessToken) []driver.Value {
+	var lastUsedAt driver.Value
+	if token.LastUsedAt != nil {
+		lastUsedAt = *token.LastUsedAt
+	}
+	scopes, _ := pq.Array(token.Scopes).Value()
+	return []driver.Value{token.ID, token.UserID, token.Name, scopes, token.CreatedAt, lastUsedAt}
+}
+
+func TestRepository_Create(t *testing.T) {
+	type fields struct {
+		mock   sqlmock.Sqlmock
+		params *pkgAccessTokens.CreateParams
+		token  *models.AccessToken
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		params  *pkgAccessTokens.CreateParams
+		token   models.AccessToken
+		err     error
+	}
+
+	createdAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]testCase{
+		"good query": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows(tokenColumns).AddRow(tokenRow(f.token)...)
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(createCmd)).
+					WithArgs(f.params.UserID, f.params.Name, f.params.TokenHash, pq.Array(f.params.Scopes)).
+					WillReturnRows(rows)
+			},
+			params: &pkgAccessTokens.CreateParams{UserID: 3, Name: "ci", TokenHash: "hash",
+				Scopes: []string{models.ScopePinsRead}},
+			token: models.AccessToken{ID: 1, UserID: 3, Name: "ci", Scopes: []string{models.ScopePinsRead},
+				CreatedAt: createdAt},
+			err: nil,
+		},
+		"query error": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(createCmd)).
+					WithArgs(f.params.UserID, f.params.Name, f.params.TokenHash, pq.Array(f.params.Scopes)).
+					WillReturnError(&pq.Error{Message: "sql error"})
+			},
+			params: &pkgAccessTokens.CreateParams{UserID: 3, Name: "ci", TokenHash: "hash",
+				Scopes: []string{models.ScopePinsRead}},
+			token: models.AccessToken{},
+			err:   pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, err := zap.NewDevelopment()
+			if err != nil {
+				t.Fatalf("can't create logger: %s", err)
+			}
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			f := fields{mock: sqlMock, params: test.params, token: &test.token}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			repo := NewRepository(db, logger)
+			token, err := repo.Create(test.params)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(token, test.token) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.token, token)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestRepository_List(t *testing.T) {
+	type fields struct {
+		mock   sqlmock.Sqlmock
+		userID int
+		tokens []models.AccessToken
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		userID  int
+		tokens  []models.AccessToken
+		err     error
+	}
+
+	createdAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	lastUsedAt := time.Date(2023, 5, 2, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows(tokenColumns)
+				for i := range f.tokens {
+					rows = rows.AddRow(tokenRow(&f.tokens[i])...)
+				}
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listCmd)).
+					WithArgs(f.userID).
+					WillReturnRows(rows)
+			},
+			userID: 3,
+			tokens: []models.AccessToken{
+				{ID: 1, UserID: 3, Name: "ci", Scopes: []string{models.ScopePinsRead}, CreatedAt: createdAt},
+				{ID: 2, UserID: 3, Name: "mobile", Scopes: []string{models.ScopeBoards, models.ScopeChats},
+					CreatedAt: createdAt, LastUsedAt: &lastUsedAt},
+			},
+			err: nil,
+		},
+		"empty result": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listCmd)).
+					WithArgs(f.userID).
+					WillReturnRows(sqlmock.NewRows(tokenColumns))
+			},
+			userID: 3,
+			tokens: []models.AccessToken{},
+			err:    nil,
+		},
+		"query error": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listCmd)).
+					WithArgs(f.userID).
+					WillReturnError(&pq.Error{Message: "sql error"})
+			},
+			userID: 3,
+			tokens: nil,
+			err:    pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, err := zap.NewDevelopment()
+			if err != nil {
+				t.Fatalf("can't create logger: %s", err)
+			}
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			f := fields{mock: sqlMock, userID: test.userID, tokens: test.tokens}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			repo := NewRepository(db, logger)
+			tokens, err := repo.List(test.userID)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(tokens, test.tokens) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.tokens, tokens)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestRepository_Delete(t *testing.T) {
+	type testCase struct {
+		prepare func(mock sqlmock.Sqlmock)
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(deleteCmd)).
+					WithArgs(1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			err: nil,
+		},
+		"token not found": {
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(deleteCmd)).
+					WithArgs(1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			err: pkgErrors.ErrAccessTokenNotFound,
+		},
+		"query error": {
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(deleteCmd)).
+					WithArgs(1, 3).
+					WillReturnError(&pq.Error{Message: "sql error"})
+			},
+			err: pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, err := zap.NewDevelopment()
+			if err != nil {
+				t.Fatalf("can't create logger: %s", err)
+			}
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			test.prepare(sqlMock)
+
+			repo := NewRepository(db, logger)
+			err = repo.Delete(3, 1)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestRepository_GetByHash(t *testing.T) {
+	type testCase struct {
+		prepare func(mock sqlmock.Sqlmock, token *models.AccessToken)
+		token   models.AccessToken
+		err     error
+	}
+
+	createdAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(mock sqlmock.Sqlmock, token *models.AccessToken) {
+				mock.ExpectQuery(regexp.QuoteMeta(getByHashCmd)).
+					WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows(tokenColumns).AddRow(tokenRow(token)...))
+			},
+			token: models.AccessToken{ID: 1, UserID: 3, Name: "ci", Scopes: []string{models.ScopePinsWrite},
+				CreatedAt: createdAt},
+			err: nil,
+		},
+		"token not found": {
+			prepare: func(mock sqlmock.Sqlmock, token *models.AccessToken) {
+				mock.ExpectQuery(regexp.QuoteMeta(getByHashCmd)).
+					WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows(tokenColumns))
+			},
+			token: models.AccessToken{},
+			err:   pkgErrors.ErrAccessTokenNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, err := zap.NewDevelopment()
+			if err != nil {
+				t.Fatalf("can't create logger: %s", err)
+			}
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			test.prepare(sqlMock, &test.token)
+
+			repo := NewRepository(db, logger)
+			token, err := repo.GetByHash("hash")
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(token, test.token) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.token, token)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
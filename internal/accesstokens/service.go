@@ -0,0 +1,13 @@
+package accesstokens
+
+import (
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+)
+
+type Service interface {
+	// Create returns the stored token and its plain value, which is never available again.
+	Create(userID int, name string, scopes []string) (models.AccessToken, string, error)
+	List(userID int) ([]models.AccessToken, error)
+	Revoke(userID, tokenID int) error
+	Check(token string) (models.AccessToken, error)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	pkgAccessTokens "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/accesstokens"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+const (
+	tokenPrefix     = "pat_"
+	tokenBytes      = 32
+	maxTokenNameLen = 64
+)
+
+type service struct {
+	rep pkgAccessTokens.Repository
+}
+
+func NewService(rep pkgAccessTokens.Repository) pkgAccessTokens.Service {
+	return &service{rep: rep}
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return tokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func validateScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, pkgErrors.ErrInvalidTokenScope
+	}
+
+	unique := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !contains(models.AccessTokenScopes, scope) {
+			return nil, errors.Wrapf(pkgErrors.ErrInvalidTokenScope, "unknown scope %q", scope)
+		}
+		if !contains(unique, scope) {
+			unique = append(unique, scope)
+		}
+	}
+	return unique, nil
+}
+
+func (serv *service) Create(userID int, name string, scopes []string) (models.AccessToken, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return models.AccessToken{}, "", pkgErrors.ErrEmptyTokenName
+	}
+	if utf8.RuneCountInString(name) > maxTokenNameLen {
+		return models.AccessToken{}, "", pkgErrors.ErrTooLongTokenName
+	}
+
+	scopes, err := validateScopes(scopes)
+	if err != nil {
+		return models.AccessToken{}, "", err
+	}
+
+	plain, err := generateToken()
+	if err != nil {
+		return models.AccessToken{}, "", errors.Wrap(pkgErrors.ErrService, err.Error())
+	}
+
+	token, err := serv.rep.Create(&pkgAccessTokens.CreateParams{
+		UserID:    userID,
+		Name:      name,
+		TokenHash: hashToken(plain),
+		Scopes:    scopes,
+	})
+	if err != nil {
+		return models.AccessToken{}, "", err
+	}
+
+	return token, plain, nil
+}
+
+func (serv *service) List(userID int) ([]models.AccessToken, error) {
+	return serv.rep.List(userID)
+}
+
+func (serv *service) Revoke(userID, tokenID int) error {
+	return serv.rep.Delete(userID, tokenID)
+}
+
+func (serv *service) Check(token string) (models.AccessToken, error) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return models.AccessToken{}, pkgErrors.ErrUnauthorized
+	}
+
+	accessToken, err := serv.rep.GetByHash(hashToken(token))
+	if err != nil {
+		return models.AccessToken{}, err
+	}
+
+	_ = serv.rep.UpdateLastUsed(accessToken.ID)
+	return accessToken, nil
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+
+	pkgAccessTokens "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/accesstokens"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/accesstokens/mocks"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+func TestService_Create(t *testing.T) {
+	type fields struct {
+		repo *mocks.MockRepository
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		name    string
+		scopes  []string
+		token   models.AccessToken
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().Create(gomock.Any()).DoAndReturn(
+					func(params *pkgAccessTokens.CreateParams) (models.AccessToken, error) {
+						return models.AccessToken{ID: 1, UserID: params.UserID, Name: params.Name,
+							Scopes: params.Scopes}, nil
+					})
+			},
+			name:   " ci ",
+			scopes: []string{models.ScopePinsRead, models.ScopeBoards, models.ScopePinsRead},
+			token: models.AccessToken{ID: 1, UserID: 3, Name: "ci",
+				Scopes: []string{models.ScopePinsRead, models.ScopeBoards}},
+			err: nil,
+		},
+		"empty name": {
+			prepare: func(f *fields) {},
+			name:    "  ",
+			scopes:  []string{models.ScopePinsRead},
+			token:   models.AccessToken{},
+			err:     pkgErrors.ErrEmptyTokenName,
+		},
+		"too long name": {
+			prepare: func(f *fields) {},
+			name:    strings.Repeat("a", 65),
+			scopes:  []string{models.ScopePinsRead},
+			token:   models.AccessToken{},
+			err:     pkgErrors.ErrTooLongTokenName,
+		},
+		"no scopes": {
+			prepare: func(f *fields) {},
+			name:    "ci",
+			scopes:  nil,
+			token:   models.AccessToken{},
+			err:     pkgErrors.ErrInvalidTokenScope,
+		},
+		"unknown scope": {
+			prepare: func(f *fields) {},
+			name:    "ci",
+			scopes:  []string{"admin"},
+			token:   models.AccessToken{},
+			err:     pkgErrors.ErrInvalidTokenScope,
+		},
+		"repository error": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().Create(gomock.Any()).Return(models.AccessToken{}, pkgErrors.ErrDb)
+			},
+			name:   "ci",
+			scopes: []string{models.ScopeChats},
+			token:  models.AccessToken{},
+			err:    pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{repo: mocks.NewMockRepository(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			serv := NewService(f.repo)
+			token, plain, err := serv.Create(3, test.name, test.scopes)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(token, test.token) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.token, token)
+			}
+			if test.err == nil && !strings.HasPrefix(plain, tokenPrefix) {
+				t.Errorf("\nExpected token with prefix %s\nGot: %s", tokenPrefix, plain)
+			}
+		})
+	}
+}
+
+func TestService_Check(t *testing.T) {
+	type fields struct {
+		repo *mocks.MockRepository
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		token   string
+		result  models.AccessToken
+		err     error
+	}
+
+	const plain = "pat_secret"
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().GetByHash(hashToken(plain)).
+						Return(models.AccessToken{ID: 1, UserID: 3}, nil),
+					f.repo.EXPECT().UpdateLastUsed(1).Return(nil))
+			},
+			token:  plain,
+			result: models.AccessToken{ID: 1, UserID: 3},
+			err:    nil,
+		},
+		"unknown token": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().GetByHash(hashToken(plain)).
+					Return(models.AccessToken{}, pkgErrors.ErrAccessTokenNotFound)
+			},
+			token:  plain,
+			result: models.AccessToken{},
+			err:    pkgErrors.ErrAccessTokenNotFound,
+		},
+		"malformed token": {
+			prepare: func(f *fields) {},
+			token:   "secret",
+			result:  models.AccessToken{},
+			err:     pkgErrors.ErrUnauthorized,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{repo: mocks.NewMockRepository(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			serv := NewService(f.repo)
+			token, err := serv.Check(test.token)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(token, test.result) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.result, token)
+			}
+		})
+	}
+}
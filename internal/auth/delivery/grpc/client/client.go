@@ -3,7 +3,6 @@ package client
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"time"
 
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/auth"
@@ -12,7 +11,6 @@ import (
 	hasherPkg "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/auth/hasher"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
-	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 )
@@ -28,10 +26,11 @@ func NewAuthenficatorClient(con *grpc.ClientConn) auth.Service {
 	return &client{authClient: proto.NewAuthenficatorClient(con)}
 }
 
-func (client *client) Authenticate(login, hashedPassword string) (models.User, auth.SessionParams, error) {
+func (client *client) Authenticate(login, hashedPassword, ip string, rememberMe bool) (models.User, auth.SessionParams, error) {
 	authParams := proto.LoginParams{
 		Email:    login,
 		Password: hashedPassword,
+		IP:       ip,
 	}
 	resp, err := client.authClient.Authenticate(context.TODO(), &authParams)
 	fmt.Println("here", resp, err)
@@ -39,11 +38,12 @@ func (client *client) Authenticate(login, hashedPassword string) (models.User, a
 		return models.User{}, auth.SessionParams{}, pkgErrors.RestoreHTTPError(pkgErrors.GRPCUnwrapper(err))
 	}
 
-	sessionParams := client.CreateSession(int(resp.GetID()))
+	sessionParams := client.CreateSession(int(resp.GetID()), rememberMe)
 
 	session := models.Session{
-		UserId:    int(resp.GetID()),
-		UserEmail: resp.GetEmail(),
+		UserId:      int(resp.GetID()),
+		UserEmail:   resp.GetEmail(),
+		IdleTimeout: sessionParams.IdleTimeout,
 	}
 	err = client.SetSession(sessionParams.Token, &session, sessionParams.LivingTime)
 	if err != nil {
@@ -55,19 +55,14 @@ func (client *client) Authenticate(login, hashedPassword string) (models.User, a
 
 }
 
-func (client *client) CreateSession(userId int) auth.SessionParams {
-	token := strconv.Itoa(userId) + "$" + uuid.New().String()
-	livingTime := 5 * time.Hour
-	return auth.SessionParams{Token: token, LivingTime: livingTime}
+func (client *client) CreateSession(userId int, rememberMe bool) auth.SessionParams {
+	return auth.NewSessionParams(userId, rememberMe)
 }
 
 func (client *client) SetSession(token string, session *models.Session, expiration time.Duration) error {
 	setParams := proto.SessionSetParams{
-		Token: token,
-		Session: &proto.Session{
-			UserId:    int64(session.UserId),
-			UserEmail: session.UserEmail,
-		},
+		Token:      token,
+		Session:    protomodels.NewProtoSession(session),
 		Experation: expiration.Nanoseconds(),
 	}
 	_, err := client.authClient.SetSession(context.TODO(), &setParams)
@@ -95,7 +90,16 @@ func (client *client) DeleteSession(userId, sessionId string) error {
 		SessionId: sessionId,
 	}
 
-	_, err := client.authClient.CheckAuth(context.TODO(), &checkParams)
+	_, err := client.authClient.DeleteSession(context.TODO(), &checkParams)
+	if err != nil {
+		return pkgErrors.RestoreHTTPError(pkgErrors.GRPCUnwrapper(err))
+	}
+
+	return nil
+}
+
+func (client *client) DeleteUserSessions(userId int) error {
+	_, err := client.authClient.DeleteUserSessions(context.TODO(), &proto.UserId{UserId: int64(userId)})
 	if err != nil {
 		return pkgErrors.RestoreHTTPError(pkgErrors.GRPCUnwrapper(err))
 	}
@@ -122,5 +126,5 @@ func (client *client) Register(user *auth.RegisterParams) (models.User, auth.Ses
 		return models.User{}, auth.SessionParams{}, pkgErrors.RestoreHTTPError(pkgErrors.GRPCUnwrapper(err))
 	}
 
-	return client.Authenticate(usr.GetEmail(), user.Password)
+	return client.Authenticate(usr.GetEmail(), user.Password, user.IP, false)
 }
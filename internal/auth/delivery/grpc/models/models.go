@@ -36,28 +36,36 @@ func NewUser(user *proto.User) *models.User {
 
 func NewProtoSessionParams(params *auth.SessionParams) *proto.SessionParams {
 	return &proto.SessionParams{
-		Token:      params.Token,
-		LivingTime: params.LivingTime.Nanoseconds(),
+		Token:       params.Token,
+		LivingTime:  params.LivingTime.Nanoseconds(),
+		IdleTimeout: params.IdleTimeout.Nanoseconds(),
+		RememberMe:  params.RememberMe,
 	}
 }
 
 func NewSessionParams(params *proto.SessionParams) *auth.SessionParams {
 	return &auth.SessionParams{
-		Token:      params.GetToken(),
-		LivingTime: time.Duration(params.LivingTime),
+		Token:       params.GetToken(),
+		LivingTime:  time.Duration(params.LivingTime),
+		IdleTimeout: time.Duration(params.GetIdleTimeout()),
+		RememberMe:  params.GetRememberMe(),
 	}
 }
 
 func NewProtoSession(session *models.Session) *proto.Session {
 	return &proto.Session{
-		UserId:    int64(session.UserId),
-		UserEmail: session.UserEmail,
+		UserId:      int64(session.UserId),
+		UserEmail:   session.UserEmail,
+		IdleTimeout: session.IdleTimeout.Nanoseconds(),
+		ExpiresAt:   session.ExpiresAt,
 	}
 }
 
 func NewSession(session *proto.Session) *models.Session {
 	return &models.Session{
-		UserId:    int(session.GetUserId()),
-		UserEmail: session.GetUserEmail(),
+		UserId:      int(session.GetUserId()),
+		UserEmail:   session.GetUserEmail(),
+		IdleTimeout: time.Duration(session.GetIdleTimeout()),
+		ExpiresAt:   session.GetExpiresAt(),
 	}
 }
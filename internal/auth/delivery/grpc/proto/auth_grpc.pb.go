@@ -19,11 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Authenficator_Authenticate_FullMethodName  = "/auth.Authenficator/Authenticate"
-	Authenficator_Register_FullMethodName      = "/auth.Authenficator/Register"
-	Authenficator_SetSession_FullMethodName    = "/auth.Authenficator/SetSession"
-	Authenficator_CheckAuth_FullMethodName     = "/auth.Authenficator/CheckAuth"
-	Authenficator_DeleteSession_FullMethodName = "/auth.Authenficator/DeleteSession"
+	Authenficator_Authenticate_FullMethodName       = "/auth.Authenficator/Authenticate"
+	Authenficator_Register_FullMethodName           = "/auth.Authenficator/Register"
+	Authenficator_SetSession_FullMethodName         = "/auth.Authenficator/SetSession"
+	Authenficator_CheckAuth_FullMethodName          = "/auth.Authenficator/CheckAuth"
+	Authenficator_DeleteSession_FullMethodName      = "/auth.Authenficator/DeleteSession"
+	Authenficator_DeleteUserSessions_FullMethodName = "/auth.Authenficator/DeleteUserSessions"
 )
 
 // AuthenficatorClient is the client API for Authenficator service.
@@ -35,6 +36,7 @@ type AuthenficatorClient interface {
 	SetSession(ctx context.Context, in *SessionSetParams, opts ...grpc.CallOption) (*Nothing, error)
 	CheckAuth(ctx context.Context, in *SessionCheckParams, opts ...grpc.CallOption) (*User, error)
 	DeleteSession(ctx context.Context, in *SessionCheckParams, opts ...grpc.CallOption) (*Nothing, error)
+	DeleteUserSessions(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Nothing, error)
 }
 
 type authenficatorClient struct {
@@ -90,6 +92,15 @@ func (c *authenficatorClient) DeleteSession(ctx context.Context, in *SessionChec
 	return out, nil
 }
 
+func (c *authenficatorClient) DeleteUserSessions(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, Authenficator_DeleteUserSessions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthenficatorServer is the server API for Authenficator service.
 // All implementations must embed UnimplementedAuthenficatorServer
 // for forward compatibility
@@ -99,6 +110,7 @@ type AuthenficatorServer interface {
 	SetSession(context.Context, *SessionSetParams) (*Nothing, error)
 	CheckAuth(context.Context, *SessionCheckParams) (*User, error)
 	DeleteSession(context.Context, *SessionCheckParams) (*Nothing, error)
+	DeleteUserSessions(context.Context, *UserId) (*Nothing, error)
 	mustEmbedUnimplementedAuthenficatorServer()
 }
 
@@ -121,6 +133,9 @@ func (UnimplementedAuthenficatorServer) CheckAuth(context.Context, *SessionCheck
 func (UnimplementedAuthenficatorServer) DeleteSession(context.Context, *SessionCheckParams) (*Nothing, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteSession not implemented")
 }
+func (UnimplementedAuthenficatorServer) DeleteUserSessions(context.Context, *UserId) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserSessions not implemented")
+}
 func (UnimplementedAuthenficatorServer) mustEmbedUnimplementedAuthenficatorServer() {}
 
 // UnsafeAuthenficatorServer may be embedded to opt out of forward compatibility for this service.
@@ -224,6 +239,24 @@ func _Authenficator_DeleteSession_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Authenficator_DeleteUserSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenficatorServer).DeleteUserSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Authenficator_DeleteUserSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenficatorServer).DeleteUserSessions(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Authenficator_ServiceDesc is the grpc.ServiceDesc for Authenficator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -251,6 +284,10 @@ var Authenficator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteSession",
 			Handler:    _Authenficator_DeleteSession_Handler,
 		},
+		{
+			MethodName: "DeleteUserSessions",
+			Handler:    _Authenficator_DeleteUserSessions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth.proto",
@@ -24,7 +24,7 @@ func NewAuthServer(rep auth.Repository) proto.AuthenficatorServer {
 }
 
 func (serv *server) Authenticate(ctx context.Context, loginParams *proto.LoginParams) (*proto.User, error) {
-	user, err := serv.rep.Authenticate(loginParams.GetEmail(), loginParams.GetPassword())
+	user, err := serv.rep.Authenticate(loginParams.GetEmail(), loginParams.GetPassword(), loginParams.GetIP())
 	fmt.Println("here", err)
 	if err != nil {
 		return &proto.User{}, pkgErrors.GRPCWrapper(err)
@@ -55,6 +55,14 @@ func (serv *server) CheckAuth(ctx context.Context, params *proto.SessionCheckPar
 	}
 	return protomodels.NewProtoUser(&user), err
 }
+func (serv *server) DeleteUserSessions(ctx context.Context, params *proto.UserId) (*proto.Nothing, error) {
+	err := serv.rep.DeleteUserSessions(int(params.GetUserId()))
+	if err != nil {
+		return &proto.Nothing{}, pkgErrors.GRPCWrapper(err)
+	}
+	return &proto.Nothing{}, err
+}
+
 func (serv *server) DeleteSession(ctx context.Context, params *proto.SessionCheckParams) (*proto.Nothing, error) {
 	err := serv.rep.DeleteSession(params.GetUserId(), params.GetSessionId())
 	if err != nil {
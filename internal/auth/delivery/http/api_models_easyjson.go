@@ -235,6 +235,8 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalAuthDel
 			out.Email = string(in.String())
 		case "password":
 			out.Password = string(in.String())
+		case "remember_me":
+			out.RememberMe = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -259,6 +261,11 @@ func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalAuthDel
 		out.RawString(prefix)
 		out.String(string(in.Password))
 	}
+	{
+		const prefix string = ",\"remember_me\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.RememberMe))
+	}
 	out.RawByte('}')
 }
 
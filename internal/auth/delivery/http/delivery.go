@@ -39,21 +39,30 @@ func parseSessionCookie(c *http.Cookie) (string, string, error) {
 	return tmp[0], c.Value, nil
 }
 
+// cookieExpiration returns zero time for regular sessions so that browsers drop
+// the cookie on close, remembered sessions live until their absolute timeout.
+func cookieExpiration(s auth.SessionParams) time.Time {
+	if !s.RememberMe {
+		return time.Time{}
+	}
+	return time.Now().Add(s.LivingTime)
+}
+
 func createSessionCookie(s auth.SessionParams) *http.Cookie {
 	return &http.Cookie{
 		Name:     "JSESSIONID",
 		Value:    s.Token,
-		Expires:  time.Now().Add(s.LivingTime),
+		Expires:  cookieExpiration(s),
 		HttpOnly: true,
 		Path:     "/",
 	}
 }
 
-func createCsrfTokenCookie(token string) *http.Cookie {
+func createCsrfTokenCookie(token string, s auth.SessionParams) *http.Cookie {
 	return &http.Cookie{
 		Name:     "XSRF-TOKEN",
 		Value:    token,
-		Expires:  time.Now().Add(time.Hour * 5),
+		Expires:  cookieExpiration(s),
 		HttpOnly: false,
 		Path:     "/",
 	}
@@ -71,7 +80,7 @@ func (del *delivery) Authenticate(w http.ResponseWriter, r *http.Request, p http
 		return errors.Wrap(pkgErrors.ErrParseJson, err.Error())
 	}
 
-	user, session, err := del.serv.Authenticate(request.Email, request.Password)
+	user, session, err := del.serv.Authenticate(request.Email, request.Password, utils.GetRealIP(r), request.RememberMe)
 	if err != nil {
 		return err
 	}
@@ -85,7 +94,7 @@ func (del *delivery) Authenticate(w http.ResponseWriter, r *http.Request, p http
 		return pkgErrors.ErrCreateCsrfToken
 	}
 
-	csrfCookie := createCsrfTokenCookie(token)
+	csrfCookie := createCsrfTokenCookie(token, session)
 	http.SetCookie(w, csrfCookie)
 
 	response := newAuthenticateResponse(&user)
@@ -175,6 +184,7 @@ func (del *delivery) Register(w http.ResponseWriter, r *http.Request, p httprout
 		Email:    request.Email,
 		Name:     request.Name,
 		Password: request.Password,
+		IP:       utils.GetRealIP(r),
 	}
 	user, sessionParams, err := del.serv.Register(&params)
 	if err != nil {
@@ -190,7 +200,7 @@ func (del *delivery) Register(w http.ResponseWriter, r *http.Request, p httprout
 		return pkgErrors.ErrCreateCsrfToken
 	}
 
-	csrfCookie := createCsrfTokenCookie(token)
+	csrfCookie := createCsrfTokenCookie(token, sessionParams)
 	http.SetCookie(w, csrfCookie)
 
 	response := newRegisterResponse(&user)
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"go.uber.org/zap"
@@ -27,6 +28,9 @@ func init() {
 	}
 }
 
+// httptest.NewRequest uses 192.0.2.1:1234 as the remote address
+const testIP = "192.0.2.1"
+
 var existingUsers = []loginRequest{
 	{
 		Email:    "geogreck@vk.com",
@@ -72,7 +76,7 @@ func TestAuthenticate(t *testing.T) {
 	tests := []AuthenticateTestCase{
 		{
 			prepare: func(f *fields) {
-				f.serv.EXPECT().Authenticate(existingUsers[0].Email, existingUsers[0].Password).
+				f.serv.EXPECT().Authenticate(existingUsers[0].Email, existingUsers[0].Password, testIP, false).
 					Return(models.User{
 						Id:             2,
 						Username:       "vitya",
@@ -89,7 +93,7 @@ func TestAuthenticate(t *testing.T) {
 		},
 		{
 			prepare: func(f *fields) {
-				f.serv.EXPECT().Authenticate("123@vk.com", "12345678").
+				f.serv.EXPECT().Authenticate("123@vk.com", "12345678", testIP, false).
 					Return(models.User{}, auth.SessionParams{}, pkgErrors.ErrUserNotFound)
 			},
 			req: loginRequest{
@@ -98,6 +102,36 @@ func TestAuthenticate(t *testing.T) {
 			},
 			err: pkgErrors.ErrUserNotFound,
 		},
+		{
+			prepare: func(f *fields) {
+				f.serv.EXPECT().Authenticate(existingUsers[1].Email, "wrong_password", testIP, false).
+					Return(models.User{}, auth.SessionParams{}, pkgErrors.ErrTooManyLoginAttempts)
+			},
+			req: loginRequest{
+				Email:    existingUsers[1].Email,
+				Password: "wrong_password",
+			},
+			err: pkgErrors.ErrTooManyLoginAttempts,
+		},
+		{
+			prepare: func(f *fields) {
+				f.serv.EXPECT().Authenticate(existingUsers[2].Email, existingUsers[2].Password, testIP, true).
+					Return(models.User{
+						Id:    3,
+						Email: existingUsers[2].Email,
+					}, auth.SessionParams{
+						Token:      "3$token",
+						LivingTime: 24 * time.Hour,
+						RememberMe: true,
+					}, nil)
+			},
+			req: loginRequest{
+				Email:      existingUsers[2].Email,
+				Password:   existingUsers[2].Password,
+				RememberMe: true,
+			},
+			err: nil,
+		},
 	}
 
 	ctrl := gomock.NewController(t)
@@ -133,6 +167,7 @@ func TestRegister(t *testing.T) {
 					Email:    "test1@test.ru",
 					Name:     "test",
 					Password: "12345",
+					IP:       testIP,
 				}).Return(models.User{
 					Id:             2,
 					Username:       "test1",
@@ -159,6 +194,7 @@ func TestRegister(t *testing.T) {
 					Email:    "test1@test.ru",
 					Name:     "test",
 					Password: "12345",
+					IP:       testIP,
 				}).Return(models.User{
 					Id:             2,
 					Username:       "test3",
@@ -185,6 +221,7 @@ func TestRegister(t *testing.T) {
 					Email:    "test1@test.ru",
 					Name:     "test",
 					Password: "12345",
+					IP:       testIP,
 				}).Return(models.User{
 					Id:             2,
 					Username:       "test3",
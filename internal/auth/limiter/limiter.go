@@ -0,0 +1,188 @@
+package limiter
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/config"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+const (
+	accountKind = "account"
+	ipKind      = "ip"
+)
+
+type Params struct {
+	Window             time.Duration // sliding window in which failed attempts are counted
+	MaxAccountAttempts int           // failed attempts per account before lockout
+	MaxIPAttempts      int           // failed attempts per ip before lockout
+	LockoutDuration    time.Duration
+	DelayThreshold     int // failed attempts per account before progressive delay starts
+	BaseDelay          time.Duration
+	MaxDelay           time.Duration
+}
+
+func ParamsFromConfig() Params {
+	return Params{
+		Window:             viper.GetDuration(config.LoginLimiterConfig.Window),
+		MaxAccountAttempts: viper.GetInt(config.LoginLimiterConfig.MaxAccountAttempts),
+		MaxIPAttempts:      viper.GetInt(config.LoginLimiterConfig.MaxIPAttempts),
+		LockoutDuration:    viper.GetDuration(config.LoginLimiterConfig.LockoutDuration),
+		DelayThreshold:     viper.GetInt(config.LoginLimiterConfig.DelayThreshold),
+		BaseDelay:          viper.GetDuration(config.LoginLimiterConfig.BaseDelay),
+		MaxDelay:           viper.GetDuration(config.LoginLimiterConfig.MaxDelay),
+	}
+}
+
+// Limiter keeps Redis-backed sliding-window counters of failed logins per account and per ip.
+type Limiter interface {
+	// Check returns the delay to apply before checking the password
+	// or ErrTooManyLoginAttempts if the account or the ip is locked out.
+	Check(email, ip string) (time.Duration, error)
+	RegisterFailure(email, ip string) error
+	Reset(email string) error
+	SetupMetrics() error
+}
+
+type limiter struct {
+	rdb     *redis.Client
+	ctx     context.Context
+	log     *zap.Logger
+	params  Params
+	blocked *prometheus.CounterVec
+	now     func() time.Time
+}
+
+func NewLimiter(rdb *redis.Client, ctx context.Context, log *zap.Logger, params Params) Limiter {
+	return &limiter{
+		rdb:    rdb,
+		ctx:    ctx,
+		log:    log,
+		params: params,
+		blocked: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "auth_blocked_login_attempts",
+			Help: "Counts login attempts rejected because of lockout",
+		}, []string{"reason"}),
+		now: time.Now,
+	}
+}
+
+func (l *limiter) SetupMetrics() error {
+	return prometheus.Register(l.blocked)
+}
+
+func attemptsKey(kind, value string) string {
+	return "login_attempts:" + kind + ":" + value
+}
+
+func lockoutKey(kind, value string) string {
+	return "login_lockout:" + kind + ":" + value
+}
+
+func (l *limiter) Check(email, ip string) (time.Duration, error) {
+	targets := []struct{ kind, value string }{{accountKind, email}, {ipKind, ip}}
+	for _, target := range targets {
+		if target.value == "" {
+			continue
+		}
+
+		locked, err := l.rdb.Exists(l.ctx, lockoutKey(target.kind, target.value)).Result()
+		if err != nil {
+			l.log.Error("Failed to check login lockout", zap.Error(err), zap.String("kind", target.kind))
+			continue
+		}
+		if locked > 0 {
+			l.blocked.WithLabelValues(target.kind).Inc()
+			return 0, errors.Wrapf(pkgErrors.ErrTooManyLoginAttempts, "%s %s is locked out", target.kind, target.value)
+		}
+	}
+
+	failures, err := l.countFailures(attemptsKey(accountKind, email))
+	if err != nil {
+		l.log.Error("Failed to count failed logins", zap.Error(err), zap.String("email", email))
+		return 0, nil
+	}
+
+	return l.delay(failures), nil
+}
+
+func (l *limiter) RegisterFailure(email, ip string) error {
+	accountFailures, err := l.addFailure(attemptsKey(accountKind, email))
+	if err != nil {
+		return err
+	}
+	if accountFailures >= int64(l.params.MaxAccountAttempts) {
+		if err = l.lock(accountKind, email); err != nil {
+			return err
+		}
+	}
+
+	if ip == "" {
+		return nil
+	}
+
+	ipFailures, err := l.addFailure(attemptsKey(ipKind, ip))
+	if err != nil {
+		return err
+	}
+	if ipFailures >= int64(l.params.MaxIPAttempts) {
+		return l.lock(ipKind, ip)
+	}
+	return nil
+}
+
+func (l *limiter) Reset(email string) error {
+	return l.rdb.Del(l.ctx, attemptsKey(accountKind, email)).Err()
+}
+
+func (l *limiter) addFailure(key string) (int64, error) {
+	now := l.now()
+	windowStart := now.Add(-l.params.Window).UnixNano()
+
+	pipe := l.rdb.TxPipeline()
+	pipe.ZRemRangeByScore(l.ctx, key, "-inf", strconv.FormatInt(windowStart, 10))
+	pipe.ZAdd(l.ctx, key, redis.Z{Score: float64(now.UnixNano()), Member: uuid.NewString()})
+	count := pipe.ZCard(l.ctx, key)
+	pipe.Expire(l.ctx, key, l.params.Window)
+	if _, err := pipe.Exec(l.ctx); err != nil {
+		return 0, err
+	}
+	return count.Val(), nil
+}
+
+func (l *limiter) countFailures(key string) (int64, error) {
+	windowStart := l.now().Add(-l.params.Window).UnixNano()
+	return l.rdb.ZCount(l.ctx, key, strconv.FormatInt(windowStart, 10), "+inf").Result()
+}
+
+func (l *limiter) lock(kind, value string) error {
+	l.log.Warn("Login locked out", zap.String("kind", kind), zap.String("value", value),
+		zap.Duration("duration", l.params.LockoutDuration))
+	return l.rdb.Set(l.ctx, lockoutKey(kind, value), 1, l.params.LockoutDuration).Err()
+}
+
+// delay doubles BaseDelay for every failed attempt above DelayThreshold, up to MaxDelay.
+func (l *limiter) delay(failures int64) time.Duration {
+	over := failures - int64(l.params.DelayThreshold)
+	if over < 0 {
+		return 0
+	}
+
+	delay := l.params.BaseDelay
+	for i := int64(0); i < over && delay < l.params.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > l.params.MaxDelay {
+		delay = l.params.MaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,190 @@
+package limiter
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/redis/redistest"
+)
+
+var testParams = Params{
+	Window:             10 * time.Minute,
+	MaxAccountAttempts: 3,
+	MaxIPAttempts:      5,
+	LockoutDuration:    15 * time.Minute,
+	DelayThreshold:     1,
+	BaseDelay:          time.Second,
+	MaxDelay:           4 * time.Second,
+}
+
+func newTestLimiter(params Params) (*limiter, *redistest.Server, *redistest.Clock) {
+	clock := redistest.NewClock()
+	rdb, srv := redistest.NewClient(clock.Now)
+	l := NewLimiter(rdb, context.Background(), zap.NewNop(), params).(*limiter)
+	l.now = clock.Now
+	return l, srv, clock
+}
+
+func registerFailures(t *testing.T, l *limiter, n int, email, ip string) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if err := l.RegisterFailure(email, ip); err != nil {
+			t.Fatalf("RegisterFailure: unexpected error %v", err)
+		}
+	}
+}
+
+func checkLocked(t *testing.T, l *limiter, email, ip string, locked bool) {
+	t.Helper()
+	_, err := l.Check(email, ip)
+	if got := errors.Is(err, pkgErrors.ErrTooManyLoginAttempts); got != locked {
+		t.Errorf("Check(%q, %q): locked = %v, expected %v (error %v)", email, ip, got, locked, err)
+	}
+}
+
+func TestLimiter_AccountLockout(t *testing.T) {
+	l, _, clock := newTestLimiter(testParams)
+
+	// every failure is from another ip, so only the account is locked out
+	registerFailures(t, l, 1, "user@mail.ru", "10.0.0.1")
+	registerFailures(t, l, 1, "user@mail.ru", "10.0.0.2")
+	checkLocked(t, l, "user@mail.ru", "10.0.0.3", false)
+	registerFailures(t, l, 1, "user@mail.ru", "10.0.0.3")
+
+	checkLocked(t, l, "user@mail.ru", "10.0.0.4", true)
+	checkLocked(t, l, "other@mail.ru", "10.0.0.1", false)
+
+	clock.Advance(testParams.LockoutDuration - time.Second)
+	checkLocked(t, l, "user@mail.ru", "10.0.0.4", true)
+	clock.Advance(time.Second)
+	checkLocked(t, l, "user@mail.ru", "10.0.0.4", false)
+}
+
+func TestLimiter_IPLockout(t *testing.T) {
+	l, _, clock := newTestLimiter(testParams)
+
+	emails := []string{"a@mail.ru", "b@mail.ru", "c@mail.ru", "d@mail.ru", "e@mail.ru"}
+	for _, email := range emails {
+		registerFailures(t, l, 1, email, "10.0.0.1")
+	}
+
+	checkLocked(t, l, "f@mail.ru", "10.0.0.1", true)
+	// the accounts tried from the ip are not locked out from other ips
+	checkLocked(t, l, "a@mail.ru", "10.0.0.2", false)
+	// an empty ip is not checked
+	checkLocked(t, l, "f@mail.ru", "", false)
+
+	clock.Advance(testParams.LockoutDuration)
+	checkLocked(t, l, "f@mail.ru", "10.0.0.1", false)
+}
+
+func TestLimiter_SlidingWindow(t *testing.T) {
+	l, srv, clock := newTestLimiter(testParams)
+
+	registerFailures(t, l, 1, "user@mail.ru", "10.0.0.1")
+	clock.Advance(6 * time.Minute)
+	registerFailures(t, l, 1, "user@mail.ru", "10.0.0.2")
+	clock.Advance(5 * time.Minute)
+
+	// the first failure is out of the window, so the third one does not lock the account out
+	registerFailures(t, l, 1, "user@mail.ru", "10.0.0.3")
+	checkLocked(t, l, "user@mail.ru", "10.0.0.4", false)
+
+	count, err := l.countFailures(attemptsKey(accountKind, "user@mail.ru"))
+	if err != nil {
+		t.Fatalf("countFailures: unexpected error %v", err)
+	}
+	if count != 2 {
+		t.Errorf("countFailures = %d, expected 2", count)
+	}
+
+	// the counter expires a window after the last failure
+	clock.Advance(testParams.Window)
+	if srv.Exists(attemptsKey(accountKind, "user@mail.ru")) {
+		t.Errorf("attempts counter did not expire")
+	}
+}
+
+func TestLimiter_Delay(t *testing.T) {
+	params := testParams
+	params.MaxAccountAttempts = 100
+
+	type testCase struct {
+		failures int
+		delay    time.Duration
+	}
+
+	tests := map[string]testCase{
+		"no failures":       {failures: 0, delay: 0},
+		"threshold":         {failures: 1, delay: time.Second},
+		"doubled":           {failures: 2, delay: 2 * time.Second},
+		"doubled twice":     {failures: 3, delay: 4 * time.Second},
+		"capped by maximum": {failures: 6, delay: 4 * time.Second},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			l, _, _ := newTestLimiter(params)
+			// every failure is from another ip, so none is locked out
+			for i := 0; i < test.failures; i++ {
+				registerFailures(t, l, 1, "user@mail.ru", "10.0.1."+strconv.Itoa(i))
+			}
+
+			delay, err := l.Check("user@mail.ru", "10.0.0.1")
+			if err != nil {
+				t.Fatalf("Check: unexpected error %v", err)
+			}
+			if delay != test.delay {
+				t.Errorf("Check: delay = %v, expected %v", delay, test.delay)
+			}
+		})
+	}
+}
+
+func TestLimiter_Reset(t *testing.T) {
+	l, srv, _ := newTestLimiter(testParams)
+
+	registerFailures(t, l, 2, "user@mail.ru", "10.0.0.1")
+	if err := l.Reset("user@mail.ru"); err != nil {
+		t.Fatalf("Reset: unexpected error %v", err)
+	}
+
+	delay, err := l.Check("user@mail.ru", "10.0.0.1")
+	if err != nil || delay != 0 {
+		t.Errorf("Check after Reset = (%v, %v), expected (0, nil)", delay, err)
+	}
+	if srv.Exists(attemptsKey(accountKind, "user@mail.ru")) {
+		t.Errorf("account counter is kept after Reset")
+	}
+	// a successful login does not forgive the ip
+	if !srv.Exists(attemptsKey(ipKind, "10.0.0.1")) {
+		t.Errorf("ip counter is cleared by Reset")
+	}
+
+	// the account is counted from zero again
+	registerFailures(t, l, 2, "user@mail.ru", "10.0.0.2")
+	checkLocked(t, l, "user@mail.ru", "10.0.0.3", false)
+}
+
+func TestLimiter_RedisError(t *testing.T) {
+	l, srv, _ := newTestLimiter(testParams)
+	srv.Err = errors.New("connection refused")
+
+	// logins are not blocked while redis is down
+	delay, err := l.Check("user@mail.ru", "10.0.0.1")
+	if err != nil || delay != 0 {
+		t.Errorf("Check = (%v, %v), expected (0, nil)", delay, err)
+	}
+	if err = l.RegisterFailure("user@mail.ru", "10.0.0.1"); err == nil {
+		t.Errorf("RegisterFailure: expected error")
+	}
+}
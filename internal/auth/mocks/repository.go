@@ -36,18 +36,18 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 }
 
 // Authenticate mocks base method.
-func (m *MockRepository) Authenticate(email, password string) (models.User, error) {
+func (m *MockRepository) Authenticate(email, password, ip string) (models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Authenticate", email, password)
+	ret := m.ctrl.Call(m, "Authenticate", email, password, ip)
 	ret0, _ := ret[0].(models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Authenticate indicates an expected call of Authenticate.
-func (mr *MockRepositoryMockRecorder) Authenticate(email, password interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Authenticate(email, password, ip interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockRepository)(nil).Authenticate), email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockRepository)(nil).Authenticate), email, password, ip)
 }
 
 // CheckAuth mocks base method.
@@ -79,6 +79,20 @@ func (mr *MockRepositoryMockRecorder) DeleteSession(userId, sessionId interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockRepository)(nil).DeleteSession), userId, sessionId)
 }
 
+// DeleteUserSessions mocks base method.
+func (m *MockRepository) DeleteUserSessions(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSessions", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSessions indicates an expected call of DeleteUserSessions.
+func (mr *MockRepositoryMockRecorder) DeleteUserSessions(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSessions", reflect.TypeOf((*MockRepository)(nil).DeleteUserSessions), userId)
+}
+
 // Register mocks base method.
 func (m *MockRepository) Register(user *models.User) error {
 	m.ctrl.T.Helper()
@@ -37,9 +37,9 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // Authenticate mocks base method.
-func (m *MockService) Authenticate(login, hashedPassword string) (models.User, auth.SessionParams, error) {
+func (m *MockService) Authenticate(login, hashedPassword, ip string, rememberMe bool) (models.User, auth.SessionParams, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Authenticate", login, hashedPassword)
+	ret := m.ctrl.Call(m, "Authenticate", login, hashedPassword, ip, rememberMe)
 	ret0, _ := ret[0].(models.User)
 	ret1, _ := ret[1].(auth.SessionParams)
 	ret2, _ := ret[2].(error)
@@ -47,9 +47,9 @@ func (m *MockService) Authenticate(login, hashedPassword string) (models.User, a
 }
 
 // Authenticate indicates an expected call of Authenticate.
-func (mr *MockServiceMockRecorder) Authenticate(login, hashedPassword interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) Authenticate(login, hashedPassword, ip, rememberMe interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockService)(nil).Authenticate), login, hashedPassword)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockService)(nil).Authenticate), login, hashedPassword, ip, rememberMe)
 }
 
 // CheckAuth mocks base method.
@@ -68,17 +68,17 @@ func (mr *MockServiceMockRecorder) CheckAuth(userId, sessionId interface{}) *gom
 }
 
 // CreateSession mocks base method.
-func (m *MockService) CreateSession(userId int) auth.SessionParams {
+func (m *MockService) CreateSession(userId int, rememberMe bool) auth.SessionParams {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", userId)
+	ret := m.ctrl.Call(m, "CreateSession", userId, rememberMe)
 	ret0, _ := ret[0].(auth.SessionParams)
 	return ret0
 }
 
 // CreateSession indicates an expected call of CreateSession.
-func (mr *MockServiceMockRecorder) CreateSession(userId interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) CreateSession(userId, rememberMe interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockService)(nil).CreateSession), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockService)(nil).CreateSession), userId, rememberMe)
 }
 
 // DeleteSession mocks base method.
@@ -95,6 +95,20 @@ func (mr *MockServiceMockRecorder) DeleteSession(userId, sessionId interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockService)(nil).DeleteSession), userId, sessionId)
 }
 
+// DeleteUserSessions mocks base method.
+func (m *MockService) DeleteUserSessions(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSessions", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSessions indicates an expected call of DeleteUserSessions.
+func (mr *MockServiceMockRecorder) DeleteUserSessions(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSessions", reflect.TypeOf((*MockService)(nil).DeleteUserSessions), userId)
+}
+
 // Register mocks base method.
 func (m *MockService) Register(user *auth.RegisterParams) (models.User, auth.SessionParams, error) {
 	m.ctrl.T.Helper()
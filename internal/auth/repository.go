@@ -12,9 +12,12 @@ var (
 )
 
 type Repository interface {
-	Authenticate(email, password string) (models.User, error)
+	Authenticate(email, password, ip string) (models.User, error)
+	// SetSession stores the session until it is idle for session.IdleTimeout or expiration passes since creation.
 	SetSession(id string, session *models.Session, expiration time.Duration) error
+	// CheckAuth validates the session and extends its idle timeout.
 	CheckAuth(userId, sessionId string) (models.User, error)
 	Register(user *models.User) error
 	DeleteSession(userId, sessionId string) error
+	DeleteUserSessions(userId int) error
 }
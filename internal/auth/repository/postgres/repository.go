@@ -13,19 +13,22 @@ import (
 
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/auth"
 	hasherPkg "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/auth/hasher"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/auth/limiter"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 )
 
 type repository struct {
-	db  *sql.DB
-	rdb *redis.Client
-	ctx context.Context
-	log *zap.Logger
+	db      *sql.DB
+	rdb     *redis.Client
+	ctx     context.Context
+	log     *zap.Logger
+	limiter limiter.Limiter
+	now     func() time.Time
 }
 
-func NewRepository(db *sql.DB, rdb *redis.Client, ctx context.Context, log *zap.Logger) auth.Repository {
-	return &repository{db, rdb, ctx, log}
+func NewRepository(db *sql.DB, rdb *redis.Client, ctx context.Context, log *zap.Logger, limiter limiter.Limiter) auth.Repository {
+	return &repository{db, rdb, ctx, log, limiter, time.Now}
 }
 
 func scanUser(user *models.User, row *sql.Row) error {
@@ -36,18 +39,28 @@ func scanUser(user *models.User, row *sql.Row) error {
 	return err
 }
 
-const authCommand = "SELECT * FROM users WHERE email = $1"
+const authCommand = "SELECT id, username, email, hashed_password, name, profile_image, website_url, account_type FROM users WHERE email = $1"
 
-func (rep *repository) Authenticate(email, password string) (models.User, error) {
+const cancelDeletionCmd = "DELETE FROM account_deletions WHERE user_id = $1"
+
+func (rep *repository) Authenticate(email, password, ip string) (models.User, error) {
 	const fnAuthenticate = "Authenticate"
 
+	delay, err := rep.limiter.Check(email, ip)
+	if err != nil {
+		rep.log.Warn("Login attempt blocked", zap.String("email", email), zap.String("ip", ip))
+		return models.User{}, err
+	}
+	time.Sleep(delay)
+
 	row := rep.db.QueryRow(authCommand, email)
 	user := models.User{}
 	hasher := hasherPkg.NewHasher()
 
-	err := scanUser(&user, row)
+	err = scanUser(&user, row)
 
 	if errors.Is(err, sql.ErrNoRows) {
+		rep.registerFailedLogin(email, ip)
 		return models.User{}, errors.Wrap(pkgErrors.ErrUserNotFound,
 			pkgErrors.ErrRepositoryQuery{
 				Func:   fnAuthenticate,
@@ -68,37 +81,91 @@ func (rep *repository) Authenticate(email, password string) (models.User, error)
 	}
 
 	if err = hasher.CompareHashAndPassword(user.HashedPassword, password); err != nil {
+		rep.registerFailedLogin(email, ip)
 		return models.User{}, errors.Wrapf(pkgErrors.ErrWrongLoginOrPassword,
 			"%s: error [%s]", fnAuthenticate, err)
 	}
 
+	if err = rep.limiter.Reset(email); err != nil {
+		rep.log.Error("Failed to reset failed logins counter", zap.Error(err), zap.String("email", email))
+	}
+
+	// logging in during the grace period cancels the account deletion
+	if _, err = rep.db.Exec(cancelDeletionCmd, user.Id); err != nil {
+		rep.log.Error(constants.DBQueryError, zap.Error(err), zap.String("sql_query", cancelDeletionCmd),
+			zap.Int("user_id", user.Id))
+	}
+
 	return user, nil
 }
 
-func (rep *repository) SetSession(sessionId string, session *models.Session, expiration time.Duration) error {
-	tmp, _ := session.MarshalJSON()
+func (rep *repository) registerFailedLogin(email, ip string) {
+	if err := rep.limiter.RegisterFailure(email, ip); err != nil {
+		rep.log.Error("Failed to register failed login", zap.Error(err), zap.String("email", email),
+			zap.String("ip", ip))
+	}
+}
+
+func sessionKey(sessionId string) string {
+	return "session:" + sessionId
+}
 
-	err := rep.rdb.HSet(rep.ctx, strconv.Itoa(session.UserId), sessionId, tmp).Err()
+// sessionTTL returns the time the session may stay idle from now, bounded by its absolute expiration.
+func sessionTTL(session *models.Session, now time.Time) time.Duration {
+	remaining := time.Unix(session.ExpiresAt, 0).Sub(now)
+	if session.IdleTimeout > 0 && session.IdleTimeout < remaining {
+		return session.IdleTimeout
+	}
+	return remaining
+}
+
+func (rep *repository) SetSession(sessionId string, session *models.Session, expiration time.Duration) error {
+	now := rep.now()
+	session.ExpiresAt = now.Add(expiration).Unix()
+	tmp, err := session.MarshalJSON()
 	if err != nil {
-		rep.rdb.Expire(rep.ctx, strconv.Itoa(session.UserId), expiration)
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
 	}
 
-	return err
+	err = rep.rdb.Set(rep.ctx, sessionKey(sessionId), tmp, sessionTTL(session, now)).Err()
+	if err != nil {
+		rep.log.Error("Failed to save session to redis", zap.Error(err), zap.Int("user_id", session.UserId))
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return nil
 }
 
 const checkAuthCmd = `
-		SELECT * 
-		FROM users 
+		SELECT id, username, email, hashed_password, name, profile_image, website_url, account_type
+		FROM users
 		WHERE id = $1;`
 
 func (rep *repository) CheckAuth(userId, sessionId string) (models.User, error) {
-	err := rep.rdb.HGet(rep.ctx, userId, sessionId).Err()
+	data, err := rep.rdb.Get(rep.ctx, sessionKey(sessionId)).Bytes()
 	if err != nil {
 		rep.log.Error("Failed to get session from redis", zap.Error(err), zap.String("user_id", userId),
 			zap.String("session_id", sessionId))
 		return models.User{}, errors.Wrap(pkgErrors.ErrUnauthorized, err.Error())
 	}
 
+	var session models.Session
+	if err = session.UnmarshalJSON(data); err != nil {
+		return models.User{}, errors.Wrap(pkgErrors.ErrUnauthorized, err.Error())
+	}
+	if strconv.Itoa(session.UserId) != userId {
+		return models.User{}, errors.Wrap(pkgErrors.ErrUnauthorized, "session belongs to another user")
+	}
+
+	ttl := sessionTTL(&session, rep.now())
+	if ttl < time.Millisecond {
+		rep.rdb.Del(rep.ctx, sessionKey(sessionId))
+		return models.User{}, errors.Wrap(pkgErrors.ErrUnauthorized, "session expired")
+	}
+	// Expire rounds the ttl to seconds, up for a ttl below a second, so the session could outlive its deadline
+	if err = rep.rdb.PExpire(rep.ctx, sessionKey(sessionId), ttl).Err(); err != nil {
+		rep.log.Error("Failed to renew session", zap.Error(err), zap.String("session_id", sessionId))
+	}
+
 	var user models.User
 	row := rep.db.QueryRow(checkAuthCmd, userId)
 	err = scanUser(&user, row)
@@ -112,10 +179,31 @@ func (rep *repository) CheckAuth(userId, sessionId string) (models.User, error)
 }
 
 func (rep *repository) DeleteSession(userId, sessionId string) error {
-	if err := rep.rdb.HGet(rep.ctx, userId, sessionId).Err(); err != nil {
+	deleted, err := rep.rdb.Del(rep.ctx, sessionKey(sessionId)).Result()
+	if err != nil {
 		return err
 	}
-	rep.rdb.HDel(rep.ctx, userId, sessionId)
+	if deleted == 0 {
+		return errors.Wrap(pkgErrors.ErrUnauthorized, "session not found")
+	}
+	return nil
+}
+
+// DeleteUserSessions revokes all sessions of the user. Session tokens start with the user id,
+// so they are found by the key prefix.
+func (rep *repository) DeleteUserSessions(userId int) error {
+	pattern := sessionKey(strconv.Itoa(userId) + "$*")
+	iter := rep.rdb.Scan(rep.ctx, 0, pattern, 100).Iterator()
+	for iter.Next(rep.ctx) {
+		if err := rep.rdb.Del(rep.ctx, iter.Val()).Err(); err != nil {
+			rep.log.Error("Failed to delete session", zap.Error(err), zap.String("key", iter.Val()))
+			return errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+	}
+	if err := iter.Err(); err != nil {
+		rep.log.Error("Failed to scan user sessions", zap.Error(err), zap.Int("user_id", userId))
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
 	return nil
 }
 
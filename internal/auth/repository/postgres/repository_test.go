@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/redis/redistest"
+)
+
+var userColumns = []string{"id", "username", "email", "hashed_password", "name", "profile_image", "website_url", "account_type"}
+
+type sessionFixture struct {
+	rep   *repository
+	mock  sqlmock.Sqlmock
+	srv   *redistest.Server
+	clock *redistest.Clock
+}
+
+func newSessionFixture(t *testing.T) *sessionFixture {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("can't create mock: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	clock := redistest.NewClock()
+	rdb, srv := redistest.NewClient(clock.Now)
+	rep := NewRepository(db, rdb, context.Background(), zap.NewNop(), nil).(*repository)
+	rep.now = clock.Now
+	return &sessionFixture{rep: rep, mock: mock, srv: srv, clock: clock}
+}
+
+func (f *sessionFixture) expectUser(userId int) {
+	rows := sqlmock.NewRows(userColumns).
+		AddRow(userId, "user", "user@mail.ru", "hash", "User", "", "", "personal")
+	f.mock.ExpectQuery(regexp.QuoteMeta(checkAuthCmd)).WithArgs(strconv.Itoa(userId)).WillReturnRows(rows)
+}
+
+func TestSessionTTL(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		idle      time.Duration
+		expiresAt time.Time
+		ttl       time.Duration
+	}
+
+	tests := map[string]testCase{
+		"idle timeout first": {idle: time.Hour, expiresAt: now.Add(24 * time.Hour), ttl: time.Hour},
+		"deadline first":     {idle: time.Hour, expiresAt: now.Add(20 * time.Minute), ttl: 20 * time.Minute},
+		"no idle timeout":    {idle: 0, expiresAt: now.Add(24 * time.Hour), ttl: 24 * time.Hour},
+		"expired":            {idle: time.Hour, expiresAt: now.Add(-time.Minute), ttl: -time.Minute},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			session := models.Session{IdleTimeout: test.idle, ExpiresAt: test.expiresAt.Unix()}
+			if ttl := sessionTTL(&session, now); ttl != test.ttl {
+				t.Errorf("sessionTTL = %v, expected %v", ttl, test.ttl)
+			}
+		})
+	}
+}
+
+func TestRepository_SetSession(t *testing.T) {
+	type testCase struct {
+		idle       time.Duration
+		expiration time.Duration
+		ttl        time.Duration
+	}
+
+	tests := map[string]testCase{
+		"remember me":     {idle: 24 * time.Hour, expiration: 30 * 24 * time.Hour, ttl: 24 * time.Hour},
+		"short session":   {idle: 24 * time.Hour, expiration: 2 * time.Hour, ttl: 2 * time.Hour},
+		"no idle timeout": {idle: 0, expiration: 2 * time.Hour, ttl: 2 * time.Hour},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f := newSessionFixture(t)
+			session := models.Session{UserId: 1, UserEmail: "user@mail.ru", IdleTimeout: test.idle}
+			if err := f.rep.SetSession("1$token", &session, test.expiration); err != nil {
+				t.Fatalf("SetSession: unexpected error %v", err)
+			}
+
+			if ttl := f.srv.TTL(sessionKey("1$token")); ttl != test.ttl {
+				t.Errorf("session ttl = %v, expected %v", ttl, test.ttl)
+			}
+			if expiresAt := f.clock.Now().Add(test.expiration).Unix(); session.ExpiresAt != expiresAt {
+				t.Errorf("ExpiresAt = %v, expected %v", session.ExpiresAt, expiresAt)
+			}
+		})
+	}
+}
+
+func TestRepository_CheckAuth_Renewal(t *testing.T) {
+	f := newSessionFixture(t)
+	key := sessionKey("1$token")
+
+	session := models.Session{UserId: 1, UserEmail: "user@mail.ru", IdleTimeout: time.Hour}
+	if err := f.rep.SetSession("1$token", &session, 3*time.Hour); err != nil {
+		t.Fatalf("SetSession: unexpected error %v", err)
+	}
+	deadline := time.Unix(session.ExpiresAt, 0)
+
+	steps := []struct {
+		advance time.Duration
+		ttl     time.Duration
+	}{
+		{advance: 50 * time.Minute, ttl: time.Hour},
+		{advance: 50 * time.Minute, ttl: time.Hour},
+		// the idle timeout is cut by the deadline
+		{advance: 50 * time.Minute, ttl: 30 * time.Minute},
+		{advance: 20 * time.Minute, ttl: 10 * time.Minute},
+		// below a second the ttl is not rounded up past the deadline
+		{advance: 10*time.Minute - 500*time.Millisecond, ttl: 500 * time.Millisecond},
+	}
+	for i, step := range steps {
+		f.clock.Advance(step.advance)
+		f.expectUser(1)
+		if _, err := f.rep.CheckAuth("1", "1$token"); err != nil {
+			t.Fatalf("step %d: CheckAuth: unexpected error %v", i, err)
+		}
+
+		ttl := f.srv.TTL(key)
+		if ttl != step.ttl {
+			t.Errorf("step %d: session ttl = %v, expected %v", i, ttl, step.ttl)
+		}
+		if f.clock.Now().Add(ttl).After(deadline) {
+			t.Errorf("step %d: session renewed past its deadline", i)
+		}
+	}
+
+	f.clock.Advance(500 * time.Millisecond)
+	if _, err := f.rep.CheckAuth("1", "1$token"); !errors.Is(err, pkgErrors.ErrUnauthorized) {
+		t.Errorf("CheckAuth at the deadline: error = %v, expected %v", err, pkgErrors.ErrUnauthorized)
+	}
+	if err := f.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRepository_CheckAuth(t *testing.T) {
+	type testCase struct {
+		prepare func(f *sessionFixture)
+		userId  string
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"valid session": {
+			prepare: func(f *sessionFixture) {
+				session := models.Session{UserId: 1, IdleTimeout: time.Hour}
+				_ = f.rep.SetSession("1$token", &session, 24*time.Hour)
+				f.expectUser(1)
+			},
+			userId: "1",
+			err:    nil,
+		},
+		"idle timeout passed": {
+			prepare: func(f *sessionFixture) {
+				session := models.Session{UserId: 1, IdleTimeout: time.Hour}
+				_ = f.rep.SetSession("1$token", &session, 24*time.Hour)
+				f.clock.Advance(time.Hour)
+			},
+			userId: "1",
+			err:    pkgErrors.ErrUnauthorized,
+		},
+		"deadline passed": {
+			prepare: func(f *sessionFixture) {
+				// a session stored without expiration is still checked against its deadline
+				session := models.Session{UserId: 1, ExpiresAt: f.clock.Now().Add(-time.Second).Unix()}
+				data, _ := session.MarshalJSON()
+				_ = f.rep.rdb.Set(f.rep.ctx, sessionKey("1$token"), data, 0).Err()
+			},
+			userId: "1",
+			err:    pkgErrors.ErrUnauthorized,
+		},
+		"another user": {
+			prepare: func(f *sessionFixture) {
+				session := models.Session{UserId: 1, IdleTimeout: time.Hour}
+				_ = f.rep.SetSession("1$token", &session, 24*time.Hour)
+			},
+			userId: "2",
+			err:    pkgErrors.ErrUnauthorized,
+		},
+		"no session": {
+			userId: "1",
+			err:    pkgErrors.ErrUnauthorized,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			f := newSessionFixture(t)
+			if test.prepare != nil {
+				test.prepare(f)
+			}
+
+			_, err := f.rep.CheckAuth(test.userId, "1$token")
+			if !errors.Is(err, test.err) {
+				t.Errorf("CheckAuth: error = %v, expected %v", err, test.err)
+			}
+			if test.err != nil && test.userId == "1" && f.srv.Exists(sessionKey("1$token")) {
+				t.Errorf("expired session is kept")
+			}
+			if err = f.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
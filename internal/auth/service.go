@@ -7,8 +7,10 @@ import (
 )
 
 type SessionParams struct {
-	Token      string
-	LivingTime time.Duration
+	Token       string
+	LivingTime  time.Duration // absolute session lifetime
+	IdleTimeout time.Duration // session expires after this period of inactivity
+	RememberMe  bool
 }
 
 type RegisterParams struct {
@@ -16,13 +18,15 @@ type RegisterParams struct {
 	Email    string
 	Name     string
 	Password string
+	IP       string
 }
 
 type Service interface {
-	Authenticate(login, hashedPassword string) (models.User, SessionParams, error)
+	Authenticate(login, hashedPassword, ip string, rememberMe bool) (models.User, SessionParams, error)
 	Register(user *RegisterParams) (models.User, SessionParams, error)
 	SetSession(id string, session *models.Session, expiration time.Duration) error
 	CheckAuth(userId, sessionId string) (models.User, error)
 	DeleteSession(userId, sessionId string) error
-	CreateSession(userId int) SessionParams
+	DeleteUserSessions(userId int) error
+	CreateSession(userId int, rememberMe bool) SessionParams
 }
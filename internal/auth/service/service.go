@@ -5,9 +5,7 @@ import (
 	hasherPkg "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/auth/hasher"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
-	"github.com/google/uuid"
 	"github.com/pkg/errors"
-	"strconv"
 	"time"
 )
 
@@ -19,25 +17,24 @@ type service struct {
 	rep auth.Repository
 }
 
-func (serv *service) Authenticate(email, password string) (models.User, auth.SessionParams, error) {
-	user, err := serv.rep.Authenticate(email, password)
+func (serv *service) Authenticate(email, password, ip string, rememberMe bool) (models.User, auth.SessionParams, error) {
+	user, err := serv.rep.Authenticate(email, password, ip)
 	if err != nil {
 		return user, auth.SessionParams{}, errors.Wrap(err, "Authenticate")
 	}
 
-	sessionParams := serv.CreateSession(user.Id)
+	sessionParams := serv.CreateSession(user.Id, rememberMe)
 	sessionData := models.Session{
-		UserId:    user.Id,
-		UserEmail: user.Email,
+		UserId:      user.Id,
+		UserEmail:   user.Email,
+		IdleTimeout: sessionParams.IdleTimeout,
 	}
 	err = serv.SetSession(sessionParams.Token, &sessionData, sessionParams.LivingTime)
 	return user, sessionParams, err
 }
 
-func (serv *service) CreateSession(userId int) auth.SessionParams {
-	token := strconv.Itoa(userId) + "$" + uuid.New().String()
-	livingTime := 5 * time.Hour
-	return auth.SessionParams{Token: token, LivingTime: livingTime}
+func (serv *service) CreateSession(userId int, rememberMe bool) auth.SessionParams {
+	return auth.NewSessionParams(userId, rememberMe)
 }
 
 func (serv *service) SetSession(token string, session *models.Session, expiration time.Duration) error {
@@ -52,6 +49,10 @@ func (serv *service) DeleteSession(userId, sessionId string) error {
 	return serv.rep.DeleteSession(userId, sessionId)
 }
 
+func (serv *service) DeleteUserSessions(userId int) error {
+	return serv.rep.DeleteUserSessions(userId)
+}
+
 func (serv *service) Register(user *auth.RegisterParams) (models.User, auth.SessionParams, error) {
 	hasher := hasherPkg.NewHasher()
 	hash, _ := hasher.GetHashedPassword(user.Password)
@@ -71,5 +72,5 @@ func (serv *service) Register(user *auth.RegisterParams) (models.User, auth.Sess
 		return models.User{}, auth.SessionParams{}, errors.Wrap(err, "Register")
 	}
 
-	return serv.Authenticate(user.Email, user.Password)
+	return serv.Authenticate(user.Email, user.Password, user.IP, false)
 }
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/config"
+)
+
+// NewSessionParams generates a session token for the user. Sessions created with
+// rememberMe use the long-lived timeouts.
+func NewSessionParams(userId int, rememberMe bool) SessionParams {
+	params := SessionParams{
+		Token:       strconv.Itoa(userId) + "$" + uuid.New().String(),
+		LivingTime:  viper.GetDuration(config.SessionConfig.AbsoluteTimeout),
+		IdleTimeout: viper.GetDuration(config.SessionConfig.IdleTimeout),
+		RememberMe:  rememberMe,
+	}
+	if rememberMe {
+		params.LivingTime = viper.GetDuration(config.SessionConfig.RememberAbsoluteTimeout)
+		params.IdleTimeout = viper.GetDuration(config.SessionConfig.RememberIdleTimeout)
+	}
+	return params
+}
@@ -9,6 +9,7 @@ import (
 
 	pkgBoards "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/boards"
 	mw "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/middleware"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 	"github.com/julienschmidt/httprouter"
 )
@@ -16,16 +17,16 @@ import (
 func RegisterHandlers(mux *httprouter.Router, logger *zap.Logger, authorizer mw.Authorizer, csrf mw.CSRFMiddleware, access mw.AccessChecker, serv pkgBoards.Service, m *mw.HttpMetricsMiddleware) {
 	del := delivery{serv, logger}
 
-	mux.POST("/boards", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.create))), logger), logger), logger))
-	mux.GET("/boards", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.list))), logger), logger), logger))
-	mux.GET("/boards/:id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.get))), logger), logger), logger))
-	mux.PUT("/boards/:id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(access.WriteChecker(del.fullUpdate)))), logger), logger), logger))
-	mux.PATCH("/boards/:id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(access.WriteChecker(del.partialUpdate)))), logger), logger), logger))
-	mux.DELETE("/boards/:id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(access.WriteChecker(del.delete)))), logger), logger), logger))
+	mux.POST("/boards", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer.Scoped(models.ScopeBoards)(csrf(del.create))), logger), logger), logger))
+	mux.GET("/boards", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer.Scoped(models.ScopeBoards)(csrf(del.list))), logger), logger), logger))
+	mux.GET("/boards/:id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer.Scoped(models.ScopeBoards)(csrf(del.get))), logger), logger), logger))
+	mux.PUT("/boards/:id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer.Scoped(models.ScopeBoards)(csrf(access.WriteChecker(del.fullUpdate)))), logger), logger), logger))
+	mux.PATCH("/boards/:id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer.Scoped(models.ScopeBoards)(csrf(access.WriteChecker(del.partialUpdate)))), logger), logger), logger))
+	mux.DELETE("/boards/:id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer.Scoped(models.ScopeBoards)(csrf(access.WriteChecker(del.delete)))), logger), logger), logger))
 
-	mux.POST("/boards/:id/pins/:pin_id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(access.WriteChecker(del.addPin)))), logger), logger), logger))
-	mux.GET("/boards/:id/pins", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.pinsList))), logger), logger), logger))
-	mux.DELETE("/boards/:id/pins/:pin_id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(access.WriteChecker(del.removePin)))), logger), logger), logger))
+	mux.POST("/boards/:id/pins/:pin_id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer.Scoped(models.ScopeBoards)(csrf(access.WriteChecker(del.addPin)))), logger), logger), logger))
+	mux.GET("/boards/:id/pins", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer.Scoped(models.ScopeBoards)(csrf(del.pinsList))), logger), logger), logger))
+	mux.DELETE("/boards/:id/pins/:pin_id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer.Scoped(models.ScopeBoards)(csrf(access.WriteChecker(del.removePin)))), logger), logger), logger))
 }
 
 type delivery struct {
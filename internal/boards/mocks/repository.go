@@ -183,6 +183,21 @@ func (mr *MockRepositoryMockRecorder) PartialUpdate(params interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartialUpdate", reflect.TypeOf((*MockRepository)(nil).PartialUpdate), params)
 }
 
+// PinIds mocks base method.
+func (m *MockRepository) PinIds(boardId int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinIds", boardId)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PinIds indicates an expected call of PinIds.
+func (mr *MockRepositoryMockRecorder) PinIds(boardId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinIds", reflect.TypeOf((*MockRepository)(nil).PinIds), boardId)
+}
+
 // PinsList mocks base method.
 func (m *MockRepository) PinsList(boardId, page, limit int) ([]models.Pin, error) {
 	m.ctrl.T.Helper()
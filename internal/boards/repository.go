@@ -38,6 +38,8 @@ type Repository interface {
 
 	AddPin(boardId, pinId int) error
 	PinsList(boardId int, page, limit int) ([]models.Pin, error)
+	// PinIds returns the ids of all pins saved to the board.
+	PinIds(boardId int) ([]int, error)
 	RemovePin(boardId, pinId int) error
 	HasPin(boardId, pinId int) (bool, error)
 
@@ -22,7 +22,7 @@ func NewPostgresRepository(db *sql.DB, log *zap.Logger) pkgBoards.Repository {
 
 const insertCommand = `INSERT INTO boards (name, description, privacy, user_id) 
 				      	   VALUES ($1, $2, $3, $4)
-						   RETURNING *;`
+						   RETURNING id, name, description, privacy, user_id;`
 
 func (rep *repository) Create(params *pkgBoards.CreateParams) (models.Board, error) {
 	const fnCreate = "Create"
@@ -51,7 +51,7 @@ func (rep *repository) Create(params *pkgBoards.CreateParams) (models.Board, err
 	return createdBoard, nil
 }
 
-const getBoardsCommand = `SELECT * 
+const getBoardsCommand = `SELECT id, name, description, privacy, user_id
 							  FROM boards
 							  WHERE user_id = $1;`
 
@@ -90,7 +90,7 @@ func (rep *repository) List(userId int) ([]models.Board, error) {
 	return boards, nil
 }
 
-const getCmd = `SELECT *
+const getCmd = `SELECT id, name, description, privacy, user_id
 				 FROM boards
 				 WHERE id = $1;`
 
@@ -128,7 +128,7 @@ const fullUpdateCmd = `UPDATE boards
     							description = $2::TEXT,
     							privacy = $3::privacy
 								WHERE id = $4
-								RETURNING *;`
+								RETURNING id, name, description, privacy, user_id;`
 
 func (rep *repository) FullUpdate(params *pkgBoards.FullUpdateParams) (models.Board, error) {
 	const fnFullUpdate = "FullUpdate"
@@ -169,7 +169,7 @@ const partialUpdateCmd = `UPDATE boards
     							description = CASE WHEN $3::boolean THEN $4::TEXT ELSE description END,
     							privacy = CASE WHEN $5::boolean THEN $6::privacy ELSE privacy END
 								WHERE id = $7
-								RETURNING *;`
+								RETURNING id, name, description, privacy, user_id;`
 
 func (rep *repository) PartialUpdate(params *pkgBoards.PartialUpdateParams) (models.Board, error) {
 	const fnPartialUpdate = "PartialUpdate"
@@ -248,7 +248,7 @@ func (rep *repository) AddPin(boardId, pinId int) error {
 	return nil
 }
 
-const pinsListCmd = `SELECT pins.id, title, description, media_source, media_source_color, author_id 
+const pinsListCmd = `SELECT pins.id, title, description, media_source, media_source_color, media_type, author_id 
 						FROM pins 
 						JOIN boards_pins AS b
 						ON b.board_id = $1 AND b.pin_id = pins.id
@@ -275,7 +275,7 @@ func (rep *repository) PinsList(boardId int, page, limit int) ([]models.Pin, err
 
 	for rows.Next() {
 		err = rows.Scan(&retrievedPin.Id, &title, &description, &mediaSource, &retrievedPin.MediaSourceColor,
-			&retrievedPin.Author)
+			&retrievedPin.MediaType, &retrievedPin.Author)
 		if err != nil {
 			return nil, errors.Wrap(pkgErrors.ErrDb,
 				pkgErrors.ErrRepositoryQuery{
@@ -294,6 +294,43 @@ func (rep *repository) PinsList(boardId int, page, limit int) ([]models.Pin, err
 	return pins, nil
 }
 
+const pinIdsCmd = `SELECT pin_id
+						FROM boards_pins
+						WHERE board_id = $1;`
+
+func (rep *repository) PinIds(boardId int) ([]int, error) {
+	const fnPinIds = "PinIds"
+
+	rows, err := rep.db.Query(pinIdsCmd, boardId)
+	if err != nil {
+		return nil, errors.Wrap(pkgErrors.ErrDb,
+			pkgErrors.ErrRepositoryQuery{
+				Func:   fnPinIds,
+				Query:  pinIdsCmd,
+				Params: []any{boardId},
+				Err:    err,
+			}.Error())
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err = rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(pkgErrors.ErrDb,
+				pkgErrors.ErrRepositoryQuery{
+					Func:   fnPinIds,
+					Query:  pinIdsCmd,
+					Params: []any{boardId},
+					Err:    err,
+				}.Error())
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
 const RemovePinCmd = `DELETE FROM boards_pins
 						WHERE pin_id = $1 AND board_id = $2;`
 
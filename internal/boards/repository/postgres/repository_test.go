@@ -41,7 +41,7 @@ func TestCreate(t *testing.T) {
 
 	const createCmd = `INSERT INTO boards (name, description, privacy, user_id) 
 				       VALUES ($1, $2, $3, $4)
-					   RETURNING *;`
+					   RETURNING id, name, description, privacy, user_id;`
 
 	tests := map[string]testCase{
 		"good query": {
@@ -124,7 +124,7 @@ func TestList(t *testing.T) {
 		err     error
 	}
 
-	const listCmd = `SELECT *
+	const listCmd = `SELECT id, name, description, privacy, user_id
 					 FROM boards
 					 WHERE user_id = $1;`
 
@@ -222,7 +222,7 @@ func TestGet(t *testing.T) {
 		err     error
 	}
 
-	const getCmd = `SELECT *
+	const getCmd = `SELECT id, name, description, privacy, user_id
 					FROM boards
 					WHERE id = $1;`
 
@@ -314,7 +314,7 @@ func TestFullUpdate(t *testing.T) {
 						   description = $2::TEXT,
 						   privacy = $3::privacy
 						   WHERE id = $4
-						   RETURNING *;`
+						   RETURNING id, name, description, privacy, user_id;`
 
 	tests := map[string]testCase{
 		"good query": {
@@ -408,7 +408,7 @@ func TestPartialUpdate(t *testing.T) {
     						  description = CASE WHEN $3::boolean THEN $4::TEXT ELSE description END,
     						  privacy = CASE WHEN $5::boolean THEN $6::privacy ELSE privacy END
 						      WHERE id = $7
-							  RETURNING *;`
+							  RETURNING id, name, description, privacy, user_id;`
 
 	tests := map[string]testCase{
 		"good query": {
@@ -557,6 +557,88 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestPinIds(t *testing.T) {
+	type fields struct {
+		mock sqlmock.Sqlmock
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		boardId int
+		ids     []int
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"good query": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"pin_id"}).AddRow(1).AddRow(5)
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(pinIdsCmd)).
+					WithArgs(3).
+					WillReturnRows(rows)
+			},
+			boardId: 3,
+			ids:     []int{1, 5},
+			err:     nil,
+		},
+		"no pins": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"pin_id"})
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(pinIdsCmd)).
+					WithArgs(3).
+					WillReturnRows(rows)
+			},
+			boardId: 3,
+			ids:     []int{},
+			err:     nil,
+		},
+		"query error": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(pinIdsCmd)).
+					WithArgs(3).
+					WillReturnError(fmt.Errorf("db error"))
+			},
+			boardId: 3,
+			ids:     nil,
+			err:     pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			repo := NewPostgresRepository(db, logger)
+
+			f := fields{mock: mock}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			ids, err := repo.PinIds(test.boardId)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(ids, test.ids) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.ids, ids)
+			}
+			if err = mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func TestPinsList(t *testing.T) {
 	type fields struct {
 		mock sqlmock.Sqlmock
@@ -575,10 +657,10 @@ func TestPinsList(t *testing.T) {
 		"good query": {
 			prepare: func(f *fields) {
 				rows := sqlmock.NewRows([]string{"id", "title", "description", "media_source", "media_source_color",
-					"author_id"})
-				rows = rows.AddRow(1, "t1", "d1", "ms_url1", "rgb(39, 102, 120)", 12)
-				rows = rows.AddRow(2, "t2", "d2", "ms_url2", "rgb(39, 102, 120)", 12)
-				rows = rows.AddRow(3, "t3", "d3", "ms_url3", "rgb(39, 102, 120)", 12)
+					"media_type", "author_id"})
+				rows = rows.AddRow(1, "t1", "d1", "ms_url1", "rgb(39, 102, 120)", "image", 12)
+				rows = rows.AddRow(2, "t2", "d2", "ms_url2", "rgb(39, 102, 120)", "video", 12)
+				rows = rows.AddRow(3, "t3", "d3", "ms_url3", "rgb(39, 102, 120)", "gif", 12)
 				f.mock.
 					ExpectQuery(regexp.QuoteMeta(pinsListCmd)).
 					WithArgs(3, 30, 0).
@@ -589,11 +671,11 @@ func TestPinsList(t *testing.T) {
 			limit:   30,
 			pins: []models.Pin{
 				{Id: 1, Title: "t1", MediaSource: "ms_url1", MediaSourceColor: "rgb(39, 102, 120)", Description: "d1",
-					Author: 12},
+					MediaType: models.MediaTypeImage, Author: 12},
 				{Id: 2, Title: "t2", MediaSource: "ms_url2", MediaSourceColor: "rgb(39, 102, 120)", Description: "d2",
-					Author: 12},
+					MediaType: models.MediaTypeVideo, Author: 12},
 				{Id: 3, Title: "t3", MediaSource: "ms_url3", MediaSourceColor: "rgb(39, 102, 120)", Description: "d3",
-					Author: 12},
+					MediaType: models.MediaTypeGIF, Author: 12},
 			},
 			err: nil,
 		},
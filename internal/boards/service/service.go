@@ -46,7 +46,11 @@ func (serv *service) FullUpdate(params *boards.FullUpdateParams) (models.Board,
 		return models.Board{}, err
 	}
 
-	return serv.repo.FullUpdate(params)
+	board, err := serv.repo.FullUpdate(params)
+	if err != nil {
+		return models.Board{}, err
+	}
+	return board, serv.syncPinsPrivacy(board.Id)
 }
 
 func (serv *service) PartialUpdate(params *boards.PartialUpdateParams) (models.Board, error) {
@@ -66,11 +70,24 @@ func (serv *service) PartialUpdate(params *boards.PartialUpdateParams) (models.B
 		}
 	}
 
-	return serv.repo.PartialUpdate(params)
+	board, err := serv.repo.PartialUpdate(params)
+	if err != nil || !params.UpdatePrivacy {
+		return board, err
+	}
+	return board, serv.syncPinsPrivacy(board.Id)
 }
 
 func (serv *service) Delete(id int) error {
-	return serv.repo.Delete(id)
+	pinIds, err := serv.repo.PinIds(id)
+	if err != nil {
+		return err
+	}
+
+	err = serv.repo.Delete(id)
+	if err != nil {
+		return err
+	}
+	return serv.pinServ.SyncMediaPrivacy(pinIds)
 }
 
 func (serv *service) AddPin(boardId, pinId int) error {
@@ -82,7 +99,11 @@ func (serv *service) AddPin(boardId, pinId int) error {
 		return pkgErrors.ErrPinAlreadyAdded
 	}
 
-	return serv.repo.AddPin(boardId, pinId)
+	err = serv.repo.AddPin(boardId, pinId)
+	if err != nil {
+		return err
+	}
+	return serv.pinServ.SyncMediaPrivacy([]int{pinId})
 }
 
 func (serv *service) PinsList(userId, boardId int, page, limit int) ([]models.Pin, error) {
@@ -98,11 +119,25 @@ func (serv *service) PinsList(userId, boardId int, page, limit int) ([]models.Pi
 		}
 	}
 
+	err = serv.pinServ.SignPrivateMedia(pins, userId)
 	return pins, err
 }
 
 func (serv *service) RemovePin(boardId, pinId int) error {
-	return serv.repo.RemovePin(boardId, pinId)
+	err := serv.repo.RemovePin(boardId, pinId)
+	if err != nil {
+		return err
+	}
+	return serv.pinServ.SyncMediaPrivacy([]int{pinId})
+}
+
+// syncPinsPrivacy updates the visibility of the pin images after the board privacy may have changed.
+func (serv *service) syncPinsPrivacy(boardId int) error {
+	pinIds, err := serv.repo.PinIds(boardId)
+	if err != nil {
+		return err
+	}
+	return serv.pinServ.SyncMediaPrivacy(pinIds)
 }
 
 func (serv *service) CheckWriteAccess(userId, boardId string) (bool, error) {
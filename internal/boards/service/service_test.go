@@ -217,7 +217,8 @@ func TestGet(t *testing.T) {
 
 func TestFullUpdate(t *testing.T) {
 	type fields struct {
-		repo *mocks.MockRepository
+		repo     *mocks.MockRepository
+		pinsServ *pinsMock.MockService
 	}
 
 	type testCase struct {
@@ -230,18 +231,22 @@ func TestFullUpdate(t *testing.T) {
 	tests := map[string]testCase{
 		"usual": {
 			prepare: func(f *fields) {
-				f.repo.EXPECT().FullUpdate(&_boards.FullUpdateParams{
-					Id:          3,
-					Name:        "n1",
-					Description: "d1",
-					Privacy:     "secret",
-				}).Return(models.Board{
-					Id:          3,
-					Name:        "n1",
-					Description: "d1",
-					Privacy:     "secret",
-					UserId:      12,
-				}, nil)
+				gomock.InOrder(
+					f.repo.EXPECT().FullUpdate(&_boards.FullUpdateParams{
+						Id:          3,
+						Name:        "n1",
+						Description: "d1",
+						Privacy:     "secret",
+					}).Return(models.Board{
+						Id:          3,
+						Name:        "n1",
+						Description: "d1",
+						Privacy:     "secret",
+						UserId:      12,
+					}, nil),
+					f.repo.EXPECT().PinIds(3).Return([]int{1, 2}, nil),
+					f.pinsServ.EXPECT().SyncMediaPrivacy([]int{1, 2}).Return(nil),
+				)
 			},
 			params: _boards.FullUpdateParams{Id: 3, Name: "n1", Description: "d1", Privacy: "secret"},
 			board:  models.Board{Id: 3, Name: "n1", Description: "d1", Privacy: "secret", UserId: 12},
@@ -257,12 +262,12 @@ func TestFullUpdate(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			f := fields{repo: mocks.NewMockRepository(ctrl)}
+			f := fields{repo: mocks.NewMockRepository(ctrl), pinsServ: pinsMock.NewMockService(ctrl)}
 			if test.prepare != nil {
 				test.prepare(&f)
 			}
 
-			serv := NewBoardsService(f.repo, pinsMock.NewMockService(ctrl))
+			serv := NewBoardsService(f.repo, f.pinsServ)
 
 			board, err := serv.FullUpdate(&test.params)
 			if !errors.Is(err, test.err) {
@@ -277,7 +282,8 @@ func TestFullUpdate(t *testing.T) {
 
 func TestPartialUpdate(t *testing.T) {
 	type fields struct {
-		repo *mocks.MockRepository
+		repo     *mocks.MockRepository
+		pinsServ *pinsMock.MockService
 	}
 
 	type testCase struct {
@@ -290,21 +296,25 @@ func TestPartialUpdate(t *testing.T) {
 	tests := map[string]testCase{
 		"usual": {
 			prepare: func(f *fields) {
-				f.repo.EXPECT().PartialUpdate(&_boards.PartialUpdateParams{
-					Id:                3,
-					Name:              "n1",
-					UpdateName:        true,
-					Description:       "d1",
-					UpdateDescription: true,
-					Privacy:           "secret",
-					UpdatePrivacy:     true,
-				}).Return(models.Board{
-					Id:          3,
-					Name:        "n1",
-					Description: "d1",
-					Privacy:     "secret",
-					UserId:      12,
-				}, nil)
+				gomock.InOrder(
+					f.repo.EXPECT().PartialUpdate(&_boards.PartialUpdateParams{
+						Id:                3,
+						Name:              "n1",
+						UpdateName:        true,
+						Description:       "d1",
+						UpdateDescription: true,
+						Privacy:           "secret",
+						UpdatePrivacy:     true,
+					}).Return(models.Board{
+						Id:          3,
+						Name:        "n1",
+						Description: "d1",
+						Privacy:     "secret",
+						UserId:      12,
+					}, nil),
+					f.repo.EXPECT().PinIds(3).Return([]int{1, 2}, nil),
+					f.pinsServ.EXPECT().SyncMediaPrivacy([]int{1, 2}).Return(nil),
+				)
 			},
 			params: _boards.PartialUpdateParams{
 				Id:                3,
@@ -328,12 +338,12 @@ func TestPartialUpdate(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			f := fields{repo: mocks.NewMockRepository(ctrl)}
+			f := fields{repo: mocks.NewMockRepository(ctrl), pinsServ: pinsMock.NewMockService(ctrl)}
 			if test.prepare != nil {
 				test.prepare(&f)
 			}
 
-			serv := NewBoardsService(f.repo, pinsMock.NewMockService(ctrl))
+			serv := NewBoardsService(f.repo, f.pinsServ)
 
 			board, err := serv.PartialUpdate(&test.params)
 			if !errors.Is(err, test.err) {
@@ -348,7 +358,8 @@ func TestPartialUpdate(t *testing.T) {
 
 func TestDelete(t *testing.T) {
 	type fields struct {
-		repo *mocks.MockRepository
+		repo     *mocks.MockRepository
+		pinsServ *pinsMock.MockService
 	}
 
 	type testCase struct {
@@ -360,14 +371,21 @@ func TestDelete(t *testing.T) {
 	tests := map[string]testCase{
 		"usual": {
 			prepare: func(f *fields) {
-				f.repo.EXPECT().Delete(3).Return(nil)
+				gomock.InOrder(
+					f.repo.EXPECT().PinIds(3).Return([]int{1, 2}, nil),
+					f.repo.EXPECT().Delete(3).Return(nil),
+					f.pinsServ.EXPECT().SyncMediaPrivacy([]int{1, 2}).Return(nil),
+				)
 			},
 			id:  3,
 			err: nil,
 		},
 		"board not found": {
 			prepare: func(f *fields) {
-				f.repo.EXPECT().Delete(3).Return(pkgErrors.ErrBoardNotFound)
+				gomock.InOrder(
+					f.repo.EXPECT().PinIds(3).Return([]int{}, nil),
+					f.repo.EXPECT().Delete(3).Return(pkgErrors.ErrBoardNotFound),
+				)
 			},
 			id:  3,
 			err: pkgErrors.ErrBoardNotFound,
@@ -382,12 +400,12 @@ func TestDelete(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			f := fields{repo: mocks.NewMockRepository(ctrl)}
+			f := fields{repo: mocks.NewMockRepository(ctrl), pinsServ: pinsMock.NewMockService(ctrl)}
 			if test.prepare != nil {
 				test.prepare(&f)
 			}
 
-			serv := NewBoardsService(f.repo, pinsMock.NewMockService(ctrl))
+			serv := NewBoardsService(f.repo, f.pinsServ)
 
 			err := serv.Delete(test.id)
 			if !errors.Is(err, test.err) {
@@ -423,6 +441,7 @@ func TestPinsList(t *testing.T) {
 						{Id: 3, Title: "t3", MediaSource: "ms_url3", Description: "d3", Author: 12},
 					}, nil),
 					f.pinsServ.EXPECT().SetLikedField(gomock.Any(), 10).Return(nil).Times(3),
+					f.pinsServ.EXPECT().SignPrivateMedia(gomock.Any(), 10).Return(nil),
 				)
 			},
 			page:    1,
@@ -438,7 +457,10 @@ func TestPinsList(t *testing.T) {
 		},
 		"no boards": {
 			prepare: func(f *fields) {
-				f.repo.EXPECT().PinsList(3, 1, 30).Return([]models.Pin{}, nil)
+				gomock.InOrder(
+					f.repo.EXPECT().PinsList(3, 1, 30).Return([]models.Pin{}, nil),
+					f.pinsServ.EXPECT().SignPrivateMedia([]models.Pin{}, 10).Return(nil),
+				)
 			},
 			boardId: 3,
 			userId:  10,
@@ -48,15 +48,15 @@ func RegisterHandlers(mux *httprouter.Router, logger *zap.Logger, authorizer mw.
 		}}
 
 	// chats
-	mux.GET(chatsUrl, mw.HandleLogger(mw.ErrorHandler(mw.Cors(authorizer(csrf(del.ListByUser))), logger), logger))
-	mux.GET(chatUrl, mw.HandleLogger(mw.ErrorHandler(mw.Cors(authorizer(csrf(del.Get))), logger), logger))
+	mux.GET(chatsUrl, mw.HandleLogger(mw.ErrorHandler(mw.Cors(authorizer.Scoped(models.ScopeChats)(csrf(del.ListByUser))), logger), logger))
+	mux.GET(chatUrl, mw.HandleLogger(mw.ErrorHandler(mw.Cors(authorizer.Scoped(models.ScopeChats)(csrf(del.Get))), logger), logger))
 
 	// messages
-	mux.GET(chatMessagesUrl, mw.HandleLogger(mw.ErrorHandler(mw.Cors(authorizer(csrf(del.MessagesList))), logger), logger))
-	mux.GET(messagesUrl, mw.HandleLogger(mw.ErrorHandler(mw.Cors(authorizer(csrf(del.GetMessagesByReceiver))), logger), logger))
+	mux.GET(chatMessagesUrl, mw.HandleLogger(mw.ErrorHandler(mw.Cors(authorizer.Scoped(models.ScopeChats)(csrf(del.MessagesList))), logger), logger))
+	mux.GET(messagesUrl, mw.HandleLogger(mw.ErrorHandler(mw.Cors(authorizer.Scoped(models.ScopeChats)(csrf(del.GetMessagesByReceiver))), logger), logger))
 
 	// connect to websocket
-	mux.GET(wsChatUrl, mw.HandleLogger(mw.ErrorHandler(authorizer(del.chatHandler), logger), logger))
+	mux.GET(wsChatUrl, mw.HandleLogger(mw.ErrorHandler(authorizer.Scoped(models.ScopeChats)(del.chatHandler), logger), logger))
 }
 
 func (del *delivery) ListByUser(w http.ResponseWriter, _ *http.Request, p httprouter.Params) error {
@@ -9,6 +9,7 @@ import (
 	"strconv"
 
 	mw "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/middleware"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 	"github.com/julienschmidt/httprouter"
 )
@@ -25,8 +26,8 @@ type delivery struct {
 func RegisterHandlers(mux *httprouter.Router, logger *zap.Logger, authorizer mw.Authorizer, csrf mw.CSRFMiddleware, serv pkgComments.Service, m *mw.HttpMetricsMiddleware) {
 	del := delivery{serv, logger}
 
-	mux.POST(commentsUrl, mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer(mw.Cors(csrf(del.Create))), logger), logger), logger))
-	mux.GET(commentsUrl, mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer(mw.Cors(csrf(del.List))), logger), logger), logger))
+	mux.POST(commentsUrl, mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer.Scoped(models.ScopePinsWrite)(mw.Cors(csrf(del.Create))), logger), logger), logger))
+	mux.GET(commentsUrl, mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer.Scoped(models.ScopePinsRead)(mw.Cors(csrf(del.List))), logger), logger), logger))
 }
 
 func (del delivery) Create(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
@@ -18,10 +18,7 @@ func New(logger *zap.Logger) (*sql.DB, error) {
 		zap.String("dbname", viper.GetString(config.PostgresConfig.DB)),
 		zap.String("sslmode", viper.GetString(config.PostgresConfig.SSLMode)))
 
-	params := fmt.Sprintf("host=%s port=%d user=%s dbname=%s password=%s sslmode=%s",
-		viper.GetString(config.PostgresConfig.Host), viper.GetInt(config.PostgresConfig.Port), viper.GetString(config.PostgresConfig.User),
-		viper.GetString(config.PostgresConfig.DB), viper.GetString(config.PostgresConfig.Password), viper.GetString(config.PostgresConfig.SSLMode))
-	db, err := sql.Open("postgres", params)
+	db, err := sql.Open("postgres", ConnString())
 	if err != nil {
 		logger.Error("Failed to create DB connection", zap.Error(err))
 		return nil, err
@@ -36,3 +33,10 @@ func New(logger *zap.Logger) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// ConnString returns the connection parameters of the configured database.
+func ConnString() string {
+	return fmt.Sprintf("host=%s port=%d user=%s dbname=%s password=%s sslmode=%s",
+		viper.GetString(config.PostgresConfig.Host), viper.GetInt(config.PostgresConfig.Port), viper.GetString(config.PostgresConfig.User),
+		viper.GetString(config.PostgresConfig.DB), viper.GetString(config.PostgresConfig.Password), viper.GetString(config.PostgresConfig.SSLMode))
+}
@@ -1,17 +1,39 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"time"
 
 	proto "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/proto"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	pkgErrors "github.com/pkg/errors"
 	"google.golang.org/grpc"
 )
 
+// Images in memory larger than streamThreshold are uploaded in chunks of streamChunkSize like image streams,
+// so that they are not limited by the gRPC message size.
+const (
+	streamThreshold = 1024 * 1024
+	streamChunkSize = 64 * 1024
+)
+
 type ImageClient interface {
 	UploadImage(ctx context.Context, image *models.Image) (string, error)
+	// UploadImageStream sends the file to the images service chunk by chunk as it is read from image.Body,
+	// so only a chunk is held in memory. The size limit of streamed images applies, see images.Limits.
+	UploadImageStream(ctx context.Context, image *models.ImageStream) (string, error)
+	// UploadRenditions returns the image URLs by rendition name, see pkg/image.RenditionWidths,
+	// and the perceptual hash of the image.
+	UploadRenditions(ctx context.Context, image *models.Image) (models.ImageRenditions, error)
+	// SetPrivate makes the images readable only by URLs from SignURLs, uploaded images are public.
+	SetPrivate(ctx context.Context, urls []string, private bool) error
+	// SignURLs returns expiring URLs of the images in the same order.
+	SignURLs(ctx context.Context, urls []string) ([]string, error)
+	DeleteImage(ctx context.Context, url string) error
 }
 
 type client struct {
@@ -23,6 +45,10 @@ func NewImageUploaderClient(con *grpc.ClientConn) ImageClient {
 }
 
 func (client *client) UploadImage(ctx context.Context, image *models.Image) (string, error) {
+	if len(image.Bytes) > streamThreshold {
+		return client.UploadImageStream(ctx, &models.ImageStream{ID: image.ID, Body: bytes.NewReader(image.Bytes)})
+	}
+
 	img := proto.Image{
 		ID:    image.ID,
 		Bytes: image.Bytes,
@@ -31,8 +57,106 @@ func (client *client) UploadImage(ctx context.Context, image *models.Image) (str
 
 	if err != nil {
 		fmt.Println(err)
-		return "", errors.RestoreHTTPError(errors.GRPCUnwrapper(err))
+		return "", restoreError(err)
+	}
+
+	return url.GetURL(), nil
+}
+
+func (client *client) UploadImageStream(ctx context.Context, image *models.ImageStream) (string, error) {
+	// the call is cancelled when the file can't be read, so that the service does not store it cut short
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.imageClient.UploadImageStream(ctx)
+	if err != nil {
+		return "", restoreError(err)
+	}
+
+	err = stream.Send(&proto.ImageChunk{Data: &proto.ImageChunk_ID{ID: image.ID}})
+	for err == nil {
+		// a message may be used after Send returns, so every chunk is a new slice
+		chunk := make([]byte, streamChunkSize)
+		n, readErr := io.ReadFull(image.Body, chunk)
+		if n > 0 {
+			err = stream.Send(&proto.ImageChunk{Data: &proto.ImageChunk_Bytes{Bytes: chunk[:n]}})
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return "", pkgErrors.Wrap(errors.ErrFileCopy, readErr.Error())
+		}
+	}
+	// io.EOF means the server has already finished the call, its status comes with CloseAndRecv
+	if err != nil && err != io.EOF {
+		return "", restoreError(err)
+	}
+
+	url, err := stream.CloseAndRecv()
+	if err != nil {
+		return "", restoreError(err)
 	}
 
 	return url.GetURL(), nil
 }
+
+func (client *client) UploadRenditions(ctx context.Context, image *models.Image) (models.ImageRenditions, error) {
+	img := proto.Image{
+		ID:    image.ID,
+		Bytes: image.Bytes,
+	}
+	renditions, err := client.imageClient.UploadRenditions(ctx, &img)
+	if err != nil {
+		return models.ImageRenditions{}, restoreError(err)
+	}
+
+	return models.ImageRenditions{
+		URLs:      renditions.GetURLs(),
+		Hash:      renditions.GetHash(),
+		MediaType: renditions.GetMediaType(),
+		Width:     int(renditions.GetWidth()),
+		Height:    int(renditions.GetHeight()),
+		Duration:  time.Duration(renditions.GetDurationMs()) * time.Millisecond,
+		Color:     renditions.GetColor(),
+		Palette:   renditions.GetPalette(),
+		BlurHash:  renditions.GetBlurHash(),
+	}, nil
+}
+
+func (client *client) SetPrivate(ctx context.Context, urls []string, private bool) error {
+	_, err := client.imageClient.SetPrivate(ctx, &proto.Visibility{URLs: urls, Private: private})
+	if err != nil {
+		return restoreError(err)
+	}
+
+	return nil
+}
+
+func (client *client) SignURLs(ctx context.Context, urls []string) ([]string, error) {
+	signed, err := client.imageClient.SignURLs(ctx, &proto.Urls{URLs: urls})
+	if err != nil {
+		return nil, restoreError(err)
+	}
+
+	return signed.GetURLs(), nil
+}
+
+func (client *client) DeleteImage(ctx context.Context, url string) error {
+	_, err := client.imageClient.DeleteImage(ctx, &proto.Url{URL: url})
+	if err != nil {
+		return restoreError(err)
+	}
+
+	return nil
+}
+
+// restoreError keeps the typed errors of the images service, e.g. image validation errors,
+// and reports any other failure as ErrImageService.
+func restoreError(err error) error {
+	restored := errors.RestoreHTTPError(errors.GRPCUnwrapper(err))
+	if restored == nil {
+		return pkgErrors.Wrap(errors.ErrImageService, err.Error())
+	}
+	return restored
+}
@@ -0,0 +1,166 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"testing/iotest"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	proto "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/proto"
+	images "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server/imagestest"
+	service "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server/service/grpc"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image/imagetest"
+)
+
+// serve runs the images service over an in-memory connection, stop waits for the calls in progress.
+func serve(t *testing.T, rep images.Repository, limits images.Limits) (ImageClient, func()) {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	proto.RegisterImageUploaderServer(srv, service.NewS3Service(rep, limits, 0, nil))
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+
+	con, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("can't dial: %v", err)
+	}
+	return NewImageUploaderClient(con), func() {
+		_ = con.Close()
+		srv.GracefulStop()
+	}
+}
+
+// pngOfSize is a PNG padded with a private chunk to about size bytes.
+func pngOfSize(t *testing.T, size int) []byte {
+	t.Helper()
+	return imagetest.PNG(t, 16, 8, imagetest.Chunk{Type: "zzZz", Data: make([]byte, size)})
+}
+
+func TestUploadImageStream(t *testing.T) {
+	small := pngOfSize(t, 100)
+	large := pngOfSize(t, 5*streamChunkSize/2)
+
+	type testCase struct {
+		body   io.Reader
+		limits images.Limits
+		stored []byte
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"one chunk": {
+			body:   bytes.NewReader(small),
+			limits: images.Limits{MaxFileSize: len(small)},
+			stored: small,
+		},
+		"several chunks": {
+			body:   bytes.NewReader(large),
+			limits: images.Limits{MaxFileSize: len(small), MaxStreamFileSize: len(large)},
+			stored: large,
+		},
+		"short reads": {
+			body:   iotest.OneByteReader(bytes.NewReader(small)),
+			limits: images.Limits{MaxFileSize: len(small)},
+			stored: small,
+		},
+		"above the stream limit": {
+			body:   bytes.NewReader(large),
+			limits: images.Limits{MaxFileSize: len(large), MaxStreamFileSize: len(large) - 1},
+			err:    pkgErrors.ErrImageTooLarge,
+		},
+		"broken body": {
+			body:   io.MultiReader(bytes.NewReader(large[:len(large)/2]), iotest.ErrReader(io.ErrClosedPipe)),
+			limits: images.Limits{MaxStreamFileSize: len(large)},
+			err:    pkgErrors.ErrFileCopy,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rep := imagestest.NewRepository()
+			client, stop := serve(t, rep, test.limits)
+
+			url, err := client.UploadImageStream(context.Background(), &models.ImageStream{ID: "a.png", Body: test.body})
+			stop()
+			if !errors.Is(err, test.err) {
+				t.Fatalf("error = %v, expected %v", err, test.err)
+			}
+			if test.err != nil {
+				// a file cut short by a failed read is not stored
+				if keys := rep.Keys(); len(keys) != 0 {
+					t.Errorf("images are stored: %v", keys)
+				}
+				return
+			}
+
+			if url != imagestest.URL("a.png") {
+				t.Errorf("url = %q, expected %q", url, imagestest.URL("a.png"))
+			}
+			stored, err := pkgImage.StripPNGMetadata(test.stored)
+			if err != nil {
+				t.Fatalf("StripPNGMetadata: unexpected error %v", err)
+			}
+			if file, _ := rep.File("a.png"); !bytes.Equal(file, stored) {
+				t.Errorf("stored image differs from the sanitized one")
+			}
+		})
+	}
+}
+
+func TestUploadImage(t *testing.T) {
+	small := pngOfSize(t, 100)
+	large := pngOfSize(t, streamThreshold)
+
+	type testCase struct {
+		image  []byte
+		method string
+	}
+
+	// images in memory above the threshold are streamed, so the message size does not limit them
+	tests := map[string]testCase{
+		"small": {image: small, method: "UploadImage"},
+		"large": {image: large, method: "UploadStream"},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rep := imagestest.NewRepository()
+			client, stop := serve(t, rep, images.Limits{MaxFileSize: len(large)})
+			defer stop()
+
+			url, err := client.UploadImage(context.Background(), &models.Image{ID: "a.png", Bytes: test.image})
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if url != imagestest.URL("a.png") {
+				t.Errorf("url = %q, expected %q", url, imagestest.URL("a.png"))
+			}
+			if calls := rep.Calls(test.method); calls != 1 {
+				t.Errorf("%s is called %d times, expected once", test.method, calls)
+			}
+		})
+	}
+}
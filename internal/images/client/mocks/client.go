@@ -35,6 +35,49 @@ func (m *MockImageClient) EXPECT() *MockImageClientMockRecorder {
 	return m.recorder
 }
 
+// DeleteImage mocks base method.
+func (m *MockImageClient) DeleteImage(ctx context.Context, url string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImage", ctx, url)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImage indicates an expected call of DeleteImage.
+func (mr *MockImageClientMockRecorder) DeleteImage(ctx, url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockImageClient)(nil).DeleteImage), ctx, url)
+}
+
+// SetPrivate mocks base method.
+func (m *MockImageClient) SetPrivate(ctx context.Context, urls []string, private bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrivate", ctx, urls, private)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrivate indicates an expected call of SetPrivate.
+func (mr *MockImageClientMockRecorder) SetPrivate(ctx, urls, private interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrivate", reflect.TypeOf((*MockImageClient)(nil).SetPrivate), ctx, urls, private)
+}
+
+// SignURLs mocks base method.
+func (m *MockImageClient) SignURLs(ctx context.Context, urls []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignURLs", ctx, urls)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignURLs indicates an expected call of SignURLs.
+func (mr *MockImageClientMockRecorder) SignURLs(ctx, urls interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignURLs", reflect.TypeOf((*MockImageClient)(nil).SignURLs), ctx, urls)
+}
+
 // UploadImage mocks base method.
 func (m *MockImageClient) UploadImage(ctx context.Context, image *models.Image) (string, error) {
 	m.ctrl.T.Helper()
@@ -49,3 +92,33 @@ func (mr *MockImageClientMockRecorder) UploadImage(ctx, image interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockImageClient)(nil).UploadImage), ctx, image)
 }
+
+// UploadImageStream mocks base method.
+func (m *MockImageClient) UploadImageStream(ctx context.Context, image *models.ImageStream) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadImageStream", ctx, image)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadImageStream indicates an expected call of UploadImageStream.
+func (mr *MockImageClientMockRecorder) UploadImageStream(ctx, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImageStream", reflect.TypeOf((*MockImageClient)(nil).UploadImageStream), ctx, image)
+}
+
+// UploadRenditions mocks base method.
+func (m *MockImageClient) UploadRenditions(ctx context.Context, image *models.Image) (models.ImageRenditions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadRenditions", ctx, image)
+	ret0, _ := ret[0].(models.ImageRenditions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadRenditions indicates an expected call of UploadRenditions.
+func (mr *MockImageClientMockRecorder) UploadRenditions(ctx, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadRenditions", reflect.TypeOf((*MockImageClient)(nil).UploadRenditions), ctx, image)
+}
@@ -0,0 +1,85 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/client"
+)
+
+// batchSize limits the number of images deleted in one run.
+const batchSize = 500
+
+type Collector struct {
+	rep    Repository
+	images client.ImageClient
+	grace  time.Duration
+	dryRun bool
+	log    *zap.Logger
+}
+
+// NewCollector creates a collector deleting images that have been unreferenced for the grace period,
+// the grace period also covers the time between an upload and saving the pin or profile referencing it.
+// In dry-run mode the orphaned images are only logged.
+func NewCollector(rep Repository, images client.ImageClient, grace time.Duration, dryRun bool, log *zap.Logger) *Collector {
+	return &Collector{
+		rep:    rep,
+		images: images,
+		grace:  grace,
+		dryRun: dryRun,
+		log:    log,
+	}
+}
+
+// Collect deletes orphaned images once and returns their number. Images that fail to be deleted
+// stay tracked and are retried on the next run.
+func (c *Collector) Collect(ctx context.Context) (int, error) {
+	orphans, err := c.rep.ListOrphans(time.Now().Add(-c.grace), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.dryRun {
+		for _, url := range orphans {
+			c.log.Info("Orphaned image would be deleted", zap.String("url", url))
+		}
+		return len(orphans), nil
+	}
+
+	deleted := 0
+	for _, url := range orphans {
+		if err = c.images.DeleteImage(ctx, url); err != nil {
+			c.log.Error("Failed to delete orphaned image", zap.String("url", url), zap.Error(err))
+			continue
+		}
+		if err = c.rep.Forget(url); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// StartCollector periodically collects orphaned images until ctx is done.
+func StartCollector(ctx context.Context, c *Collector, interval time.Duration, log *zap.Logger) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				collected, err := c.Collect(ctx)
+				if err != nil {
+					log.Error("Failed to collect orphaned images", zap.Error(err), zap.Int("collected", collected))
+				} else if collected > 0 {
+					log.Info("Collected orphaned images", zap.Int("collected", collected), zap.Bool("dry_run", c.dryRun))
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,103 @@
+package gc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	imagesMocks "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/client/mocks"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/gc/mocks"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+func TestCollector_Collect(t *testing.T) {
+	type fields struct {
+		repo   *mocks.MockRepository
+		images *imagesMocks.MockImageClient
+	}
+
+	type testCase struct {
+		prepare   func(f *fields)
+		dryRun    bool
+		collected int
+		err       error
+	}
+
+	orphans := []string{"https://images/a.jpg", "https://images/b.jpg"}
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().ListOrphans(gomock.Any(), batchSize).Return(orphans, nil)
+				for _, url := range orphans {
+					f.images.EXPECT().DeleteImage(gomock.Any(), url).Return(nil)
+					f.repo.EXPECT().Forget(url).Return(nil)
+				}
+			},
+			collected: 2,
+			err:       nil,
+		},
+		"dry run": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().ListOrphans(gomock.Any(), batchSize).Return(orphans, nil)
+			},
+			dryRun:    true,
+			collected: 2,
+			err:       nil,
+		},
+		"image service error": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().ListOrphans(gomock.Any(), batchSize).Return(orphans, nil)
+				f.images.EXPECT().DeleteImage(gomock.Any(), orphans[0]).Return(pkgErrors.ErrImageService)
+				f.images.EXPECT().DeleteImage(gomock.Any(), orphans[1]).Return(nil)
+				f.repo.EXPECT().Forget(orphans[1]).Return(nil)
+			},
+			collected: 1,
+			err:       nil,
+		},
+		"list error": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().ListOrphans(gomock.Any(), batchSize).Return(nil, pkgErrors.ErrDb)
+			},
+			collected: 0,
+			err:       pkgErrors.ErrDb,
+		},
+		"forget error": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().ListOrphans(gomock.Any(), batchSize).Return(orphans, nil)
+				f.images.EXPECT().DeleteImage(gomock.Any(), orphans[0]).Return(nil)
+				f.repo.EXPECT().Forget(orphans[0]).Return(pkgErrors.ErrDb)
+			},
+			collected: 0,
+			err:       pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{repo: mocks.NewMockRepository(ctrl), images: imagesMocks.NewMockImageClient(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			collector := NewCollector(f.repo, f.images, time.Hour, test.dryRun, zap.NewNop())
+			collected, err := collector.Collect(context.Background())
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if collected != test.collected {
+				t.Errorf("\nExpected: %d\nGot: %d", test.collected, collected)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package gc
+
+import "time"
+
+type Repository interface {
+	// Track records uploaded images, renditions are tracked together with the URL of their original.
+	Track(original string, renditions []string) error
+	// ListOrphans returns images uploaded before the given time that are referenced by no pin or profile.
+	ListOrphans(uploadedBefore time.Time, limit int) ([]string, error)
+	Forget(url string) error
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/gc"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+type repository struct {
+	db  *sql.DB
+	log *zap.Logger
+}
+
+func NewRepository(db *sql.DB, log *zap.Logger) gc.Repository {
+	return &repository{db, log}
+}
+
+// Images are keyed by content, so uploading an image again restarts its grace period.
+const trackCmd = `
+		INSERT INTO images (url, original)
+		SELECT $1::TEXT, NULL
+		UNION ALL
+		SELECT rendition, $1::TEXT FROM unnest($2::TEXT[]) AS rendition
+		ON CONFLICT (url) DO UPDATE SET uploaded_at = now();`
+
+func (rep *repository) Track(original string, renditions []string) error {
+	_, err := rep.db.Exec(trackCmd, original, pq.Array(renditions))
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", trackCmd),
+			zap.String("url", original), zap.Error(err))
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return nil
+}
+
+// An image is referenced by a pin or a profile, a rendition is referenced through its original.
+const listOrphansCmd = `
+		SELECT i.url
+		FROM images i
+		WHERE i.uploaded_at < $1
+		  AND NOT EXISTS (SELECT 1 FROM users u WHERE u.profile_image = COALESCE(i.original, i.url))
+		  AND NOT EXISTS (SELECT 1 FROM pins p WHERE p.media_source = COALESCE(i.original, i.url))
+		ORDER BY i.uploaded_at
+		LIMIT $2;`
+
+func (rep *repository) ListOrphans(uploadedBefore time.Time, limit int) ([]string, error) {
+	rows, err := rep.db.Query(listOrphansCmd, uploadedBefore, limit)
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", listOrphansCmd), zap.Error(err))
+		return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer rows.Close()
+
+	urls := []string{}
+	for rows.Next() {
+		var url string
+		if err = rows.Scan(&url); err != nil {
+			rep.log.Error(constants.DBScanError, zap.String("sql_query", listOrphansCmd), zap.Error(err))
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		urls = append(urls, url)
+	}
+	return urls, nil
+}
+
+const forgetCmd = `
+		DELETE FROM images
+		WHERE url = $1;`
+
+func (rep *repository) Forget(url string) error {
+	_, err := rep.db.Exec(forgetCmd, url)
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", forgetCmd),
+			zap.String("url", url), zap.Error(err))
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+func TestRepository_Track(t *testing.T) {
+	type testCase struct {
+		prepare    func(mock sqlmock.Sqlmock)
+		original   string
+		renditions []string
+		err        error
+	}
+
+	tests := map[string]testCase{
+		"good query": {
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(trackCmd)).
+					WithArgs("https://images/a.jpg", pq.Array([]string{"https://images/a_236w.jpg"})).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			original:   "https://images/a.jpg",
+			renditions: []string{"https://images/a_236w.jpg"},
+			err:        nil,
+		},
+		"query error": {
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(trackCmd)).
+					WithArgs("https://images/a.jpg", pq.Array([]string(nil))).
+					WillReturnError(&pq.Error{Message: "sql error"})
+			},
+			original:   "https://images/a.jpg",
+			renditions: nil,
+			err:        pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			test.prepare(sqlMock)
+
+			repo := NewRepository(db, zap.NewNop())
+			err = repo.Track(test.original, test.renditions)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestRepository_ListOrphans(t *testing.T) {
+	type testCase struct {
+		prepare func(mock sqlmock.Sqlmock)
+		urls    []string
+		err     error
+	}
+
+	before := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"url"}).
+					AddRow("https://images/a.jpg").
+					AddRow("https://images/a_236w.jpg")
+				mock.ExpectQuery(regexp.QuoteMeta(listOrphansCmd)).
+					WithArgs(before, 10).
+					WillReturnRows(rows)
+			},
+			urls: []string{"https://images/a.jpg", "https://images/a_236w.jpg"},
+			err:  nil,
+		},
+		"no orphans": {
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(listOrphansCmd)).
+					WithArgs(before, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"url"}))
+			},
+			urls: []string{},
+			err:  nil,
+		},
+		"query error": {
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(listOrphansCmd)).
+					WithArgs(before, 10).
+					WillReturnError(&pq.Error{Message: "sql error"})
+			},
+			urls: nil,
+			err:  pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			test.prepare(sqlMock)
+
+			repo := NewRepository(db, zap.NewNop())
+			urls, err := repo.ListOrphans(before, 10)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(urls, test.urls) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.urls, urls)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package gc
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/client"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+)
+
+type trackingClient struct {
+	client.ImageClient
+
+	rep Repository
+	log *zap.Logger
+}
+
+// NewTrackingClient records every image uploaded through imageClient so that the collector
+// can find it once nothing references it anymore.
+func NewTrackingClient(imageClient client.ImageClient, rep Repository, log *zap.Logger) client.ImageClient {
+	return &trackingClient{imageClient, rep, log}
+}
+
+// An untracked image is never collected, which is better than failing the upload that has already succeeded.
+func (c *trackingClient) track(original string, renditions []string) {
+	if err := c.rep.Track(original, renditions); err != nil {
+		c.log.Error("Failed to track uploaded image", zap.String("url", original), zap.Error(err))
+	}
+}
+
+func (c *trackingClient) UploadImage(ctx context.Context, image *models.Image) (string, error) {
+	url, err := c.ImageClient.UploadImage(ctx, image)
+	if err != nil {
+		return "", err
+	}
+
+	c.track(url, nil)
+	return url, nil
+}
+
+func (c *trackingClient) UploadImageStream(ctx context.Context, image *models.ImageStream) (string, error) {
+	url, err := c.ImageClient.UploadImageStream(ctx, image)
+	if err != nil {
+		return "", err
+	}
+
+	c.track(url, nil)
+	return url, nil
+}
+
+func (c *trackingClient) UploadRenditions(ctx context.Context, image *models.Image) (models.ImageRenditions, error) {
+	uploaded, err := c.ImageClient.UploadRenditions(ctx, image)
+	if err != nil {
+		return models.ImageRenditions{}, err
+	}
+
+	renditions := make([]string, 0, len(uploaded.URLs))
+	for name, url := range uploaded.URLs {
+		if name != pkgImage.OriginalRendition {
+			renditions = append(renditions, url)
+		}
+	}
+	c.track(uploaded.URLs[pkgImage.OriginalRendition], renditions)
+	return uploaded, nil
+}
+
+func (c *trackingClient) DeleteImage(ctx context.Context, url string) error {
+	err := c.ImageClient.DeleteImage(ctx, url)
+	if err != nil {
+		return err
+	}
+
+	if err = c.rep.Forget(url); err != nil {
+		c.log.Error("Failed to forget deleted image", zap.String("url", url), zap.Error(err))
+	}
+	return nil
+}
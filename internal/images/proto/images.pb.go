@@ -20,6 +20,7 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Images are stored under a key derived from their content, the ID only gives the file name extension
 type Image struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -122,6 +123,354 @@ func (x *Url) GetURL() string {
 	return ""
 }
 
+type Urls struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	URLs []string `protobuf:"bytes,1,rep,name=URLs,proto3" json:"URLs,omitempty"`
+}
+
+func (x *Urls) Reset() {
+	*x = Urls{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_images_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Urls) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Urls) ProtoMessage() {}
+
+func (x *Urls) ProtoReflect() protoreflect.Message {
+	mi := &file_images_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Urls.ProtoReflect.Descriptor instead.
+func (*Urls) Descriptor() ([]byte, []int) {
+	return file_images_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Urls) GetURLs() []string {
+	if x != nil {
+		return x.URLs
+	}
+	return nil
+}
+
+// Private images are readable only with signed URLs
+type Visibility struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	URLs    []string `protobuf:"bytes,1,rep,name=URLs,proto3" json:"URLs,omitempty"`
+	Private bool     `protobuf:"varint,2,opt,name=Private,proto3" json:"Private,omitempty"`
+}
+
+func (x *Visibility) Reset() {
+	*x = Visibility{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_images_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Visibility) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Visibility) ProtoMessage() {}
+
+func (x *Visibility) ProtoReflect() protoreflect.Message {
+	mi := &file_images_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Visibility.ProtoReflect.Descriptor instead.
+func (*Visibility) Descriptor() ([]byte, []int) {
+	return file_images_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Visibility) GetURLs() []string {
+	if x != nil {
+		return x.URLs
+	}
+	return nil
+}
+
+func (x *Visibility) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+// URLs by rendition name: "236w", "474w", "736w" and "original", animated GIFs and videos also have "poster"
+// with their first frame in full size, which the other renditions are made of.
+// Hash is the perceptual hash of the image, 0 if it could not be decoded.
+// MediaType is "image", "gif" or "video". Color and Palette are the average and the dominant colors,
+// most common first, as CSS rgb() strings, BlurHash is the placeholder of the image; they are made of
+// the oriented image or the poster frame and empty if it could not be decoded
+type Renditions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	URLs       map[string]string `protobuf:"bytes,1,rep,name=URLs,proto3" json:"URLs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Hash       uint64            `protobuf:"varint,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	MediaType  string            `protobuf:"bytes,3,opt,name=MediaType,proto3" json:"MediaType,omitempty"`
+	Width      int32             `protobuf:"varint,4,opt,name=Width,proto3" json:"Width,omitempty"`
+	Height     int32             `protobuf:"varint,5,opt,name=Height,proto3" json:"Height,omitempty"`
+	DurationMs int64             `protobuf:"varint,6,opt,name=DurationMs,proto3" json:"DurationMs,omitempty"`
+	Color      string            `protobuf:"bytes,8,opt,name=Color,proto3" json:"Color,omitempty"`
+	Palette    []string          `protobuf:"bytes,9,rep,name=Palette,proto3" json:"Palette,omitempty"`
+	BlurHash   string            `protobuf:"bytes,10,opt,name=BlurHash,proto3" json:"BlurHash,omitempty"`
+}
+
+func (x *Renditions) Reset() {
+	*x = Renditions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_images_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Renditions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Renditions) ProtoMessage() {}
+
+func (x *Renditions) ProtoReflect() protoreflect.Message {
+	mi := &file_images_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Renditions.ProtoReflect.Descriptor instead.
+func (*Renditions) Descriptor() ([]byte, []int) {
+	return file_images_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Renditions) GetURLs() map[string]string {
+	if x != nil {
+		return x.URLs
+	}
+	return nil
+}
+
+func (x *Renditions) GetHash() uint64 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *Renditions) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *Renditions) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Renditions) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Renditions) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *Renditions) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Renditions) GetPalette() []string {
+	if x != nil {
+		return x.Palette
+	}
+	return nil
+}
+
+func (x *Renditions) GetBlurHash() string {
+	if x != nil {
+		return x.BlurHash
+	}
+	return ""
+}
+
+// The first chunk of a stream carries the image ID as in Image, the rest carry the file bytes
+type ImageChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*ImageChunk_ID
+	//	*ImageChunk_Bytes
+	Data isImageChunk_Data `protobuf_oneof:"Data"`
+}
+
+func (x *ImageChunk) Reset() {
+	*x = ImageChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_images_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImageChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageChunk) ProtoMessage() {}
+
+func (x *ImageChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_images_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageChunk.ProtoReflect.Descriptor instead.
+func (*ImageChunk) Descriptor() ([]byte, []int) {
+	return file_images_proto_rawDescGZIP(), []int{5}
+}
+
+func (m *ImageChunk) GetData() isImageChunk_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *ImageChunk) GetID() string {
+	if x, ok := x.GetData().(*ImageChunk_ID); ok {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *ImageChunk) GetBytes() []byte {
+	if x, ok := x.GetData().(*ImageChunk_Bytes); ok {
+		return x.Bytes
+	}
+	return nil
+}
+
+type isImageChunk_Data interface {
+	isImageChunk_Data()
+}
+
+type ImageChunk_ID struct {
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3,oneof"`
+}
+
+type ImageChunk_Bytes struct {
+	Bytes []byte `protobuf:"bytes,2,opt,name=Bytes,proto3,oneof"`
+}
+
+func (*ImageChunk_ID) isImageChunk_Data() {}
+
+func (*ImageChunk_Bytes) isImageChunk_Data() {}
+
+type Nothing struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dummy bool `protobuf:"varint,1,opt,name=dummy,proto3" json:"dummy,omitempty"`
+}
+
+func (x *Nothing) Reset() {
+	*x = Nothing{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_images_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Nothing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Nothing) ProtoMessage() {}
+
+func (x *Nothing) ProtoReflect() protoreflect.Message {
+	mi := &file_images_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Nothing.ProtoReflect.Descriptor instead.
+func (*Nothing) Descriptor() ([]byte, []int) {
+	return file_images_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Nothing) GetDummy() bool {
+	if x != nil {
+		return x.Dummy
+	}
+	return false
+}
+
 var File_images_proto protoreflect.FileDescriptor
 
 var file_images_proto_rawDesc = []byte{
@@ -130,13 +479,61 @@ var file_images_proto_rawDesc = []byte{
 	0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12,
 	0x14, 0x0a, 0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
 	0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x17, 0x0a, 0x03, 0x55, 0x72, 0x6c, 0x12, 0x10, 0x0a, 0x03,
-	0x55, 0x52, 0x4c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x55, 0x52, 0x4c, 0x32, 0x3c,
-	0x0a, 0x0d, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x12,
-	0x2b, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x0d,
-	0x2e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x1a, 0x0b, 0x2e,
-	0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x55, 0x72, 0x6c, 0x22, 0x00, 0x42, 0x0b, 0x5a, 0x09,
-	0x2e, 0x2f, 0x3b, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x55, 0x52, 0x4c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x55, 0x52, 0x4c, 0x22, 0x1a,
+	0x0a, 0x04, 0x55, 0x72, 0x6c, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x52, 0x4c, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x55, 0x52, 0x4c, 0x73, 0x22, 0x3a, 0x0a, 0x0a, 0x56, 0x69,
+	0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x52, 0x4c, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x55, 0x52, 0x4c, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x50,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x22, 0xc9, 0x02, 0x0a, 0x0a, 0x52, 0x65, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x30, 0x0a, 0x04, 0x55, 0x52, 0x4c, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x55, 0x52, 0x4c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x04, 0x55, 0x52, 0x4c, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x4d,
+	0x65, 0x64, 0x69, 0x61, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x4d, 0x65, 0x64, 0x69, 0x61, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x57, 0x69, 0x64,
+	0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x57, 0x69, 0x64, 0x74, 0x68, 0x12,
+	0x16, 0x0a, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x18, 0x0a,
+	0x07, 0x50, 0x61, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x50, 0x61, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x42, 0x6c, 0x75, 0x72, 0x48,
+	0x61, 0x73, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x42, 0x6c, 0x75, 0x72, 0x48,
+	0x61, 0x73, 0x68, 0x1a, 0x37, 0x0a, 0x09, 0x55, 0x52, 0x4c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x4a, 0x04, 0x08, 0x07,
+	0x10, 0x08, 0x22, 0x3e, 0x0a, 0x0a, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x12, 0x10, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x02,
+	0x49, 0x44, 0x12, 0x16, 0x0a, 0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x48, 0x00, 0x52, 0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x42, 0x06, 0x0a, 0x04, 0x44, 0x61,
+	0x74, 0x61, 0x22, 0x1f, 0x0a, 0x07, 0x4e, 0x6f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a,
+	0x05, 0x64, 0x75, 0x6d, 0x6d, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x64, 0x75,
+	0x6d, 0x6d, 0x79, 0x32, 0xbd, 0x02, 0x0a, 0x0d, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x12, 0x0d, 0x2e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x1a, 0x0b, 0x2e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x55, 0x72, 0x6c,
+	0x22, 0x00, 0x12, 0x38, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x12, 0x2e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73,
+	0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x0b, 0x2e, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x73, 0x2e, 0x55, 0x72, 0x6c, 0x22, 0x00, 0x28, 0x01, 0x12, 0x37, 0x0a, 0x10,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x0d, 0x2e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x1a,
+	0x12, 0x2e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x50, 0x72, 0x69, 0x76,
+	0x61, 0x74, 0x65, 0x12, 0x12, 0x2e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x56, 0x69, 0x73,
+	0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x1a, 0x0f, 0x2e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73,
+	0x2e, 0x4e, 0x6f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x22, 0x00, 0x12, 0x28, 0x0a, 0x08, 0x53, 0x69,
+	0x67, 0x6e, 0x55, 0x52, 0x4c, 0x73, 0x12, 0x0c, 0x2e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x55, 0x72, 0x6c, 0x73, 0x1a, 0x0c, 0x2e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x55, 0x72,
+	0x6c, 0x73, 0x22, 0x00, 0x12, 0x2d, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x12, 0x0b, 0x2e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x55, 0x72, 0x6c,
+	0x1a, 0x0f, 0x2e, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x4e, 0x6f, 0x74, 0x68, 0x69, 0x6e,
+	0x67, 0x22, 0x00, 0x42, 0x0b, 0x5a, 0x09, 0x2e, 0x2f, 0x3b, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -151,19 +548,36 @@ func file_images_proto_rawDescGZIP() []byte {
 	return file_images_proto_rawDescData
 }
 
-var file_images_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_images_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_images_proto_goTypes = []interface{}{
-	(*Image)(nil), // 0: images.Image
-	(*Url)(nil),   // 1: images.Url
+	(*Image)(nil),      // 0: images.Image
+	(*Url)(nil),        // 1: images.Url
+	(*Urls)(nil),       // 2: images.Urls
+	(*Visibility)(nil), // 3: images.Visibility
+	(*Renditions)(nil), // 4: images.Renditions
+	(*ImageChunk)(nil), // 5: images.ImageChunk
+	(*Nothing)(nil),    // 6: images.Nothing
+	nil,                // 7: images.Renditions.URLsEntry
 }
 var file_images_proto_depIdxs = []int32{
-	0, // 0: images.ImageUploader.UploadImage:input_type -> images.Image
-	1, // 1: images.ImageUploader.UploadImage:output_type -> images.Url
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	7, // 0: images.Renditions.URLs:type_name -> images.Renditions.URLsEntry
+	0, // 1: images.ImageUploader.UploadImage:input_type -> images.Image
+	5, // 2: images.ImageUploader.UploadImageStream:input_type -> images.ImageChunk
+	0, // 3: images.ImageUploader.UploadRenditions:input_type -> images.Image
+	3, // 4: images.ImageUploader.SetPrivate:input_type -> images.Visibility
+	2, // 5: images.ImageUploader.SignURLs:input_type -> images.Urls
+	1, // 6: images.ImageUploader.DeleteImage:input_type -> images.Url
+	1, // 7: images.ImageUploader.UploadImage:output_type -> images.Url
+	1, // 8: images.ImageUploader.UploadImageStream:output_type -> images.Url
+	4, // 9: images.ImageUploader.UploadRenditions:output_type -> images.Renditions
+	6, // 10: images.ImageUploader.SetPrivate:output_type -> images.Nothing
+	2, // 11: images.ImageUploader.SignURLs:output_type -> images.Urls
+	6, // 12: images.ImageUploader.DeleteImage:output_type -> images.Nothing
+	7, // [7:13] is the sub-list for method output_type
+	1, // [1:7] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_images_proto_init() }
@@ -196,6 +610,70 @@ func file_images_proto_init() {
 				return nil
 			}
 		}
+		file_images_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Urls); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_images_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Visibility); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_images_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Renditions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_images_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImageChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_images_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Nothing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_images_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*ImageChunk_ID)(nil),
+		(*ImageChunk_Bytes)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -203,7 +681,7 @@ func file_images_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_images_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
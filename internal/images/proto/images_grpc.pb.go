@@ -23,6 +23,12 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ImageUploaderClient interface {
 	UploadImage(ctx context.Context, in *Image, opts ...grpc.CallOption) (*Url, error)
+	UploadImageStream(ctx context.Context, opts ...grpc.CallOption) (ImageUploader_UploadImageStreamClient, error)
+	UploadRenditions(ctx context.Context, in *Image, opts ...grpc.CallOption) (*Renditions, error)
+	SetPrivate(ctx context.Context, in *Visibility, opts ...grpc.CallOption) (*Nothing, error)
+	// Signed URLs expire after the time configured in the images service
+	SignURLs(ctx context.Context, in *Urls, opts ...grpc.CallOption) (*Urls, error)
+	DeleteImage(ctx context.Context, in *Url, opts ...grpc.CallOption) (*Nothing, error)
 }
 
 type imageUploaderClient struct {
@@ -42,11 +48,87 @@ func (c *imageUploaderClient) UploadImage(ctx context.Context, in *Image, opts .
 	return out, nil
 }
 
+func (c *imageUploaderClient) UploadImageStream(ctx context.Context, opts ...grpc.CallOption) (ImageUploader_UploadImageStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ImageUploader_ServiceDesc.Streams[0], "/images.ImageUploader/UploadImageStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &imageUploaderUploadImageStreamClient{stream}
+	return x, nil
+}
+
+type ImageUploader_UploadImageStreamClient interface {
+	Send(*ImageChunk) error
+	CloseAndRecv() (*Url, error)
+	grpc.ClientStream
+}
+
+type imageUploaderUploadImageStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *imageUploaderUploadImageStreamClient) Send(m *ImageChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *imageUploaderUploadImageStreamClient) CloseAndRecv() (*Url, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Url)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *imageUploaderClient) UploadRenditions(ctx context.Context, in *Image, opts ...grpc.CallOption) (*Renditions, error) {
+	out := new(Renditions)
+	err := c.cc.Invoke(ctx, "/images.ImageUploader/UploadRenditions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imageUploaderClient) SetPrivate(ctx context.Context, in *Visibility, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, "/images.ImageUploader/SetPrivate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imageUploaderClient) SignURLs(ctx context.Context, in *Urls, opts ...grpc.CallOption) (*Urls, error) {
+	out := new(Urls)
+	err := c.cc.Invoke(ctx, "/images.ImageUploader/SignURLs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *imageUploaderClient) DeleteImage(ctx context.Context, in *Url, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, "/images.ImageUploader/DeleteImage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ImageUploaderServer is the server API for ImageUploader service.
 // All implementations must embed UnimplementedImageUploaderServer
 // for forward compatibility
 type ImageUploaderServer interface {
 	UploadImage(context.Context, *Image) (*Url, error)
+	UploadImageStream(ImageUploader_UploadImageStreamServer) error
+	UploadRenditions(context.Context, *Image) (*Renditions, error)
+	SetPrivate(context.Context, *Visibility) (*Nothing, error)
+	// Signed URLs expire after the time configured in the images service
+	SignURLs(context.Context, *Urls) (*Urls, error)
+	DeleteImage(context.Context, *Url) (*Nothing, error)
 	mustEmbedUnimplementedImageUploaderServer()
 }
 
@@ -57,6 +139,21 @@ type UnimplementedImageUploaderServer struct {
 func (UnimplementedImageUploaderServer) UploadImage(context.Context, *Image) (*Url, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UploadImage not implemented")
 }
+func (UnimplementedImageUploaderServer) UploadImageStream(ImageUploader_UploadImageStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadImageStream not implemented")
+}
+func (UnimplementedImageUploaderServer) UploadRenditions(context.Context, *Image) (*Renditions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadRenditions not implemented")
+}
+func (UnimplementedImageUploaderServer) SetPrivate(context.Context, *Visibility) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPrivate not implemented")
+}
+func (UnimplementedImageUploaderServer) SignURLs(context.Context, *Urls) (*Urls, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignURLs not implemented")
+}
+func (UnimplementedImageUploaderServer) DeleteImage(context.Context, *Url) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteImage not implemented")
+}
 func (UnimplementedImageUploaderServer) mustEmbedUnimplementedImageUploaderServer() {}
 
 // UnsafeImageUploaderServer may be embedded to opt out of forward compatibility for this service.
@@ -88,6 +185,104 @@ func _ImageUploader_UploadImage_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ImageUploader_UploadImageStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ImageUploaderServer).UploadImageStream(&imageUploaderUploadImageStreamServer{stream})
+}
+
+type ImageUploader_UploadImageStreamServer interface {
+	SendAndClose(*Url) error
+	Recv() (*ImageChunk, error)
+	grpc.ServerStream
+}
+
+type imageUploaderUploadImageStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *imageUploaderUploadImageStreamServer) SendAndClose(m *Url) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *imageUploaderUploadImageStreamServer) Recv() (*ImageChunk, error) {
+	m := new(ImageChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ImageUploader_UploadRenditions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Image)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImageUploaderServer).UploadRenditions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/images.ImageUploader/UploadRenditions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImageUploaderServer).UploadRenditions(ctx, req.(*Image))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImageUploader_SetPrivate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Visibility)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImageUploaderServer).SetPrivate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/images.ImageUploader/SetPrivate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImageUploaderServer).SetPrivate(ctx, req.(*Visibility))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImageUploader_SignURLs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Urls)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImageUploaderServer).SignURLs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/images.ImageUploader/SignURLs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImageUploaderServer).SignURLs(ctx, req.(*Urls))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImageUploader_DeleteImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Url)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImageUploaderServer).DeleteImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/images.ImageUploader/DeleteImage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImageUploaderServer).DeleteImage(ctx, req.(*Url))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ImageUploader_ServiceDesc is the grpc.ServiceDesc for ImageUploader service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -99,7 +294,29 @@ var ImageUploader_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UploadImage",
 			Handler:    _ImageUploader_UploadImage_Handler,
 		},
+		{
+			MethodName: "UploadRenditions",
+			Handler:    _ImageUploader_UploadRenditions_Handler,
+		},
+		{
+			MethodName: "SetPrivate",
+			Handler:    _ImageUploader_SetPrivate_Handler,
+		},
+		{
+			MethodName: "SignURLs",
+			Handler:    _ImageUploader_SignURLs_Handler,
+		},
+		{
+			MethodName: "DeleteImage",
+			Handler:    _ImageUploader_DeleteImage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadImageStream",
+			Handler:       _ImageUploader_UploadImageStream_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "images.proto",
 }
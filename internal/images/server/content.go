@@ -0,0 +1,75 @@
+package images
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+)
+
+// Streamed images are stored under a temporary key until their content key is known.
+const uploadKeyPrefix = "upload-"
+
+type contentRepository struct {
+	Repository
+	log *zap.Logger
+}
+
+// NewContentRepository keys the images stored in rep by ContentKey instead of the IDs given by callers,
+// only the extension of the ID is kept. Uploading an image that is already stored returns
+// the existing URL without uploading it again, so retried uploads are idempotent.
+// The existing image is made public again like a newly uploaded one.
+func NewContentRepository(rep Repository, log *zap.Logger) Repository {
+	return &contentRepository{rep, log}
+}
+
+func (rep *contentRepository) UploadImage(image *models.Image) (string, error) {
+	key := ContentKey(image.Bytes, filepath.Ext(image.ID))
+	url, exists, err := rep.Repository.Lookup(key)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return rep.reuse(url)
+	}
+	return rep.Repository.UploadImage(&models.Image{ID: key, Bytes: image.Bytes})
+}
+
+// UploadStream hashes the image while it is uploaded under a temporary key, then moves it to
+// its content key or drops it if an equal image is already stored.
+func (rep *contentRepository) UploadStream(id string, body io.Reader) (string, error) {
+	ext := filepath.Ext(id)
+	tmpKey := uploadKeyPrefix + uuid.NewString() + ext
+
+	hash := sha256.New()
+	tmpURL, err := rep.Repository.UploadStream(tmpKey, io.TeeReader(body, hash))
+	if err != nil {
+		return "", err
+	}
+
+	key := hex.EncodeToString(hash.Sum(nil)) + ext
+	url, exists, err := rep.Repository.Lookup(key)
+	if err == nil && !exists {
+		return rep.Repository.Move(tmpKey, key)
+	}
+
+	if deleteErr := rep.Repository.DeleteImage(tmpURL); deleteErr != nil {
+		rep.log.Error("Failed to delete temporary image", zap.String("url", tmpURL), zap.Error(deleteErr))
+	}
+	if err != nil {
+		return "", err
+	}
+	return rep.reuse(url)
+}
+
+func (rep *contentRepository) reuse(url string) (string, error) {
+	if err := rep.Repository.SetPrivate(url, false); err != nil {
+		return "", err
+	}
+	return url, nil
+}
@@ -0,0 +1,145 @@
+package images
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server/imagestest"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+)
+
+func TestContentKey(t *testing.T) {
+	data := []byte("image bytes")
+
+	key := ContentKey(data, ".jpg")
+	if len(key) != 64+len(".jpg") || !strings.HasSuffix(key, ".jpg") {
+		t.Fatalf("key %q is not a sha256 hex digest with the extension", key)
+	}
+	if again := ContentKey(append([]byte(nil), data...), ".jpg"); again != key {
+		t.Errorf("equal bytes give keys %q and %q", key, again)
+	}
+	if other := ContentKey([]byte("other bytes"), ".jpg"); other == key {
+		t.Errorf("different bytes give the same key %q", key)
+	}
+	if !ValidKey(key) {
+		t.Errorf("content key %q is not a valid key", key)
+	}
+}
+
+func TestContentRepository_UploadImage(t *testing.T) {
+	fake := imagestest.NewRepository()
+	rep := NewContentRepository(fake, zap.NewNop())
+	data := []byte("image bytes")
+	key := ContentKey(data, ".jpg")
+
+	url, err := rep.UploadImage(&models.Image{ID: "first.jpg", Bytes: data})
+	if err != nil {
+		t.Fatalf("first upload: unexpected error %v", err)
+	}
+	if url != imagestest.URL(key) {
+		t.Errorf("first upload: url = %q, expected %q", url, imagestest.URL(key))
+	}
+
+	// the ID of the caller is not a part of the key, only its extension is
+	again, err := rep.UploadImage(&models.Image{ID: "second.jpg", Bytes: append([]byte(nil), data...)})
+	if err != nil {
+		t.Fatalf("second upload: unexpected error %v", err)
+	}
+	if again != url {
+		t.Errorf("second upload: url = %q, expected %q", again, url)
+	}
+	if uploads := fake.Calls("UploadImage"); uploads != 1 {
+		t.Errorf("%d uploads, expected the second one to be skipped", uploads)
+	}
+
+	other, err := rep.UploadImage(&models.Image{ID: "first.jpg", Bytes: []byte("other bytes")})
+	if err != nil {
+		t.Fatalf("other image: unexpected error %v", err)
+	}
+	if other == url {
+		t.Errorf("another image with the same ID got the same url")
+	}
+	if keys := fake.Keys(); len(keys) != 2 {
+		t.Errorf("stored keys %v, expected 2", keys)
+	}
+}
+
+func TestContentRepository_UploadImage_Private(t *testing.T) {
+	fake := imagestest.NewRepository()
+	rep := NewContentRepository(fake, zap.NewNop())
+	data := []byte("image bytes")
+
+	url, err := rep.UploadImage(&models.Image{ID: "a.jpg", Bytes: data})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = rep.SetPrivate(url, true); err != nil {
+		t.Fatalf("SetPrivate: unexpected error %v", err)
+	}
+
+	// the same image uploaded again is public like a new one
+	if _, err = rep.UploadImage(&models.Image{ID: "b.jpg", Bytes: data}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if fake.Private(url) {
+		t.Errorf("reused image is private")
+	}
+}
+
+func TestContentRepository_UploadStream(t *testing.T) {
+	fake := imagestest.NewRepository()
+	rep := NewContentRepository(fake, zap.NewNop())
+	data := []byte("streamed image bytes")
+	key := ContentKey(data, ".png")
+
+	url, err := rep.UploadStream("first.png", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("first upload: unexpected error %v", err)
+	}
+	if url != imagestest.URL(key) {
+		t.Errorf("first upload: url = %q, expected %q", url, imagestest.URL(key))
+	}
+	if stored, _ := fake.File(key); !bytes.Equal(stored, data) {
+		t.Errorf("stored bytes differ from the uploaded ones")
+	}
+
+	again, err := rep.UploadStream("second.png", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("second upload: unexpected error %v", err)
+	}
+	if again != url {
+		t.Errorf("second upload: url = %q, expected %q", again, url)
+	}
+	// the second copy is uploaded under a temporary key before it is hashed, then dropped
+	if moves := fake.Calls("Move"); moves != 1 {
+		t.Errorf("%d moves, expected the second copy not to be moved", moves)
+	}
+	if keys := fake.Keys(); len(keys) != 1 || keys[0] != key {
+		t.Errorf("stored keys %v, expected only %q", keys, key)
+	}
+
+	// streamed and whole uploads of equal images share the key
+	whole, err := rep.UploadImage(&models.Image{ID: "third.png", Bytes: data})
+	if err != nil {
+		t.Fatalf("whole upload: unexpected error %v", err)
+	}
+	if whole != url || fake.Calls("UploadImage") != 0 {
+		t.Errorf("whole upload of a streamed image is not skipped")
+	}
+}
+
+func TestContentRepository_UploadStream_Failed(t *testing.T) {
+	fake := imagestest.NewRepository()
+	fake.ReadLimit = 4
+	rep := NewContentRepository(fake, zap.NewNop())
+
+	if _, err := rep.UploadStream("a.png", bytes.NewReader([]byte("streamed image bytes"))); err == nil {
+		t.Fatalf("expected error")
+	}
+	if keys := fake.Keys(); len(keys) != 0 {
+		t.Errorf("stored keys %v after a failed upload", keys)
+	}
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+
+	images "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server"
+	mw "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/middleware"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+// RegisterHandlers serves the images stored by the filesystem repository from root,
+// private images are served only by URLs signed with signer.
+func RegisterHandlers(mux *httprouter.Router, logger *zap.Logger, root string, signer *images.URLSigner,
+	m *mw.HttpMetricsMiddleware) {
+	del := delivery{root, signer, logger}
+
+	mux.GET("/images/:key", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(del.get, logger), logger), logger))
+	mux.HEAD("/images/:key", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(del.get, logger), logger), logger))
+}
+
+type delivery struct {
+	root   string
+	signer *images.URLSigner
+	log    *zap.Logger
+}
+
+func (del *delivery) get(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+	key := p.ByName("key")
+	if !images.ValidKey(key) {
+		return pkgErrors.ErrImageNotFound
+	}
+
+	file, err := os.Open(filepath.Join(del.root, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return pkgErrors.ErrImageNotFound
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return pkgErrors.ErrImageNotFound
+	}
+
+	private, err := del.private(key)
+	if err != nil {
+		return err
+	}
+	if private {
+		query := r.URL.Query()
+		if !del.signer.Verify(key, query.Get(images.ExpiresParam), query.Get(images.SignatureParam)) {
+			return pkgErrors.ErrForbidden
+		}
+		// the signed URL expires, shared caches must not keep the image
+		w.Header().Set("Cache-Control", "private, no-store")
+	} else {
+		// revalidation is answered with 304 by the modification time, or 403 once the image is private
+		w.Header().Set("Cache-Control", images.PublicCacheControl)
+	}
+	// files of unknown types are not guessed from their names or contents to be rendered
+	contentType := images.ContentType(key)
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, key, info.ModTime(), file)
+	return nil
+}
+
+func (del *delivery) private(key string) (bool, error) {
+	_, err := os.Stat(filepath.Join(del.root, images.FSPrivateDir, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
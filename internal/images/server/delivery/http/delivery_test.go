@@ -0,0 +1,241 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	images "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func newTestDelivery(t *testing.T, files map[string]string) *delivery {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, images.FSPrivateDir), 0o755); err != nil {
+		t.Fatalf("can't create private directory: %v", err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("can't write %s: %v", name, err)
+		}
+	}
+	return &delivery{root: root, signer: newTestSigner(t), log: zap.NewNop()}
+}
+
+func TestGet(t *testing.T) {
+	del := newTestDelivery(t, map[string]string{
+		"photo.jpg":  "jpeg",
+		"photo.png":  "png",
+		"anim.gif":   "gif",
+		"clip.mp4":   "mp4",
+		"clip.webm":  "webm",
+		"page.html":  "<script>alert(1)</script>",
+		"noext":      "<html></html>",
+		".upload-42": "partial upload",
+		filepath.Join(images.FSPrivateDir, "marker"): "",
+	})
+	if err := os.Mkdir(filepath.Join(del.root, "dir.jpg"), 0o755); err != nil {
+		t.Fatalf("can't create directory: %v", err)
+	}
+	// a file next to the root that traversal would reach
+	outside := filepath.Join(filepath.Dir(del.root), "outside.jpg")
+	if err := os.WriteFile(outside, []byte("outside"), 0o644); err != nil {
+		t.Fatalf("can't write %s: %v", outside, err)
+	}
+	t.Cleanup(func() { _ = os.Remove(outside) })
+
+	type testCase struct {
+		method      string
+		key         string
+		err         error
+		body        string
+		contentType string
+	}
+
+	tests := map[string]testCase{
+		"jpeg": {key: "photo.jpg", body: "jpeg", contentType: "image/jpeg"},
+		"png":  {key: "photo.png", body: "png", contentType: "image/png"},
+		"gif":  {key: "anim.gif", body: "gif", contentType: "image/gif"},
+		"mp4":  {key: "clip.mp4", body: "mp4", contentType: "video/mp4"},
+		"webm": {key: "clip.webm", body: "webm", contentType: "video/webm"},
+		"head": {method: http.MethodHead, key: "photo.jpg", contentType: "image/jpeg"},
+		"unknown extension": {
+			key:         "page.html",
+			body:        "<script>alert(1)</script>",
+			contentType: "application/octet-stream",
+		},
+		"no extension":        {key: "noext", body: "<html></html>", contentType: "application/octet-stream"},
+		"missing":             {key: "missing.jpg", err: pkgErrors.ErrImageNotFound},
+		"empty key":           {key: "", err: pkgErrors.ErrImageNotFound},
+		"directory":           {key: "dir.jpg", err: pkgErrors.ErrImageNotFound},
+		"parent":              {key: "..", err: pkgErrors.ErrImageNotFound},
+		"traversal":           {key: "../outside.jpg", err: pkgErrors.ErrImageNotFound},
+		"absolute path":       {key: outside, err: pkgErrors.ErrImageNotFound},
+		"nested":              {key: images.FSPrivateDir + "/marker", err: pkgErrors.ErrImageNotFound},
+		"temporary upload":    {key: ".upload-42", err: pkgErrors.ErrImageNotFound},
+		"private markers":     {key: images.FSPrivateDir, err: pkgErrors.ErrImageNotFound},
+		"current directory":   {key: ".", err: pkgErrors.ErrImageNotFound},
+		"backslash separator": {key: `..\outside.jpg`, err: pkgErrors.ErrImageNotFound},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "/images/x", nil)
+			rec := httptest.NewRecorder()
+			err := del.get(rec, req, httprouter.Params{{Key: "key", Value: test.key}})
+			if !errors.Is(err, test.err) {
+				t.Fatalf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if err != nil {
+				if code, _ := pkgErrors.GetHTTPCodeByError(err); code != http.StatusNotFound {
+					t.Errorf("status = %d, expected %d", code, http.StatusNotFound)
+				}
+				return
+			}
+			if rec.Code != http.StatusOK || rec.Body.String() != test.body {
+				t.Errorf("response = %d %q, expected %d %q", rec.Code, rec.Body.String(), http.StatusOK, test.body)
+			}
+			if contentType := rec.Header().Get("Content-Type"); contentType != test.contentType {
+				t.Errorf("Content-Type = %q, expected %q", contentType, test.contentType)
+			}
+			if nosniff := rec.Header().Get("X-Content-Type-Options"); nosniff != "nosniff" {
+				t.Errorf("X-Content-Type-Options = %q, expected nosniff", nosniff)
+			}
+		})
+	}
+}
+
+func TestGet_Private(t *testing.T) {
+	del := newTestDelivery(t, map[string]string{
+		"public.jpg":  "public",
+		"private.jpg": "private",
+		filepath.Join(images.FSPrivateDir, "private.jpg"): "",
+	})
+	signer := newTestSigner(t)
+	sign := func(key string, expires time.Time) string {
+		signed, err := signer.Sign("/images/"+key, key, expires)
+		if err != nil {
+			t.Fatalf("can't sign %s: %v", key, err)
+		}
+		return signed
+	}
+
+	type testCase struct {
+		url          string
+		key          string
+		err          error
+		body         string
+		cacheControl string
+	}
+
+	tests := map[string]testCase{
+		"public": {
+			url:          "/images/public.jpg",
+			key:          "public.jpg",
+			body:         "public",
+			cacheControl: images.PublicCacheControl,
+		},
+		"private without signature": {
+			url: "/images/private.jpg",
+			key: "private.jpg",
+			err: pkgErrors.ErrForbidden,
+		},
+		"private with signature": {
+			url:          sign("private.jpg", time.Now().Add(time.Minute)),
+			key:          "private.jpg",
+			body:         "private",
+			cacheControl: "private, no-store",
+		},
+		"private with expired signature": {
+			url: sign("private.jpg", time.Now().Add(-time.Minute)),
+			key: "private.jpg",
+			err: pkgErrors.ErrForbidden,
+		},
+		"signature of another image": {
+			url: "/images/private.jpg?" + mustQuery(t, sign("public.jpg", time.Now().Add(time.Minute))),
+			key: "private.jpg",
+			err: pkgErrors.ErrForbidden,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, test.url, nil)
+			rec := httptest.NewRecorder()
+			err := del.get(rec, req, httprouter.Params{{Key: "key", Value: test.key}})
+			if !errors.Is(err, test.err) {
+				t.Fatalf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if err != nil {
+				if code, _ := pkgErrors.GetHTTPCodeByError(err); code != http.StatusForbidden {
+					t.Errorf("status = %d, expected %d", code, http.StatusForbidden)
+				}
+				return
+			}
+			if rec.Code != http.StatusOK || rec.Body.String() != test.body {
+				t.Errorf("response = %d %q, expected %d %q", rec.Code, rec.Body.String(), http.StatusOK, test.body)
+			}
+			if cacheControl := rec.Header().Get("Cache-Control"); cacheControl != test.cacheControl {
+				t.Errorf("Cache-Control = %q, expected %q", cacheControl, test.cacheControl)
+			}
+		})
+	}
+}
+
+func TestGet_Revalidation(t *testing.T) {
+	del := newTestDelivery(t, map[string]string{"image.jpg": "image"})
+	revalidate := func() (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodGet, "/images/image.jpg", nil)
+		req.Header.Set("If-Modified-Since", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		rec := httptest.NewRecorder()
+		return rec, del.get(rec, req, httprouter.Params{{Key: "key", Value: "image.jpg"}})
+	}
+
+	rec, err := revalidate()
+	if err != nil || rec.Code != http.StatusNotModified {
+		t.Fatalf("revalidating a public image: %d, %v, expected %d", rec.Code, err, http.StatusNotModified)
+	}
+
+	// a cached public copy stops being served once the image is made private
+	if err = os.WriteFile(filepath.Join(del.root, images.FSPrivateDir, "image.jpg"), nil, 0o644); err != nil {
+		t.Fatalf("can't mark the image private: %v", err)
+	}
+	if _, err = revalidate(); !errors.Is(err, pkgErrors.ErrForbidden) {
+		t.Errorf("revalidating a private image: expected %v, got %v", pkgErrors.ErrForbidden, err)
+	}
+}
+
+func mustQuery(t *testing.T, url string) string {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	return req.URL.RawQuery
+}
+
+func newTestSigner(t *testing.T) *images.URLSigner {
+	t.Helper()
+	signer, err := images.NewURLSigner(testSecret)
+	if err != nil {
+		t.Fatalf("can't create signer: %v", err)
+	}
+	return signer
+}
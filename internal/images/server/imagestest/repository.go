@@ -0,0 +1,169 @@
+// Package imagestest provides an in-memory images.Repository for the tests of the images service.
+package imagestest
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+const urlPrefix = "https://images.test/"
+
+// ErrStoppedReading is returned by UploadStream of a repository with ReadLimit.
+var ErrStoppedReading = errors.New("storage stopped reading")
+
+// Repository keeps the images in memory and counts the calls of its methods.
+type Repository struct {
+	// ReadLimit makes UploadStream fail with ErrStoppedReading after reading that many bytes,
+	// zero means the whole body is read.
+	ReadLimit int
+
+	mu      sync.Mutex
+	files   map[string][]byte
+	private map[string]bool
+	calls   map[string]int
+}
+
+func NewRepository() *Repository {
+	return &Repository{
+		files:   make(map[string][]byte),
+		private: make(map[string]bool),
+		calls:   make(map[string]int),
+	}
+}
+
+// URL is the URL of the image stored with the key.
+func URL(key string) string {
+	return urlPrefix + key
+}
+
+func keyOf(url string) string {
+	return strings.TrimPrefix(url, urlPrefix)
+}
+
+// Calls returns how many times the method was called.
+func (rep *Repository) Calls(method string) int {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	return rep.calls[method]
+}
+
+// Keys returns the keys of the stored images.
+func (rep *Repository) Keys() []string {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	keys := make([]string, 0, len(rep.files))
+	for key := range rep.files {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// File returns the bytes of the image stored with the key.
+func (rep *Repository) File(key string) ([]byte, bool) {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	data, ok := rep.files[key]
+	return data, ok
+}
+
+// Private reports whether the image with the URL is private.
+func (rep *Repository) Private(url string) bool {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	return rep.private[keyOf(url)]
+}
+
+func (rep *Repository) call(method string) {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	rep.calls[method]++
+}
+
+func (rep *Repository) store(key string, data []byte) string {
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	rep.files[key] = data
+	delete(rep.private, key)
+	return URL(key)
+}
+
+func (rep *Repository) UploadImage(image *models.Image) (string, error) {
+	rep.call("UploadImage")
+	return rep.store(image.ID, append([]byte(nil), image.Bytes...)), nil
+}
+
+func (rep *Repository) UploadStream(id string, body io.Reader) (string, error) {
+	rep.call("UploadStream")
+	if rep.ReadLimit > 0 {
+		_, _ = io.ReadFull(body, make([]byte, rep.ReadLimit))
+		return "", ErrStoppedReading
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	return rep.store(id, data), nil
+}
+
+func (rep *Repository) Lookup(id string) (string, bool, error) {
+	rep.call("Lookup")
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	_, ok := rep.files[id]
+	if !ok {
+		return "", false, nil
+	}
+	return URL(id), true, nil
+}
+
+func (rep *Repository) Move(from, to string) (string, error) {
+	rep.call("Move")
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	data, ok := rep.files[from]
+	if !ok {
+		return "", pkgErrors.ErrImageNotFound
+	}
+	delete(rep.files, from)
+	rep.files[to] = data
+	rep.private[to] = rep.private[from]
+	delete(rep.private, from)
+	return URL(to), nil
+}
+
+func (rep *Repository) SetPrivate(url string, private bool) error {
+	rep.call("SetPrivate")
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	key := keyOf(url)
+	if _, ok := rep.files[key]; !ok {
+		return pkgErrors.ErrImageNotFound
+	}
+	rep.private[key] = private
+	return nil
+}
+
+func (rep *Repository) SignURL(url string, ttl time.Duration) (string, error) {
+	rep.call("SignURL")
+	return url + "?ttl=" + ttl.String(), nil
+}
+
+func (rep *Repository) DeleteImage(url string) error {
+	rep.call("DeleteImage")
+	rep.mu.Lock()
+	defer rep.mu.Unlock()
+	key := keyOf(url)
+	if _, ok := rep.files[key]; !ok {
+		return pkgErrors.ErrImageNotFound
+	}
+	delete(rep.files, key)
+	delete(rep.private, key)
+	return nil
+}
@@ -0,0 +1,74 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+// posterTimeout bounds the time ffmpeg may take to decode the first frame of a video.
+const posterTimeout = 30 * time.Second
+
+// FrameExtractor decodes poster frames of videos, there is no video decoder among the dependencies.
+type FrameExtractor interface {
+	// PosterFrame returns the first frame of the video, ext is the extension of its format.
+	// Videos that cannot be decoded give pkgErrors.ErrInvalidImage.
+	PosterFrame(video []byte, ext string) (image.Image, error)
+}
+
+type ffmpeg struct {
+	path string
+}
+
+// NewFFmpeg runs the ffmpeg binary at path. The video is passed in a temporary file
+// since MP4 files with the index at the end cannot be read from a pipe.
+func NewFFmpeg(path string) FrameExtractor {
+	return &ffmpeg{path: path}
+}
+
+func (f *ffmpeg) PosterFrame(video []byte, ext string) (image.Image, error) {
+	file, err := os.CreateTemp("", "poster-*"+ext)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(video)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), posterTimeout)
+	defer cancel()
+
+	// ffmpeg applies the rotation of the video, so the frame is upright
+	cmd := exec.CommandContext(ctx, f.path, "-hide_banner", "-loglevel", "error", "-i", file.Name(),
+		"-frames:v", "1", "-f", "image2pipe", "-c:v", "png", "pipe:1")
+	stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return nil, errors.Wrap(pkgErrors.ErrInvalidImage, string(bytes.TrimSpace(stderr.Bytes())))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	frame, err := png.Decode(stdout)
+	if err != nil {
+		return nil, errors.Wrap(pkgErrors.ErrInvalidImage, err.Error())
+	}
+	return frame, nil
+}
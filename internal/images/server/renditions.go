@@ -0,0 +1,110 @@
+package images
+
+import (
+	"image"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+)
+
+func renditionID(id string, name string) string {
+	return strings.TrimSuffix(id, filepath.Ext(id)) + "_" + name + ".jpg"
+}
+
+// UploadRenditions uploads the original file and its JPEG copies resized to pkgImage.RenditionWidths,
+// see there why there are no WebP copies.
+// Widths not smaller than the original are skipped, undecodable images get the original only.
+// The perceptual hash, the colors and the BlurHash of the image are computed along the way from
+// the sanitized image, so its EXIF orientation has already been applied. For animated GIFs and
+// videos the renditions, the hash and the colors are made of the poster frame, which is uploaded as well.
+// info is the result of SanitizeMedia for the file.
+func UploadRenditions(rep Repository, file *models.Image, info pkgImage.MediaInfo,
+	frames FrameExtractor) (models.ImageRenditions, error) {
+	img, err := posterFrame(file, info, frames)
+	if err != nil {
+		return models.ImageRenditions{}, err
+	}
+
+	url, err := rep.UploadImage(file)
+	if err != nil {
+		return models.ImageRenditions{}, err
+	}
+	uploaded := models.ImageRenditions{
+		URLs:      map[string]string{pkgImage.OriginalRendition: url},
+		MediaType: mediaType(info),
+		Width:     info.Width,
+		Height:    info.Height,
+		Duration:  info.Duration,
+	}
+	if img == nil {
+		return uploaded, nil
+	}
+	uploaded.Hash = pkgImage.DHash(img)
+	uploaded.Color = pkgImage.CalcAvgColor(img).CSS()
+	uploaded.Palette = []string{}
+	for _, color := range pkgImage.Palette(img, constants.PaletteSize) {
+		uploaded.Palette = append(uploaded.Palette, color.CSS())
+	}
+	uploaded.BlurHash = pkgImage.BlurHash(img, constants.BlurHashXComponents, constants.BlurHashYComponents)
+
+	if info.Animated() {
+		poster, err := pkgImage.EncodeJPEG(img)
+		if err != nil {
+			return models.ImageRenditions{}, err
+		}
+		url, err = rep.UploadImage(&models.Image{
+			ID:    renditionID(file.ID, pkgImage.PosterRendition),
+			Bytes: poster,
+		})
+		if err != nil {
+			return models.ImageRenditions{}, err
+		}
+		uploaded.URLs[pkgImage.PosterRendition] = url
+	}
+
+	for _, width := range pkgImage.RenditionWidths {
+		if img.Bounds().Dx() <= width {
+			break
+		}
+
+		data, err := pkgImage.EncodeJPEG(pkgImage.Resize(img, width))
+		if err != nil {
+			return models.ImageRenditions{}, err
+		}
+
+		name := pkgImage.RenditionName(width)
+		url, err = rep.UploadImage(&models.Image{ID: renditionID(file.ID, name), Bytes: data})
+		if err != nil {
+			return models.ImageRenditions{}, err
+		}
+		uploaded.URLs[name] = url
+	}
+	return uploaded, nil
+}
+
+// posterFrame decodes the image the renditions are made of, nil if an image cannot be decoded.
+// Videos that cannot be decoded are rejected, only their headers have been checked so far.
+func posterFrame(file *models.Image, info pkgImage.MediaInfo, frames FrameExtractor) (image.Image, error) {
+	switch {
+	case pkgImage.IsVideo(info.Format):
+		return frames.PosterFrame(file.Bytes, filepath.Ext(file.ID))
+	case info.Format == pkgImage.FormatGIF:
+		img, _ := pkgImage.GIFPoster(file.Bytes)
+		return img, nil
+	}
+	img, _ := pkgImage.BytesToImage(file.Bytes)
+	return img, nil
+}
+
+func mediaType(info pkgImage.MediaInfo) string {
+	switch {
+	case pkgImage.IsVideo(info.Format):
+		return models.MediaTypeVideo
+	case info.Animated():
+		return models.MediaTypeGIF
+	}
+	return models.MediaTypeImage
+}
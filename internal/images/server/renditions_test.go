@@ -0,0 +1,95 @@
+package images
+
+import (
+	"encoding/binary"
+	"image"
+	"reflect"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server/imagestest"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image/imagetest"
+)
+
+func colorsOf(img image.Image) models.ImageRenditions {
+	palette := []string{}
+	for _, color := range pkgImage.Palette(img, constants.PaletteSize) {
+		palette = append(palette, color.CSS())
+	}
+	return models.ImageRenditions{
+		Color:    pkgImage.CalcAvgColor(img).CSS(),
+		Palette:  palette,
+		BlurHash: pkgImage.BlurHash(img, constants.BlurHashXComponents, constants.BlurHashYComponents),
+	}
+}
+
+func TestUploadRenditions_Colors(t *testing.T) {
+	type testCase struct {
+		image  models.Image
+		poster func(t *testing.T, data []byte) image.Image
+	}
+
+	decode := func(t *testing.T, data []byte) image.Image {
+		img, err := pkgImage.BytesToImage(data)
+		if err != nil {
+			t.Fatalf("can't decode image: %v", err)
+		}
+		return img
+	}
+	gifPoster := func(t *testing.T, data []byte) image.Image {
+		img, err := pkgImage.GIFPoster(data)
+		if err != nil {
+			t.Fatalf("can't decode gif: %v", err)
+		}
+		return img
+	}
+
+	tests := map[string]testCase{
+		"jpeg": {
+			image:  models.Image{ID: "a.jpg", Bytes: imagetest.JPEG(t, 16, 8)},
+			poster: decode,
+		},
+		"rotated jpeg": {
+			image:  models.Image{ID: "a.jpg", Bytes: imagetest.JPEG(t, 16, 8, imagetest.EXIF(binary.BigEndian, 6))},
+			poster: decode,
+		},
+		"animated gif": {
+			image:  models.Image{ID: "a.gif", Bytes: imagetest.GIF(t, 16, 8, 10, 10)},
+			poster: gifPoster,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := test.poster(t, test.image.Bytes)
+			file := test.image
+			info, err := SanitizeMedia(&file, testLimits)
+			if err != nil {
+				t.Fatalf("can't sanitize image: %v", err)
+			}
+
+			uploaded, err := UploadRenditions(imagestest.NewRepository(), &file, info, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// the colors are of the sanitized image, which is oriented as it is displayed
+			expected := colorsOf(test.poster(t, file.Bytes))
+			got := models.ImageRenditions{Color: uploaded.Color, Palette: uploaded.Palette, BlurHash: uploaded.BlurHash}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("\nExpected: %v\nGot: %v", expected, got)
+			}
+			if got.Color == "" || len(got.Palette) == 0 || got.BlurHash == "" {
+				t.Errorf("colors are not computed: %v", got)
+			}
+			if raw.Bounds().Dx() != info.Width && got.BlurHash == colorsOf(raw).BlurHash {
+				t.Errorf("BlurHash is of the image before orientation")
+			}
+		})
+	}
+}
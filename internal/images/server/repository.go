@@ -1,9 +1,68 @@
 package images
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 )
 
+// Storage backends selectable with config.ImageStorageConfig.Backend
+const (
+	S3Storage = "s3"
+	FSStorage = "fs"
+)
+
+// PublicCacheControl lets caches store public images but makes them revalidate every use.
+// Images are not immutable for caches: SetPrivate changes the visibility of a key in place
+// when the boards of its pins become secret, and a cached public copy would outlive that.
+const PublicCacheControl = "public, no-cache"
+
+// FSPrivateDir is the directory of the filesystem storage where empty files mark private images.
+const FSPrivateDir = ".private"
+
 type Repository interface {
 	UploadImage(image *models.Image) (string, error)
+	// UploadStream stores the image read from body without holding the whole file in memory.
+	UploadStream(id string, body io.Reader) (string, error)
+	// Lookup returns the URL of the image stored with the key, false if there is none.
+	Lookup(id string) (string, bool, error)
+	// Move stores the image under a new key and returns its new URL.
+	Move(from, to string) (string, error)
+	// SetPrivate makes the image readable only with URLs from SignURL, uploaded images are public.
+	SetPrivate(url string, private bool) error
+	// SignURL returns the URL of the image that stays valid for ttl even if the image is private.
+	SignURL(url string, ttl time.Duration) (string, error)
+	DeleteImage(url string) error
+}
+
+// Types of the stored files by extension, the extensions are set by Sanitize and SanitizeMedia.
+var contentTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".mp4":  "video/mp4",
+	".webm": "video/webm",
+}
+
+// ContentType returns the MIME type of the file stored with the key, an empty string if it is unknown.
+func ContentType(key string) string {
+	return contentTypes[filepath.Ext(key)]
+}
+
+// ValidKey reports whether key is a flat file name, image keys never contain directories.
+// Hidden names are not image keys either, they are kept for the temporary files of uploads and FSPrivateDir.
+func ValidKey(key string) bool {
+	return key != "" && !strings.HasPrefix(key, ".") && filepath.Base(key) == key && path.Base(key) == key
+}
+
+// ContentKey is the storage key of an image with the given bytes, equal images get equal keys.
+func ContentKey(data []byte, ext string) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]) + ext
 }
@@ -0,0 +1,179 @@
+package fs
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	neturl "net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	images "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/config"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+type fsRepository struct {
+	log     *zap.Logger
+	root    string
+	baseURL string
+	signer  *images.URLSigner
+}
+
+// NewFSRepository stores images as files in the config.ImageStorageConfig.Root directory,
+// the files are served by the images http delivery under config.ImageStorageConfig.BaseURL.
+// URLs of private images are signed with signer.
+func NewFSRepository(log *zap.Logger, signer *images.URLSigner) (images.Repository, error) {
+	root := viper.GetString(config.ImageStorageConfig.Root)
+	log.Info("Opening local image storage...", zap.String("root", root))
+
+	err := os.MkdirAll(filepath.Join(root, images.FSPrivateDir), 0o755)
+	if err != nil {
+		log.Error("Failed to create image storage directory", zap.Error(err))
+		return &fsRepository{}, err
+	}
+
+	return &fsRepository{
+		log:     log,
+		root:    root,
+		baseURL: viper.GetString(config.ImageStorageConfig.BaseURL),
+		signer:  signer,
+	}, nil
+}
+
+func (rep *fsRepository) UploadImage(image *models.Image) (string, error) {
+	return rep.UploadStream(image.ID, bytes.NewReader(image.Bytes))
+}
+
+func (rep *fsRepository) UploadStream(id string, body io.Reader) (string, error) {
+	if !images.ValidKey(id) {
+		return "", pkgErrors.ErrInvalidImage
+	}
+
+	// writing to a temporary file first so that readers never see a partially written image
+	tmp, err := os.CreateTemp(rep.root, ".upload-*")
+	if err != nil {
+		rep.log.Error("Failed to create image file", zap.Error(err))
+		return "", err
+	}
+	defer os.Remove(tmp.Name()) //nolint
+
+	_, err = io.Copy(tmp, body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		rep.log.Error("Failed to write image file", zap.Error(err))
+		return "", err
+	}
+
+	err = os.Rename(tmp.Name(), filepath.Join(rep.root, id))
+	if err != nil {
+		rep.log.Error("Failed to save image file", zap.Error(err))
+		return "", err
+	}
+
+	url := rep.url(id)
+	rep.log.Debug("Successfully saved image", zap.String("location", url))
+	return url, nil
+}
+
+func (rep *fsRepository) Lookup(id string) (string, bool, error) {
+	if !images.ValidKey(id) {
+		return "", false, pkgErrors.ErrInvalidImage
+	}
+
+	_, err := os.Stat(filepath.Join(rep.root, id))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		rep.log.Error("Failed to stat image file", zap.Error(err), zap.String("key", id))
+		return "", false, err
+	}
+	return rep.url(id), true, nil
+}
+
+func (rep *fsRepository) Move(from, to string) (string, error) {
+	if !images.ValidKey(from) || !images.ValidKey(to) {
+		return "", pkgErrors.ErrInvalidImage
+	}
+
+	err := os.Rename(filepath.Join(rep.root, from), filepath.Join(rep.root, to))
+	if err != nil {
+		rep.log.Error("Failed to move image file", zap.Error(err), zap.String("from", from), zap.String("to", to))
+		return "", err
+	}
+	return rep.url(to), nil
+}
+
+func (rep *fsRepository) url(id string) string {
+	return rep.baseURL + "/" + neturl.PathEscape(id)
+}
+
+// SetPrivate creates or removes the marker file of the image in images.FSPrivateDir.
+func (rep *fsRepository) SetPrivate(url string, private bool) error {
+	key, err := urlKey(url)
+	if err != nil {
+		return err
+	}
+
+	marker := filepath.Join(rep.root, images.FSPrivateDir, key)
+	if private {
+		err = os.WriteFile(marker, nil, 0o644)
+	} else if err = os.Remove(marker); errors.Is(err, os.ErrNotExist) {
+		err = nil
+	}
+	if err != nil {
+		rep.log.Error("Failed to change image visibility", zap.Error(err), zap.String("key", key),
+			zap.Bool("private", private))
+		return err
+	}
+	return nil
+}
+
+func (rep *fsRepository) SignURL(url string, ttl time.Duration) (string, error) {
+	key, err := urlKey(url)
+	if err != nil {
+		return "", err
+	}
+	return rep.signer.Sign(url, key, time.Now().Add(ttl))
+}
+
+// DeleteImage removes the file the url points to, missing files are not an error as with S3.
+func (rep *fsRepository) DeleteImage(url string) error {
+	key, err := urlKey(url)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(rep.root, key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		rep.log.Error("Failed to delete image", zap.Error(err), zap.String("key", key))
+		return err
+	}
+	err = os.Remove(filepath.Join(rep.root, images.FSPrivateDir, key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		rep.log.Error("Failed to delete image visibility marker", zap.Error(err), zap.String("key", key))
+	}
+	rep.log.Debug("Successfully deleted image", zap.String("key", key))
+	return nil
+}
+
+func urlKey(url string) (string, error) {
+	parsed, err := neturl.Parse(url)
+	if err != nil {
+		return "", err
+	}
+	key := path.Base(parsed.Path)
+	if !images.ValidKey(key) {
+		return "", pkgErrors.ErrBadParams
+	}
+	return key, nil
+}
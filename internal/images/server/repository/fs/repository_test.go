@@ -0,0 +1,314 @@
+package fs
+
+import (
+	"errors"
+	"io"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"go.uber.org/zap"
+
+	images "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+const (
+	testBaseURL = "http://images.test/storage"
+	testSecret  = "0123456789abcdef0123456789abcdef"
+)
+
+func newTestRepository(t *testing.T) *fsRepository {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, images.FSPrivateDir), 0o755); err != nil {
+		t.Fatalf("can't create private directory: %v", err)
+	}
+	return &fsRepository{log: zap.NewNop(), root: root, baseURL: testBaseURL, signer: newTestSigner(t)}
+}
+
+func TestLookup(t *testing.T) {
+	rep := newTestRepository(t)
+	if _, err := rep.UploadImage(&models.Image{ID: "stored.jpg", Bytes: []byte("image")}); err != nil {
+		t.Fatalf("can't upload image: %v", err)
+	}
+
+	type testCase struct {
+		id     string
+		url    string
+		exists bool
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"stored":        {id: "stored.jpg", url: testBaseURL + "/stored.jpg", exists: true},
+		"missing":       {id: "missing.jpg", exists: false},
+		"other ext":     {id: "stored.png", exists: false},
+		"path":          {id: "../stored.jpg", err: pkgErrors.ErrInvalidImage},
+		"empty":         {id: "", err: pkgErrors.ErrInvalidImage},
+		"nested":        {id: "a/stored.jpg", err: pkgErrors.ErrInvalidImage},
+		"parent escape": {id: "..", err: pkgErrors.ErrInvalidImage},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			url, exists, err := rep.Lookup(test.id)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if exists != test.exists || url != test.url {
+				t.Errorf("Lookup = %q, %v, expected %q, %v", url, exists, test.url, test.exists)
+			}
+		})
+	}
+}
+
+func TestMove(t *testing.T) {
+	rep := newTestRepository(t)
+	data := []byte("image")
+	if _, err := rep.UploadImage(&models.Image{ID: "upload-1.jpg", Bytes: data}); err != nil {
+		t.Fatalf("can't upload image: %v", err)
+	}
+
+	url, err := rep.Move("upload-1.jpg", "content.jpg")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if url != testBaseURL+"/content.jpg" {
+		t.Errorf("url = %q, expected %q", url, testBaseURL+"/content.jpg")
+	}
+
+	if _, exists, _ := rep.Lookup("upload-1.jpg"); exists {
+		t.Errorf("moved image is still stored under the old key")
+	}
+	stored, err := os.ReadFile(filepath.Join(rep.root, "content.jpg"))
+	if err != nil || string(stored) != string(data) {
+		t.Errorf("moved image = %q, %v, expected %q", stored, err, data)
+	}
+
+	if _, err = rep.Move("upload-1.jpg", "other.jpg"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("moving a missing image: expected %v, got %v", os.ErrNotExist, err)
+	}
+	if _, err = rep.Move("content.jpg", "../content.jpg"); !errors.Is(err, pkgErrors.ErrInvalidImage) {
+		t.Errorf("moving out of the root: expected %v, got %v", pkgErrors.ErrInvalidImage, err)
+	}
+	if _, err = rep.Move("../content.jpg", "content.jpg"); !errors.Is(err, pkgErrors.ErrInvalidImage) {
+		t.Errorf("moving from outside the root: expected %v, got %v", pkgErrors.ErrInvalidImage, err)
+	}
+}
+
+// rootFiles returns the contents of the files in the root of the storage by name, except images.FSPrivateDir.
+func rootFiles(t *testing.T, rep *fsRepository) map[string]string {
+	t.Helper()
+	entries, err := os.ReadDir(rep.root)
+	if err != nil {
+		t.Fatalf("can't read root: %v", err)
+	}
+	files := make(map[string]string)
+	for _, entry := range entries {
+		if entry.Name() == images.FSPrivateDir {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(rep.root, entry.Name()))
+		if err != nil {
+			t.Fatalf("can't read %s: %v", entry.Name(), err)
+		}
+		files[entry.Name()] = string(data)
+	}
+	return files
+}
+
+func TestUploadStream(t *testing.T) {
+	errRead := errors.New("connection reset")
+
+	type testCase struct {
+		id    string
+		body  io.Reader
+		url   string
+		files map[string]string
+		err   error
+	}
+
+	tests := map[string]testCase{
+		"stored": {
+			id:    "a.jpg",
+			body:  strings.NewReader("image"),
+			url:   testBaseURL + "/a.jpg",
+			files: map[string]string{"a.jpg": "image"},
+		},
+		"escaped url": {
+			id:    "a b.jpg",
+			body:  strings.NewReader("image"),
+			url:   testBaseURL + "/a%20b.jpg",
+			files: map[string]string{"a b.jpg": "image"},
+		},
+		"empty": {
+			id:    "a.jpg",
+			body:  strings.NewReader(""),
+			url:   testBaseURL + "/a.jpg",
+			files: map[string]string{"a.jpg": ""},
+		},
+		"read error":      {id: "a.jpg", body: iotest.ErrReader(errRead), err: errRead},
+		"traversal":       {id: "../a.jpg", body: strings.NewReader("image"), err: pkgErrors.ErrInvalidImage},
+		"nested":          {id: "dir/a.jpg", body: strings.NewReader("image"), err: pkgErrors.ErrInvalidImage},
+		"temporary file":  {id: ".upload-1", body: strings.NewReader("image"), err: pkgErrors.ErrInvalidImage},
+		"private markers": {id: images.FSPrivateDir, body: strings.NewReader("image"), err: pkgErrors.ErrInvalidImage},
+		"empty key":       {id: "", body: strings.NewReader("image"), err: pkgErrors.ErrInvalidImage},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rep := newTestRepository(t)
+			url, err := rep.UploadStream(test.id, test.body)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if url != test.url {
+				t.Errorf("url = %q, expected %q", url, test.url)
+			}
+			// failed uploads leave no temporary files behind
+			files := rootFiles(t, rep)
+			if test.files == nil {
+				test.files = map[string]string{}
+			}
+			if !reflect.DeepEqual(files, test.files) {
+				t.Errorf("files = %v, expected %v", files, test.files)
+			}
+		})
+	}
+}
+
+func TestUploadImage_Overwrite(t *testing.T) {
+	rep := newTestRepository(t)
+	for _, data := range []string{"first", "second"} {
+		if _, err := rep.UploadImage(&models.Image{ID: "a.jpg", Bytes: []byte(data)}); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if files := rootFiles(t, rep); !reflect.DeepEqual(files, map[string]string{"a.jpg": data}) {
+			t.Errorf("files = %v, expected a.jpg of %q", files, data)
+		}
+	}
+}
+
+func TestDeleteImage(t *testing.T) {
+	rep := newTestRepository(t)
+	url, err := rep.UploadImage(&models.Image{ID: "a.jpg", Bytes: []byte("image")})
+	if err != nil {
+		t.Fatalf("can't upload image: %v", err)
+	}
+	if _, err = rep.UploadImage(&models.Image{ID: "b.jpg", Bytes: []byte("image")}); err != nil {
+		t.Fatalf("can't upload image: %v", err)
+	}
+
+	if err = rep.DeleteImage(url); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if files := rootFiles(t, rep); !reflect.DeepEqual(files, map[string]string{"b.jpg": "image"}) {
+		t.Errorf("files = %v, expected b.jpg only", files)
+	}
+
+	// missing images are not an error, as with S3
+	if err = rep.DeleteImage(url); err != nil {
+		t.Errorf("deleting a missing image: unexpected error %v", err)
+	}
+	for _, url := range []string{testBaseURL + "/..", testBaseURL + "/.private", testBaseURL + "/.upload-1"} {
+		if err = rep.DeleteImage(url); !errors.Is(err, pkgErrors.ErrBadParams) {
+			t.Errorf("deleting %q: expected %v, got %v", url, pkgErrors.ErrBadParams, err)
+		}
+	}
+	if _, err = os.Stat(filepath.Join(rep.root, images.FSPrivateDir)); err != nil {
+		t.Errorf("private directory is deleted: %v", err)
+	}
+}
+
+func TestSetPrivate(t *testing.T) {
+	rep := newTestRepository(t)
+	url, err := rep.UploadImage(&models.Image{ID: "stored.jpg", Bytes: []byte("image")})
+	if err != nil {
+		t.Fatalf("can't upload image: %v", err)
+	}
+	marker := filepath.Join(rep.root, images.FSPrivateDir, "stored.jpg")
+	markerExists := func() bool {
+		_, err := os.Stat(marker)
+		return err == nil
+	}
+
+	steps := []struct {
+		private bool
+		marked  bool
+	}{
+		{private: false, marked: false},
+		{private: true, marked: true},
+		{private: true, marked: true},
+		{private: false, marked: false},
+		{private: false, marked: false},
+	}
+	for i, step := range steps {
+		if err = rep.SetPrivate(url, step.private); err != nil {
+			t.Fatalf("step %d: unexpected error %v", i, err)
+		}
+		if markerExists() != step.marked {
+			t.Errorf("step %d: marker exists = %v, expected %v", i, markerExists(), step.marked)
+		}
+	}
+
+	if err = rep.SetPrivate(url, true); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = rep.DeleteImage(url); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if markerExists() {
+		t.Errorf("marker of the deleted image is kept")
+	}
+
+	if err = rep.SetPrivate(testBaseURL+"/..", true); !errors.Is(err, pkgErrors.ErrBadParams) {
+		t.Errorf("marking an invalid key: expected %v, got %v", pkgErrors.ErrBadParams, err)
+	}
+}
+
+func TestSignURL(t *testing.T) {
+	rep := newTestRepository(t)
+
+	signed, err := rep.SignURL(testBaseURL+"/stored.jpg", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	parsed, err := neturl.Parse(signed)
+	if err != nil {
+		t.Fatalf("signed url %q does not parse: %v", signed, err)
+	}
+	if base := parsed.Scheme + "://" + parsed.Host + parsed.Path; base != testBaseURL+"/stored.jpg" {
+		t.Errorf("signed url points to %q", base)
+	}
+	query := parsed.Query()
+	if !newTestSigner(t).Verify("stored.jpg", query.Get(images.ExpiresParam),
+		query.Get(images.SignatureParam)) {
+		t.Errorf("signed url %q is not valid", signed)
+	}
+	if exp, _ := strconv.ParseInt(query.Get(images.ExpiresParam), 10, 64); exp > time.Now().Add(time.Minute).Unix() {
+		t.Errorf("signed url %q outlives its ttl", signed)
+	}
+}
+
+func newTestSigner(t *testing.T) *images.URLSigner {
+	t.Helper()
+	signer, err := images.NewURLSigner(testSecret)
+	if err != nil {
+		t.Fatalf("can't create signer: %v", err)
+	}
+	return signer
+}
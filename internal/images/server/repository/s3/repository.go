@@ -3,10 +3,18 @@ package s3
 import (
 	"bytes"
 	"context"
+	"errors"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"path"
+	"time"
 
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 
@@ -17,6 +25,8 @@ import (
 
 type s3Repository struct {
 	log        *zap.Logger
+	client     *s3.Client
+	presigner  *s3.PresignClient
 	uploader   *manager.Uploader
 	bucketName string
 }
@@ -40,17 +50,35 @@ func NewS3Repository(log *zap.Logger) (images.Repository, error) {
 
 	return &s3Repository{
 		log:        log,
+		client:     client,
+		presigner:  s3.NewPresignClient(client),
 		uploader:   uploader,
 		bucketName: viper.GetString(config.S3BucketConfig.BucketName),
 	}, nil
 }
 
 func (rep *s3Repository) UploadImage(image *models.Image) (string, error) {
+	return rep.UploadStream(image.ID, bytes.NewReader(image.Bytes))
+}
+
+// UploadStream relies on the s3 manager uploading unseekable bodies in parts.
+// Images are uploaded with the public-read ACL, so the bucket itself must not be publicly readable
+// for private images to work.
+func (rep *s3Repository) UploadStream(id string, body io.Reader) (string, error) {
 	rep.log.Debug("Initiating s3 transaction...")
+	// without a type S3 serves objects as binary data, which browsers may refuse to play
+	var contentType *string
+	if t := images.ContentType(id); t != "" {
+		contentType = &t
+	}
+	cacheControl := images.PublicCacheControl
 	output, err := rep.uploader.Upload(context.TODO(), &s3.PutObjectInput{
-		Bucket: &rep.bucketName,
-		Key:    &image.ID,
-		Body:   bytes.NewReader(image.Bytes),
+		Bucket:       &rep.bucketName,
+		Key:          &id,
+		Body:         body,
+		ACL:          types.ObjectCannedACLPublicRead,
+		ContentType:  contentType,
+		CacheControl: &cacheControl,
 	})
 	if err != nil {
 		rep.log.Error("Failed to upload image", zap.Error(err))
@@ -59,3 +87,135 @@ func (rep *s3Repository) UploadImage(image *models.Image) (string, error) {
 	rep.log.Debug("Successfully uploaded image", zap.String("location", output.Location))
 	return output.Location, nil
 }
+
+func (rep *s3Repository) Lookup(id string) (string, bool, error) {
+	_, err := rep.client.HeadObject(context.TODO(), &s3.HeadObjectInput{
+		Bucket: &rep.bucketName,
+		Key:    &id,
+	})
+	// HeadObject responses have no body, so a missing object is only told by the status code
+	var responseErr *awshttp.ResponseError
+	if errors.As(err, &responseErr) && responseErr.HTTPStatusCode() == http.StatusNotFound {
+		return "", false, nil
+	}
+	if err != nil {
+		rep.log.Error("Failed to look up image", zap.Error(err), zap.String("key", id))
+		return "", false, err
+	}
+
+	url, err := rep.objectURL(id)
+	if err != nil {
+		return "", false, err
+	}
+	return url, true, nil
+}
+
+// Move copies the object, S3 has no renames. The copy keeps the metadata of the object, its Cache-Control too.
+func (rep *s3Repository) Move(from, to string) (string, error) {
+	source := rep.bucketName + "/" + neturl.PathEscape(from)
+	_, err := rep.client.CopyObject(context.TODO(), &s3.CopyObjectInput{
+		Bucket:     &rep.bucketName,
+		CopySource: &source,
+		Key:        &to,
+		ACL:        types.ObjectCannedACLPublicRead,
+	})
+	if err != nil {
+		rep.log.Error("Failed to copy image", zap.Error(err), zap.String("from", from), zap.String("to", to))
+		return "", err
+	}
+
+	_, err = rep.client.DeleteObject(context.TODO(), &s3.DeleteObjectInput{
+		Bucket: &rep.bucketName,
+		Key:    &from,
+	})
+	if err != nil {
+		rep.log.Error("Failed to delete moved image", zap.Error(err), zap.String("key", from))
+	}
+	return rep.objectURL(to)
+}
+
+func (rep *s3Repository) SetPrivate(url string, private bool) error {
+	key, err := urlKey(url)
+	if err != nil {
+		return err
+	}
+
+	acl := types.ObjectCannedACLPublicRead
+	if private {
+		acl = types.ObjectCannedACLPrivate
+	}
+	_, err = rep.client.PutObjectAcl(context.TODO(), &s3.PutObjectAclInput{
+		Bucket: &rep.bucketName,
+		Key:    &key,
+		ACL:    acl,
+	})
+	if err != nil {
+		rep.log.Error("Failed to change image visibility", zap.Error(err), zap.String("key", key),
+			zap.Bool("private", private))
+		return err
+	}
+	return nil
+}
+
+func (rep *s3Repository) SignURL(url string, ttl time.Duration) (string, error) {
+	key, err := urlKey(url)
+	if err != nil {
+		return "", err
+	}
+
+	request, err := rep.presigner.PresignGetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: &rep.bucketName,
+		Key:    &key,
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		rep.log.Error("Failed to sign image url", zap.Error(err), zap.String("key", key))
+		return "", err
+	}
+	return request.URL, nil
+}
+
+// objectURL builds the URL of the object the way the uploader reports its location,
+// from the request the client would send for it without the signature.
+func (rep *s3Repository) objectURL(key string) (string, error) {
+	request, err := rep.presigner.PresignGetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: &rep.bucketName,
+		Key:    &key,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	url, err := neturl.Parse(request.URL)
+	if err != nil {
+		return "", err
+	}
+	url.RawQuery = ""
+	return url.String(), nil
+}
+
+func (rep *s3Repository) DeleteImage(url string) error {
+	key, err := urlKey(url)
+	if err != nil {
+		return err
+	}
+
+	_, err = rep.client.DeleteObject(context.TODO(), &s3.DeleteObjectInput{
+		Bucket: &rep.bucketName,
+		Key:    &key,
+	})
+	if err != nil {
+		rep.log.Error("Failed to delete image", zap.Error(err), zap.String("key", key))
+		return err
+	}
+	rep.log.Debug("Successfully deleted image", zap.String("key", key))
+	return nil
+}
+
+// urlKey returns the key of the object the url points to. Image keys are flat, so the key is the last path element.
+func urlKey(url string) (string, error) {
+	parsed, err := neturl.Parse(url)
+	if err != nil {
+		return "", err
+	}
+	return path.Base(parsed.Path), nil
+}
@@ -0,0 +1,210 @@
+package s3
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"go.uber.org/zap"
+
+	images "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+)
+
+const testBucket = "images"
+
+// fakeBucket serves the requests of the path-style S3 API the repository sends for a single bucket.
+type fakeBucket struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	headers  map[string]http.Header
+	requests []string
+}
+
+func (b *fakeBucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+testBucket+"/")
+	if !ok {
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	b.requests = append(b.requests, r.Method+" "+key)
+
+	switch {
+	case r.Method == http.MethodHead:
+		if _, ok := b.objects[key]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		source, err := neturl.PathUnescape(r.Header.Get("X-Amz-Copy-Source"))
+		data, ok := b.objects[strings.TrimPrefix(source, testBucket+"/")]
+		if err != nil || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `<Error><Code>NoSuchKey</Code></Error>`)
+			return
+		}
+		b.objects[key] = data
+		_, _ = io.WriteString(w, `<CopyObjectResult><ETag>"etag"</ETag></CopyObjectResult>`)
+	case r.Method == http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+		b.objects[key] = data
+		b.headers[key] = r.Header.Clone()
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodDelete:
+		delete(b.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "", http.StatusMethodNotAllowed)
+	}
+}
+
+// Header returns the headers the object was uploaded with.
+func (b *fakeBucket) Header(key string) http.Header {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.headers[key]
+}
+
+func (b *fakeBucket) Requests() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.requests...)
+}
+
+func newTestRepository(t *testing.T, objects map[string][]byte) (*s3Repository, *fakeBucket, string) {
+	t.Helper()
+	bucket := &fakeBucket{objects: objects, headers: map[string]http.Header{}}
+	srv := httptest.NewServer(bucket)
+	t.Cleanup(srv.Close)
+
+	client := s3.New(s3.Options{
+		Region:           "us-east-1",
+		EndpointResolver: s3.EndpointResolverFromURL(srv.URL),
+		UsePathStyle:     true,
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	})
+	return &s3Repository{
+		log:        zap.NewNop(),
+		client:     client,
+		presigner:  s3.NewPresignClient(client),
+		uploader:   manager.NewUploader(client),
+		bucketName: testBucket,
+	}, bucket, srv.URL + "/" + testBucket + "/"
+}
+
+func TestLookup(t *testing.T) {
+	rep, _, baseURL := newTestRepository(t, map[string][]byte{"stored.jpg": []byte("image")})
+
+	type testCase struct {
+		id     string
+		url    string
+		exists bool
+	}
+
+	tests := map[string]testCase{
+		"stored":    {id: "stored.jpg", url: baseURL + "stored.jpg", exists: true},
+		"missing":   {id: "missing.jpg", exists: false},
+		"other ext": {id: "stored.png", exists: false},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			url, exists, err := rep.Lookup(test.id)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if exists != test.exists || url != test.url {
+				t.Errorf("Lookup = %q, %v, expected %q, %v", url, exists, test.url, test.exists)
+			}
+		})
+	}
+}
+
+func TestLookup_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	rep, _, _ := newTestRepository(t, nil)
+	rep.client = s3.New(s3.Options{
+		Region:           "us-east-1",
+		EndpointResolver: s3.EndpointResolverFromURL(srv.URL),
+		UsePathStyle:     true,
+		Credentials:      aws.AnonymousCredentials{},
+		RetryMaxAttempts: 1,
+	})
+
+	// only a missing object means that the image is not stored
+	if _, exists, err := rep.Lookup("stored.jpg"); err == nil || exists {
+		t.Errorf("Lookup = %v, %v, expected an error", exists, err)
+	}
+}
+
+func TestMove(t *testing.T) {
+	rep, bucket, baseURL := newTestRepository(t, map[string][]byte{"upload-1.jpg": []byte("image")})
+
+	url, err := rep.Move("upload-1.jpg", "content.jpg")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if url != baseURL+"content.jpg" {
+		t.Errorf("url = %q, expected %q", url, baseURL+"content.jpg")
+	}
+
+	expected := []string{"PUT content.jpg", "DELETE upload-1.jpg"}
+	if requests := bucket.Requests(); strings.Join(requests, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("requests %v, expected %v", requests, expected)
+	}
+	if _, exists, _ := rep.Lookup("upload-1.jpg"); exists {
+		t.Errorf("moved image is still stored under the old key")
+	}
+	if _, exists, _ := rep.Lookup("content.jpg"); !exists {
+		t.Errorf("moved image is not stored under the new key")
+	}
+
+	if _, err = rep.Move("upload-1.jpg", "other.jpg"); err == nil {
+		t.Errorf("moving a missing image: expected error")
+	}
+}
+
+func TestUploadImage(t *testing.T) {
+	rep, bucket, baseURL := newTestRepository(t, map[string][]byte{})
+
+	url, err := rep.UploadImage(&models.Image{ID: "content.gif", Bytes: []byte("image")})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if url != baseURL+"content.gif" {
+		t.Errorf("url = %q, expected %q", url, baseURL+"content.gif")
+	}
+
+	header := bucket.Header("content.gif")
+	expected := map[string]string{
+		"Content-Type":  "image/gif",
+		"Cache-Control": images.PublicCacheControl,
+		"X-Amz-Acl":     "public-read",
+	}
+	for name, value := range expected {
+		if header.Get(name) != value {
+			t.Errorf("%s = %q, expected %q", name, header.Get(name), value)
+		}
+	}
+}
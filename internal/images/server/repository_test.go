@@ -0,0 +1,28 @@
+package images
+
+import "testing"
+
+func TestValidKey(t *testing.T) {
+	tests := map[string]bool{
+		"a.jpg":           true,
+		"upload-42.jpg":   true,
+		"a b.jpg":         true,
+		"poster_474w.jpg": true,
+		"":                false,
+		".":               false,
+		"..":              false,
+		"../a.jpg":        false,
+		"dir/a.jpg":       false,
+		"/a.jpg":          false,
+		"a.jpg/":          false,
+		".private":        false,
+		".upload-42":      false,
+		".hidden.jpg":     false,
+	}
+
+	for key, valid := range tests {
+		if ValidKey(key) != valid {
+			t.Errorf("ValidKey(%q) = %v, expected %v", key, !valid, valid)
+		}
+	}
+}
@@ -1,7 +1,17 @@
 package images
 
-import "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+import (
+	"io"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+)
 
 type Service interface {
 	UploadImage(image *models.Image) (string, error)
+	UploadImageStream(id string, body io.Reader) (string, error)
+	UploadRenditions(image *models.Image) (models.ImageRenditions, error)
+	SetPrivate(urls []string, private bool) error
+	// SignURLs returns expiring URLs the images can be read by even if they are private.
+	SignURLs(urls []string) ([]string, error)
+	DeleteImage(url string) error
 }
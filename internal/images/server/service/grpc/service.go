@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	proto "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/proto"
 	images "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server"
@@ -12,12 +13,19 @@ import (
 type service struct {
 	proto.UnimplementedImageUploaderServer
 
-	rep images.Repository
+	rep          images.Repository
+	limits       images.Limits
+	signedURLTTL time.Duration
+	frames       images.FrameExtractor
 }
 
-func NewS3Service(rep images.Repository) proto.ImageUploaderServer {
+func NewS3Service(rep images.Repository, limits images.Limits, signedURLTTL time.Duration,
+	frames images.FrameExtractor) proto.ImageUploaderServer {
 	return &service{
-		rep: rep,
+		rep:          rep,
+		limits:       limits,
+		signedURLTTL: signedURLTTL,
+		frames:       frames,
 	}
 }
 
@@ -26,6 +34,9 @@ func (serv *service) UploadImage(ctx context.Context, image *proto.Image) (*prot
 		ID:    image.GetID(),
 		Bytes: image.GetBytes(),
 	}
+	if err := images.Sanitize(img, serv.limits); err != nil {
+		return &proto.Url{}, errors.GRPCWrapper(err)
+	}
 	url, err := serv.rep.UploadImage(img)
 	if err != nil {
 		return &proto.Url{}, errors.GRPCWrapper(err)
@@ -33,3 +44,99 @@ func (serv *service) UploadImage(ctx context.Context, image *proto.Image) (*prot
 
 	return &proto.Url{URL: url}, nil
 }
+
+// chunkReader reads the file bytes sent after the image ID.
+type chunkReader struct {
+	stream proto.ImageUploader_UploadImageStreamServer
+	chunk  []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.chunk = msg.GetBytes()
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+func (serv *service) UploadImageStream(stream proto.ImageUploader_UploadImageStreamServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return errors.GRPCWrapper(err)
+	}
+	if first.GetID() == "" {
+		return errors.GRPCWrapper(errors.ErrBadParams)
+	}
+
+	url, err := images.UploadStream(serv.rep, first.GetID(), &chunkReader{stream: stream}, serv.limits)
+	if err != nil {
+		return errors.GRPCWrapper(err)
+	}
+
+	return stream.SendAndClose(&proto.Url{URL: url})
+}
+
+func (serv *service) UploadRenditions(ctx context.Context, image *proto.Image) (*proto.Renditions, error) {
+	img := &models.Image{
+		ID:    image.GetID(),
+		Bytes: image.GetBytes(),
+	}
+	info, err := images.SanitizeMedia(img, serv.limits)
+	if err != nil {
+		return &proto.Renditions{}, errors.GRPCWrapper(err)
+	}
+	renditions, err := images.UploadRenditions(serv.rep, img, info, serv.frames)
+	if err != nil {
+		return &proto.Renditions{}, errors.GRPCWrapper(err)
+	}
+
+	return &proto.Renditions{
+		URLs:       renditions.URLs,
+		Hash:       renditions.Hash,
+		MediaType:  renditions.MediaType,
+		Width:      int32(renditions.Width),
+		Height:     int32(renditions.Height),
+		DurationMs: renditions.Duration.Milliseconds(),
+		Color:      renditions.Color,
+		Palette:    renditions.Palette,
+		BlurHash:   renditions.BlurHash,
+	}, nil
+}
+
+func (serv *service) SetPrivate(ctx context.Context, visibility *proto.Visibility) (*proto.Nothing, error) {
+	for _, url := range visibility.GetURLs() {
+		if err := serv.rep.SetPrivate(url, visibility.GetPrivate()); err != nil {
+			return &proto.Nothing{}, errors.GRPCWrapper(err)
+		}
+	}
+
+	return &proto.Nothing{}, nil
+}
+
+func (serv *service) SignURLs(ctx context.Context, urls *proto.Urls) (*proto.Urls, error) {
+	signed := make([]string, 0, len(urls.GetURLs()))
+	for _, url := range urls.GetURLs() {
+		signedURL, err := serv.rep.SignURL(url, serv.signedURLTTL)
+		if err != nil {
+			return &proto.Urls{}, errors.GRPCWrapper(err)
+		}
+		signed = append(signed, signedURL)
+	}
+
+	return &proto.Urls{URLs: signed}, nil
+}
+
+func (serv *service) DeleteImage(ctx context.Context, url *proto.Url) (*proto.Nothing, error) {
+	err := serv.rep.DeleteImage(url.GetURL())
+	if err != nil {
+		return &proto.Nothing{}, errors.GRPCWrapper(err)
+	}
+
+	return &proto.Nothing{}, nil
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/proto"
+	images "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server/imagestest"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image/imagetest"
+)
+
+// uploadStream sends the chunks to the server, then io.EOF or err if it is set.
+type uploadStream struct {
+	grpc.ServerStream
+	chunks []*proto.ImageChunk
+	err    error
+	url    *proto.Url
+}
+
+func (s *uploadStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *uploadStream) Recv() (*proto.ImageChunk, error) {
+	if len(s.chunks) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func (s *uploadStream) SendAndClose(url *proto.Url) error {
+	s.url = url
+	return nil
+}
+
+func idChunk(id string) *proto.ImageChunk {
+	return &proto.ImageChunk{Data: &proto.ImageChunk_ID{ID: id}}
+}
+
+func bytesChunk(b []byte) *proto.ImageChunk {
+	return &proto.ImageChunk{Data: &proto.ImageChunk_Bytes{Bytes: b}}
+}
+
+// bytesChunks splits data into chunks of the given sizes, the rest of data is the last chunk.
+func bytesChunks(data []byte, sizes ...int) []*proto.ImageChunk {
+	chunks := make([]*proto.ImageChunk, 0, len(sizes)+1)
+	for _, size := range sizes {
+		chunks = append(chunks, bytesChunk(data[:size]))
+		data = data[size:]
+	}
+	return append(chunks, bytesChunk(data))
+}
+
+func TestChunkReader(t *testing.T) {
+	data := []byte("0123456789")
+
+	type testCase struct {
+		chunks []*proto.ImageChunk
+		bufLen int
+	}
+
+	tests := map[string]testCase{
+		"single chunk":         {chunks: bytesChunks(data), bufLen: 64},
+		"buffer smaller":       {chunks: bytesChunks(data, 4), bufLen: 3},
+		"empty first chunk":    {chunks: bytesChunks(data, 0), bufLen: 64},
+		"empty chunks between": {chunks: bytesChunks(data, 2, 0, 0, 5, 0), bufLen: 4},
+		"one byte chunks":      {chunks: bytesChunks(data, 1, 1, 1, 1, 1, 1, 1, 1, 1), bufLen: 2},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &chunkReader{stream: &uploadStream{chunks: test.chunks}}
+			var read []byte
+			buf := make([]byte, test.bufLen)
+			for {
+				n, err := r.Read(buf)
+				read = append(read, buf[:n]...)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+				if n == 0 {
+					t.Fatalf("Read returned no bytes and no error")
+				}
+			}
+			if !bytes.Equal(read, data) {
+				t.Errorf("read %q, expected %q", read, data)
+			}
+		})
+	}
+
+	// errors of the stream are passed on
+	errCanceled := status.Error(codes.Canceled, "canceled")
+	r := &chunkReader{stream: &uploadStream{chunks: bytesChunks(data), err: errCanceled}}
+	if _, err := io.ReadAll(r); err != errCanceled {
+		t.Errorf("error = %v, expected %v", err, errCanceled)
+	}
+}
+
+func TestUploadImageStream(t *testing.T) {
+	pngData := imagetest.PNG(t, 16, 8, imagetest.Chunk{Type: "tEXt", Data: []byte("Author\x00someone")})
+	stripped, err := pkgImage.StripPNGMetadata(pngData)
+	if err != nil {
+		t.Fatalf("StripPNGMetadata: unexpected error %v", err)
+	}
+	largePNG := imagetest.PNG(t, 16, 8, imagetest.Chunk{Type: "zzZz", Data: make([]byte, 1024)})
+
+	type testCase struct {
+		chunks []*proto.ImageChunk
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"chunked image": {
+			chunks: append([]*proto.ImageChunk{idChunk("a.png")}, bytesChunks(pngData, 5, 20, 40)...),
+		},
+		"empty first chunk": {
+			chunks: append([]*proto.ImageChunk{idChunk("a.png")}, bytesChunks(pngData, 0)...),
+		},
+		"too large": {
+			chunks: append([]*proto.ImageChunk{idChunk("a.png")}, bytesChunks(largePNG, 64, 512)...),
+			err:    pkgErrors.ErrImageTooLarge,
+		},
+		"no ID": {
+			chunks: bytesChunks(pngData),
+			err:    pkgErrors.ErrBadParams,
+		},
+		"no bytes": {
+			chunks: []*proto.ImageChunk{idChunk("a.png")},
+			err:    pkgErrors.ErrInvalidImage,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rep := imagestest.NewRepository()
+			serv := NewS3Service(rep, images.Limits{MaxFileSize: len(pngData) + 512}, 0, nil)
+			stream := &uploadStream{chunks: test.chunks}
+
+			err := serv.UploadImageStream(stream)
+			if test.err != nil {
+				if status.Convert(err).Message() != test.err.Error() {
+					t.Fatalf("error = %v, expected %v", err, test.err)
+				}
+				if keys := rep.Keys(); len(keys) != 0 {
+					t.Errorf("images are stored: %v", keys)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if stream.url.GetURL() != imagestest.URL("a.png") {
+				t.Errorf("url = %q, expected %q", stream.url.GetURL(), imagestest.URL("a.png"))
+			}
+			if file, _ := rep.File("a.png"); !bytes.Equal(file, stripped) {
+				t.Errorf("stored image differs from the sanitized one")
+			}
+		})
+	}
+}
@@ -1,21 +1,34 @@
 package service
 
 import (
+	"io"
+	"time"
+
 	images "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 )
 
 type service struct {
-	rep images.Repository
+	rep          images.Repository
+	limits       images.Limits
+	signedURLTTL time.Duration
+	frames       images.FrameExtractor
 }
 
-func NewS3Service(rep images.Repository) images.Service {
+func NewS3Service(rep images.Repository, limits images.Limits, signedURLTTL time.Duration,
+	frames images.FrameExtractor) images.Service {
 	return &service{
-		rep: rep,
+		rep:          rep,
+		limits:       limits,
+		signedURLTTL: signedURLTTL,
+		frames:       frames,
 	}
 }
 
 func (serv *service) UploadImage(image *models.Image) (string, error) {
+	if err := images.Sanitize(image, serv.limits); err != nil {
+		return "", err
+	}
 	url, err := serv.rep.UploadImage(image)
 	if err != nil {
 		return "", err
@@ -23,3 +36,40 @@ func (serv *service) UploadImage(image *models.Image) (string, error) {
 
 	return url, nil
 }
+
+func (serv *service) UploadImageStream(id string, body io.Reader) (string, error) {
+	return images.UploadStream(serv.rep, id, body, serv.limits)
+}
+
+func (serv *service) UploadRenditions(image *models.Image) (models.ImageRenditions, error) {
+	info, err := images.SanitizeMedia(image, serv.limits)
+	if err != nil {
+		return models.ImageRenditions{}, err
+	}
+	return images.UploadRenditions(serv.rep, image, info, serv.frames)
+}
+
+func (serv *service) SetPrivate(urls []string, private bool) error {
+	for _, url := range urls {
+		if err := serv.rep.SetPrivate(url, private); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (serv *service) SignURLs(urls []string) ([]string, error) {
+	signed := make([]string, 0, len(urls))
+	for _, url := range urls {
+		signedURL, err := serv.rep.SignURL(url, serv.signedURLTTL)
+		if err != nil {
+			return nil, err
+		}
+		signed = append(signed, signedURL)
+	}
+	return signed, nil
+}
+
+func (serv *service) DeleteImage(url string) error {
+	return serv.rep.DeleteImage(url)
+}
@@ -0,0 +1,129 @@
+package images
+
+import (
+	"errors"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func newTestSigner(t *testing.T, secret string) *URLSigner {
+	t.Helper()
+	signer, err := NewURLSigner(secret)
+	if err != nil {
+		t.Fatalf("can't create signer: %v", err)
+	}
+	return signer
+}
+
+func TestNewURLSigner(t *testing.T) {
+	type testCase struct {
+		secret string
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"long enough": {secret: testSecret},
+		"empty":       {secret: "", err: ErrWeakSigningKey},
+		"too short":   {secret: "pickpinimagesecret", err: ErrWeakSigningKey},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			signer, err := NewURLSigner(test.secret)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if (signer == nil) != (test.err != nil) {
+				t.Errorf("signer = %v with error %v", signer, err)
+			}
+		})
+	}
+}
+
+func TestURLSigner(t *testing.T) {
+	signer := newTestSigner(t, testSecret)
+	expires := time.Now().Add(time.Hour)
+
+	signed, err := signer.Sign("http://images.test/storage/image.jpg?old=1", "image.jpg", expires)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	parsed, err := neturl.Parse(signed)
+	if err != nil {
+		t.Fatalf("signed url %q does not parse: %v", signed, err)
+	}
+	if parsed.Path != "/storage/image.jpg" {
+		t.Errorf("path = %q, expected the path of the image", parsed.Path)
+	}
+	query := parsed.Query()
+	if query.Has("old") {
+		t.Errorf("signed url %q keeps the old query", signed)
+	}
+	exp, signature := query.Get(ExpiresParam), query.Get(SignatureParam)
+	if exp != strconv.FormatInt(expires.Unix(), 10) {
+		t.Errorf("expires = %q, expected %d", exp, expires.Unix())
+	}
+
+	tampered := []byte(signature)
+	if tampered[0] == 'a' {
+		tampered[0] = 'b'
+	} else {
+		tampered[0] = 'a'
+	}
+
+	type testCase struct {
+		signer    *URLSigner
+		key       string
+		expires   string
+		signature string
+		valid     bool
+	}
+
+	tests := map[string]testCase{
+		"valid":              {signer: signer, key: "image.jpg", expires: exp, signature: signature, valid: true},
+		"other key":          {signer: signer, key: "other.jpg", expires: exp, signature: signature},
+		"extended expiry":    {signer: signer, key: "image.jpg", expires: strconv.FormatInt(expires.Unix()+1, 10), signature: signature},
+		"tampered signature": {signer: signer, key: "image.jpg", expires: exp, signature: string(tampered)},
+		"other secret":       {signer: newTestSigner(t, strings.Repeat("x", MinSigningKeyLength)), key: "image.jpg", expires: exp, signature: signature},
+		"no signature":       {signer: signer, key: "image.jpg", expires: exp},
+		"no expiry":          {signer: signer, key: "image.jpg", signature: signature},
+		"invalid expiry":     {signer: signer, key: "image.jpg", expires: "tomorrow", signature: signature},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if valid := test.signer.Verify(test.key, test.expires, test.signature); valid != test.valid {
+				t.Errorf("Verify = %v, expected %v", valid, test.valid)
+			}
+		})
+	}
+}
+
+func TestURLSigner_Expired(t *testing.T) {
+	signer := newTestSigner(t, testSecret)
+	expires := time.Now().Add(-time.Second)
+
+	signed, err := signer.Sign("http://images.test/storage/image.jpg", "image.jpg", expires)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	parsed, err := neturl.Parse(signed)
+	if err != nil {
+		t.Fatalf("signed url %q does not parse: %v", signed, err)
+	}
+	query := parsed.Query()
+	if signer.Verify("image.jpg", query.Get(ExpiresParam), query.Get(SignatureParam)) {
+		t.Errorf("expired url %q is valid", signed)
+	}
+}
@@ -0,0 +1,113 @@
+package images
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"image"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+)
+
+// limitReader fails with ErrImageTooLarge as soon as more than limit bytes are read, zero limit means no limit.
+type limitReader struct {
+	r     io.Reader
+	limit int
+	read  int
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += n
+	if l.limit > 0 && l.read > l.limit {
+		return 0, pkgErrors.ErrImageTooLarge
+	}
+	return n, err
+}
+
+// UploadStream validates the image read from body as it arrives and streams it to rep without metadata,
+// see Sanitize. The format and dimensions are checked once the image header is read, the size limit
+// is checked for every chunk. Only JPEG images that need rotation are read into memory completely.
+func UploadStream(rep Repository, id string, body io.Reader, limits Limits) (string, error) {
+	limits = limits.streamLimits()
+	r := bufio.NewReader(&limitReader{r: body, limit: limits.MaxFileSize})
+
+	magic, err := r.Peek(12)
+	if len(magic) == 0 {
+		return "", streamError(err)
+	}
+	format := pkgImage.DetectFormat(magic)
+	ext, supported := extensions[format]
+	if !supported {
+		return "", pkgErrors.ErrUnsupportedImageType
+	}
+
+	head := bytes.NewBuffer(nil)
+	conf, decodedFormat, err := image.DecodeConfig(io.TeeReader(r, head))
+	if err != nil || decodedFormat != format {
+		return "", streamError(err)
+	}
+	if err = checkDimensions(conf, limits); err != nil {
+		return "", err
+	}
+
+	id = strings.TrimSuffix(id, filepath.Ext(id)) + ext
+	full := io.MultiReader(head, r)
+
+	if format == pkgImage.FormatJPEG && pkgImage.JPEGOrientation(head.Bytes()) != 1 {
+		data, err := io.ReadAll(full)
+		if err != nil {
+			return "", streamError(err)
+		}
+		img := &models.Image{ID: id, Bytes: data}
+		if err = Sanitize(img, limits); err != nil {
+			return "", err
+		}
+		return rep.UploadImage(img)
+	}
+
+	pr, pw := io.Pipe()
+	stripped := make(chan error, 1)
+	go func() {
+		err := stripMetadataStream(pw, full, format)
+		pw.CloseWithError(err)
+		stripped <- err
+	}()
+
+	url, err := rep.UploadStream(id, pr)
+	// unblocking the writer if the upload stopped reading early
+	pr.CloseWithError(io.ErrClosedPipe)
+	if stripErr := <-stripped; stripErr != nil && !errors.Is(stripErr, io.ErrClosedPipe) {
+		return "", streamError(stripErr)
+	}
+	if err != nil {
+		return "", err
+	}
+	return url, nil
+}
+
+func stripMetadataStream(dst io.Writer, src io.Reader, format string) error {
+	switch format {
+	case pkgImage.FormatJPEG:
+		return pkgImage.StripJPEGMetadataStream(dst, src)
+	case pkgImage.FormatPNG:
+		return pkgImage.StripPNGMetadataStream(dst, src)
+	case pkgImage.FormatGIF:
+		return pkgImage.StripGIFMetadataStream(dst, src)
+	}
+	_, err := io.Copy(dst, src)
+	return err
+}
+
+// streamError keeps the size limit error, anything else means the image is broken.
+func streamError(err error) error {
+	if errors.Is(err, pkgErrors.ErrImageTooLarge) {
+		return pkgErrors.ErrImageTooLarge
+	}
+	return pkgErrors.ErrInvalidImage
+}
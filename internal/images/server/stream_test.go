@@ -0,0 +1,222 @@
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/server/imagestest"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image/imagetest"
+)
+
+var _ Repository = imagestest.NewRepository()
+
+// oneByteReader returns the data a byte at a time, like a stream of tiny chunks.
+type oneByteReader struct {
+	data []byte
+}
+
+func (r *oneByteReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	p[0] = r.data[0]
+	r.data = r.data[1:]
+	return 1, nil
+}
+
+func TestLimitReader(t *testing.T) {
+	type testCase struct {
+		size  int
+		limit int
+		err   error
+	}
+
+	tests := map[string]testCase{
+		"below limit": {size: 9, limit: 10, err: nil},
+		"at limit":    {size: 10, limit: 10, err: nil},
+		"above limit": {size: 11, limit: 10, err: pkgErrors.ErrImageTooLarge},
+		"no limit":    {size: 1000, limit: 0, err: nil},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &limitReader{r: &oneByteReader{data: make([]byte, test.size)}, limit: test.limit}
+			data, err := io.ReadAll(r)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("error = %v, expected %v", err, test.err)
+			}
+			// the error comes as soon as the limit is passed, not at the end of the body
+			if test.err != nil && len(data) != test.limit {
+				t.Errorf("%d bytes read before the error, expected %d", len(data), test.limit)
+			}
+		})
+	}
+}
+
+func TestUploadStream(t *testing.T) {
+	jpegData := imagetest.JPEG(t, 16, 8, imagetest.JFIF(), imagetest.EXIF(binary.BigEndian, 1), imagetest.Comment())
+	strippedJPEG, err := pkgImage.StripJPEGMetadata(jpegData)
+	if err != nil {
+		t.Fatalf("StripJPEGMetadata: unexpected error %v", err)
+	}
+	pngData := imagetest.PNG(t, 16, 8, imagetest.Chunk{Type: "tEXt", Data: []byte("Author\x00someone")})
+	strippedPNG, err := pkgImage.StripPNGMetadata(pngData)
+	if err != nil {
+		t.Fatalf("StripPNGMetadata: unexpected error %v", err)
+	}
+	strippedGIF := imagetest.GIF(t, 16, 8)
+	gifData := imagetest.InsertGIFBlocks(strippedGIF, imagetest.GIFExtension(0xFE, []byte("taken at home")))
+
+	type testCase struct {
+		id     string
+		body   []byte
+		limits Limits
+		key    string
+		stored []byte
+		err    error
+	}
+
+	tests := map[string]testCase{
+		"jpeg":              {id: "a.jpeg", body: jpegData, limits: testLimits, key: "a.jpg", stored: strippedJPEG},
+		"png named as jpeg": {id: "a.jpg", body: pngData, limits: testLimits, key: "a.png", stored: strippedPNG},
+		"gif":               {id: "a", body: gifData, limits: testLimits, key: "a.gif", stored: strippedGIF},
+		"no limits":         {id: "a.png", body: pngData, key: "a.png", stored: strippedPNG},
+		"too large in the middle": {
+			id:     "a.png",
+			body:   pngData,
+			limits: Limits{MaxFileSize: len(pngData) - 1},
+			err:    pkgErrors.ErrImageTooLarge,
+		},
+		"too large header": {
+			id:     "a.png",
+			body:   pngData,
+			limits: Limits{MaxFileSize: 10},
+			err:    pkgErrors.ErrImageTooLarge,
+		},
+		"too wide": {
+			id:     "a.jpg",
+			body:   imagetest.JPEG(t, 65, 8),
+			limits: testLimits,
+			err:    pkgErrors.ErrImageDimensionsTooLarge,
+		},
+		"empty": {
+			id:  "a.jpg",
+			err: pkgErrors.ErrInvalidImage,
+		},
+		"unsupported": {
+			id:   "a.svg",
+			body: []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"/>"),
+			err:  pkgErrors.ErrUnsupportedImageType,
+		},
+		"truncated header": {
+			id:   "a.png",
+			body: pngData[:20],
+			err:  pkgErrors.ErrInvalidImage,
+		},
+		"truncated data": {
+			id:   "a.png",
+			body: pngData[:len(pngData)-12],
+			err:  pkgErrors.ErrInvalidImage,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rep := imagestest.NewRepository()
+			url, err := UploadStream(rep, test.id, &oneByteReader{data: test.body}, test.limits)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("error = %v, expected %v", err, test.err)
+			}
+			if test.err != nil {
+				if keys := rep.Keys(); len(keys) != 0 {
+					t.Errorf("images are stored: %v", keys)
+				}
+				return
+			}
+
+			if url != imagestest.URL(test.key) {
+				t.Errorf("url = %q, expected %q", url, imagestest.URL(test.key))
+			}
+			if rep.Calls("UploadStream") != 1 || rep.Calls("UploadImage") != 0 {
+				t.Errorf("image is not streamed")
+			}
+			if stored, _ := rep.File(test.key); !bytes.Equal(stored, test.stored) {
+				t.Errorf("stored image differs from the sanitized one")
+			}
+		})
+	}
+}
+
+func TestUploadStream_Rotated(t *testing.T) {
+	rep := imagestest.NewRepository()
+	data := imagetest.JPEG(t, 16, 8, imagetest.EXIF(binary.LittleEndian, 6), imagetest.XMP())
+
+	url, err := UploadStream(rep, "a.jpg", bytes.NewReader(data), testLimits)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	// rotated images are decoded, so they are read into memory and uploaded at once
+	if rep.Calls("UploadImage") != 1 || rep.Calls("UploadStream") != 0 {
+		t.Errorf("rotated image is streamed")
+	}
+
+	stored, ok := rep.File("a.jpg")
+	if !ok || url != imagestest.URL("a.jpg") {
+		t.Fatalf("image is not stored as a.jpg, url %q", url)
+	}
+	conf, _, err := image.DecodeConfig(bytes.NewReader(stored))
+	if err != nil {
+		t.Fatalf("stored image can't be decoded: %v", err)
+	}
+	if conf.Width != 8 || conf.Height != 16 {
+		t.Errorf("size = %dx%d, expected 8x16", conf.Width, conf.Height)
+	}
+	if pkgImage.JPEGOrientation(stored) != 1 || bytes.Contains(stored, []byte("xmpmeta")) {
+		t.Errorf("metadata is kept")
+	}
+
+	// the size limit holds for the buffered images too
+	_, err = UploadStream(rep, "a.jpg", bytes.NewReader(data), Limits{MaxFileSize: len(data) - 1})
+	if !errors.Is(err, pkgErrors.ErrImageTooLarge) {
+		t.Errorf("error = %v, expected %v", err, pkgErrors.ErrImageTooLarge)
+	}
+}
+
+func TestUploadStream_StorageStopsReading(t *testing.T) {
+	rep := imagestest.NewRepository()
+	rep.ReadLimit = 100
+	// far more than the pipe and the buffers hold, so the stripping goroutine blocks on writing
+	data := imagetest.PNG(t, 8, 8, imagetest.Chunk{Type: "zzZz", Data: make([]byte, 1<<20)})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := UploadStream(rep, "a.png", bytes.NewReader(data), Limits{})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, imagestest.ErrStoppedReading) {
+			t.Errorf("error = %v, expected %v", err, imagestest.ErrStoppedReading)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("UploadStream is blocked after the storage stopped reading")
+	}
+}
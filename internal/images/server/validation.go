@@ -0,0 +1,188 @@
+package images
+
+import (
+	"bytes"
+	"image"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/config"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+)
+
+type Limits struct {
+	MaxFileSize       int // bytes
+	MaxStreamFileSize int // bytes, of images uploaded with UploadStream, MaxFileSize if zero
+	MaxWidth          int
+	MaxHeight         int
+	MaxDuration       time.Duration // of videos and animated GIFs
+}
+
+func LimitsFromConfig() Limits {
+	return Limits{
+		MaxFileSize:       viper.GetInt(config.ImageLimitsConfig.MaxFileSize),
+		MaxStreamFileSize: viper.GetInt(config.ImageLimitsConfig.MaxStreamFileSize),
+		MaxWidth:          viper.GetInt(config.ImageLimitsConfig.MaxWidth),
+		MaxHeight:         viper.GetInt(config.ImageLimitsConfig.MaxHeight),
+		MaxDuration:       viper.GetDuration(config.ImageLimitsConfig.MaxDuration),
+	}
+}
+
+// streamLimits are the limits of streamed images, their size is limited by MaxStreamFileSize.
+func (limits Limits) streamLimits() Limits {
+	if limits.MaxStreamFileSize > 0 {
+		limits.MaxFileSize = limits.MaxStreamFileSize
+	}
+	return limits
+}
+
+var extensions = map[string]string{
+	pkgImage.FormatJPEG: ".jpg",
+	pkgImage.FormatPNG:  ".png",
+	pkgImage.FormatGIF:  ".gif",
+}
+
+// Videos are accepted as pin media only, see SanitizeMedia.
+var videoExtensions = map[string]string{
+	pkgImage.FormatMP4:  ".mp4",
+	pkgImage.FormatWebM: ".webm",
+}
+
+// Sanitize checks the uploaded image against limits and replaces its bytes with a copy
+// without EXIF/GPS and text metadata. JPEG images with EXIF orientation are rotated upright.
+// The image ID extension is set to match the real format of the image.
+func Sanitize(img *models.Image, limits Limits) error {
+	if len(img.Bytes) == 0 {
+		return pkgErrors.ErrInvalidImage
+	}
+	if limits.MaxFileSize > 0 && len(img.Bytes) > limits.MaxFileSize {
+		return pkgErrors.ErrImageTooLarge
+	}
+
+	format := pkgImage.DetectFormat(img.Bytes)
+	ext, supported := extensions[format]
+	if !supported {
+		return pkgErrors.ErrUnsupportedImageType
+	}
+
+	conf, decodedFormat, err := image.DecodeConfig(bytes.NewReader(img.Bytes))
+	if err != nil || decodedFormat != format {
+		return pkgErrors.ErrInvalidImage
+	}
+	if err = checkDimensions(conf, limits); err != nil {
+		return err
+	}
+
+	data, err := stripMetadata(img.Bytes, format)
+	if err != nil {
+		return pkgErrors.ErrInvalidImage
+	}
+
+	img.Bytes = data
+	img.ID = strings.TrimSuffix(img.ID, filepath.Ext(img.ID)) + ext
+	return nil
+}
+
+// SanitizeMedia is Sanitize for the media of pins, which may also be MP4 and WebM videos.
+// The duration of videos and animated GIFs is checked against limits too. Videos keep their
+// metadata except for the user data of MP4 files, which may contain the recording location.
+func SanitizeMedia(img *models.Image, limits Limits) (pkgImage.MediaInfo, error) {
+	format := pkgImage.DetectFormat(img.Bytes)
+	ext, video := videoExtensions[format]
+	if !video {
+		if err := Sanitize(img, limits); err != nil {
+			return pkgImage.MediaInfo{}, err
+		}
+		info, err := imageInfo(img.Bytes)
+		if err != nil {
+			return pkgImage.MediaInfo{}, pkgErrors.ErrInvalidImage
+		}
+		return info, checkDuration(info, limits)
+	}
+
+	if limits.MaxFileSize > 0 && len(img.Bytes) > limits.MaxFileSize {
+		return pkgImage.MediaInfo{}, pkgErrors.ErrImageTooLarge
+	}
+	info, err := videoInfo(img.Bytes, format)
+	if err != nil {
+		return pkgImage.MediaInfo{}, pkgErrors.ErrInvalidImage
+	}
+	if err = checkDimensions(image.Config{Width: info.Width, Height: info.Height}, limits); err != nil {
+		return pkgImage.MediaInfo{}, err
+	}
+	if err = checkDuration(info, limits); err != nil {
+		return pkgImage.MediaInfo{}, err
+	}
+
+	if format == pkgImage.FormatMP4 {
+		data, err := pkgImage.StripMP4Metadata(img.Bytes)
+		if err != nil {
+			return pkgImage.MediaInfo{}, pkgErrors.ErrInvalidImage
+		}
+		img.Bytes = data
+	}
+	img.ID = strings.TrimSuffix(img.ID, filepath.Ext(img.ID)) + ext
+	return info, nil
+}
+
+func imageInfo(data []byte) (pkgImage.MediaInfo, error) {
+	format := pkgImage.DetectFormat(data)
+	if format == pkgImage.FormatGIF {
+		return pkgImage.GIFInfo(data)
+	}
+
+	conf, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return pkgImage.MediaInfo{}, err
+	}
+	return pkgImage.MediaInfo{Format: format, Width: conf.Width, Height: conf.Height, Frames: 1}, nil
+}
+
+func videoInfo(data []byte, format string) (pkgImage.MediaInfo, error) {
+	if format == pkgImage.FormatMP4 {
+		return pkgImage.MP4Info(data)
+	}
+	return pkgImage.WebMInfo(data)
+}
+
+func checkDuration(info pkgImage.MediaInfo, limits Limits) error {
+	if limits.MaxDuration > 0 && info.Duration > limits.MaxDuration {
+		return pkgErrors.ErrVideoTooLong
+	}
+	return nil
+}
+
+func checkDimensions(conf image.Config, limits Limits) error {
+	if (limits.MaxWidth > 0 && conf.Width > limits.MaxWidth) ||
+		(limits.MaxHeight > 0 && conf.Height > limits.MaxHeight) {
+		return pkgErrors.ErrImageDimensionsTooLarge
+	}
+	return nil
+}
+
+func stripMetadata(data []byte, format string) ([]byte, error) {
+	switch format {
+	case pkgImage.FormatJPEG:
+		orientation := pkgImage.JPEGOrientation(data)
+		stripped, err := pkgImage.StripJPEGMetadata(data)
+		if err != nil || orientation == 1 {
+			return stripped, err
+		}
+
+		decoded, err := pkgImage.BytesToImage(stripped)
+		if err != nil {
+			return nil, err
+		}
+		return pkgImage.EncodeJPEG(pkgImage.ApplyOrientation(decoded, orientation))
+	case pkgImage.FormatPNG:
+		return pkgImage.StripPNGMetadata(data)
+	case pkgImage.FormatGIF:
+		return pkgImage.StripGIFMetadata(data)
+	}
+	return data, nil
+}
@@ -0,0 +1,109 @@
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image/imagetest"
+)
+
+var testLimits = Limits{MaxFileSize: 64 << 10, MaxWidth: 64, MaxHeight: 48}
+
+func TestSanitize(t *testing.T) {
+	type testCase struct {
+		image  models.Image
+		id     string
+		width  int
+		height int
+		err    error
+	}
+
+	withGPS := imagetest.JPEG(t, 16, 8, imagetest.JFIF(), imagetest.EXIF(binary.LittleEndian, 1),
+		imagetest.XMP(), imagetest.Comment())
+	rotated := imagetest.JPEG(t, 16, 8, imagetest.EXIF(binary.BigEndian, 6))
+	pngWithText := imagetest.PNG(t, 16, 8, imagetest.Chunk{Type: "tEXt", Data: []byte("Author\x00someone")})
+	gifWithComment := imagetest.InsertGIFBlocks(imagetest.GIF(t, 16, 8, 10, 10),
+		imagetest.GIFExtension(0xFE, []byte("taken at home")))
+
+	tests := map[string]testCase{
+		"jpeg":              {image: models.Image{ID: "a.jpeg", Bytes: withGPS}, id: "a.jpg", width: 16, height: 8},
+		"rotated jpeg":      {image: models.Image{ID: "a.jpg", Bytes: rotated}, id: "a.jpg", width: 8, height: 16},
+		"png named as jpeg": {image: models.Image{ID: "a.jpg", Bytes: pngWithText}, id: "a.png", width: 16, height: 8},
+		"no extension":      {image: models.Image{ID: "a", Bytes: pngWithText}, id: "a.png", width: 16, height: 8},
+		"gif":               {image: models.Image{ID: "a.gif", Bytes: gifWithComment}, id: "a.gif", width: 16, height: 8},
+		"empty": {
+			image: models.Image{ID: "a.jpg"},
+			err:   pkgErrors.ErrInvalidImage,
+		},
+		"too large file": {
+			image: models.Image{ID: "a.jpg", Bytes: append(withGPS, make([]byte, testLimits.MaxFileSize)...)},
+			err:   pkgErrors.ErrImageTooLarge,
+		},
+		"too wide": {
+			image: models.Image{ID: "a.jpg", Bytes: imagetest.JPEG(t, 65, 8)},
+			err:   pkgErrors.ErrImageDimensionsTooLarge,
+		},
+		"too high": {
+			image: models.Image{ID: "a.png", Bytes: imagetest.PNG(t, 8, 49)},
+			err:   pkgErrors.ErrImageDimensionsTooLarge,
+		},
+		"webp": {
+			image: models.Image{ID: "a.webp", Bytes: []byte("RIFF\x1a\x00\x00\x00WEBPVP8 ")},
+			err:   pkgErrors.ErrUnsupportedImageType,
+		},
+		"text": {
+			image: models.Image{ID: "a.jpg", Bytes: []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"/>")},
+			err:   pkgErrors.ErrUnsupportedImageType,
+		},
+		"jpeg magic only": {
+			image: models.Image{ID: "a.jpg", Bytes: []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10, 'J', 'F'}},
+			err:   pkgErrors.ErrInvalidImage,
+		},
+		"truncated png": {
+			image: models.Image{ID: "a.png", Bytes: pngWithText[:len(pngWithText)-12]},
+			err:   pkgErrors.ErrInvalidImage,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			img := test.image
+			err := Sanitize(&img, testLimits)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("error = %v, expected %v", err, test.err)
+			}
+			if test.err != nil {
+				return
+			}
+
+			if img.ID != test.id {
+				t.Errorf("ID = %q, expected %q", img.ID, test.id)
+			}
+			conf, _, err := image.DecodeConfig(bytes.NewReader(img.Bytes))
+			if err != nil {
+				t.Fatalf("sanitized image can't be decoded: %v", err)
+			}
+			if conf.Width != test.width || conf.Height != test.height {
+				t.Errorf("size = %dx%d, expected %dx%d", conf.Width, conf.Height, test.width, test.height)
+			}
+			if pkgImage.JPEGOrientation(img.Bytes) != 1 {
+				t.Errorf("orientation is kept")
+			}
+			for _, leaked := range []string{"Exif", "xmpmeta", "taken at home", "someone"} {
+				if bytes.Contains(img.Bytes, []byte(leaked)) {
+					t.Errorf("%q is kept", leaked)
+				}
+			}
+		})
+	}
+}
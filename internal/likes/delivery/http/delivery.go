@@ -10,16 +10,17 @@ import (
 
 	pkgLikes "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/likes"
 	mw "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/middleware"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 )
 
 func RegisterHandlers(mux *httprouter.Router, logger *zap.Logger, authorizer mw.Authorizer, csrf mw.CSRFMiddleware, serv pkgLikes.Service, m *mw.HttpMetricsMiddleware) {
 	del := delivery{serv, logger}
 
-	mux.POST("/pins/:id/like", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.like))), logger), logger), logger))
-	mux.DELETE("/pins/:id/like", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.unlike))), logger), logger), logger))
+	mux.POST("/pins/:id/like", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer.Scoped(models.ScopePinsWrite)(csrf(del.like))), logger), logger), logger))
+	mux.DELETE("/pins/:id/like", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer.Scoped(models.ScopePinsWrite)(csrf(del.unlike))), logger), logger), logger))
 
-	mux.GET("/pins/:id/likes", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.listByPin))), logger), logger), logger))
+	mux.GET("/pins/:id/likes", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer.Scoped(models.ScopePinsRead)(csrf(del.listByPin))), logger), logger), logger))
 	mux.GET("/users/:id/likes", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.listByAuthor))), logger), logger), logger))
 }
 
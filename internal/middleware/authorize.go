@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/julienschmidt/httprouter"
@@ -13,17 +15,36 @@ import (
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/router"
 )
 
+const (
+	bearerPrefix = "Bearer "
+
+	// authMethodParam is set by the authorizer, so that CSRF check can be skipped
+	// for requests that are not authenticated by cookies.
+	authMethodParam = "auth-method"
+	authMethodToken = "token"
+)
+
 type (
+	// Authorizer authorizes the requests by session cookies. Handlers wrapped by an authorizer of
+	// Authorizer.Scoped are authorized by access tokens of the scope as well.
 	Authorizer func(h router.Handler) router.Handler
 
 	AuthService interface {
 		CheckAuth(userId, sessionId string) (models.User, error)
 	}
+
+	AccessTokenService interface {
+		Check(token string) (models.AccessToken, error)
+	}
 )
 
-func NewAuthorizer(serv AuthService, log *zap.Logger) Authorizer {
+func NewAuthorizer(serv AuthService, tokenServ AccessTokenService, log *zap.Logger) Authorizer {
 	return func(handler router.Handler) router.Handler {
 		return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+			if header := r.Header.Get("Authorization"); strings.HasPrefix(header, bearerPrefix) {
+				return authorizeToken(tokenServ, strings.TrimPrefix(header, bearerPrefix), handler, log)(w, r, p)
+			}
+
 			sessionCookie, err := r.Cookie("JSESSIONID")
 			if err != nil {
 				log.Debug("Failed to get session cookie", zap.Error(err))
@@ -46,3 +67,44 @@ func NewAuthorizer(serv AuthService, log *zap.Logger) Authorizer {
 		}
 	}
 }
+
+type scopeKey struct{}
+
+// Scoped returns the authorizer that accepts access tokens of the scope. The scope is declared
+// with the route, so that new routes are not available with access tokens unless they are scoped.
+func (a Authorizer) Scoped(scope string) Authorizer {
+	return func(handler router.Handler) router.Handler {
+		authorized := a(handler)
+		return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+			return authorized(w, r.WithContext(context.WithValue(r.Context(), scopeKey{}, scope)), p)
+		}
+	}
+}
+
+// RequiredScope returns the access token scope the route of the request is declared with,
+// or empty string if the route is not available with access tokens.
+func RequiredScope(r *http.Request) string {
+	scope, _ := r.Context().Value(scopeKey{}).(string)
+	return scope
+}
+
+func authorizeToken(tokenServ AccessTokenService, token string, handler router.Handler, log *zap.Logger) router.Handler {
+	return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+		accessToken, err := tokenServ.Check(token)
+		if err != nil {
+			log.Debug("Failed to check access token", zap.Error(err))
+			return errors.Wrap(pkgErrors.ErrUnauthorized, err.Error())
+		}
+
+		scope := RequiredScope(r)
+		if scope == "" || !accessToken.HasScope(scope) {
+			return errors.Wrapf(pkgErrors.ErrInsufficientScope, "%s %s requires scope %q",
+				r.Method, r.URL.Path, scope)
+		}
+
+		p = append(p,
+			httprouter.Param{Key: "user-id", Value: strconv.Itoa(accessToken.UserID)},
+			httprouter.Param{Key: authMethodParam, Value: authMethodToken})
+		return handler(w, r, p)
+	}
+}
@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/router"
+)
+
+type authServiceStub struct{}
+
+func (authServiceStub) CheckAuth(userId, sessionId string) (models.User, error) {
+	if sessionId != "12$session" {
+		return models.User{}, pkgErrors.ErrUnauthorized
+	}
+	return models.User{Id: 12}, nil
+}
+
+// tokenServiceStub accepts the tokens named after their scopes.
+type tokenServiceStub map[string][]string
+
+func (s tokenServiceStub) Check(token string) (models.AccessToken, error) {
+	scopes, ok := s[token]
+	if !ok {
+		return models.AccessToken{}, pkgErrors.ErrUnauthorized
+	}
+	return models.AccessToken{UserID: 7, Scopes: scopes}, nil
+}
+
+func TestAuthorizer(t *testing.T) {
+	tokens := tokenServiceStub{
+		"pins-read":  {models.ScopePinsRead},
+		"pins-write": {models.ScopePinsWrite},
+		"boards":     {models.ScopeBoards},
+		"all":        models.AccessTokenScopes,
+	}
+	authorizer := NewAuthorizer(authServiceStub{}, tokens, zap.NewNop())
+
+	type testCase struct {
+		scope      string // empty for routes without a scope
+		token      string
+		cookie     string
+		userId     string
+		authMethod string
+		err        error
+	}
+
+	tests := map[string]testCase{
+		"token of the scope": {
+			scope:      models.ScopePinsRead,
+			token:      "pins-read",
+			userId:     "7",
+			authMethod: authMethodToken,
+		},
+		"token of all scopes": {
+			scope:      models.ScopeBoards,
+			token:      "all",
+			userId:     "7",
+			authMethod: authMethodToken,
+		},
+		"token of another scope": {
+			scope: models.ScopePinsWrite,
+			token: "pins-read",
+			err:   pkgErrors.ErrInsufficientScope,
+		},
+		"route without a scope": {
+			token: "all",
+			err:   pkgErrors.ErrInsufficientScope,
+		},
+		"unknown token": {
+			scope: models.ScopePinsRead,
+			token: "revoked",
+			err:   pkgErrors.ErrUnauthorized,
+		},
+		"session of a scoped route": {
+			scope:  models.ScopeBoards,
+			cookie: "12$session",
+			userId: "12",
+		},
+		"session of a route without a scope": {
+			cookie: "12$session",
+			userId: "12",
+		},
+		"invalid session": {
+			cookie: "12$expired",
+			err:    pkgErrors.ErrUnauthorized,
+		},
+		"no credentials": {
+			scope: models.ScopeBoards,
+			err:   pkgErrors.ErrUnauthorized,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var params httprouter.Params
+			called := false
+			handler := func(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+				called = true
+				params = p
+				return nil
+			}
+
+			wrapped := authorizer(handler)
+			if test.scope != "" {
+				wrapped = authorizer.Scoped(test.scope)(handler)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/boards", nil)
+			if test.token != "" {
+				req.Header.Set("Authorization", bearerPrefix+test.token)
+			}
+			if test.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "JSESSIONID", Value: test.cookie})
+			}
+
+			err := wrapped(httptest.NewRecorder(), req, nil)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("error = %v, expected %v", err, test.err)
+			}
+			if called != (test.err == nil) {
+				t.Fatalf("handler called = %v, expected %v", called, test.err == nil)
+			}
+			if test.err != nil {
+				return
+			}
+			if userId := params.ByName("user-id"); userId != test.userId {
+				t.Errorf("user-id = %q, expected %q", userId, test.userId)
+			}
+			if authMethod := params.ByName(authMethodParam); authMethod != test.authMethod {
+				t.Errorf("auth method = %q, expected %q", authMethod, test.authMethod)
+			}
+		})
+	}
+}
+
+func TestRequiredScope(t *testing.T) {
+	var scope string
+	record := func(h router.Handler) router.Handler {
+		return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+			scope = RequiredScope(r)
+			return nil
+		}
+	}
+	authorizer := Authorizer(record)
+
+	req := httptest.NewRequest(http.MethodGet, "/pins", nil)
+	_ = authorizer.Scoped(models.ScopePinsWrite)(nil)(httptest.NewRecorder(), req, nil)
+	if scope != models.ScopePinsWrite {
+		t.Errorf("RequiredScope of a scoped route = %q, expected %q", scope, models.ScopePinsWrite)
+	}
+
+	_ = authorizer(nil)(httptest.NewRecorder(), req, nil)
+	if scope != "" {
+		t.Errorf("RequiredScope of a route without a scope = %q, expected empty", scope)
+	}
+}
@@ -15,6 +15,10 @@ type CSRFMiddleware func(h router.Handler) router.Handler
 func NewCSRFMiddleware(token *tokens.HashToken, log *zap.Logger) CSRFMiddleware {
 	return func(handler router.Handler) router.Handler {
 		return func(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+			if p.ByName(authMethodParam) == authMethodToken {
+				return handler(w, r, p)
+			}
+
 			sessionCookie, _ := r.Cookie("JSESSIONID")
 			csrfToken := r.Header.Get("X-XSRF-TOKEN")
 			session := tokens.SessionParams{Token: sessionCookie.Value}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+//go:generate easyjson -all -snake_case access_token.go
+
+// Personal access token scopes
+const (
+	ScopePinsRead  = "pins:read"
+	ScopePinsWrite = "pins:write"
+	ScopeBoards    = "boards"
+	ScopeChats     = "chats"
+)
+
+var AccessTokenScopes = []string{ScopePinsRead, ScopePinsWrite, ScopeBoards, ScopeChats}
+
+type AccessToken struct {
+	ID         int        `json:"id"`
+	UserID     int        `json:"user_id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+func (token *AccessToken) HasScope(scope string) bool {
+	for _, s := range token.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
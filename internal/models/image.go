@@ -1,6 +1,40 @@
 package models
 
+import (
+	"io"
+	"time"
+)
+
 type Image struct {
 	ID    string
 	Bytes []byte
 }
+
+// ImageStream is an image file read as it is uploaded instead of being held in memory like Image.
+type ImageStream struct {
+	ID   string
+	Body io.Reader
+}
+
+// Media types of pins
+const (
+	MediaTypeImage = "image"
+	MediaTypeGIF   = "gif" // animated, GIFs of one frame are images
+	MediaTypeVideo = "video"
+)
+
+// ImageRenditions are the URLs of an uploaded image by rendition name, its perceptual hash and colors.
+// The hash is 0 and the colors are empty for images that could not be decoded. Animated GIFs
+// and videos have a poster rendition with their first frame, their hash and colors are of it.
+// Color and Palette hold CSS rgb() strings, the palette starts with the most common color.
+type ImageRenditions struct {
+	URLs      map[string]string
+	Hash      uint64
+	MediaType string
+	Width     int
+	Height    int
+	Duration  time.Duration
+	Color     string
+	Palette   []string
+	BlurHash  string
+}
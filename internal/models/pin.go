@@ -3,12 +3,20 @@ package models
 //go:generate easyjson -all -snake_case pin.go
 
 type Pin struct {
-	Id               int    `json:"id"`
-	Title            string `json:"title"`
-	Description      string `json:"description"`
-	MediaSource      string `json:"media_source"`
-	MediaSourceColor string `json:"media_source_color"`
-	NumLikes         int    `json:"n_likes"`
-	Liked            bool   `json:"liked"`
-	Author           int    `json:"author_id"`
+	Id               int               `json:"id"`
+	Title            string            `json:"title"`
+	Description      string            `json:"description"`
+	MediaSource      string            `json:"media_source"`
+	MediaSourceColor string            `json:"media_source_color"`
+	Renditions       map[string]string `json:"renditions,omitempty"` // image URLs by rendition name for srcset
+	Palette          []string          `json:"palette,omitempty"`    // dominant colors of the image, the most common first
+	BlurHash         string            `json:"blurhash,omitempty"`
+	MediaType        string            `json:"media_type,omitempty"` // image, gif or video
+	MediaWidth       int               `json:"media_width,omitempty"`
+	MediaHeight      int               `json:"media_height,omitempty"`
+	MediaDurationMs  int               `json:"media_duration_ms,omitempty"` // of GIFs and videos
+	NumLikes         int               `json:"n_likes"`
+	Liked            bool              `json:"liked"`
+	Promoted         bool              `json:"promoted,omitempty"`
+	Author           int               `json:"author_id"`
 }
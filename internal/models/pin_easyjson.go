@@ -46,10 +46,65 @@ func easyjsonD6aedbb7DecodeGithubComGoParkMailRu20231PracticalDevInternalModels(
 			out.MediaSource = string(in.String())
 		case "media_source_color":
 			out.MediaSourceColor = string(in.String())
+		case "renditions":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Renditions = make(map[string]string)
+				} else {
+					out.Renditions = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v1 string
+					v1 = string(in.String())
+					(out.Renditions)[key] = v1
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "palette":
+			if in.IsNull() {
+				in.Skip()
+				out.Palette = nil
+			} else {
+				in.Delim('[')
+				if out.Palette == nil {
+					if !in.IsDelim(']') {
+						out.Palette = make([]string, 0, 4)
+					} else {
+						out.Palette = []string{}
+					}
+				} else {
+					out.Palette = (out.Palette)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 string
+					v2 = string(in.String())
+					out.Palette = append(out.Palette, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "blurhash":
+			out.BlurHash = string(in.String())
+		case "media_type":
+			out.MediaType = string(in.String())
+		case "media_width":
+			out.MediaWidth = int(in.Int())
+		case "media_height":
+			out.MediaHeight = int(in.Int())
+		case "media_duration_ms":
+			out.MediaDurationMs = int(in.Int())
 		case "n_likes":
 			out.NumLikes = int(in.Int())
 		case "liked":
 			out.Liked = bool(in.Bool())
+		case "promoted":
+			out.Promoted = bool(in.Bool())
 		case "author_id":
 			out.Author = int(in.Int())
 		default:
@@ -91,6 +146,64 @@ func easyjsonD6aedbb7EncodeGithubComGoParkMailRu20231PracticalDevInternalModels(
 		out.RawString(prefix)
 		out.String(string(in.MediaSourceColor))
 	}
+	if len(in.Renditions) != 0 {
+		const prefix string = ",\"renditions\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v3First := true
+			for v3Name, v3Value := range in.Renditions {
+				if v3First {
+					v3First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v3Name))
+				out.RawByte(':')
+				out.String(string(v3Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.Palette) != 0 {
+		const prefix string = ",\"palette\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v4, v5 := range in.Palette {
+				if v4 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v5))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.BlurHash != "" {
+		const prefix string = ",\"blurhash\":"
+		out.RawString(prefix)
+		out.String(string(in.BlurHash))
+	}
+	if in.MediaType != "" {
+		const prefix string = ",\"media_type\":"
+		out.RawString(prefix)
+		out.String(string(in.MediaType))
+	}
+	if in.MediaWidth != 0 {
+		const prefix string = ",\"media_width\":"
+		out.RawString(prefix)
+		out.Int(int(in.MediaWidth))
+	}
+	if in.MediaHeight != 0 {
+		const prefix string = ",\"media_height\":"
+		out.RawString(prefix)
+		out.Int(int(in.MediaHeight))
+	}
+	if in.MediaDurationMs != 0 {
+		const prefix string = ",\"media_duration_ms\":"
+		out.RawString(prefix)
+		out.Int(int(in.MediaDurationMs))
+	}
 	{
 		const prefix string = ",\"n_likes\":"
 		out.RawString(prefix)
@@ -101,6 +214,11 @@ func easyjsonD6aedbb7EncodeGithubComGoParkMailRu20231PracticalDevInternalModels(
 		out.RawString(prefix)
 		out.Bool(bool(in.Liked))
 	}
+	if in.Promoted {
+		const prefix string = ",\"promoted\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Promoted))
+	}
 	{
 		const prefix string = ",\"author_id\":"
 		out.RawString(prefix)
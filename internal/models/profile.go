@@ -1,11 +1,31 @@
 package models
 
+import "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
+
 //go:generate easyjson -all -snake_case profile.go
 
 type Profile struct {
-	Id           int    `json:"id"`
-	Username     string `json:"username"`
-	Name         string `json:"name"`
-	ProfileImage string `json:"profile_image"`
-	WebsiteUrl   string `json:"website_url"`
+	Id                  int    `json:"id"`
+	Username            string `json:"username"`
+	Name                string `json:"name"`
+	ProfileImage        string `json:"profile_image"`
+	WebsiteUrl          string `json:"website_url"`
+	AccountType         string `json:"account_type"`
+	CanViewPinAnalytics bool   `json:"can_view_pin_analytics"`
+	CanPromotePins      bool   `json:"can_promote_pins"`
+}
+
+// SetAccountType sets the account type together with the capabilities it grants.
+func (p *Profile) SetAccountType(accountType string) {
+	p.AccountType = accountType
+	p.CanViewPinAnalytics = CanViewPinAnalytics(accountType)
+	p.CanPromotePins = CanPromotePins(accountType)
+}
+
+func CanViewPinAnalytics(accountType string) bool {
+	return accountType == constants.BusinessAccountType
+}
+
+func CanPromotePins(accountType string) bool {
+	return accountType == constants.BusinessAccountType
 }
@@ -46,6 +46,12 @@ func easyjson521a5691DecodeGithubComGoParkMailRu20231PracticalDevInternalModels(
 			out.ProfileImage = string(in.String())
 		case "website_url":
 			out.WebsiteUrl = string(in.String())
+		case "account_type":
+			out.AccountType = string(in.String())
+		case "can_view_pin_analytics":
+			out.CanViewPinAnalytics = bool(in.Bool())
+		case "can_promote_pins":
+			out.CanPromotePins = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -85,6 +91,21 @@ func easyjson521a5691EncodeGithubComGoParkMailRu20231PracticalDevInternalModels(
 		out.RawString(prefix)
 		out.String(string(in.WebsiteUrl))
 	}
+	{
+		const prefix string = ",\"account_type\":"
+		out.RawString(prefix)
+		out.String(string(in.AccountType))
+	}
+	{
+		const prefix string = ",\"can_view_pin_analytics\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.CanViewPinAnalytics))
+	}
+	{
+		const prefix string = ",\"can_promote_pins\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.CanPromotePins))
+	}
 	out.RawByte('}')
 }
 
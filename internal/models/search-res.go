@@ -3,7 +3,38 @@ package models
 //go:generate easyjson -all -snake_case search-res.go
 
 type SearchRes struct {
-	Pins   []Pin     `json:"pins"`
-	Boards []Board   `json:"boards"`
-	Users  []Profile `json:"users"`
+	Pins    []Pin         `json:"pins"`
+	Boards  []Board       `json:"boards"`
+	Users   []Profile     `json:"users"`
+	Counts  SearchCounts  `json:"counts"`
+	Cursors SearchCursors `json:"cursors"`
+	// DidYouMean is the query with misspelled words corrected, empty if it had none
+	DidYouMean string `json:"did_you_mean,omitempty"`
+}
+
+// SearchCounts are the total numbers of results of each type
+type SearchCounts struct {
+	Pins   int `json:"pins"`
+	Boards int `json:"boards"`
+	Users  int `json:"users"`
+}
+
+// SearchCursors point to the next pages of each type, empty on the last page
+type SearchCursors struct {
+	Pins   string `json:"pins,omitempty"`
+	Boards string `json:"boards,omitempty"`
+	Users  string `json:"users,omitempty"`
+}
+
+// SearchSuggestions complete a prefix typed in the search box
+type SearchSuggestions struct {
+	Users   []string `json:"users"`   // usernames
+	Boards  []string `json:"boards"`  // names of public boards
+	Tags    []string `json:"tags"`    // hashtags of pins without the #
+	Queries []string `json:"queries"` // popular past queries
+}
+
+type TrendingQuery struct {
+	Query    string `json:"query"`
+	Searches int    `json:"searches"` // within the window
 }
@@ -17,7 +17,295 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels(in *jlexer.Lexer, out *SearchRes) {
+func easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels(in *jlexer.Lexer, out *TrendingQuery) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "query":
+			out.Query = string(in.String())
+		case "searches":
+			out.Searches = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels(out *jwriter.Writer, in TrendingQuery) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"query\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Query))
+	}
+	{
+		const prefix string = ",\"searches\":"
+		out.RawString(prefix)
+		out.Int(int(in.Searches))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TrendingQuery) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TrendingQuery) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TrendingQuery) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TrendingQuery) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels(l, v)
+}
+func easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels1(in *jlexer.Lexer, out *SearchSuggestions) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "users":
+			if in.IsNull() {
+				in.Skip()
+				out.Users = nil
+			} else {
+				in.Delim('[')
+				if out.Users == nil {
+					if !in.IsDelim(']') {
+						out.Users = make([]string, 0, 4)
+					} else {
+						out.Users = []string{}
+					}
+				} else {
+					out.Users = (out.Users)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.Users = append(out.Users, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "boards":
+			if in.IsNull() {
+				in.Skip()
+				out.Boards = nil
+			} else {
+				in.Delim('[')
+				if out.Boards == nil {
+					if !in.IsDelim(']') {
+						out.Boards = make([]string, 0, 4)
+					} else {
+						out.Boards = []string{}
+					}
+				} else {
+					out.Boards = (out.Boards)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 string
+					v2 = string(in.String())
+					out.Boards = append(out.Boards, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 string
+					v3 = string(in.String())
+					out.Tags = append(out.Tags, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "queries":
+			if in.IsNull() {
+				in.Skip()
+				out.Queries = nil
+			} else {
+				in.Delim('[')
+				if out.Queries == nil {
+					if !in.IsDelim(']') {
+						out.Queries = make([]string, 0, 4)
+					} else {
+						out.Queries = []string{}
+					}
+				} else {
+					out.Queries = (out.Queries)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.Queries = append(out.Queries, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels1(out *jwriter.Writer, in SearchSuggestions) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"users\":"
+		out.RawString(prefix[1:])
+		if in.Users == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Users {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"boards\":"
+		out.RawString(prefix)
+		if in.Boards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v7, v8 := range in.Boards {
+				if v7 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v8))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v9, v10 := range in.Tags {
+				if v9 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v10))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"queries\":"
+		out.RawString(prefix)
+		if in.Queries == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Queries {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v12))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SearchSuggestions) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SearchSuggestions) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SearchSuggestions) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SearchSuggestions) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels1(l, v)
+}
+func easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels2(in *jlexer.Lexer, out *SearchRes) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -52,9 +340,9 @@ func easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels(
 					out.Pins = (out.Pins)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v1 Pin
-					(v1).UnmarshalEasyJSON(in)
-					out.Pins = append(out.Pins, v1)
+					var v13 Pin
+					(v13).UnmarshalEasyJSON(in)
+					out.Pins = append(out.Pins, v13)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -75,9 +363,9 @@ func easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels(
 					out.Boards = (out.Boards)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v2 Board
-					(v2).UnmarshalEasyJSON(in)
-					out.Boards = append(out.Boards, v2)
+					var v14 Board
+					(v14).UnmarshalEasyJSON(in)
+					out.Boards = append(out.Boards, v14)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -98,13 +386,19 @@ func easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels(
 					out.Users = (out.Users)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v3 Profile
-					(v3).UnmarshalEasyJSON(in)
-					out.Users = append(out.Users, v3)
+					var v15 Profile
+					(v15).UnmarshalEasyJSON(in)
+					out.Users = append(out.Users, v15)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "counts":
+			(out.Counts).UnmarshalEasyJSON(in)
+		case "cursors":
+			(out.Cursors).UnmarshalEasyJSON(in)
+		case "did_you_mean":
+			out.DidYouMean = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -115,7 +409,7 @@ func easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels(
 		in.Consumed()
 	}
 }
-func easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels(out *jwriter.Writer, in SearchRes) {
+func easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels2(out *jwriter.Writer, in SearchRes) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -126,11 +420,11 @@ func easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels(
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v4, v5 := range in.Pins {
-				if v4 > 0 {
+			for v16, v17 := range in.Pins {
+				if v16 > 0 {
 					out.RawByte(',')
 				}
-				(v5).MarshalEasyJSON(out)
+				(v17).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -142,11 +436,11 @@ func easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels(
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v6, v7 := range in.Boards {
-				if v6 > 0 {
+			for v18, v19 := range in.Boards {
+				if v18 > 0 {
 					out.RawByte(',')
 				}
-				(v7).MarshalEasyJSON(out)
+				(v19).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -158,38 +452,224 @@ func easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels(
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v8, v9 := range in.Users {
-				if v8 > 0 {
+			for v20, v21 := range in.Users {
+				if v20 > 0 {
 					out.RawByte(',')
 				}
-				(v9).MarshalEasyJSON(out)
+				(v21).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
+	{
+		const prefix string = ",\"counts\":"
+		out.RawString(prefix)
+		(in.Counts).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"cursors\":"
+		out.RawString(prefix)
+		(in.Cursors).MarshalEasyJSON(out)
+	}
+	if in.DidYouMean != "" {
+		const prefix string = ",\"did_you_mean\":"
+		out.RawString(prefix)
+		out.String(string(in.DidYouMean))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v SearchRes) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels(&w, v)
+	easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v SearchRes) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels(w, v)
+	easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *SearchRes) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels(&r, v)
+	easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *SearchRes) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels(l, v)
+	easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels2(l, v)
+}
+func easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels3(in *jlexer.Lexer, out *SearchCursors) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "pins":
+			out.Pins = string(in.String())
+		case "boards":
+			out.Boards = string(in.String())
+		case "users":
+			out.Users = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels3(out *jwriter.Writer, in SearchCursors) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Pins != "" {
+		const prefix string = ",\"pins\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Pins))
+	}
+	if in.Boards != "" {
+		const prefix string = ",\"boards\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Boards))
+	}
+	if in.Users != "" {
+		const prefix string = ",\"users\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Users))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SearchCursors) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SearchCursors) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SearchCursors) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SearchCursors) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels3(l, v)
+}
+func easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels4(in *jlexer.Lexer, out *SearchCounts) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "pins":
+			out.Pins = int(in.Int())
+		case "boards":
+			out.Boards = int(in.Int())
+		case "users":
+			out.Users = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels4(out *jwriter.Writer, in SearchCounts) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"pins\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Pins))
+	}
+	{
+		const prefix string = ",\"boards\":"
+		out.RawString(prefix)
+		out.Int(int(in.Boards))
+	}
+	{
+		const prefix string = ",\"users\":"
+		out.RawString(prefix)
+		out.Int(int(in.Users))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SearchCounts) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SearchCounts) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson54c26bf9EncodeGithubComGoParkMailRu20231PracticalDevInternalModels4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SearchCounts) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SearchCounts) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson54c26bf9DecodeGithubComGoParkMailRu20231PracticalDevInternalModels4(l, v)
 }
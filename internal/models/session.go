@@ -1,8 +1,12 @@
 package models
 
+import "time"
+
 //go:generate easyjson -all -snake_case session.go
 
 type Session struct {
-	UserId    int    `json:"id"`
-	UserEmail string `json:"email"`
+	UserId      int           `json:"id"`
+	UserEmail   string        `json:"email"`
+	IdleTimeout time.Duration `json:"idle_timeout"`
+	ExpiresAt   int64         `json:"expires_at"`
 }
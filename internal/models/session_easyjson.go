@@ -7,6 +7,7 @@ import (
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
 )
 
 // suppress unused package warning
@@ -40,6 +41,10 @@ func easyjsonA818f49aDecodeGithubComGoParkMailRu20231PracticalDevInternalModels(
 			out.UserId = int(in.Int())
 		case "email":
 			out.UserEmail = string(in.String())
+		case "idle_timeout":
+			out.IdleTimeout = time.Duration(in.Int64())
+		case "expires_at":
+			out.ExpiresAt = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -64,6 +69,16 @@ func easyjsonA818f49aEncodeGithubComGoParkMailRu20231PracticalDevInternalModels(
 		out.RawString(prefix)
 		out.String(string(in.UserEmail))
 	}
+	{
+		const prefix string = ",\"idle_timeout\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.IdleTimeout))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ExpiresAt))
+	}
 	out.RawByte('}')
 }
 
@@ -2,19 +2,40 @@ package http
 
 import (
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgPins "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pins"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/xss"
 )
 
 //go:generate easyjson -all -snake_case api_models.go
 
+// API requests
+type promotionRequest struct {
+	Promoted bool `json:"promoted"`
+}
+
 // API responses
+
+// maxReportedDuplicates limits the duplicates reported when a pin is created with check_duplicates.
+const maxReportedDuplicates = 10
+
+const duplicateImageWarning = "this image already exists"
+
 type createResponse struct {
-	Id               int    `json:"id"`
-	Title            string `json:"title"`
-	Description      string `json:"description"`
-	MediaSource      string `json:"media_source"`
-	MediaSourceColor string `json:"media_source_color"`
-	Author           int    `json:"author_id"`
+	Id               int               `json:"id"`
+	Title            string            `json:"title"`
+	Description      string            `json:"description"`
+	MediaSource      string            `json:"media_source"`
+	MediaSourceColor string            `json:"media_source_color"`
+	Renditions       map[string]string `json:"renditions,omitempty"`
+	Palette          []string          `json:"palette,omitempty"`
+	BlurHash         string            `json:"blurhash,omitempty"`
+	MediaType        string            `json:"media_type,omitempty"`
+	MediaWidth       int               `json:"media_width,omitempty"`
+	MediaHeight      int               `json:"media_height,omitempty"`
+	MediaDurationMs  int               `json:"media_duration_ms,omitempty"`
+	Author           int               `json:"author_id"`
+	Warning          string            `json:"warning,omitempty"`
+	Duplicates       []int             `json:"duplicates,omitempty"` // ids of pins with the same image
 }
 
 func newCreateResponse(pin *models.Pin) *createResponse {
@@ -24,10 +45,28 @@ func newCreateResponse(pin *models.Pin) *createResponse {
 		Description:      xss.Sanitize(pin.Description),
 		MediaSource:      pin.MediaSource,
 		MediaSourceColor: pin.MediaSourceColor,
+		Renditions:       pin.Renditions,
+		Palette:          pin.Palette,
+		BlurHash:         pin.BlurHash,
+		MediaType:        pin.MediaType,
+		MediaWidth:       pin.MediaWidth,
+		MediaHeight:      pin.MediaHeight,
+		MediaDurationMs:  pin.MediaDurationMs,
 		Author:           pin.Author,
 	}
 }
 
+func (response *createResponse) setDuplicates(duplicates []models.Pin) {
+	if len(duplicates) == 0 {
+		return
+	}
+
+	response.Warning = duplicateImageWarning
+	for _, pin := range duplicates {
+		response.Duplicates = append(response.Duplicates, pin.Id)
+	}
+}
+
 type listResponse struct {
 	Pins []models.Pin `json:"pins"`
 }
@@ -44,14 +83,22 @@ func newListResponse(pins []models.Pin) *listResponse {
 }
 
 type getResponse struct {
-	Id               int    `json:"id"`
-	Title            string `json:"title"`
-	Description      string `json:"description"`
-	MediaSource      string `json:"media_source"`
-	MediaSourceColor string `json:"media_source_color"`
-	NumLikes         int    `json:"n_likes"`
-	Liked            bool   `json:"liked"`
-	Author           int    `json:"author_id"`
+	Id               int               `json:"id"`
+	Title            string            `json:"title"`
+	Description      string            `json:"description"`
+	MediaSource      string            `json:"media_source"`
+	MediaSourceColor string            `json:"media_source_color"`
+	Renditions       map[string]string `json:"renditions,omitempty"`
+	Palette          []string          `json:"palette,omitempty"`
+	BlurHash         string            `json:"blurhash,omitempty"`
+	MediaType        string            `json:"media_type,omitempty"`
+	MediaWidth       int               `json:"media_width,omitempty"`
+	MediaHeight      int               `json:"media_height,omitempty"`
+	MediaDurationMs  int               `json:"media_duration_ms,omitempty"`
+	NumLikes         int               `json:"n_likes"`
+	Liked            bool              `json:"liked"`
+	Promoted         bool              `json:"promoted,omitempty"`
+	Author           int               `json:"author_id"`
 }
 
 func newGetResponse(pin *models.Pin) *getResponse {
@@ -61,8 +108,16 @@ func newGetResponse(pin *models.Pin) *getResponse {
 		Description:      xss.Sanitize(pin.Description),
 		MediaSource:      pin.MediaSource,
 		MediaSourceColor: pin.MediaSourceColor,
+		Renditions:       pin.Renditions,
+		Palette:          pin.Palette,
+		BlurHash:         pin.BlurHash,
+		MediaType:        pin.MediaType,
+		MediaWidth:       pin.MediaWidth,
+		MediaHeight:      pin.MediaHeight,
+		MediaDurationMs:  pin.MediaDurationMs,
 		NumLikes:         pin.NumLikes,
 		Liked:            pin.Liked,
+		Promoted:         pin.Promoted,
 		Author:           pin.Author,
 	}
 }
@@ -86,3 +141,21 @@ func newFullUpdateResponse(pin *models.Pin) *fullUpdateResponse {
 		Author:           pin.Author,
 	}
 }
+
+type analyticsResponse struct {
+	PinId            int `json:"pin_id"`
+	NumLikes         int `json:"n_likes"`
+	NumComments      int `json:"n_comments"`
+	NumSaves         int `json:"n_saves"`
+	NumLikesLastWeek int `json:"n_likes_last_week"`
+}
+
+func newAnalyticsResponse(analytics *pkgPins.Analytics) *analyticsResponse {
+	return &analyticsResponse{
+		PinId:            analytics.PinId,
+		NumLikes:         analytics.NumLikes,
+		NumComments:      analytics.NumComments,
+		NumSaves:         analytics.NumSaves,
+		NumLikesLastWeek: analytics.NumLikesLastWeek,
+	}
+}
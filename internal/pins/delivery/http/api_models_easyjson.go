@@ -18,7 +18,73 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp(in *jlexer.Lexer, out *listResponse) {
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp(in *jlexer.Lexer, out *promotionRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "promoted":
+			out.Promoted = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp(out *jwriter.Writer, in promotionRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"promoted\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Promoted))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v promotionRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v promotionRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *promotionRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *promotionRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp1(in *jlexer.Lexer, out *listResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -70,7 +136,7 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDel
 		in.Consumed()
 	}
 }
-func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp(out *jwriter.Writer, in listResponse) {
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp1(out *jwriter.Writer, in listResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -96,27 +162,27 @@ func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDel
 // MarshalJSON supports json.Marshaler interface
 func (v listResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp(&w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v listResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp(w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *listResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp(&r, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *listResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp(l, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp1(l, v)
 }
-func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp1(in *jlexer.Lexer, out *getResponse) {
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp2(in *jlexer.Lexer, out *getResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -145,10 +211,65 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDel
 			out.MediaSource = string(in.String())
 		case "media_source_color":
 			out.MediaSourceColor = string(in.String())
+		case "renditions":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Renditions = make(map[string]string)
+				} else {
+					out.Renditions = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v4 string
+					v4 = string(in.String())
+					(out.Renditions)[key] = v4
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "palette":
+			if in.IsNull() {
+				in.Skip()
+				out.Palette = nil
+			} else {
+				in.Delim('[')
+				if out.Palette == nil {
+					if !in.IsDelim(']') {
+						out.Palette = make([]string, 0, 4)
+					} else {
+						out.Palette = []string{}
+					}
+				} else {
+					out.Palette = (out.Palette)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 string
+					v5 = string(in.String())
+					out.Palette = append(out.Palette, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "blurhash":
+			out.BlurHash = string(in.String())
+		case "media_type":
+			out.MediaType = string(in.String())
+		case "media_width":
+			out.MediaWidth = int(in.Int())
+		case "media_height":
+			out.MediaHeight = int(in.Int())
+		case "media_duration_ms":
+			out.MediaDurationMs = int(in.Int())
 		case "n_likes":
 			out.NumLikes = int(in.Int())
 		case "liked":
 			out.Liked = bool(in.Bool())
+		case "promoted":
+			out.Promoted = bool(in.Bool())
 		case "author_id":
 			out.Author = int(in.Int())
 		default:
@@ -161,7 +282,7 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDel
 		in.Consumed()
 	}
 }
-func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp1(out *jwriter.Writer, in getResponse) {
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp2(out *jwriter.Writer, in getResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -190,6 +311,64 @@ func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDel
 		out.RawString(prefix)
 		out.String(string(in.MediaSourceColor))
 	}
+	if len(in.Renditions) != 0 {
+		const prefix string = ",\"renditions\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v6First := true
+			for v6Name, v6Value := range in.Renditions {
+				if v6First {
+					v6First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v6Name))
+				out.RawByte(':')
+				out.String(string(v6Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.Palette) != 0 {
+		const prefix string = ",\"palette\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v7, v8 := range in.Palette {
+				if v7 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v8))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.BlurHash != "" {
+		const prefix string = ",\"blurhash\":"
+		out.RawString(prefix)
+		out.String(string(in.BlurHash))
+	}
+	if in.MediaType != "" {
+		const prefix string = ",\"media_type\":"
+		out.RawString(prefix)
+		out.String(string(in.MediaType))
+	}
+	if in.MediaWidth != 0 {
+		const prefix string = ",\"media_width\":"
+		out.RawString(prefix)
+		out.Int(int(in.MediaWidth))
+	}
+	if in.MediaHeight != 0 {
+		const prefix string = ",\"media_height\":"
+		out.RawString(prefix)
+		out.Int(int(in.MediaHeight))
+	}
+	if in.MediaDurationMs != 0 {
+		const prefix string = ",\"media_duration_ms\":"
+		out.RawString(prefix)
+		out.Int(int(in.MediaDurationMs))
+	}
 	{
 		const prefix string = ",\"n_likes\":"
 		out.RawString(prefix)
@@ -200,6 +379,11 @@ func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDel
 		out.RawString(prefix)
 		out.Bool(bool(in.Liked))
 	}
+	if in.Promoted {
+		const prefix string = ",\"promoted\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Promoted))
+	}
 	{
 		const prefix string = ",\"author_id\":"
 		out.RawString(prefix)
@@ -211,27 +395,27 @@ func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDel
 // MarshalJSON supports json.Marshaler interface
 func (v getResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp1(&w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v getResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp1(w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *getResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp1(&r, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *getResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp1(l, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp2(l, v)
 }
-func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp2(in *jlexer.Lexer, out *fullUpdateResponse) {
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp3(in *jlexer.Lexer, out *fullUpdateResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -272,7 +456,7 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDel
 		in.Consumed()
 	}
 }
-func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp2(out *jwriter.Writer, in fullUpdateResponse) {
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp3(out *jwriter.Writer, in fullUpdateResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -312,27 +496,27 @@ func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDel
 // MarshalJSON supports json.Marshaler interface
 func (v fullUpdateResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp2(&w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v fullUpdateResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp2(w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *fullUpdateResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp2(&r, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *fullUpdateResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp2(l, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp3(l, v)
 }
-func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp3(in *jlexer.Lexer, out *createResponse) {
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp4(in *jlexer.Lexer, out *createResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -361,8 +545,86 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDel
 			out.MediaSource = string(in.String())
 		case "media_source_color":
 			out.MediaSourceColor = string(in.String())
+		case "renditions":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Renditions = make(map[string]string)
+				} else {
+					out.Renditions = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v9 string
+					v9 = string(in.String())
+					(out.Renditions)[key] = v9
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "palette":
+			if in.IsNull() {
+				in.Skip()
+				out.Palette = nil
+			} else {
+				in.Delim('[')
+				if out.Palette == nil {
+					if !in.IsDelim(']') {
+						out.Palette = make([]string, 0, 4)
+					} else {
+						out.Palette = []string{}
+					}
+				} else {
+					out.Palette = (out.Palette)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 string
+					v10 = string(in.String())
+					out.Palette = append(out.Palette, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "blurhash":
+			out.BlurHash = string(in.String())
+		case "media_type":
+			out.MediaType = string(in.String())
+		case "media_width":
+			out.MediaWidth = int(in.Int())
+		case "media_height":
+			out.MediaHeight = int(in.Int())
+		case "media_duration_ms":
+			out.MediaDurationMs = int(in.Int())
 		case "author_id":
 			out.Author = int(in.Int())
+		case "warning":
+			out.Warning = string(in.String())
+		case "duplicates":
+			if in.IsNull() {
+				in.Skip()
+				out.Duplicates = nil
+			} else {
+				in.Delim('[')
+				if out.Duplicates == nil {
+					if !in.IsDelim(']') {
+						out.Duplicates = make([]int, 0, 8)
+					} else {
+						out.Duplicates = []int{}
+					}
+				} else {
+					out.Duplicates = (out.Duplicates)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v11 int
+					v11 = int(in.Int())
+					out.Duplicates = append(out.Duplicates, v11)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -373,7 +635,7 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDel
 		in.Consumed()
 	}
 }
-func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp3(out *jwriter.Writer, in createResponse) {
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp4(out *jwriter.Writer, in createResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -402,34 +664,205 @@ func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDel
 		out.RawString(prefix)
 		out.String(string(in.MediaSourceColor))
 	}
+	if len(in.Renditions) != 0 {
+		const prefix string = ",\"renditions\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v12First := true
+			for v12Name, v12Value := range in.Renditions {
+				if v12First {
+					v12First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v12Name))
+				out.RawByte(':')
+				out.String(string(v12Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.Palette) != 0 {
+		const prefix string = ",\"palette\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v13, v14 := range in.Palette {
+				if v13 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v14))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.BlurHash != "" {
+		const prefix string = ",\"blurhash\":"
+		out.RawString(prefix)
+		out.String(string(in.BlurHash))
+	}
+	if in.MediaType != "" {
+		const prefix string = ",\"media_type\":"
+		out.RawString(prefix)
+		out.String(string(in.MediaType))
+	}
+	if in.MediaWidth != 0 {
+		const prefix string = ",\"media_width\":"
+		out.RawString(prefix)
+		out.Int(int(in.MediaWidth))
+	}
+	if in.MediaHeight != 0 {
+		const prefix string = ",\"media_height\":"
+		out.RawString(prefix)
+		out.Int(int(in.MediaHeight))
+	}
+	if in.MediaDurationMs != 0 {
+		const prefix string = ",\"media_duration_ms\":"
+		out.RawString(prefix)
+		out.Int(int(in.MediaDurationMs))
+	}
 	{
 		const prefix string = ",\"author_id\":"
 		out.RawString(prefix)
 		out.Int(int(in.Author))
 	}
+	if in.Warning != "" {
+		const prefix string = ",\"warning\":"
+		out.RawString(prefix)
+		out.String(string(in.Warning))
+	}
+	if len(in.Duplicates) != 0 {
+		const prefix string = ",\"duplicates\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v15, v16 := range in.Duplicates {
+				if v15 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v16))
+			}
+			out.RawByte(']')
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v createResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp3(&w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v createResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp3(w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *createResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp3(&r, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *createResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp3(l, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp4(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp5(in *jlexer.Lexer, out *analyticsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "pin_id":
+			out.PinId = int(in.Int())
+		case "n_likes":
+			out.NumLikes = int(in.Int())
+		case "n_comments":
+			out.NumComments = int(in.Int())
+		case "n_saves":
+			out.NumSaves = int(in.Int())
+		case "n_likes_last_week":
+			out.NumLikesLastWeek = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp5(out *jwriter.Writer, in analyticsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"pin_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.PinId))
+	}
+	{
+		const prefix string = ",\"n_likes\":"
+		out.RawString(prefix)
+		out.Int(int(in.NumLikes))
+	}
+	{
+		const prefix string = ",\"n_comments\":"
+		out.RawString(prefix)
+		out.Int(int(in.NumComments))
+	}
+	{
+		const prefix string = ",\"n_saves\":"
+		out.RawString(prefix)
+		out.Int(int(in.NumSaves))
+	}
+	{
+		const prefix string = ",\"n_likes_last_week\":"
+		out.RawString(prefix)
+		out.Int(int(in.NumLikesLastWeek))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v analyticsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v analyticsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *analyticsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *analyticsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalPinsDeliveryHttp5(l, v)
 }
@@ -17,17 +17,21 @@ import (
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	pkgPins "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pins"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/utils"
 )
 
 func RegisterHandlers(mux *httprouter.Router, logger *zap.Logger, authorizer mw.Authorizer, csrf mw.CSRFMiddleware, access mw.AccessChecker, serv pkgPins.Service, m *mw.HttpMetricsMiddleware) {
 	del := delivery{serv, logger}
 
-	mux.POST("/pins", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer(mw.Cors(csrf(del.create))), logger), logger), logger))
+	mux.POST("/pins", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer.Scoped(models.ScopePinsWrite)(mw.Cors(csrf(del.create))), logger), logger), logger))
 	mux.GET("/pins", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.SetUserID(del.list), logger), logger), logger))
 	mux.GET("/pins/:id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.SetUserID(del.get), logger), logger), logger))
-	mux.GET("/users/:id/pins", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer(mw.Cors(csrf(del.listByAuthor))), logger), logger), logger))
-	mux.PUT("/pins/:id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer(mw.Cors(csrf(access.WriteChecker(del.fullUpdate)))), logger), logger), logger))
-	mux.DELETE("/pins/:id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer(mw.Cors(csrf(access.WriteChecker(del.delete)))), logger), logger), logger))
+	mux.GET("/pins/:id/duplicates", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.SetUserID(del.listDuplicates), logger), logger), logger))
+	mux.GET("/users/:id/pins", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer.Scoped(models.ScopePinsRead)(mw.Cors(csrf(del.listByAuthor))), logger), logger), logger))
+	mux.PUT("/pins/:id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer.Scoped(models.ScopePinsWrite)(mw.Cors(csrf(access.WriteChecker(del.fullUpdate)))), logger), logger), logger))
+	mux.DELETE("/pins/:id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer.Scoped(models.ScopePinsWrite)(mw.Cors(csrf(access.WriteChecker(del.delete)))), logger), logger), logger))
+	mux.PUT("/pins/:id/promotion", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer(mw.Cors(csrf(access.WriteChecker(del.setPromoted)))), logger), logger), logger))
+	mux.GET("/pins/:id/analytics", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(authorizer(mw.Cors(csrf(access.WriteChecker(del.getAnalytics)))), logger), logger), logger))
 }
 
 type delivery struct {
@@ -75,6 +79,13 @@ func (del delivery) create(w http.ResponseWriter, r *http.Request, p httprouter.
 	}
 
 	response := newCreateResponse(&pin)
+	if r.FormValue("check_duplicates") == "true" {
+		duplicates, err := del.serv.ListDuplicates(pin.Id, userId, 1, maxReportedDuplicates)
+		if err != nil {
+			return err
+		}
+		response.setDuplicates(duplicates)
+	}
 	data, err := response.MarshalJSON()
 	if err != nil {
 		return pkgErrors.ErrCreateResponse
@@ -227,6 +238,57 @@ func (del delivery) list(w http.ResponseWriter, r *http.Request, p httprouter.Pa
 	return nil
 }
 
+func (del delivery) listDuplicates(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+	strId := p.ByName("id")
+	id, err := strconv.Atoi(strId)
+	if err != nil {
+		return pkgErrors.ErrInvalidPinIdParam
+	}
+
+	strUserId := p.ByName("user-id")
+	userId, err := strconv.Atoi(strUserId)
+	if err != nil && strUserId != "" {
+		return pkgErrors.ErrInvalidUserIdParam
+	}
+
+	queryValues := r.URL.Query()
+	page := 1
+	strPage := queryValues.Get("page")
+	if strPage != "" {
+		page, err = strconv.Atoi(strPage)
+		if err != nil || page < 1 {
+			return pkgErrors.ErrInvalidPageParam
+		}
+	}
+
+	limit := 30
+	strLimit := queryValues.Get("limit")
+	if strLimit != "" {
+		limit, err = strconv.Atoi(strLimit)
+		if err != nil || limit < 0 {
+			return pkgErrors.ErrInvalidLimitParam
+		}
+	}
+
+	pins, err := del.serv.ListDuplicates(id, userId, page, limit)
+	if err != nil {
+		return err
+	}
+
+	response := newListResponse(pins)
+	data, err := response.MarshalJSON()
+	if err != nil {
+		return pkgErrors.ErrCreateResponse
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil {
+		return pkgErrors.ErrCreateResponse
+	}
+	return nil
+}
+
 func (del delivery) fullUpdate(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
 	strId := p.ByName("id")
 	id, err := strconv.Atoi(strId)
@@ -271,3 +333,78 @@ func (del delivery) delete(w http.ResponseWriter, r *http.Request, p httprouter.
 	}
 	return pkgErrors.ErrNoContent
 }
+
+func (del delivery) setPromoted(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+	strId := p.ByName("id")
+	id, err := strconv.Atoi(strId)
+	if err != nil {
+		return pkgErrors.ErrInvalidPinIdParam
+	}
+
+	strUserId := p.ByName("user-id")
+	userId, err := strconv.Atoi(strUserId)
+	if err != nil {
+		return pkgErrors.ErrInvalidUserIdParam
+	}
+
+	body, err := utils.ReadBody(r, del.log)
+	if err != nil {
+		return err
+	}
+
+	var request promotionRequest
+	err = request.UnmarshalJSON(body)
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrParseJson, err.Error())
+	}
+
+	pin, err := del.serv.SetPromoted(id, userId, request.Promoted)
+	if err != nil {
+		return err
+	}
+
+	response := newGetResponse(&pin)
+	data, err := response.MarshalJSON()
+	if err != nil {
+		return pkgErrors.ErrCreateResponse
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil {
+		return pkgErrors.ErrCreateResponse
+	}
+	return nil
+}
+
+func (del delivery) getAnalytics(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+	strId := p.ByName("id")
+	id, err := strconv.Atoi(strId)
+	if err != nil {
+		return pkgErrors.ErrInvalidPinIdParam
+	}
+
+	strUserId := p.ByName("user-id")
+	userId, err := strconv.Atoi(strUserId)
+	if err != nil {
+		return pkgErrors.ErrInvalidUserIdParam
+	}
+
+	analytics, err := del.serv.GetAnalytics(id, userId)
+	if err != nil {
+		return err
+	}
+
+	response := newAnalyticsResponse(&analytics)
+	data, err := response.MarshalJSON()
+	if err != nil {
+		return pkgErrors.ErrCreateResponse
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil {
+		return pkgErrors.ErrCreateResponse
+	}
+	return nil
+}
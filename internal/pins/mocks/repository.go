@@ -94,6 +94,20 @@ func (mr *MockRepositoryMockRecorder) Delete(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), id)
 }
 
+// DeleteMediaPrivacyBackfill mocks base method.
+func (m *MockRepository) DeleteMediaPrivacyBackfill(pinIds []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMediaPrivacyBackfill", pinIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMediaPrivacyBackfill indicates an expected call of DeleteMediaPrivacyBackfill.
+func (mr *MockRepositoryMockRecorder) DeleteMediaPrivacyBackfill(pinIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMediaPrivacyBackfill", reflect.TypeOf((*MockRepository)(nil).DeleteMediaPrivacyBackfill), pinIds)
+}
+
 // FullUpdate mocks base method.
 func (m *MockRepository) FullUpdate(params *pins.FullUpdateParams) (models.Pin, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +138,36 @@ func (mr *MockRepositoryMockRecorder) Get(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), id)
 }
 
+// GetAccountType mocks base method.
+func (m *MockRepository) GetAccountType(userId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountType", userId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountType indicates an expected call of GetAccountType.
+func (mr *MockRepositoryMockRecorder) GetAccountType(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountType", reflect.TypeOf((*MockRepository)(nil).GetAccountType), userId)
+}
+
+// GetAnalytics mocks base method.
+func (m *MockRepository) GetAnalytics(id int) (pins.Analytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnalytics", id)
+	ret0, _ := ret[0].(pins.Analytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnalytics indicates an expected call of GetAnalytics.
+func (mr *MockRepositoryMockRecorder) GetAnalytics(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnalytics", reflect.TypeOf((*MockRepository)(nil).GetAnalytics), id)
+}
+
 // IsLikedByUser mocks base method.
 func (m *MockRepository) IsLikedByUser(pinId, userId int) (bool, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +213,21 @@ func (mr *MockRepositoryMockRecorder) ListByAuthor(userId, page, limit interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAuthor", reflect.TypeOf((*MockRepository)(nil).ListByAuthor), userId, page, limit)
 }
 
+// ListDuplicates mocks base method.
+func (m *MockRepository) ListDuplicates(id, userId, page, limit int) ([]models.Pin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDuplicates", id, userId, page, limit)
+	ret0, _ := ret[0].([]models.Pin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDuplicates indicates an expected call of ListDuplicates.
+func (mr *MockRepositoryMockRecorder) ListDuplicates(id, userId, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDuplicates", reflect.TypeOf((*MockRepository)(nil).ListDuplicates), id, userId, page, limit)
+}
+
 // ListLiked mocks base method.
 func (m *MockRepository) ListLiked(userID, page, limit int) ([]models.Pin, error) {
 	m.ctrl.T.Helper()
@@ -184,6 +243,21 @@ func (mr *MockRepositoryMockRecorder) ListLiked(userID, page, limit interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLiked", reflect.TypeOf((*MockRepository)(nil).ListLiked), userID, page, limit)
 }
 
+// ListMediaPrivacyBackfill mocks base method.
+func (m *MockRepository) ListMediaPrivacyBackfill(limit int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMediaPrivacyBackfill", limit)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMediaPrivacyBackfill indicates an expected call of ListMediaPrivacyBackfill.
+func (mr *MockRepositoryMockRecorder) ListMediaPrivacyBackfill(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMediaPrivacyBackfill", reflect.TypeOf((*MockRepository)(nil).ListMediaPrivacyBackfill), limit)
+}
+
 // ListWithLikedField mocks base method.
 func (m *MockRepository) ListWithLikedField(userID, page, limit int) ([]models.Pin, error) {
 	m.ctrl.T.Helper()
@@ -198,3 +272,46 @@ func (mr *MockRepositoryMockRecorder) ListWithLikedField(userID, page, limit int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWithLikedField", reflect.TypeOf((*MockRepository)(nil).ListWithLikedField), userID, page, limit)
 }
+
+// SetPromoted mocks base method.
+func (m *MockRepository) SetPromoted(id int, promoted bool) (models.Pin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPromoted", id, promoted)
+	ret0, _ := ret[0].(models.Pin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPromoted indicates an expected call of SetPromoted.
+func (mr *MockRepositoryMockRecorder) SetPromoted(id, promoted interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPromoted", reflect.TypeOf((*MockRepository)(nil).SetPromoted), id, promoted)
+}
+
+// SignPrivateMedia mocks base method.
+func (m *MockRepository) SignPrivateMedia(pins []models.Pin, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignPrivateMedia", pins, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignPrivateMedia indicates an expected call of SignPrivateMedia.
+func (mr *MockRepositoryMockRecorder) SignPrivateMedia(pins, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignPrivateMedia", reflect.TypeOf((*MockRepository)(nil).SignPrivateMedia), pins, userId)
+}
+
+// SyncMediaPrivacy mocks base method.
+func (m *MockRepository) SyncMediaPrivacy(pinIds []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncMediaPrivacy", pinIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncMediaPrivacy indicates an expected call of SyncMediaPrivacy.
+func (mr *MockRepositoryMockRecorder) SyncMediaPrivacy(pinIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncMediaPrivacy", reflect.TypeOf((*MockRepository)(nil).SyncMediaPrivacy), pinIds)
+}
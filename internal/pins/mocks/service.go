@@ -35,6 +35,21 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// BackfillMediaPrivacy mocks base method.
+func (m *MockService) BackfillMediaPrivacy() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillMediaPrivacy")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillMediaPrivacy indicates an expected call of BackfillMediaPrivacy.
+func (mr *MockServiceMockRecorder) BackfillMediaPrivacy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillMediaPrivacy", reflect.TypeOf((*MockService)(nil).BackfillMediaPrivacy))
+}
+
 // CheckReadAccess mocks base method.
 func (m *MockService) CheckReadAccess(userId, pinId string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +139,21 @@ func (mr *MockServiceMockRecorder) Get(id, userId interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), id, userId)
 }
 
+// GetAnalytics mocks base method.
+func (m *MockService) GetAnalytics(id, userId int) (pins.Analytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnalytics", id, userId)
+	ret0, _ := ret[0].(pins.Analytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnalytics indicates an expected call of GetAnalytics.
+func (mr *MockServiceMockRecorder) GetAnalytics(id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnalytics", reflect.TypeOf((*MockService)(nil).GetAnalytics), id, userId)
+}
+
 // List mocks base method.
 func (m *MockService) List(authorized bool, userId int, liked bool, page, limit int) ([]models.Pin, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +184,21 @@ func (mr *MockServiceMockRecorder) ListByAuthor(authorId, userId, page, limit in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAuthor", reflect.TypeOf((*MockService)(nil).ListByAuthor), authorId, userId, page, limit)
 }
 
+// ListDuplicates mocks base method.
+func (m *MockService) ListDuplicates(id, userId, page, limit int) ([]models.Pin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDuplicates", id, userId, page, limit)
+	ret0, _ := ret[0].([]models.Pin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDuplicates indicates an expected call of ListDuplicates.
+func (mr *MockServiceMockRecorder) ListDuplicates(id, userId, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDuplicates", reflect.TypeOf((*MockService)(nil).ListDuplicates), id, userId, page, limit)
+}
+
 // SetLikedField mocks base method.
 func (m *MockService) SetLikedField(pin *models.Pin, userId int) error {
 	m.ctrl.T.Helper()
@@ -167,3 +212,46 @@ func (mr *MockServiceMockRecorder) SetLikedField(pin, userId interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLikedField", reflect.TypeOf((*MockService)(nil).SetLikedField), pin, userId)
 }
+
+// SetPromoted mocks base method.
+func (m *MockService) SetPromoted(id, userId int, promoted bool) (models.Pin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPromoted", id, userId, promoted)
+	ret0, _ := ret[0].(models.Pin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPromoted indicates an expected call of SetPromoted.
+func (mr *MockServiceMockRecorder) SetPromoted(id, userId, promoted interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPromoted", reflect.TypeOf((*MockService)(nil).SetPromoted), id, userId, promoted)
+}
+
+// SignPrivateMedia mocks base method.
+func (m *MockService) SignPrivateMedia(pins []models.Pin, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignPrivateMedia", pins, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignPrivateMedia indicates an expected call of SignPrivateMedia.
+func (mr *MockServiceMockRecorder) SignPrivateMedia(pins, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignPrivateMedia", reflect.TypeOf((*MockService)(nil).SignPrivateMedia), pins, userId)
+}
+
+// SyncMediaPrivacy mocks base method.
+func (m *MockService) SyncMediaPrivacy(pinIds []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncMediaPrivacy", pinIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncMediaPrivacy indicates an expected call of SyncMediaPrivacy.
+func (mr *MockServiceMockRecorder) SyncMediaPrivacy(pinIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncMediaPrivacy", reflect.TypeOf((*MockService)(nil).SyncMediaPrivacy), pinIds)
+}
@@ -17,6 +17,14 @@ type FullUpdateParams struct {
 	Description string
 }
 
+type Analytics struct {
+	PinId            int
+	NumLikes         int
+	NumComments      int
+	NumSaves         int // number of boards the pin is saved to
+	NumLikesLastWeek int
+}
+
 type Repository interface {
 	Create(params *CreateParams) (models.Pin, error)
 	Get(id int) (models.Pin, error)
@@ -25,12 +33,28 @@ type Repository interface {
 	List(page, limit int) ([]models.Pin, error)
 	ListLiked(userID int, page, limit int) ([]models.Pin, error)
 	ListWithLikedField(userID int, page, limit int) ([]models.Pin, error)
+	// ListDuplicates returns pins with images similar to the image of the pin, the most similar first.
+	// Only the pins the user can read are listed.
+	ListDuplicates(id, userId int, page, limit int) ([]models.Pin, error)
 
 	FullUpdate(params *FullUpdateParams) (models.Pin, error)
 	Delete(id int) error
 
 	IsLikedByUser(pinId, userId int) (bool, error)
 
+	// SignPrivateMedia replaces the image URLs of private pins the user can read with signed ones.
+	SignPrivateMedia(pins []models.Pin, userId int) error
+	// SyncMediaPrivacy makes the images of the pins private or public after their boards have changed.
+	SyncMediaPrivacy(pinIds []int) error
+	// ListMediaPrivacyBackfill returns up to limit pins saved to secret boards before their images could be private,
+	// DeleteMediaPrivacyBackfill removes the synced ones from the list.
+	ListMediaPrivacyBackfill(limit int) ([]int, error)
+	DeleteMediaPrivacyBackfill(pinIds []int) error
+
+	GetAccountType(userId int) (string, error)
+	SetPromoted(id int, promoted bool) (models.Pin, error)
+	GetAnalytics(id int) (Analytics, error)
+
 	CheckWriteAccess(userId, pinId string) (bool, error)
 	CheckReadAccess(userId, pinId string) (bool, error)
 }
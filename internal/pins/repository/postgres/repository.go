@@ -3,9 +3,12 @@ package postgres
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"encoding/json"
+	"sort"
+
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
@@ -27,39 +30,73 @@ type repository struct {
 }
 
 const createCmd = `
-		INSERT INTO pins (title, media_source, media_source_color, description, author_id)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, title, media_source, media_source_color, description, author_id;`
+		INSERT INTO pins (title, media_source, media_source_color, media_renditions, media_palette, media_blurhash,
+						  media_type, media_width, media_height, media_duration_ms, media_hash, description, author_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, title, media_source, media_source_color, media_renditions, media_palette, media_blurhash,
+			media_type, media_width, media_height, media_duration_ms, description, author_id;`
 
 func (repo *repository) Create(params *pkgPins.CreateParams) (models.Pin, error) {
-	url, err := repo.imgServ.UploadImage(context.Background(), &params.MediaSource)
+	uploaded, err := repo.imgServ.UploadRenditions(context.Background(), &params.MediaSource)
+	if err != nil {
+		return models.Pin{}, err
+	}
+	renditions := uploaded.URLs
+	url := renditions[pkgImage.OriginalRendition]
+	delete(renditions, pkgImage.OriginalRendition)
+	rawRenditions, err := json.Marshal(renditions)
 	if err != nil {
-		return models.Pin{}, errors.Wrap(pkgErrors.ErrImageService, err.Error())
+		return models.Pin{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
 	}
 
-	avgColor := pkgImage.Color{
-		Red:   constants.DefaultRedAvgColor,
-		Green: constants.DefaultGreenAvgColor,
-		Blue:  constants.DefaultBlueAvgColor,
+	// the colors are computed by the images service from the oriented image or poster frame,
+	// images that it could not decode get the default color
+	avgColor := uploaded.Color
+	if avgColor == "" {
+		avgColor = pkgImage.Color{
+			Red:   constants.DefaultRedAvgColor,
+			Green: constants.DefaultGreenAvgColor,
+			Blue:  constants.DefaultBlueAvgColor,
+		}.CSS()
 	}
-	img, err := pkgImage.BytesToImage(params.MediaSource.Bytes)
-	if err == nil {
-		avgColor = pkgImage.CalcAvgColor(img)
+	palette := uploaded.Palette
+	if palette == nil {
+		palette = []string{}
+	}
+	rawPalette, err := json.Marshal(palette)
+	if err != nil {
+		return models.Pin{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
 	}
-	avgColorStr := fmt.Sprintf("rgb(%d, %d, %d)", avgColor.Red, avgColor.Green, avgColor.Blue)
 
 	row := repo.db.QueryRow(createCmd,
 		params.Title,
 		url,
-		avgColorStr,
+		avgColor,
+		rawRenditions,
+		rawPalette,
+		uploaded.BlurHash,
+		mediaType(uploaded.MediaType),
+		uploaded.Width,
+		uploaded.Height,
+		uploaded.Duration.Milliseconds(),
+		mediaHash(uploaded.Hash),
 		params.Description,
 		params.Author,
 	)
 
 	retrievedPin := models.Pin{}
 	var title, description, mediaSource sql.NullString
-	err = row.Scan(&retrievedPin.Id, &title, &mediaSource, &retrievedPin.MediaSourceColor, &description,
-		&retrievedPin.Author)
+	err = row.Scan(&retrievedPin.Id, &title, &mediaSource, &retrievedPin.MediaSourceColor, &rawRenditions,
+		&rawPalette, &retrievedPin.BlurHash, &retrievedPin.MediaType, &retrievedPin.MediaWidth,
+		&retrievedPin.MediaHeight, &retrievedPin.MediaDurationMs, &description, &retrievedPin.Author)
+	if err != nil {
+		return models.Pin{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	retrievedPin.Renditions, err = parseRenditions(rawRenditions)
+	if err != nil {
+		return models.Pin{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	retrievedPin.Palette, err = parsePalette(rawPalette)
 	if err != nil {
 		return models.Pin{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
 	}
@@ -70,8 +107,46 @@ func (repo *repository) Create(params *pkgPins.CreateParams) (models.Pin, error)
 	return retrievedPin, nil
 }
 
+// mediaType defaults to an image for images services that do not report the type.
+func mediaType(typ string) string {
+	if typ == "" {
+		return models.MediaTypeImage
+	}
+	return typ
+}
+
+// mediaHash stores the unsigned perceptual hash bit for bit in a bigint, 0 means no hash.
+func mediaHash(hash uint64) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(hash), Valid: hash != 0}
+}
+
+// parsePalette returns nil for pins created before palettes were introduced.
+func parsePalette(raw []byte) ([]string, error) {
+	var palette []string
+	if err := json.Unmarshal(raw, &palette); err != nil {
+		return nil, err
+	}
+	if len(palette) == 0 {
+		return nil, nil
+	}
+	return palette, nil
+}
+
+// parseRenditions returns nil for pins created before renditions were introduced.
+func parseRenditions(raw []byte) (map[string]string, error) {
+	var renditions map[string]string
+	if err := json.Unmarshal(raw, &renditions); err != nil {
+		return nil, err
+	}
+	if len(renditions) == 0 {
+		return nil, nil
+	}
+	return renditions, nil
+}
+
 const getCmd = `
-		SELECT id, title, description, media_source, media_source_color, n_likes, author_id
+		SELECT id, title, description, media_source, media_source_color, media_renditions, media_palette, media_blurhash,
+			media_type, media_width, media_height, media_duration_ms, n_likes, promoted, author_id
 		FROM pins
 		WHERE id = $1;`
 
@@ -80,7 +155,10 @@ func (repo *repository) Get(id int) (models.Pin, error) {
 
 	pin := models.Pin{}
 	var title, description, mediaSource sql.NullString
-	err := row.Scan(&pin.Id, &title, &description, &mediaSource, &pin.MediaSourceColor, &pin.NumLikes, &pin.Author)
+	var rawRenditions, rawPalette []byte
+	err := row.Scan(&pin.Id, &title, &description, &mediaSource, &pin.MediaSourceColor, &rawRenditions, &rawPalette,
+		&pin.BlurHash, &pin.MediaType, &pin.MediaWidth, &pin.MediaHeight, &pin.MediaDurationMs, &pin.NumLikes,
+		&pin.Promoted, &pin.Author)
 	if err != nil {
 		repo.log.Error(constants.DBScanError, zap.Error(err), zap.String("sql_query", getCmd),
 			zap.Int("id", id))
@@ -92,6 +170,14 @@ func (repo *repository) Get(id int) (models.Pin, error) {
 		}
 	}
 
+	pin.Renditions, err = parseRenditions(rawRenditions)
+	if err != nil {
+		return models.Pin{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	pin.Palette, err = parsePalette(rawPalette)
+	if err != nil {
+		return models.Pin{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
 	pin.Title = title.String
 	pin.Description = description.String
 	pin.MediaSource = mediaSource.String
@@ -99,7 +185,8 @@ func (repo *repository) Get(id int) (models.Pin, error) {
 }
 
 const listByUserCmd = `
-		SELECT id, title, description, media_source, media_source_color, n_likes, author_id
+		SELECT id, title, description, media_source, media_source_color, media_renditions, media_palette, media_blurhash,
+			media_type, media_width, media_height, media_duration_ms, n_likes, author_id
 		FROM pins 
 		WHERE author_id = $1
 		ORDER BY created_at DESC 
@@ -114,9 +201,20 @@ func (repo *repository) ListByAuthor(userId int, page, limit int) ([]models.Pin,
 	var pins []models.Pin
 	pin := models.Pin{}
 	var title, description, mediaSource sql.NullString
+	var rawRenditions, rawPalette []byte
 
 	for rows.Next() {
-		err = rows.Scan(&pin.Id, &title, &description, &mediaSource, &pin.MediaSourceColor, &pin.NumLikes, &pin.Author)
+		err = rows.Scan(&pin.Id, &title, &description, &mediaSource, &pin.MediaSourceColor, &rawRenditions,
+			&rawPalette, &pin.BlurHash, &pin.MediaType, &pin.MediaWidth, &pin.MediaHeight, &pin.MediaDurationMs,
+			&pin.NumLikes, &pin.Author)
+		if err != nil {
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		pin.Renditions, err = parseRenditions(rawRenditions)
+		if err != nil {
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		pin.Palette, err = parsePalette(rawPalette)
 		if err != nil {
 			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
 		}
@@ -135,6 +233,13 @@ const listWithLikedFieldCmd = `
 				description,
 				media_source,
 				media_source_color,
+				media_renditions,
+				media_palette,
+				media_blurhash,
+				media_type,
+				media_width,
+				media_height,
+				media_duration_ms,
 				n_likes,
 				CASE WHEN pin_likes.author_id IS NOT NULL THEN true ELSE false END AS liked,
 				pins.author_id 
@@ -161,16 +266,26 @@ func (repo *repository) ListWithLikedField(userID int, page, limit int) ([]model
 	pins := []models.Pin{}
 	pin := models.Pin{}
 	var title, description, mediaSource sql.NullString
+	var rawRenditions, rawPalette []byte
 
 	for rows.Next() {
-		err = rows.Scan(&pin.Id, &title, &description, &mediaSource, &pin.MediaSourceColor, &pin.NumLikes, &pin.Liked,
-			&pin.Author)
+		err = rows.Scan(&pin.Id, &title, &description, &mediaSource, &pin.MediaSourceColor, &rawRenditions,
+			&rawPalette, &pin.BlurHash, &pin.MediaType, &pin.MediaWidth, &pin.MediaHeight, &pin.MediaDurationMs,
+			&pin.NumLikes, &pin.Liked, &pin.Author)
 		if err != nil {
 			repo.log.Error(constants.DBScanError, zap.Error(err), zap.String("sql_query", listWithLikedFieldCmd),
 				zap.Int("page", page), zap.Int("limit", limit))
 			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
 		}
 
+		pin.Renditions, err = parseRenditions(rawRenditions)
+		if err != nil {
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		pin.Palette, err = parsePalette(rawPalette)
+		if err != nil {
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
 		pin.Title = title.String
 		pin.Description = description.String
 		pin.MediaSource = mediaSource.String
@@ -186,6 +301,13 @@ const listCmd = `
 				description,
 				media_source,
 				media_source_color,
+				media_renditions,
+				media_palette,
+				media_blurhash,
+				media_type,
+				media_width,
+				media_height,
+				media_duration_ms,
 				n_likes,
 				false AS liked,
 				pins.author_id 
@@ -210,16 +332,111 @@ func (repo *repository) List(page, limit int) ([]models.Pin, error) {
 	pins := []models.Pin{}
 	pin := models.Pin{}
 	var title, description, mediaSource sql.NullString
+	var rawRenditions, rawPalette []byte
 
 	for rows.Next() {
-		err = rows.Scan(&pin.Id, &title, &description, &mediaSource, &pin.MediaSourceColor, &pin.NumLikes, &pin.Liked,
-			&pin.Author)
+		err = rows.Scan(&pin.Id, &title, &description, &mediaSource, &pin.MediaSourceColor, &rawRenditions,
+			&rawPalette, &pin.BlurHash, &pin.MediaType, &pin.MediaWidth, &pin.MediaHeight, &pin.MediaDurationMs,
+			&pin.NumLikes, &pin.Liked, &pin.Author)
 		if err != nil {
 			repo.log.Error(constants.DBScanError, zap.Error(err), zap.String("sql_query", listCmd),
 				zap.Int("page", page), zap.Int("limit", limit))
 			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
 		}
 
+		pin.Renditions, err = parseRenditions(rawRenditions)
+		if err != nil {
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		pin.Palette, err = parsePalette(rawPalette)
+		if err != nil {
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		pin.Title = title.String
+		pin.Description = description.String
+		pin.MediaSource = mediaSource.String
+		pins = append(pins, pin)
+	}
+
+	return pins, nil
+}
+
+// The Hamming distance between hashes is the number of ones in their xor. Pins saved to secret boards only
+// are listed to their authors and the owners of the boards, like in search results.
+const listDuplicatesCmd = `
+		SELECT p.id,
+			   p.title,
+			   p.description,
+			   p.media_source,
+			   p.media_source_color,
+			   p.media_renditions,
+			   p.media_palette,
+			   p.media_blurhash,
+			   p.media_type,
+			   p.media_width,
+			   p.media_height,
+			   p.media_duration_ms,
+			   p.n_likes,
+			   false AS liked,
+			   p.author_id
+		FROM pins src
+			JOIN LATERAL (SELECT *
+						  FROM pins c
+						  WHERE c.media_hash IS NOT NULL AND c.id <> src.id
+						  ORDER BY c.created_at DESC
+						  LIMIT $6) p ON true,
+			LATERAL (SELECT length(replace(((p.media_hash # src.media_hash)::bit(64))::text, '0', '')) AS distance) d
+		WHERE src.id = $1 AND src.media_hash IS NOT NULL AND d.distance <= $2
+		  AND (p.author_id = $3
+			   OR NOT EXISTS (SELECT 1
+							  FROM boards_pins bp
+								  JOIN boards b ON b.id = bp.board_id
+							  WHERE bp.pin_id = p.id AND b.privacy = 'secret')
+			   OR EXISTS (SELECT 1
+						  FROM boards_pins bp
+							  JOIN boards b ON b.id = bp.board_id
+						  WHERE bp.pin_id = p.id AND (b.privacy = 'public' OR b.user_id = $3)))
+		ORDER BY d.distance, p.created_at DESC
+		LIMIT $4 OFFSET $5;`
+
+func (repo *repository) ListDuplicates(id, userId int, page, limit int) ([]models.Pin, error) {
+	rows, err := repo.db.Query(listDuplicatesCmd, id, constants.DuplicateImageMaxDistance, userId, limit,
+		(page-1)*limit, constants.DuplicateScanLimit)
+	if err != nil {
+		repo.log.Error(constants.DBQueryError, zap.Error(err), zap.String("sql_query", listDuplicatesCmd),
+			zap.Int("id", id), zap.Int("user_id", userId), zap.Int("page", page), zap.Int("limit", limit))
+		return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer func() {
+		err = rows.Close()
+		if err != nil {
+			repo.log.Error(constants.FailedCloseQueryRows, zap.Error(err), zap.String("sql_query", listDuplicatesCmd))
+		}
+	}()
+
+	pins := []models.Pin{}
+	pin := models.Pin{}
+	var title, description, mediaSource sql.NullString
+	var rawRenditions, rawPalette []byte
+
+	for rows.Next() {
+		err = rows.Scan(&pin.Id, &title, &description, &mediaSource, &pin.MediaSourceColor, &rawRenditions,
+			&rawPalette, &pin.BlurHash, &pin.MediaType, &pin.MediaWidth, &pin.MediaHeight, &pin.MediaDurationMs,
+			&pin.NumLikes, &pin.Liked, &pin.Author)
+		if err != nil {
+			repo.log.Error(constants.DBScanError, zap.Error(err), zap.String("sql_query", listDuplicatesCmd),
+				zap.Int("id", id), zap.Int("page", page), zap.Int("limit", limit))
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+
+		pin.Renditions, err = parseRenditions(rawRenditions)
+		if err != nil {
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		pin.Palette, err = parsePalette(rawPalette)
+		if err != nil {
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
 		pin.Title = title.String
 		pin.Description = description.String
 		pin.MediaSource = mediaSource.String
@@ -235,6 +452,13 @@ const listLikedCmd = `
 			   description,
 			   media_source,
 			   media_source_color,
+			   media_renditions,
+			   media_palette,
+			   media_blurhash,
+			   media_type,
+			   media_width,
+			   media_height,
+			   media_duration_ms,
 			   n_likes,
 			   true AS liked,
 			   pins.author_id
@@ -260,16 +484,26 @@ func (repo *repository) ListLiked(userID int, page, limit int) ([]models.Pin, er
 	pins := []models.Pin{}
 	pin := models.Pin{}
 	var title, description, mediaSource sql.NullString
+	var rawRenditions, rawPalette []byte
 
 	for rows.Next() {
-		err = rows.Scan(&pin.Id, &title, &description, &mediaSource, &pin.MediaSourceColor, &pin.NumLikes, &pin.Liked,
-			&pin.Author)
+		err = rows.Scan(&pin.Id, &title, &description, &mediaSource, &pin.MediaSourceColor, &rawRenditions,
+			&rawPalette, &pin.BlurHash, &pin.MediaType, &pin.MediaWidth, &pin.MediaHeight, &pin.MediaDurationMs,
+			&pin.NumLikes, &pin.Liked, &pin.Author)
 		if err != nil {
 			repo.log.Error(constants.DBScanError, zap.Error(err), zap.String("sql_query", listLikedCmd),
 				zap.Int("page", page), zap.Int("limit", limit))
 			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
 		}
 
+		pin.Renditions, err = parseRenditions(rawRenditions)
+		if err != nil {
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		pin.Palette, err = parsePalette(rawPalette)
+		if err != nil {
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
 		pin.Title = title.String
 		pin.Description = description.String
 		pin.MediaSource = mediaSource.String
@@ -340,6 +574,312 @@ func (repo *repository) IsLikedByUser(pinId, userId int) (bool, error) {
 	return liked, nil
 }
 
+const getAccountTypeCmd = `
+		SELECT account_type
+		FROM users
+		WHERE id = $1;`
+
+func (repo *repository) GetAccountType(userId int) (string, error) {
+	row := repo.db.QueryRow(getAccountTypeCmd, userId)
+
+	var accountType string
+	err := row.Scan(&accountType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errors.Wrap(pkgErrors.ErrUserNotFound, err.Error())
+		}
+		return "", errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return accountType, nil
+}
+
+const setPromotedCmd = `
+		UPDATE pins
+		SET promoted = $1
+		WHERE id = $2
+		RETURNING id, title, description, media_source, media_source_color, media_renditions, media_palette,
+			media_blurhash, media_type, media_width, media_height, media_duration_ms, n_likes, promoted, author_id;`
+
+func (repo *repository) SetPromoted(id int, promoted bool) (models.Pin, error) {
+	row := repo.db.QueryRow(setPromotedCmd, promoted, id)
+
+	pin := models.Pin{}
+	var title, description, mediaSource sql.NullString
+	var rawRenditions, rawPalette []byte
+	err := row.Scan(&pin.Id, &title, &description, &mediaSource, &pin.MediaSourceColor, &rawRenditions, &rawPalette,
+		&pin.BlurHash, &pin.MediaType, &pin.MediaWidth, &pin.MediaHeight, &pin.MediaDurationMs, &pin.NumLikes,
+		&pin.Promoted, &pin.Author)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Pin{}, errors.Wrap(pkgErrors.ErrPinNotFound, err.Error())
+		}
+		repo.log.Error(constants.DBScanError, zap.Error(err), zap.String("sql_query", setPromotedCmd),
+			zap.Int("id", id))
+		return models.Pin{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+
+	pin.Renditions, err = parseRenditions(rawRenditions)
+	if err != nil {
+		return models.Pin{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	pin.Palette, err = parsePalette(rawPalette)
+	if err != nil {
+		return models.Pin{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	pin.Title = title.String
+	pin.Description = description.String
+	pin.MediaSource = mediaSource.String
+	return pin, nil
+}
+
+const getAnalyticsCmd = `
+		SELECT p.id,
+			   p.n_likes,
+			   (SELECT count(*) FROM comments WHERE pin_id = p.id),
+			   (SELECT count(*) FROM boards_pins WHERE pin_id = p.id),
+			   (SELECT count(*) FROM pin_likes WHERE pin_id = p.id AND created_at > now() - interval '7 days')
+		FROM pins p
+		WHERE p.id = $1;`
+
+func (repo *repository) GetAnalytics(id int) (pkgPins.Analytics, error) {
+	row := repo.db.QueryRow(getAnalyticsCmd, id)
+
+	var analytics pkgPins.Analytics
+	err := row.Scan(&analytics.PinId, &analytics.NumLikes, &analytics.NumComments, &analytics.NumSaves,
+		&analytics.NumLikesLastWeek)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return pkgPins.Analytics{}, errors.Wrap(pkgErrors.ErrPinNotFound, err.Error())
+		}
+		repo.log.Error(constants.DBScanError, zap.Error(err), zap.String("sql_query", getAnalyticsCmd),
+			zap.Int("id", id))
+		return pkgPins.Analytics{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return analytics, nil
+}
+
+// A pin is private when it is saved to secret boards only. Besides the author,
+// a private pin can be read by the owners of the boards it is saved to.
+const listReadablePrivateCmd = `
+		SELECT p.id
+		FROM pins p
+		WHERE p.id = ANY($1)
+		  AND EXISTS (SELECT 1
+					  FROM boards_pins bp
+						  JOIN boards b ON b.id = bp.board_id
+					  WHERE bp.pin_id = p.id AND b.privacy = 'secret')
+		  AND NOT EXISTS (SELECT 1
+						  FROM boards_pins bp
+							  JOIN boards b ON b.id = bp.board_id
+						  WHERE bp.pin_id = p.id AND b.privacy = 'public')
+		  AND (p.author_id = $2 OR EXISTS (SELECT 1
+										   FROM boards_pins bp
+											   JOIN boards b ON b.id = bp.board_id
+										   WHERE bp.pin_id = p.id AND b.user_id = $2));`
+
+func (repo *repository) SignPrivateMedia(pins []models.Pin, userId int) error {
+	if len(pins) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(pins))
+	for _, pin := range pins {
+		ids = append(ids, int64(pin.Id))
+	}
+	rows, err := repo.db.Query(listReadablePrivateCmd, pq.Array(ids), userId)
+	if err != nil {
+		repo.log.Error(constants.DBQueryError, zap.Error(err), zap.String("sql_query", listReadablePrivateCmd),
+			zap.Int("user_id", userId))
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer func() {
+		err = rows.Close()
+		if err != nil {
+			repo.log.Error(constants.FailedCloseQueryRows, zap.Error(err),
+				zap.String("sql_query", listReadablePrivateCmd))
+		}
+	}()
+
+	readable := map[int]bool{}
+	for rows.Next() {
+		var id int
+		if err = rows.Scan(&id); err != nil {
+			repo.log.Error(constants.DBScanError, zap.Error(err), zap.String("sql_query", listReadablePrivateCmd),
+				zap.Int("user_id", userId))
+			return errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		readable[id] = true
+	}
+	if len(readable) == 0 {
+		return nil
+	}
+
+	// all URLs are signed in one call, the signed ones are put back in the same order
+	var urls []string
+	for _, pin := range pins {
+		if !readable[pin.Id] {
+			continue
+		}
+		urls = append(urls, pin.MediaSource)
+		for _, name := range renditionNames(pin.Renditions) {
+			urls = append(urls, pin.Renditions[name])
+		}
+	}
+	signed, err := repo.imgServ.SignURLs(context.Background(), urls)
+	if err != nil {
+		return err
+	}
+	if len(signed) != len(urls) {
+		return pkgErrors.ErrImageService
+	}
+
+	for i := range pins {
+		if !readable[pins[i].Id] {
+			continue
+		}
+		pins[i].MediaSource, signed = signed[0], signed[1:]
+		renditions := make(map[string]string, len(pins[i].Renditions))
+		for _, name := range renditionNames(pins[i].Renditions) {
+			renditions[name], signed = signed[0], signed[1:]
+		}
+		if len(renditions) > 0 {
+			pins[i].Renditions = renditions
+		}
+	}
+	return nil
+}
+
+func renditionNames(renditions map[string]string) []string {
+	names := make([]string, 0, len(renditions))
+	for name := range renditions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Images are shared by equal uploads, so an image is private only if every pin with it is private
+// and it is not a profile image.
+const listMediaPrivacyCmd = `
+		SELECT m.media_source,
+			   m.media_renditions,
+			   NOT EXISTS (SELECT 1 FROM users u WHERE u.profile_image = m.media_source)
+				   AND NOT EXISTS (SELECT 1
+								   FROM pins p
+								   WHERE p.media_source = m.media_source
+									 AND (NOT EXISTS (SELECT 1
+													  FROM boards_pins bp
+														  JOIN boards b ON b.id = bp.board_id
+													  WHERE bp.pin_id = p.id AND b.privacy = 'secret')
+									   OR EXISTS (SELECT 1
+												  FROM boards_pins bp
+													  JOIN boards b ON b.id = bp.board_id
+												  WHERE bp.pin_id = p.id AND b.privacy = 'public'))) AS private
+		FROM (SELECT DISTINCT ON (media_source) media_source, media_renditions
+			  FROM pins
+			  WHERE id = ANY($1)) m;`
+
+func (repo *repository) SyncMediaPrivacy(pinIds []int) error {
+	if len(pinIds) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(pinIds))
+	for _, id := range pinIds {
+		ids = append(ids, int64(id))
+	}
+	rows, err := repo.db.Query(listMediaPrivacyCmd, pq.Array(ids))
+	if err != nil {
+		repo.log.Error(constants.DBQueryError, zap.Error(err), zap.String("sql_query", listMediaPrivacyCmd))
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer func() {
+		err = rows.Close()
+		if err != nil {
+			repo.log.Error(constants.FailedCloseQueryRows, zap.Error(err), zap.String("sql_query", listMediaPrivacyCmd))
+		}
+	}()
+
+	urls := map[bool][]string{}
+	for rows.Next() {
+		var mediaSource string
+		var rawRenditions []byte
+		var private bool
+		if err = rows.Scan(&mediaSource, &rawRenditions, &private); err != nil {
+			repo.log.Error(constants.DBScanError, zap.Error(err), zap.String("sql_query", listMediaPrivacyCmd))
+			return errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		renditions, err := parseRenditions(rawRenditions)
+		if err != nil {
+			return errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+
+		urls[private] = append(urls[private], mediaSource)
+		for _, name := range renditionNames(renditions) {
+			urls[private] = append(urls[private], renditions[name])
+		}
+	}
+
+	for _, private := range []bool{false, true} {
+		if len(urls[private]) == 0 {
+			continue
+		}
+		if err = repo.imgServ.SetPrivate(context.Background(), urls[private], private); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const listMediaPrivacyBackfillCmd = `
+		SELECT pin_id
+		FROM media_privacy_backfill
+		ORDER BY pin_id
+		LIMIT $1;`
+
+func (repo *repository) ListMediaPrivacyBackfill(limit int) ([]int, error) {
+	rows, err := repo.db.Query(listMediaPrivacyBackfillCmd, limit)
+	if err != nil {
+		repo.log.Error(constants.DBQueryError, zap.Error(err), zap.String("sql_query", listMediaPrivacyBackfillCmd))
+		return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer func() {
+		err = rows.Close()
+		if err != nil {
+			repo.log.Error(constants.FailedCloseQueryRows, zap.Error(err),
+				zap.String("sql_query", listMediaPrivacyBackfillCmd))
+		}
+	}()
+
+	pinIds := []int{}
+	for rows.Next() {
+		var id int
+		if err = rows.Scan(&id); err != nil {
+			repo.log.Error(constants.DBScanError, zap.Error(err), zap.String("sql_query", listMediaPrivacyBackfillCmd))
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		pinIds = append(pinIds, id)
+	}
+	return pinIds, nil
+}
+
+const deleteMediaPrivacyBackfillCmd = `
+		DELETE FROM media_privacy_backfill
+		WHERE pin_id = ANY($1);`
+
+func (repo *repository) DeleteMediaPrivacyBackfill(pinIds []int) error {
+	ids := make([]int64, 0, len(pinIds))
+	for _, id := range pinIds {
+		ids = append(ids, int64(id))
+	}
+	_, err := repo.db.Exec(deleteMediaPrivacyBackfillCmd, pq.Array(ids))
+	if err != nil {
+		repo.log.Error(constants.DBQueryError, zap.Error(err), zap.String("sql_query", deleteMediaPrivacyBackfillCmd))
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return nil
+}
+
 const checkWriteCmd = `
 		SELECT EXISTS(SELECT id
 		FROM pins
@@ -356,6 +896,29 @@ func (repo *repository) CheckWriteAccess(userId, pinId string) (bool, error) {
 	return access, err
 }
 
+// checkReadCmd checks that the pin is by the user, is not on secret boards or is on a public board
+// or a board of the user.
+const checkReadCmd = `
+		SELECT EXISTS(SELECT id
+		FROM pins p
+		WHERE p.id = $1
+		  AND (p.author_id = $2
+			   OR NOT EXISTS (SELECT 1
+							  FROM boards_pins bp
+								  JOIN boards b ON b.id = bp.board_id
+							  WHERE bp.pin_id = p.id AND b.privacy = 'secret')
+			   OR EXISTS (SELECT 1
+						  FROM boards_pins bp
+							  JOIN boards b ON b.id = bp.board_id
+						  WHERE bp.pin_id = p.id AND (b.privacy = 'public' OR b.user_id = $2))));`
+
 func (repo *repository) CheckReadAccess(userId, pinId string) (bool, error) {
-	return true, nil
+	row := repo.db.QueryRow(checkReadCmd, pinId, userId)
+
+	var access bool
+	err := row.Scan(&access)
+	if err != nil {
+		return false, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return access, err
 }
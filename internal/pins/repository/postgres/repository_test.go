@@ -2,20 +2,24 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"go.uber.org/zap"
 	"log"
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/client/mocks"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	_pins "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pins"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 )
 
@@ -45,28 +49,39 @@ func TestCreate(t *testing.T) {
 	tests := map[string]testCase{
 		"good query": {
 			prepare: func(f *fields) {
-				f.s3mock.EXPECT().UploadImage(context.Background(), &models.Image{}).Return("ms_url", nil)
+				f.s3mock.EXPECT().UploadRenditions(context.Background(), &models.Image{}).
+					Return(models.ImageRenditions{URLs: map[string]string{"original": "ms_url", "236w": "ms_url_236w"},
+						Hash: 0xF0F0F0F0F0F0F0F0, MediaType: models.MediaTypeVideo, Width: 640, Height: 360,
+						Duration: 4500 * time.Millisecond, Color: "rgb(10, 20, 30)",
+						Palette: []string{"rgb(10, 20, 30)", "rgb(200, 210, 220)"}, BlurHash: "LEHV6nWB2yk8"}, nil)
 
-				rows := sqlmock.NewRows([]string{"id", "title", "media_source", "media_source_color", "description",
-					"author_id"})
-				rows = rows.AddRow(1, "t1", "ms_url", "rgb(39, 102, 120)", "d1", 12)
+				rows := sqlmock.NewRows([]string{"id", "title", "media_source", "media_source_color",
+					"media_renditions", "media_palette", "media_blurhash", "media_type", "media_width", "media_height",
+					"media_duration_ms", "description", "author_id"})
+				rows = rows.AddRow(1, "t1", "ms_url", "rgb(10, 20, 30)", `{"236w":"ms_url_236w"}`,
+					`["rgb(10, 20, 30)","rgb(200, 210, 220)"]`, "LEHV6nWB2yk8", "video", 640, 360, 4500, "d1", 12)
 				f.mock.
 					ExpectQuery(regexp.QuoteMeta(createCmd)).
-					WithArgs("t1", "ms_url", "rgb(39, 102, 120)", "d1", 12).
+					WithArgs("t1", "ms_url", "rgb(10, 20, 30)", []byte(`{"236w":"ms_url_236w"}`),
+						[]byte(`["rgb(10, 20, 30)","rgb(200, 210, 220)"]`), "LEHV6nWB2yk8", "video", 640, 360, int64(4500), sql.NullInt64{Int64: -0x0F0F0F0F0F0F0F10, Valid: true}, "d1", 12).
 					WillReturnRows(rows)
 			},
 			params: _pins.CreateParams{Title: "t1", MediaSource: models.Image{}, Description: "d1", Author: 12},
-			pin: models.Pin{Id: 1, Title: "t1", MediaSource: "ms_url", MediaSourceColor: "rgb(39, 102, 120)",
-				Description: "d1", Author: 12},
+			pin: models.Pin{Id: 1, Title: "t1", MediaSource: "ms_url", MediaSourceColor: "rgb(10, 20, 30)",
+				Renditions: map[string]string{"236w": "ms_url_236w"}, Palette: []string{"rgb(10, 20, 30)", "rgb(200, 210, 220)"},
+				BlurHash: "LEHV6nWB2yk8", MediaType: models.MediaTypeVideo, MediaWidth: 640, MediaHeight: 360,
+				MediaDurationMs: 4500, Description: "d1", Author: 12},
 			err: nil,
 		},
 		"query error": {
 			prepare: func(f *fields) {
-				f.s3mock.EXPECT().UploadImage(context.Background(), &models.Image{}).Return("ms_url", nil)
+				f.s3mock.EXPECT().UploadRenditions(context.Background(), &models.Image{}).
+					Return(models.ImageRenditions{URLs: map[string]string{"original": "ms_url"}}, nil)
 
 				f.mock.
 					ExpectQuery(regexp.QuoteMeta(createCmd)).
-					WithArgs("t1", "ms_url", "rgb(39, 102, 120)", "d1", 12).
+					WithArgs("t1", "ms_url", "rgb(39, 102, 120)", []byte(`{}`), []byte(`[]`), "", "image", 0, 0,
+						int64(0), sql.NullInt64{}, "d1", 12).
 					WillReturnError(fmt.Errorf("sql error"))
 			},
 			params: _pins.CreateParams{
@@ -106,7 +121,7 @@ func TestCreate(t *testing.T) {
 			if !errors.Is(err, test.err) {
 				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
 			}
-			if pin != test.pin {
+			if !reflect.DeepEqual(pin, test.pin) {
 				t.Errorf("\nExpected: %v\nGot: %v", test.pin, pin)
 			}
 			if err = sqlMock.ExpectationsWereMet(); err != nil {
@@ -133,10 +148,12 @@ func TestList(t *testing.T) {
 		"good query": {
 			prepare: func(f *fields) {
 				rows := sqlmock.NewRows([]string{"id", "title", "description", "media_source", "media_source_color",
-					"n_likes", "liked", "author_id"})
-				rows = rows.AddRow(1, "t1", "d1", "ms_url1", "rgb(39, 102, 120)", 0, false, 12)
-				rows = rows.AddRow(2, "t2", "d2", "ms_url2", "rgb(39, 102, 120)", 2, false, 3)
-				rows = rows.AddRow(3, "t3", "d3", "ms_url3", "rgb(39, 102, 120)", 3, false, 10)
+					"media_renditions", "media_palette", "media_blurhash", "media_type", "media_width", "media_height",
+					"media_duration_ms", "n_likes", "liked", "author_id"})
+				rows = rows.AddRow(1, "t1", "d1", "ms_url1", "rgb(39, 102, 120)", `{"236w":"ms_url1_236w"}`,
+					`["rgb(200, 30, 30)", "rgb(20, 20, 220)"]`, "LEHV6nWB2yk8", "image", 0, 0, 0, 0, false, 12)
+				rows = rows.AddRow(2, "t2", "d2", "ms_url2", "rgb(39, 102, 120)", `{}`, `[]`, "", "image", 0, 0, 0, 2, false, 3)
+				rows = rows.AddRow(3, "t3", "d3", "ms_url3", "rgb(39, 102, 120)", `{}`, `[]`, "", "image", 0, 0, 0, 3, false, 10)
 				f.mock.
 					ExpectQuery(regexp.QuoteMeta(listCmd)).
 					WithArgs(30, 0).
@@ -146,11 +163,13 @@ func TestList(t *testing.T) {
 			limit: 30,
 			pins: []models.Pin{
 				{Id: 1, Title: "t1", MediaSource: "ms_url1", MediaSourceColor: "rgb(39, 102, 120)", Description: "d1",
-					NumLikes: 0, Author: 12},
+					Renditions: map[string]string{"236w": "ms_url1_236w"},
+					Palette:    []string{"rgb(200, 30, 30)", "rgb(20, 20, 220)"}, BlurHash: "LEHV6nWB2yk8",
+					MediaType: models.MediaTypeImage, NumLikes: 0, Author: 12},
 				{Id: 2, Title: "t2", MediaSource: "ms_url2", MediaSourceColor: "rgb(39, 102, 120)", Description: "d2",
-					NumLikes: 2, Author: 3},
+					MediaType: models.MediaTypeImage, NumLikes: 2, Author: 3},
 				{Id: 3, Title: "t3", MediaSource: "ms_url3", MediaSourceColor: "rgb(39, 102, 120)", Description: "d3",
-					NumLikes: 3, Author: 10},
+					MediaType: models.MediaTypeImage, NumLikes: 3, Author: 10},
 			},
 			err: nil,
 		},
@@ -235,10 +254,11 @@ func TestListByUser(t *testing.T) {
 		"good query": {
 			prepare: func(f *fields) {
 				rows := sqlmock.NewRows([]string{"id", "title", "description", "media_source", "media_source_color",
-					"n_likes", "author_id"})
-				rows = rows.AddRow(1, "t1", "d1", "ms_url1", "rgb(39, 102, 120)", 0, 12)
-				rows = rows.AddRow(2, "t2", "d2", "ms_url2", "rgb(39, 102, 120)", 2, 12)
-				rows = rows.AddRow(3, "t3", "d3", "ms_url3", "rgb(39, 102, 120)", 3, 12)
+					"media_renditions", "media_palette", "media_blurhash", "media_type", "media_width", "media_height",
+					"media_duration_ms", "n_likes", "author_id"})
+				rows = rows.AddRow(1, "t1", "d1", "ms_url1", "rgb(39, 102, 120)", `{}`, `[]`, "", "image", 0, 0, 0, 0, 12)
+				rows = rows.AddRow(2, "t2", "d2", "ms_url2", "rgb(39, 102, 120)", `{}`, `[]`, "", "image", 0, 0, 0, 2, 12)
+				rows = rows.AddRow(3, "t3", "d3", "ms_url3", "rgb(39, 102, 120)", `{}`, `[]`, "", "image", 0, 0, 0, 3, 12)
 				f.mock.
 					ExpectQuery(regexp.QuoteMeta(listByUserCmd)).
 					WithArgs(12, 30, 0).
@@ -249,11 +269,11 @@ func TestListByUser(t *testing.T) {
 			limit:  30,
 			pins: []models.Pin{
 				{Id: 1, Title: "t1", MediaSource: "ms_url1", MediaSourceColor: "rgb(39, 102, 120)", Description: "d1",
-					NumLikes: 0, Author: 12},
+					MediaType: models.MediaTypeImage, NumLikes: 0, Author: 12},
 				{Id: 2, Title: "t2", MediaSource: "ms_url2", MediaSourceColor: "rgb(39, 102, 120)", Description: "d2",
-					NumLikes: 2, Author: 12},
+					MediaType: models.MediaTypeImage, NumLikes: 2, Author: 12},
 				{Id: 3, Title: "t3", MediaSource: "ms_url3", MediaSourceColor: "rgb(39, 102, 120)", Description: "d3",
-					NumLikes: 3, Author: 12},
+					MediaType: models.MediaTypeImage, NumLikes: 3, Author: 12},
 			},
 			err: nil,
 		},
@@ -322,6 +342,157 @@ func TestListByUser(t *testing.T) {
 	}
 }
 
+func TestListDuplicates(t *testing.T) {
+	type fields struct {
+		mock sqlmock.Sqlmock
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		id      int
+		userId  int
+		page    int
+		limit   int
+		pins    []models.Pin
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"good query": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "media_source", "media_source_color",
+					"media_renditions", "media_palette", "media_blurhash", "media_type", "media_width", "media_height",
+					"media_duration_ms", "n_likes", "liked", "author_id"})
+				rows = rows.AddRow(5, "t5", "d5", "ms_url5", "rgb(39, 102, 120)", `{}`, `[]`, "", "image", 0, 0, 0, 1, false, 12)
+				rows = rows.AddRow(7, "t7", "d7", "ms_url7", "rgb(39, 102, 120)", `{}`, `[]`, "", "image", 0, 0, 0, 4, false, 15)
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listDuplicatesCmd)).
+					WithArgs(3, constants.DuplicateImageMaxDistance, 12, 30, 0,
+						constants.DuplicateScanLimit).
+					WillReturnRows(rows)
+			},
+			id:     3,
+			userId: 12,
+			page:   1,
+			limit:  30,
+			pins: []models.Pin{
+				{Id: 5, Title: "t5", MediaSource: "ms_url5", MediaSourceColor: "rgb(39, 102, 120)", Description: "d5",
+					MediaType: models.MediaTypeImage, NumLikes: 1, Author: 12},
+				{Id: 7, Title: "t7", MediaSource: "ms_url7", MediaSourceColor: "rgb(39, 102, 120)", Description: "d7",
+					MediaType: models.MediaTypeImage, NumLikes: 4, Author: 15},
+			},
+			err: nil,
+		},
+		"second page": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "media_source", "media_source_color",
+					"media_renditions", "media_palette", "media_blurhash", "media_type", "media_width", "media_height",
+					"media_duration_ms", "n_likes", "liked", "author_id"})
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listDuplicatesCmd)).
+					WithArgs(3, constants.DuplicateImageMaxDistance, 12, 10, 10,
+						constants.DuplicateScanLimit).
+					WillReturnRows(rows)
+			},
+			id:     3,
+			userId: 12,
+			page:   2,
+			limit:  10,
+			pins:   []models.Pin{},
+			err:    nil,
+		},
+		"another viewer": {
+			// the viewer is passed to the query, so only the pins they can read are listed
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "media_source", "media_source_color",
+					"media_renditions", "media_palette", "media_blurhash", "media_type", "media_width", "media_height",
+					"media_duration_ms", "n_likes", "liked", "author_id"})
+				rows = rows.AddRow(7, "t7", "d7", "ms_url7", "rgb(39, 102, 120)", `{}`, `[]`, "", "image", 0, 0, 0, 4, false, 15)
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listDuplicatesCmd)).
+					WithArgs(3, constants.DuplicateImageMaxDistance, 15, 30, 0,
+						constants.DuplicateScanLimit).
+					WillReturnRows(rows)
+			},
+			id:     3,
+			userId: 15,
+			page:   1,
+			limit:  30,
+			pins: []models.Pin{
+				{Id: 7, Title: "t7", MediaSource: "ms_url7", MediaSourceColor: "rgb(39, 102, 120)", Description: "d7",
+					MediaType: models.MediaTypeImage, NumLikes: 4, Author: 15},
+			},
+			err: nil,
+		},
+		"query error": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listDuplicatesCmd)).
+					WithArgs(3, constants.DuplicateImageMaxDistance, 12, 30, 0,
+						constants.DuplicateScanLimit).
+					WillReturnError(fmt.Errorf("sql error"))
+			},
+			id:     3,
+			userId: 12,
+			page:   1,
+			limit:  30,
+			pins:   nil,
+			err:    pkgErrors.ErrDb,
+		},
+		"row scan error": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"id", "title"}).AddRow(5, "t5")
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listDuplicatesCmd)).
+					WithArgs(3, constants.DuplicateImageMaxDistance, 12, 30, 0,
+						constants.DuplicateScanLimit).
+					WillReturnRows(rows)
+			},
+			id:     3,
+			userId: 12,
+			page:   1,
+			limit:  30,
+			pins:   nil,
+			err:    pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			s3Serv := mocks.NewMockImageClient(ctrl)
+
+			repo := NewRepository(db, s3Serv, logger)
+
+			f := fields{mock: sqlMock}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			pins, err := repo.ListDuplicates(test.id, test.userId, test.page, test.limit)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(pins, test.pins) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.pins, pins)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func TestGet(t *testing.T) {
 	type fields struct {
 		mock sqlmock.Sqlmock
@@ -338,8 +509,9 @@ func TestGet(t *testing.T) {
 		"good query": {
 			prepare: func(f *fields) {
 				rows := sqlmock.NewRows([]string{"id", "title", "description", "media_source", "media_source_color",
-					"n_likes", "author_id"})
-				rows = rows.AddRow(3, "t1", "d1", "ms_url1", "rgb(39, 102, 120)", 3, 12)
+					"media_renditions", "media_palette", "media_blurhash", "media_type", "media_width", "media_height",
+					"media_duration_ms", "n_likes", "promoted", "author_id"})
+				rows = rows.AddRow(3, "t1", "d1", "ms_url1", "rgb(39, 102, 120)", `{}`, `[]`, "", "image", 0, 0, 0, 3, true, 12)
 				f.mock.
 					ExpectQuery(regexp.QuoteMeta(getCmd)).
 					WithArgs(3).
@@ -347,7 +519,7 @@ func TestGet(t *testing.T) {
 			},
 			id: 3,
 			pin: models.Pin{Id: 3, Title: "t1", MediaSource: "ms_url1", MediaSourceColor: "rgb(39, 102, 120)",
-				Description: "d1", NumLikes: 3, Author: 12},
+				Description: "d1", MediaType: models.MediaTypeImage, NumLikes: 3, Promoted: true, Author: 12},
 			err: nil,
 		},
 		"query error": {
@@ -410,3 +582,506 @@ func TestGet(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckReadAccess(t *testing.T) {
+	type fields struct {
+		mock sqlmock.Sqlmock
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		access  bool
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"readable": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"exists"}).AddRow(true)
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(checkReadCmd)).
+					WithArgs("3", "12").
+					WillReturnRows(rows)
+			},
+			access: true,
+			err:    nil,
+		},
+		"not readable": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"exists"}).AddRow(false)
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(checkReadCmd)).
+					WithArgs("3", "12").
+					WillReturnRows(rows)
+			},
+			access: false,
+			err:    nil,
+		},
+		"query error": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(checkReadCmd)).
+					WithArgs("3", "12").
+					WillReturnError(fmt.Errorf("sql error"))
+			},
+			access: false,
+			err:    pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			s3Serv := mocks.NewMockImageClient(ctrl)
+
+			repo := NewRepository(db, s3Serv, logger)
+
+			f := fields{mock: sqlMock}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			access, err := repo.CheckReadAccess("12", "3")
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if access != test.access {
+				t.Errorf("\nExpected: %t\nGot: %t", test.access, access)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestSignPrivateMedia(t *testing.T) {
+	type fields struct {
+		mock   sqlmock.Sqlmock
+		s3mock *mocks.MockImageClient
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		pins    []models.Pin
+		userId  int
+		signed  []models.Pin
+		err     error
+	}
+
+	pins := []models.Pin{
+		{Id: 3, MediaSource: "ms_url3", Renditions: map[string]string{"236w": "ms_url3_236w", "474w": "ms_url3_474w"}},
+		{Id: 5, MediaSource: "ms_url5"},
+		{Id: 7, MediaSource: "ms_url7"},
+	}
+
+	tests := map[string]testCase{
+		"readable private pins": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(3).AddRow(7)
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listReadablePrivateCmd)).
+					WithArgs(pq.Array([]int64{3, 5, 7}), 12).
+					WillReturnRows(rows)
+				f.s3mock.EXPECT().
+					SignURLs(context.Background(), []string{"ms_url3", "ms_url3_236w", "ms_url3_474w", "ms_url7"}).
+					Return([]string{"ms_url3?sig", "ms_url3_236w?sig", "ms_url3_474w?sig", "ms_url7?sig"}, nil)
+			},
+			pins:   pins,
+			userId: 12,
+			signed: []models.Pin{
+				{Id: 3, MediaSource: "ms_url3?sig",
+					Renditions: map[string]string{"236w": "ms_url3_236w?sig", "474w": "ms_url3_474w?sig"}},
+				{Id: 5, MediaSource: "ms_url5"},
+				{Id: 7, MediaSource: "ms_url7?sig"},
+			},
+			err: nil,
+		},
+		"no private pins": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listReadablePrivateCmd)).
+					WithArgs(pq.Array([]int64{3, 5, 7}), 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			pins:   pins,
+			userId: 0,
+			signed: pins,
+			err:    nil,
+		},
+		"no pins": {
+			prepare: func(f *fields) {},
+			pins:    nil,
+			userId:  12,
+			signed:  nil,
+			err:     nil,
+		},
+		"query error": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listReadablePrivateCmd)).
+					WithArgs(pq.Array([]int64{3, 5, 7}), 12).
+					WillReturnError(fmt.Errorf("sql error"))
+			},
+			pins:   pins,
+			userId: 12,
+			signed: pins,
+			err:    pkgErrors.ErrDb,
+		},
+		"row scan error": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listReadablePrivateCmd)).
+					WithArgs(pq.Array([]int64{3, 5, 7}), 12).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("a"))
+			},
+			pins:   pins,
+			userId: 12,
+			signed: pins,
+			err:    pkgErrors.ErrDb,
+		},
+		"images service error": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listReadablePrivateCmd)).
+					WithArgs(pq.Array([]int64{3, 5, 7}), 12).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				f.s3mock.EXPECT().SignURLs(context.Background(), []string{"ms_url7"}).
+					Return(nil, pkgErrors.ErrImageService)
+			},
+			pins:   pins,
+			userId: 12,
+			signed: pins,
+			err:    pkgErrors.ErrImageService,
+		},
+		"urls missing from the response": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listReadablePrivateCmd)).
+					WithArgs(pq.Array([]int64{3, 5, 7}), 12).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				f.s3mock.EXPECT().
+					SignURLs(context.Background(), []string{"ms_url3", "ms_url3_236w", "ms_url3_474w"}).
+					Return([]string{"ms_url3?sig"}, nil)
+			},
+			pins:   pins,
+			userId: 12,
+			signed: pins,
+			err:    pkgErrors.ErrImageService,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			s3Serv := mocks.NewMockImageClient(ctrl)
+
+			repo := NewRepository(db, s3Serv, logger)
+
+			f := fields{mock: sqlMock, s3mock: s3Serv}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			pins := make([]models.Pin, len(test.pins))
+			copy(pins, test.pins)
+			err = repo.SignPrivateMedia(pins, test.userId)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if len(test.pins) > 0 && !reflect.DeepEqual(pins, test.signed) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.signed, pins)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestSyncMediaPrivacy(t *testing.T) {
+	type fields struct {
+		mock   sqlmock.Sqlmock
+		s3mock *mocks.MockImageClient
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		pinIds  []int
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"private and public media": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"media_source", "media_renditions", "private"}).
+					AddRow("ms_url3", `{"474w":"ms_url3_474w","236w":"ms_url3_236w"}`, true).
+					AddRow("ms_url5", `{}`, false).
+					AddRow("ms_url7", `{}`, true)
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listMediaPrivacyCmd)).
+					WithArgs(pq.Array([]int64{3, 5, 7})).
+					WillReturnRows(rows)
+				gomock.InOrder(
+					f.s3mock.EXPECT().SetPrivate(context.Background(), []string{"ms_url5"}, false).Return(nil),
+					f.s3mock.EXPECT().
+						SetPrivate(context.Background(), []string{"ms_url3", "ms_url3_236w", "ms_url3_474w", "ms_url7"}, true).
+						Return(nil),
+				)
+			},
+			pinIds: []int{3, 5, 7},
+			err:    nil,
+		},
+		"public media only": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"media_source", "media_renditions", "private"}).
+					AddRow("ms_url5", `{}`, false)
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listMediaPrivacyCmd)).
+					WithArgs(pq.Array([]int64{5})).
+					WillReturnRows(rows)
+				f.s3mock.EXPECT().SetPrivate(context.Background(), []string{"ms_url5"}, false).Return(nil)
+			},
+			pinIds: []int{5},
+			err:    nil,
+		},
+		"no pins": {
+			prepare: func(f *fields) {},
+			pinIds:  nil,
+			err:     nil,
+		},
+		"query error": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listMediaPrivacyCmd)).
+					WithArgs(pq.Array([]int64{3})).
+					WillReturnError(fmt.Errorf("sql error"))
+			},
+			pinIds: []int{3},
+			err:    pkgErrors.ErrDb,
+		},
+		"invalid renditions": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"media_source", "media_renditions", "private"}).
+					AddRow("ms_url3", `[`, true)
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listMediaPrivacyCmd)).
+					WithArgs(pq.Array([]int64{3})).
+					WillReturnRows(rows)
+			},
+			pinIds: []int{3},
+			err:    pkgErrors.ErrDb,
+		},
+		"images service error": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"media_source", "media_renditions", "private"}).
+					AddRow("ms_url3", `{}`, true)
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listMediaPrivacyCmd)).
+					WithArgs(pq.Array([]int64{3})).
+					WillReturnRows(rows)
+				f.s3mock.EXPECT().SetPrivate(context.Background(), []string{"ms_url3"}, true).
+					Return(pkgErrors.ErrImageService)
+			},
+			pinIds: []int{3},
+			err:    pkgErrors.ErrImageService,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			s3Serv := mocks.NewMockImageClient(ctrl)
+
+			repo := NewRepository(db, s3Serv, logger)
+
+			f := fields{mock: sqlMock, s3mock: s3Serv}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			err = repo.SyncMediaPrivacy(test.pinIds)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestListMediaPrivacyBackfill(t *testing.T) {
+	type fields struct {
+		mock sqlmock.Sqlmock
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		pinIds  []int
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"good query": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"pin_id"}).AddRow(3).AddRow(5)
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listMediaPrivacyBackfillCmd)).
+					WithArgs(100).
+					WillReturnRows(rows)
+			},
+			pinIds: []int{3, 5},
+			err:    nil,
+		},
+		"empty list": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listMediaPrivacyBackfillCmd)).
+					WithArgs(100).
+					WillReturnRows(sqlmock.NewRows([]string{"pin_id"}))
+			},
+			pinIds: []int{},
+			err:    nil,
+		},
+		"query error": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(listMediaPrivacyBackfillCmd)).
+					WithArgs(100).
+					WillReturnError(fmt.Errorf("sql error"))
+			},
+			pinIds: nil,
+			err:    pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			s3Serv := mocks.NewMockImageClient(ctrl)
+
+			repo := NewRepository(db, s3Serv, logger)
+
+			f := fields{mock: sqlMock}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			pinIds, err := repo.ListMediaPrivacyBackfill(100)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(pinIds, test.pinIds) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.pinIds, pinIds)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDeleteMediaPrivacyBackfill(t *testing.T) {
+	type fields struct {
+		mock sqlmock.Sqlmock
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"good query": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectExec(regexp.QuoteMeta(deleteMediaPrivacyBackfillCmd)).
+					WithArgs(pq.Array([]int64{3, 5})).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			err: nil,
+		},
+		"query error": {
+			prepare: func(f *fields) {
+				f.mock.
+					ExpectExec(regexp.QuoteMeta(deleteMediaPrivacyBackfillCmd)).
+					WithArgs(pq.Array([]int64{3, 5})).
+					WillReturnError(fmt.Errorf("sql error"))
+			},
+			err: pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			s3Serv := mocks.NewMockImageClient(ctrl)
+
+			repo := NewRepository(db, s3Serv, logger)
+
+			f := fields{mock: sqlMock}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			err = repo.DeleteMediaPrivacyBackfill([]int{3, 5})
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -9,10 +9,21 @@ type Service interface {
 	Get(id, userId int) (models.Pin, error)
 	ListByAuthor(authorId, userId, page, limit int) ([]models.Pin, error)
 	List(authorized bool, userId int, liked bool, page, limit int) ([]models.Pin, error)
+	// ListDuplicates returns pins whose images are perceptually similar to the image of the pin.
+	ListDuplicates(id, userId, page, limit int) ([]models.Pin, error)
 	FullUpdate(params *FullUpdateParams) (models.Pin, error)
 	Delete(id int) error
 
 	SetLikedField(pin *models.Pin, userId int) error
+	SignPrivateMedia(pins []models.Pin, userId int) error
+	SyncMediaPrivacy(pinIds []int) error
+	// BackfillMediaPrivacy syncs the image privacy of the pins saved to secret boards before images could be private
+	// and returns the number of synced pins.
+	BackfillMediaPrivacy() (int, error)
+
+	// SetPromoted and GetAnalytics are available to business accounts only.
+	SetPromoted(id, userId int, promoted bool) (models.Pin, error)
+	GetAnalytics(id, userId int) (Analytics, error)
 
 	CheckWriteAccess(userId, pinId string) (bool, error)
 	CheckReadAccess(userId, pinId string) (bool, error)
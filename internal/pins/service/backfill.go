@@ -0,0 +1,20 @@
+package service
+
+import (
+	"go.uber.org/zap"
+
+	pkgPins "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pins"
+)
+
+// StartMediaPrivacyBackfill syncs in the background the image privacy of the pins saved to secret boards
+// before images could be private. The pins left after a failure are synced on the next start.
+func StartMediaPrivacyBackfill(serv pkgPins.Service, log *zap.Logger) {
+	go func() {
+		synced, err := serv.BackfillMediaPrivacy()
+		if err != nil {
+			log.Error("Failed to backfill pin image privacy", zap.Error(err), zap.Int("synced", synced))
+		} else if synced > 0 {
+			log.Info("Backfilled pin image privacy", zap.Int("synced", synced))
+		}
+	}()
+}
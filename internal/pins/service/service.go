@@ -1,6 +1,8 @@
 package service
 
 import (
+	"strconv"
+
 	pkgFollowings "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/followings"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/notifications"
@@ -56,7 +58,13 @@ func (serv *service) Get(id, userId int) (models.Pin, error) {
 		return models.Pin{}, err
 	}
 
-	return pin, nil
+	pins := []models.Pin{pin}
+	err = serv.rep.SignPrivateMedia(pins, userId)
+	if err != nil {
+		return models.Pin{}, err
+	}
+
+	return pins[0], nil
 }
 
 func (serv *service) ListByAuthor(authorId, userId, page, limit int) ([]models.Pin, error) {
@@ -72,17 +80,64 @@ func (serv *service) ListByAuthor(authorId, userId, page, limit int) ([]models.P
 		}
 	}
 
+	err = serv.rep.SignPrivateMedia(pins, userId)
+	if err != nil {
+		return []models.Pin{}, err
+	}
+
 	return pins, nil
 }
 
 func (serv *service) List(authorized bool, userID int, liked bool, page, limit int) ([]models.Pin, error) {
-	if authorized {
-		if liked {
-			return serv.rep.ListLiked(userID, page, limit)
+	if !authorized {
+		return serv.rep.List(page, limit)
+	}
+
+	var pins []models.Pin
+	var err error
+	if liked {
+		pins, err = serv.rep.ListLiked(userID, page, limit)
+	} else {
+		pins, err = serv.rep.ListWithLikedField(userID, page, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = serv.rep.SignPrivateMedia(pins, userID)
+	if err != nil {
+		return nil, err
+	}
+	return pins, nil
+}
+
+func (serv *service) ListDuplicates(id, userId, page, limit int) ([]models.Pin, error) {
+	// the pins the user can't read are not found, their duplicates would show their images
+	access, err := serv.rep.CheckReadAccess(strconv.Itoa(userId), strconv.Itoa(id))
+	if err != nil {
+		return []models.Pin{}, err
+	} else if !access {
+		return []models.Pin{}, pkgErrors.ErrPinNotFound
+	}
+
+	pins, err := serv.rep.ListDuplicates(id, userId, page, limit)
+	if err != nil {
+		return []models.Pin{}, err
+	}
+
+	for i := range pins {
+		err = serv.SetLikedField(&pins[i], userId)
+		if err != nil {
+			return []models.Pin{}, err
 		}
-		return serv.rep.ListWithLikedField(userID, page, limit)
 	}
-	return serv.rep.List(page, limit)
+
+	err = serv.rep.SignPrivateMedia(pins, userId)
+	if err != nil {
+		return []models.Pin{}, err
+	}
+
+	return pins, nil
 }
 
 func (serv *service) FullUpdate(params *pkgPins.FullUpdateParams) (models.Pin, error) {
@@ -116,6 +171,65 @@ func (serv *service) SetLikedField(pin *models.Pin, userId int) error {
 	return nil
 }
 
+func (serv *service) SignPrivateMedia(pins []models.Pin, userId int) error {
+	return serv.rep.SignPrivateMedia(pins, userId)
+}
+
+func (serv *service) SyncMediaPrivacy(pinIds []int) error {
+	return serv.rep.SyncMediaPrivacy(pinIds)
+}
+
+func (serv *service) BackfillMediaPrivacy() (int, error) {
+	synced := 0
+	for {
+		pinIds, err := serv.rep.ListMediaPrivacyBackfill(constants.MediaPrivacyBackfillBatch)
+		if err != nil || len(pinIds) == 0 {
+			return synced, err
+		}
+		if err = serv.rep.SyncMediaPrivacy(pinIds); err != nil {
+			return synced, err
+		}
+		if err = serv.rep.DeleteMediaPrivacyBackfill(pinIds); err != nil {
+			return synced, err
+		}
+		synced += len(pinIds)
+	}
+}
+
+func (serv *service) SetPromoted(id, userId int, promoted bool) (models.Pin, error) {
+	accountType, err := serv.rep.GetAccountType(userId)
+	if err != nil {
+		return models.Pin{}, err
+	}
+	if !models.CanPromotePins(accountType) {
+		return models.Pin{}, pkgErrors.ErrNotBusinessAccount
+	}
+
+	pin, err := serv.rep.SetPromoted(id, promoted)
+	if err != nil {
+		return models.Pin{}, err
+	}
+
+	pins := []models.Pin{pin}
+	err = serv.rep.SignPrivateMedia(pins, userId)
+	if err != nil {
+		return models.Pin{}, err
+	}
+	return pins[0], nil
+}
+
+func (serv *service) GetAnalytics(id, userId int) (pkgPins.Analytics, error) {
+	accountType, err := serv.rep.GetAccountType(userId)
+	if err != nil {
+		return pkgPins.Analytics{}, err
+	}
+	if !models.CanViewPinAnalytics(accountType) {
+		return pkgPins.Analytics{}, pkgErrors.ErrNotBusinessAccount
+	}
+
+	return serv.rep.GetAnalytics(id)
+}
+
 func validateTitle(title string) error {
 	if len(title) > constants.MaxPinTitleLen {
 		return pkgErrors.ErrTooLongPinTitle
@@ -13,6 +13,7 @@ import (
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	pkgPins "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pins"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pins/mocks"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 )
 
@@ -81,7 +82,7 @@ func TestCreate(t *testing.T) {
 			if !errors.Is(err, test.err) {
 				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
 			}
-			if pin != test.pin {
+			if !reflect.DeepEqual(pin, test.pin) {
 				t.Errorf("\nExpected: %v\nGot: %v", test.pin, pin)
 			}
 		})
@@ -195,6 +196,7 @@ func TestListByAuthor(t *testing.T) {
 					f.repo.EXPECT().IsLikedByUser(1, 5).Return(true, nil),
 					f.repo.EXPECT().IsLikedByUser(2, 5).Return(false, nil),
 					f.repo.EXPECT().IsLikedByUser(3, 5).Return(true, nil),
+					f.repo.EXPECT().SignPrivateMedia(gomock.Any(), 5).Return(nil),
 				)
 			},
 			page:     1,
@@ -210,7 +212,9 @@ func TestListByAuthor(t *testing.T) {
 		},
 		"no pins": {
 			prepare: func(f *fields) {
-				f.repo.EXPECT().ListByAuthor(12, 1, 30).Return([]models.Pin{}, nil)
+				gomock.InOrder(
+					f.repo.EXPECT().ListByAuthor(12, 1, 30).Return([]models.Pin{}, nil),
+					f.repo.EXPECT().SignPrivateMedia([]models.Pin{}, 5).Return(nil))
 			},
 			userId:   5,
 			authorId: 12,
@@ -277,12 +281,17 @@ func TestGet(t *testing.T) {
 						Author:      12,
 					}, nil),
 					f.repo.EXPECT().IsLikedByUser(3, 12).Return(true, nil),
+					f.repo.EXPECT().SignPrivateMedia(gomock.Any(), 12).DoAndReturn(func(pins []models.Pin, userId int) error {
+						pins[0].MediaSource = "ms_url1?signature=s1"
+						return nil
+					}),
 				)
 			},
 			id:     3,
 			userId: 12,
-			pin:    models.Pin{Id: 3, Title: "t1", MediaSource: "ms_url1", Description: "d1", Liked: true, Author: 12},
-			err:    nil,
+			pin: models.Pin{Id: 3, Title: "t1", MediaSource: "ms_url1?signature=s1", Description: "d1", Liked: true,
+				Author: 12},
+			err: nil,
 		},
 		"pin not found": {
 			prepare: func(f *fields) {
@@ -326,7 +335,7 @@ func TestGet(t *testing.T) {
 			if !errors.Is(err, test.err) {
 				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
 			}
-			if pin != test.pin {
+			if !reflect.DeepEqual(pin, test.pin) {
 				t.Errorf("\nExpected: %v\nGot: %v", test.pin, pin)
 			}
 		})
@@ -518,3 +527,324 @@ func TestCheckReadAccess(t *testing.T) {
 		})
 	}
 }
+
+func TestSetPromoted(t *testing.T) {
+	type fields struct {
+		repo              *mocks.MockRepository
+		notificationsServ *notificationsMocks.MockService
+		followingsRepo    *followingsMocks.MockRepository
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		pin     models.Pin
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"business account": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().GetAccountType(12).Return("business", nil),
+					f.repo.EXPECT().SetPromoted(3, true).Return(models.Pin{Id: 3, Promoted: true, Author: 12}, nil),
+					f.repo.EXPECT().SignPrivateMedia([]models.Pin{{Id: 3, Promoted: true, Author: 12}}, 12).Return(nil))
+			},
+			pin: models.Pin{Id: 3, Promoted: true, Author: 12},
+			err: nil,
+		},
+		"personal account": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().GetAccountType(12).Return("personal", nil)
+			},
+			pin: models.Pin{},
+			err: pkgErrors.ErrNotBusinessAccount,
+		},
+		"repository error": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().GetAccountType(12).Return("", pkgErrors.ErrDb)
+			},
+			pin: models.Pin{},
+			err: pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				repo:              mocks.NewMockRepository(ctrl),
+				notificationsServ: notificationsMocks.NewMockService(ctrl),
+				followingsRepo:    followingsMocks.NewMockRepository(ctrl),
+			}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			serv := NewService(f.repo, f.notificationsServ, f.followingsRepo)
+			pin, err := serv.SetPromoted(3, 12, true)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(pin, test.pin) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.pin, pin)
+			}
+		})
+	}
+}
+
+func TestGetAnalytics(t *testing.T) {
+	type fields struct {
+		repo              *mocks.MockRepository
+		notificationsServ *notificationsMocks.MockService
+		followingsRepo    *followingsMocks.MockRepository
+	}
+
+	type testCase struct {
+		prepare   func(f *fields)
+		analytics pkgPins.Analytics
+		err       error
+	}
+
+	analytics := pkgPins.Analytics{PinId: 3, NumLikes: 10, NumComments: 2, NumSaves: 4, NumLikesLastWeek: 1}
+
+	tests := map[string]testCase{
+		"business account": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().GetAccountType(12).Return("business", nil),
+					f.repo.EXPECT().GetAnalytics(3).Return(analytics, nil))
+			},
+			analytics: analytics,
+			err:       nil,
+		},
+		"personal account": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().GetAccountType(12).Return("personal", nil)
+			},
+			analytics: pkgPins.Analytics{},
+			err:       pkgErrors.ErrNotBusinessAccount,
+		},
+		"pin not found": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().GetAccountType(12).Return("business", nil),
+					f.repo.EXPECT().GetAnalytics(3).Return(pkgPins.Analytics{}, pkgErrors.ErrPinNotFound))
+			},
+			analytics: pkgPins.Analytics{},
+			err:       pkgErrors.ErrPinNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				repo:              mocks.NewMockRepository(ctrl),
+				notificationsServ: notificationsMocks.NewMockService(ctrl),
+				followingsRepo:    followingsMocks.NewMockRepository(ctrl),
+			}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			serv := NewService(f.repo, f.notificationsServ, f.followingsRepo)
+			res, err := serv.GetAnalytics(3, 12)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if res != test.analytics {
+				t.Errorf("\nExpected: %v\nGot: %v", test.analytics, res)
+			}
+		})
+	}
+}
+
+func TestListDuplicates(t *testing.T) {
+	type fields struct {
+		repo              *mocks.MockRepository
+		notificationsServ *notificationsMocks.MockService
+		followingsRepo    *followingsMocks.MockRepository
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		pins    []models.Pin
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().CheckReadAccess("12", "3").Return(true, nil),
+					f.repo.EXPECT().ListDuplicates(3, 12, 1, 30).Return([]models.Pin{{Id: 5}, {Id: 7}}, nil))
+				f.repo.EXPECT().IsLikedByUser(5, 12).Return(true, nil)
+				f.repo.EXPECT().IsLikedByUser(7, 12).Return(false, nil)
+				f.repo.EXPECT().SignPrivateMedia(gomock.Any(), 12).Return(nil)
+			},
+			pins: []models.Pin{{Id: 5, Liked: true}, {Id: 7}},
+			err:  nil,
+		},
+		"no duplicates": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().CheckReadAccess("12", "3").Return(true, nil),
+					f.repo.EXPECT().ListDuplicates(3, 12, 1, 30).Return([]models.Pin{}, nil),
+					f.repo.EXPECT().SignPrivateMedia([]models.Pin{}, 12).Return(nil))
+			},
+			pins: []models.Pin{},
+			err:  nil,
+		},
+		"pin not readable": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().CheckReadAccess("12", "3").Return(false, nil)
+			},
+			pins: []models.Pin{},
+			err:  pkgErrors.ErrPinNotFound,
+		},
+		"access check error": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().CheckReadAccess("12", "3").Return(false, pkgErrors.ErrDb)
+			},
+			pins: []models.Pin{},
+			err:  pkgErrors.ErrDb,
+		},
+		"repository error": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().CheckReadAccess("12", "3").Return(true, nil),
+					f.repo.EXPECT().ListDuplicates(3, 12, 1, 30).Return(nil, pkgErrors.ErrDb))
+			},
+			pins: []models.Pin{},
+			err:  pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				repo:              mocks.NewMockRepository(ctrl),
+				notificationsServ: notificationsMocks.NewMockService(ctrl),
+				followingsRepo:    followingsMocks.NewMockRepository(ctrl),
+			}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			serv := NewService(f.repo, f.notificationsServ, f.followingsRepo)
+			pins, err := serv.ListDuplicates(3, 12, 1, 30)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(pins, test.pins) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.pins, pins)
+			}
+		})
+	}
+}
+
+func TestBackfillMediaPrivacy(t *testing.T) {
+	type fields struct {
+		repo              *mocks.MockRepository
+		notificationsServ *notificationsMocks.MockService
+		followingsRepo    *followingsMocks.MockRepository
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		synced  int
+		err     error
+	}
+
+	batch := constants.MediaPrivacyBackfillBatch
+
+	tests := map[string]testCase{
+		"two batches": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().ListMediaPrivacyBackfill(batch).Return([]int{3, 5}, nil),
+					f.repo.EXPECT().SyncMediaPrivacy([]int{3, 5}).Return(nil),
+					f.repo.EXPECT().DeleteMediaPrivacyBackfill([]int{3, 5}).Return(nil),
+					f.repo.EXPECT().ListMediaPrivacyBackfill(batch).Return([]int{7}, nil),
+					f.repo.EXPECT().SyncMediaPrivacy([]int{7}).Return(nil),
+					f.repo.EXPECT().DeleteMediaPrivacyBackfill([]int{7}).Return(nil),
+					f.repo.EXPECT().ListMediaPrivacyBackfill(batch).Return([]int{}, nil),
+				)
+			},
+			synced: 3,
+			err:    nil,
+		},
+		"nothing to sync": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().ListMediaPrivacyBackfill(batch).Return([]int{}, nil)
+			},
+			synced: 0,
+			err:    nil,
+		},
+		"images service error": {
+			// the pins stay in the list to be synced on the next start
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().ListMediaPrivacyBackfill(batch).Return([]int{3, 5}, nil),
+					f.repo.EXPECT().SyncMediaPrivacy([]int{3, 5}).Return(nil),
+					f.repo.EXPECT().DeleteMediaPrivacyBackfill([]int{3, 5}).Return(nil),
+					f.repo.EXPECT().ListMediaPrivacyBackfill(batch).Return([]int{7}, nil),
+					f.repo.EXPECT().SyncMediaPrivacy([]int{7}).Return(pkgErrors.ErrImageService),
+				)
+			},
+			synced: 2,
+			err:    pkgErrors.ErrImageService,
+		},
+		"db error": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().ListMediaPrivacyBackfill(batch).Return(nil, pkgErrors.ErrDb)
+			},
+			synced: 0,
+			err:    pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{
+				repo:              mocks.NewMockRepository(ctrl),
+				notificationsServ: notificationsMocks.NewMockService(ctrl),
+				followingsRepo:    followingsMocks.NewMockRepository(ctrl),
+			}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			serv := NewService(f.repo, f.notificationsServ, f.followingsRepo)
+			synced, err := serv.BackfillMediaPrivacy()
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if synced != test.synced {
+				t.Errorf("\nExpected: %d\nGot: %d", test.synced, synced)
+			}
+		})
+	}
+}
@@ -83,3 +83,127 @@ var ConsulConfig = struct {
 }{
 	Addr: "CONSUL_ADDR",
 }
+
+var LoginLimiterConfig = struct {
+	Window             string
+	MaxAccountAttempts string
+	MaxIPAttempts      string
+	LockoutDuration    string
+	DelayThreshold     string
+	BaseDelay          string
+	MaxDelay           string
+}{
+	Window:             "LOGIN_ATTEMPTS_WINDOW",
+	MaxAccountAttempts: "LOGIN_MAX_ACCOUNT_ATTEMPTS",
+	MaxIPAttempts:      "LOGIN_MAX_IP_ATTEMPTS",
+	LockoutDuration:    "LOGIN_LOCKOUT_DURATION",
+	DelayThreshold:     "LOGIN_DELAY_THRESHOLD",
+	BaseDelay:          "LOGIN_BASE_DELAY",
+	MaxDelay:           "LOGIN_MAX_DELAY",
+}
+
+var SessionConfig = struct {
+	IdleTimeout             string
+	AbsoluteTimeout         string
+	RememberIdleTimeout     string
+	RememberAbsoluteTimeout string
+}{
+	IdleTimeout:             "SESSION_IDLE_TIMEOUT",
+	AbsoluteTimeout:         "SESSION_ABSOLUTE_TIMEOUT",
+	RememberIdleTimeout:     "SESSION_REMEMBER_IDLE_TIMEOUT",
+	RememberAbsoluteTimeout: "SESSION_REMEMBER_ABSOLUTE_TIMEOUT",
+}
+
+var AccountDeletionConfig = struct {
+	GracePeriod   string
+	PurgeInterval string
+}{
+	GracePeriod:   "ACCOUNT_DELETION_GRACE_PERIOD",
+	PurgeInterval: "ACCOUNT_PURGE_INTERVAL",
+}
+
+var ImageGCConfig = struct {
+	GracePeriod string
+	Interval    string
+	DryRun      string
+}{
+	GracePeriod: "IMAGE_GC_GRACE_PERIOD",
+	Interval:    "IMAGE_GC_INTERVAL",
+	DryRun:      "IMAGE_GC_DRY_RUN",
+}
+
+var ImageLimitsConfig = struct {
+	MaxFileSize       string
+	MaxStreamFileSize string
+	MaxWidth          string
+	MaxHeight         string
+	MaxDuration       string
+}{
+	MaxFileSize:       "IMAGE_MAX_FILE_SIZE",
+	MaxStreamFileSize: "IMAGE_MAX_STREAM_FILE_SIZE",
+	MaxWidth:          "IMAGE_MAX_WIDTH",
+	MaxHeight:         "IMAGE_MAX_HEIGHT",
+	MaxDuration:       "IMAGE_MAX_DURATION",
+}
+
+var FFmpegConfig = struct {
+	Path string
+}{
+	Path: "FFMPEG_PATH",
+}
+
+var ImageStorageConfig = struct {
+	Backend      string
+	Root         string
+	BaseURL      string
+	SigningKey   string
+	SignedURLTTL string
+}{
+	Backend:      "IMAGE_STORAGE_BACKEND",
+	Root:         "IMAGE_STORAGE_ROOT",
+	BaseURL:      "IMAGE_STORAGE_BASE_URL",
+	SigningKey:   "IMAGE_URL_SIGNING_KEY",
+	SignedURLTTL: "IMAGE_SIGNED_URL_TTL",
+}
+
+var SearchSuggestConfig = struct {
+	Limit         string
+	MinQueryCount string
+	CacheSize     string
+	CacheTTL      string
+	Timeout       string
+}{
+	Limit:         "SEARCH_SUGGEST_LIMIT",
+	MinQueryCount: "SEARCH_SUGGEST_MIN_QUERY_COUNT",
+	CacheSize:     "SEARCH_SUGGEST_CACHE_SIZE",
+	CacheTTL:      "SEARCH_SUGGEST_CACHE_TTL",
+	Timeout:       "SEARCH_SUGGEST_TIMEOUT",
+}
+
+var SearchHistoryConfig = struct {
+	TrendingWindows  string
+	TrendingLimit    string
+	TrendingMinCount string
+	RecentLimit      string
+	PurgeInterval    string
+}{
+	TrendingWindows:  "SEARCH_TRENDING_WINDOWS",
+	TrendingLimit:    "SEARCH_TRENDING_LIMIT",
+	TrendingMinCount: "SEARCH_TRENDING_MIN_COUNT",
+	RecentLimit:      "SEARCH_RECENT_LIMIT",
+	PurgeInterval:    "SEARCH_LOG_PURGE_INTERVAL",
+}
+
+var SearchIndexConfig = struct {
+	Backend              string
+	MinReconnectInterval string
+	MaxReconnectInterval string
+	PingInterval         string
+	WordsRefreshInterval string
+}{
+	Backend:              "SEARCH_BACKEND",
+	MinReconnectInterval: "SEARCH_INDEX_MIN_RECONNECT_INTERVAL",
+	MaxReconnectInterval: "SEARCH_INDEX_MAX_RECONNECT_INTERVAL",
+	PingInterval:         "SEARCH_INDEX_PING_INTERVAL",
+	WordsRefreshInterval: "SEARCH_WORDS_REFRESH_INTERVAL",
+}
@@ -42,3 +42,91 @@ func DefaultMongoConfig() {
 func DefaultConsulConfig() {
 	viper.Set(ConsulConfig.Addr, "consul:8500")
 }
+
+func DefaultLoginLimiterConfig() {
+	viper.Set(LoginLimiterConfig.Window, "15m")
+	viper.Set(LoginLimiterConfig.MaxAccountAttempts, 10)
+	viper.Set(LoginLimiterConfig.MaxIPAttempts, 50)
+	viper.Set(LoginLimiterConfig.LockoutDuration, "15m")
+	viper.Set(LoginLimiterConfig.DelayThreshold, 3)
+	viper.Set(LoginLimiterConfig.BaseDelay, "250ms")
+	viper.Set(LoginLimiterConfig.MaxDelay, "4s")
+}
+
+func DefaultSessionConfig() {
+	viper.Set(SessionConfig.IdleTimeout, "5h")
+	viper.Set(SessionConfig.AbsoluteTimeout, "24h")
+	viper.Set(SessionConfig.RememberIdleTimeout, "720h")
+	viper.Set(SessionConfig.RememberAbsoluteTimeout, "2160h")
+}
+
+func DefaultAccountDeletionConfig() {
+	viper.Set(AccountDeletionConfig.GracePeriod, "720h")
+	viper.Set(AccountDeletionConfig.PurgeInterval, "1h")
+}
+
+func DefaultImageGCConfig() {
+	viper.Set(ImageGCConfig.GracePeriod, "24h")
+	viper.Set(ImageGCConfig.Interval, "1h")
+	viper.Set(ImageGCConfig.DryRun, false)
+}
+
+// DefaultImageLimitsConfig keeps the max file size below the images gRPC message size,
+// since renditions are uploaded in one message. Streamed images are not limited by the message size
+// and are never held in memory whole, so they may be larger.
+// The max duration applies to videos and animated GIFs.
+func DefaultImageLimitsConfig() {
+	viper.Set(ImageLimitsConfig.MaxFileSize, 8*1024*1024)
+	viper.Set(ImageLimitsConfig.MaxStreamFileSize, 32*1024*1024)
+	viper.Set(ImageLimitsConfig.MaxWidth, 8192)
+	viper.Set(ImageLimitsConfig.MaxHeight, 8192)
+	viper.Set(ImageLimitsConfig.MaxDuration, "30s")
+}
+
+// DefaultImageStorageConfig stores images in the S3 bucket, "fs" keeps them in the local directory instead.
+// The key signing the URLs of private images stored in the directory has no default,
+// it is read from the environment so that it never ends up in the repository.
+func DefaultImageStorageConfig() {
+	viper.Set(ImageStorageConfig.Backend, "s3")
+	viper.Set(ImageStorageConfig.Root, "/var/lib/pickpin/images")
+	viper.Set(ImageStorageConfig.BaseURL, "http://localhost:8092/images")
+	_ = viper.BindEnv(ImageStorageConfig.SigningKey)
+	viper.Set(ImageStorageConfig.SignedURLTTL, "1h")
+}
+
+// DefaultFFmpegConfig looks the ffmpeg binary up in PATH, it extracts poster frames of video pins.
+func DefaultFFmpegConfig() {
+	viper.Set(FFmpegConfig.Path, "ffmpeg")
+}
+
+// DefaultSearchSuggestConfig suggests past queries only after several searches, so that
+// the queries of single users are not shown to others. Suggestions that take longer
+// than the timeout are dropped by the client.
+func DefaultSearchSuggestConfig() {
+	viper.Set(SearchSuggestConfig.Limit, 5)
+	viper.Set(SearchSuggestConfig.MinQueryCount, 5)
+	viper.Set(SearchSuggestConfig.CacheSize, 10000)
+	viper.Set(SearchSuggestConfig.CacheTTL, "1m")
+	viper.Set(SearchSuggestConfig.Timeout, "150ms")
+}
+
+// DefaultSearchHistoryConfig keeps the log of searches for the longest trending window,
+// the first window is the default one.
+func DefaultSearchHistoryConfig() {
+	viper.Set(SearchHistoryConfig.TrendingWindows, []string{"24h", "1h", "168h"})
+	viper.Set(SearchHistoryConfig.TrendingLimit, 10)
+	viper.Set(SearchHistoryConfig.TrendingMinCount, 5)
+	viper.Set(SearchHistoryConfig.RecentLimit, 10)
+	viper.Set(SearchHistoryConfig.PurgeInterval, "1h")
+}
+
+// DefaultSearchIndexConfig searches in Postgres, "index" searches in the index embedded in the search service.
+// The index listens to the changes of pins, boards and users, and is reloaded after the connection is restored.
+// Postgres refreshes the words misspelled words of queries are corrected to every words refresh interval.
+func DefaultSearchIndexConfig() {
+	viper.Set(SearchIndexConfig.Backend, "postgres")
+	viper.Set(SearchIndexConfig.MinReconnectInterval, "1s")
+	viper.Set(SearchIndexConfig.MaxReconnectInterval, "1m")
+	viper.Set(SearchIndexConfig.PingInterval, "90s")
+	viper.Set(SearchIndexConfig.WordsRefreshInterval, "10m")
+}
@@ -29,13 +29,19 @@ func DefaultGRPCAuthConfig() {
 	DefaultPostgresConfig()
 	DefaultRedisConfig()
 	DefaultConsulConfig()
+	DefaultLoginLimiterConfig()
+	DefaultSessionConfig()
 }
 
 func DefaultGRPCImageConfig() {
 	setGRPCServiceConfig("image", "0.0.0.0", 8088, 10, "image")
 	setupMetricsConfig("0.0.0.0:9002")
+	setupHTTPConfig("0.0.0.0:8092")
 
 	DefaultS3BucketConfig()
+	DefaultImageStorageConfig()
+	DefaultImageLimitsConfig()
+	DefaultFFmpegConfig()
 	DefaultConsulConfig()
 }
 
@@ -45,6 +51,9 @@ func DefaultGRPCSearchConfig() {
 
 	DefaultPostgresConfig()
 	DefaultConsulConfig()
+	DefaultSearchSuggestConfig()
+	DefaultSearchHistoryConfig()
+	DefaultSearchIndexConfig()
 }
 
 func DefaultGRPCShortenerConfig() {
@@ -63,4 +72,8 @@ func DefaultPickPinConfig() {
 
 	DefaultPostgresConfig()
 	DefaultConsulConfig()
+	DefaultSessionConfig()
+	DefaultAccountDeletionConfig()
+	DefaultImageGCConfig()
+	DefaultSearchSuggestConfig()
 }
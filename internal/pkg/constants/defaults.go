@@ -4,7 +4,24 @@ const (
 	DefaultAvatar        = "https://pickpin.hb.bizmrg.com/default-user-icon-8-4024862977"
 	DefaultWebsiteUrl    = ""
 	DefaultAccountType   = "personal"
+	BusinessAccountType  = "business"
 	DefaultRedAvgColor   = 39
 	DefaultGreenAvgColor = 102
 	DefaultBlueAvgColor  = 120
 )
+
+// DuplicateImageMaxDistance is the max Hamming distance between perceptual hashes of duplicate pin images.
+const DuplicateImageMaxDistance = 10
+
+// DuplicateScanLimit is the number of the latest pin images compared with a pin image to find its duplicates.
+const DuplicateScanLimit = 10000
+
+// Placeholders of pin images: the number of dominant colors and the BlurHash components.
+const (
+	PaletteSize         = 5
+	BlurHashXComponents = 4
+	BlurHashYComponents = 3
+)
+
+// MediaPrivacyBackfillBatch is the number of pins whose image privacy is synced at a time by the startup backfill.
+const MediaPrivacyBackfillBatch = 100
@@ -6,4 +6,7 @@ const (
 
 	MaxPinTitleLen       = 100
 	MaxPinDescriptionLen = 500
+
+	MaxCompanyNameLen  = 256
+	MaxContactPhoneLen = 32
 )
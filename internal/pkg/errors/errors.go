@@ -40,16 +40,50 @@ var (
 	// Auth
 	ErrWrongLoginOrPassword = errors.New("wrong login or password")
 	ErrUserAlreadyExists    = errors.New("user already exists")
+	ErrTooManyLoginAttempts = errors.New("too many login attempts, try again later")
+
+	// Access tokens
+	ErrAccessTokenNotFound = errors.New("access token not found")
+	ErrEmptyTokenName      = errors.New("access token name must not be empty")
+	ErrTooLongTokenName    = errors.New("access token name must be no more than 64 characters")
+	ErrInvalidTokenScope   = errors.New("invalid access token scope")
+	ErrInsufficientScope   = errors.New("access token scope is insufficient")
+
+	// Business accounts
+	ErrAlreadyBusinessAccount    = errors.New("account is already a business account")
+	ErrNotBusinessAccount        = errors.New("business account required")
+	ErrEmptyCompanyName          = errors.New("company name must not be empty")
+	ErrTooLongCompanyName        = errors.New("company name must be no more than 256 characters")
+	ErrInvalidWebsiteUrl         = errors.New("invalid website url")
+	ErrInvalidContactEmail       = errors.New("invalid contact email")
+	ErrInvalidContactPhone       = errors.New("invalid contact phone")
+	ErrWebsiteVerificationFailed = errors.New("website verification failed")
+
+	// Images
+	ErrInvalidImage            = errors.New("invalid image")
+	ErrUnsupportedImageType    = errors.New("unsupported image type")
+	ErrImageTooLarge           = errors.New("image file is too large")
+	ErrImageDimensionsTooLarge = errors.New("image dimensions are too large")
+	ErrImageNotFound           = errors.New("image not found")
+	ErrVideoTooLong            = errors.New("video is too long")
 
 	// Invalid Param
-	ErrInvalidUserIdParam  = errors.New("invalid user id param")
-	ErrInvalidBoardIdParam = errors.New("invalid board id param")
-	ErrInvalidPinIdParam   = errors.New("invalid pin id param")
-	ErrInvalidPageParam    = errors.New("invalid page param")
-	ErrInvalidLimitParam   = errors.New("invalid limit param")
-	ErrInvalidLikedParam   = errors.New("invalid liked param")
-	ErrInvalidChatIDParam  = errors.New("invalid chat id param")
-	ErrInvalidLinkIDParam  = errors.New("invalid link param")
+	ErrInvalidUserIdParam   = errors.New("invalid user id param")
+	ErrInvalidBoardIdParam  = errors.New("invalid board id param")
+	ErrInvalidPinIdParam    = errors.New("invalid pin id param")
+	ErrInvalidPageParam     = errors.New("invalid page param")
+	ErrInvalidLimitParam    = errors.New("invalid limit param")
+	ErrInvalidLikedParam    = errors.New("invalid liked param")
+	ErrInvalidChatIDParam   = errors.New("invalid chat id param")
+	ErrInvalidLinkIDParam   = errors.New("invalid link param")
+	ErrInvalidTokenIDParam  = errors.New("invalid token id param")
+	ErrInvalidTypeParam     = errors.New("invalid type param")
+	ErrInvalidSortParam     = errors.New("invalid sort param")
+	ErrInvalidCursorParam   = errors.New("invalid cursor param")
+	ErrInvalidDateParam     = errors.New("invalid date param")
+	ErrInvalidWindowParam   = errors.New("invalid window param")
+	ErrInvalidColorParam    = errors.New("invalid color param")
+	ErrInvalidIntervalParam = errors.New("invalid interval param")
 
 	// WebSocket
 	ErrUpgradeToWebSocket = errors.New("failed to upgrade protocol to websocket")
@@ -82,6 +116,13 @@ var (
 	// Pins
 	ErrTooLongPinTitle       = errors.New("pin title must be no more than 100 characters")
 	ErrTooLongPinDescription = errors.New("pin description must be no more than 500 characters")
+
+	// Short links
+	ErrInvalidAlias      = errors.New("alias must be 4 to 32 latin letters, digits, hyphens or underscores")
+	ErrReservedAlias     = errors.New("alias is reserved")
+	ErrAliasTaken        = errors.New("alias is already taken")
+	ErrInvalidLinkLimits = errors.New("expiry must be in the future and max clicks must be positive")
+	ErrLinkExpired       = errors.New("link expired")
 )
 
 var ErrorsByNames = map[string]error{
@@ -116,16 +157,50 @@ var ErrorsByNames = map[string]error{
 	// Auth
 	ErrWrongLoginOrPassword.Error(): ErrWrongLoginOrPassword,
 	ErrUserAlreadyExists.Error():    ErrUserAlreadyExists,
+	ErrTooManyLoginAttempts.Error(): ErrTooManyLoginAttempts,
+
+	// Access tokens
+	ErrAccessTokenNotFound.Error(): ErrAccessTokenNotFound,
+	ErrEmptyTokenName.Error():      ErrEmptyTokenName,
+	ErrTooLongTokenName.Error():    ErrTooLongTokenName,
+	ErrInvalidTokenScope.Error():   ErrInvalidTokenScope,
+	ErrInsufficientScope.Error():   ErrInsufficientScope,
+
+	// Business accounts
+	ErrAlreadyBusinessAccount.Error():    ErrAlreadyBusinessAccount,
+	ErrNotBusinessAccount.Error():        ErrNotBusinessAccount,
+	ErrEmptyCompanyName.Error():          ErrEmptyCompanyName,
+	ErrTooLongCompanyName.Error():        ErrTooLongCompanyName,
+	ErrInvalidWebsiteUrl.Error():         ErrInvalidWebsiteUrl,
+	ErrInvalidContactEmail.Error():       ErrInvalidContactEmail,
+	ErrInvalidContactPhone.Error():       ErrInvalidContactPhone,
+	ErrWebsiteVerificationFailed.Error(): ErrWebsiteVerificationFailed,
+
+	// Images
+	ErrInvalidImage.Error():            ErrInvalidImage,
+	ErrUnsupportedImageType.Error():    ErrUnsupportedImageType,
+	ErrImageTooLarge.Error():           ErrImageTooLarge,
+	ErrImageDimensionsTooLarge.Error(): ErrImageDimensionsTooLarge,
+	ErrImageNotFound.Error():           ErrImageNotFound,
+	ErrVideoTooLong.Error():            ErrVideoTooLong,
 
 	// Invalid Param
-	ErrInvalidUserIdParam.Error():  ErrInvalidUserIdParam,
-	ErrInvalidBoardIdParam.Error(): ErrInvalidBoardIdParam,
-	ErrInvalidPinIdParam.Error():   ErrInvalidPinIdParam,
-	ErrInvalidPageParam.Error():    ErrInvalidPageParam,
-	ErrInvalidLimitParam.Error():   ErrInvalidLimitParam,
-	ErrInvalidPrivacy.Error():      ErrInvalidPrivacy,
-	ErrInvalidChatIDParam.Error():  ErrInvalidChatIDParam,
-	ErrInvalidLinkIDParam.Error():  ErrInvalidLinkIDParam,
+	ErrInvalidUserIdParam.Error():   ErrInvalidUserIdParam,
+	ErrInvalidBoardIdParam.Error():  ErrInvalidBoardIdParam,
+	ErrInvalidPinIdParam.Error():    ErrInvalidPinIdParam,
+	ErrInvalidPageParam.Error():     ErrInvalidPageParam,
+	ErrInvalidLimitParam.Error():    ErrInvalidLimitParam,
+	ErrInvalidPrivacy.Error():       ErrInvalidPrivacy,
+	ErrInvalidChatIDParam.Error():   ErrInvalidChatIDParam,
+	ErrInvalidLinkIDParam.Error():   ErrInvalidLinkIDParam,
+	ErrInvalidTokenIDParam.Error():  ErrInvalidTokenIDParam,
+	ErrInvalidTypeParam.Error():     ErrInvalidTypeParam,
+	ErrInvalidSortParam.Error():     ErrInvalidSortParam,
+	ErrInvalidCursorParam.Error():   ErrInvalidCursorParam,
+	ErrInvalidDateParam.Error():     ErrInvalidDateParam,
+	ErrInvalidWindowParam.Error():   ErrInvalidWindowParam,
+	ErrInvalidColorParam.Error():    ErrInvalidColorParam,
+	ErrInvalidIntervalParam.Error(): ErrInvalidIntervalParam,
 
 	// WebSocket
 	ErrUpgradeToWebSocket.Error(): ErrUpgradeToWebSocket,
@@ -148,6 +223,13 @@ var ErrorsByNames = map[string]error{
 	ErrFollowingAlreadyExists.Error(): ErrFollowingAlreadyExists,
 	ErrPinAlreadyAdded.Error():        ErrPinAlreadyAdded,
 	ErrChatAlreadyExists.Error():      ErrChatAlreadyExists,
+
+	// Short links
+	ErrInvalidAlias.Error():      ErrInvalidAlias,
+	ErrReservedAlias.Error():     ErrReservedAlias,
+	ErrAliasTaken.Error():        ErrAliasTaken,
+	ErrInvalidLinkLimits.Error(): ErrInvalidLinkLimits,
+	ErrLinkExpired.Error():       ErrLinkExpired,
 }
 
 type ErrRepositoryQuery struct {
@@ -12,13 +12,21 @@ var grpcCodes = map[error]codes.Code{
 	ErrImageService: codes.Internal,
 
 	// Invalid Param
-	ErrInvalidUserIdParam:  codes.InvalidArgument,
-	ErrInvalidBoardIdParam: codes.InvalidArgument,
-	ErrInvalidPinIdParam:   codes.InvalidArgument,
-	ErrInvalidPageParam:    codes.InvalidArgument,
-	ErrInvalidLimitParam:   codes.InvalidArgument,
-	ErrInvalidChatIDParam:  codes.InvalidArgument,
-	ErrInvalidLinkIDParam:  codes.InvalidArgument,
+	ErrInvalidUserIdParam:   codes.InvalidArgument,
+	ErrInvalidBoardIdParam:  codes.InvalidArgument,
+	ErrInvalidPinIdParam:    codes.InvalidArgument,
+	ErrInvalidPageParam:     codes.InvalidArgument,
+	ErrInvalidLimitParam:    codes.InvalidArgument,
+	ErrInvalidChatIDParam:   codes.InvalidArgument,
+	ErrInvalidLinkIDParam:   codes.InvalidArgument,
+	ErrInvalidTokenIDParam:  codes.InvalidArgument,
+	ErrInvalidTypeParam:     codes.InvalidArgument,
+	ErrInvalidSortParam:     codes.InvalidArgument,
+	ErrInvalidCursorParam:   codes.InvalidArgument,
+	ErrInvalidDateParam:     codes.InvalidArgument,
+	ErrInvalidWindowParam:   codes.InvalidArgument,
+	ErrInvalidColorParam:    codes.InvalidArgument,
+	ErrInvalidIntervalParam: codes.InvalidArgument,
 
 	ErrBadParams:          codes.InvalidArgument,
 	ErrBadRequest:         codes.InvalidArgument,
@@ -34,6 +42,22 @@ var grpcCodes = map[error]codes.Code{
 	// Auth
 	ErrWrongLoginOrPassword: codes.NotFound,
 	ErrUnauthorized:         codes.Unauthenticated,
+	ErrTooManyLoginAttempts: codes.ResourceExhausted,
+
+	// Access tokens
+	ErrAccessTokenNotFound: codes.NotFound,
+	ErrEmptyTokenName:      codes.InvalidArgument,
+	ErrTooLongTokenName:    codes.InvalidArgument,
+	ErrInvalidTokenScope:   codes.InvalidArgument,
+	ErrInsufficientScope:   codes.PermissionDenied,
+
+	// Images
+	ErrInvalidImage:            codes.InvalidArgument,
+	ErrUnsupportedImageType:    codes.InvalidArgument,
+	ErrImageTooLarge:           codes.InvalidArgument,
+	ErrImageDimensionsTooLarge: codes.InvalidArgument,
+	ErrImageNotFound:           codes.NotFound,
+	ErrVideoTooLong:            codes.InvalidArgument,
 
 	// WebSocket
 	ErrUpgradeToWebSocket: codes.InvalidArgument,
@@ -63,6 +87,13 @@ var grpcCodes = map[error]codes.Code{
 	ErrFollowingAlreadyExists: codes.AlreadyExists,
 	ErrChatAlreadyExists:      codes.AlreadyExists,
 	ErrPinAlreadyAdded:        codes.AlreadyExists,
+
+	// Short links
+	ErrInvalidAlias:      codes.InvalidArgument,
+	ErrReservedAlias:     codes.InvalidArgument,
+	ErrAliasTaken:        codes.AlreadyExists,
+	ErrInvalidLinkLimits: codes.InvalidArgument,
+	ErrLinkExpired:       codes.FailedPrecondition,
 }
 
 func GetGRPCCodeByError(err error) (codes.Code, bool) {
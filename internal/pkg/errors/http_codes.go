@@ -13,14 +13,22 @@ var httpCodes = map[error]int{
 	ErrImageService: http.StatusInternalServerError,
 
 	// Invalid Param
-	ErrInvalidUserIdParam:  http.StatusBadRequest,
-	ErrInvalidBoardIdParam: http.StatusBadRequest,
-	ErrInvalidPinIdParam:   http.StatusBadRequest,
-	ErrInvalidPageParam:    http.StatusBadRequest,
-	ErrInvalidLimitParam:   http.StatusBadRequest,
-	ErrInvalidLikedParam:   http.StatusBadRequest,
-	ErrInvalidChatIDParam:  http.StatusBadRequest,
-	ErrInvalidLinkIDParam:  http.StatusBadRequest,
+	ErrInvalidUserIdParam:   http.StatusBadRequest,
+	ErrInvalidBoardIdParam:  http.StatusBadRequest,
+	ErrInvalidPinIdParam:    http.StatusBadRequest,
+	ErrInvalidPageParam:     http.StatusBadRequest,
+	ErrInvalidLimitParam:    http.StatusBadRequest,
+	ErrInvalidLikedParam:    http.StatusBadRequest,
+	ErrInvalidChatIDParam:   http.StatusBadRequest,
+	ErrInvalidLinkIDParam:   http.StatusBadRequest,
+	ErrInvalidTokenIDParam:  http.StatusBadRequest,
+	ErrInvalidTypeParam:     http.StatusBadRequest,
+	ErrInvalidSortParam:     http.StatusBadRequest,
+	ErrInvalidCursorParam:   http.StatusBadRequest,
+	ErrInvalidDateParam:     http.StatusBadRequest,
+	ErrInvalidWindowParam:   http.StatusBadRequest,
+	ErrInvalidColorParam:    http.StatusBadRequest,
+	ErrInvalidIntervalParam: http.StatusBadRequest,
 
 	ErrBadParams:          http.StatusBadRequest,
 	ErrBadRequest:         http.StatusBadRequest,
@@ -37,6 +45,32 @@ var httpCodes = map[error]int{
 	// Auth
 	ErrWrongLoginOrPassword: http.StatusNotFound,
 	ErrUnauthorized:         http.StatusUnauthorized,
+	ErrTooManyLoginAttempts: http.StatusTooManyRequests,
+
+	// Access tokens
+	ErrAccessTokenNotFound: http.StatusNotFound,
+	ErrEmptyTokenName:      http.StatusBadRequest,
+	ErrTooLongTokenName:    http.StatusBadRequest,
+	ErrInvalidTokenScope:   http.StatusBadRequest,
+	ErrInsufficientScope:   http.StatusForbidden,
+
+	// Business accounts
+	ErrAlreadyBusinessAccount:    http.StatusConflict,
+	ErrNotBusinessAccount:        http.StatusForbidden,
+	ErrEmptyCompanyName:          http.StatusBadRequest,
+	ErrTooLongCompanyName:        http.StatusBadRequest,
+	ErrInvalidWebsiteUrl:         http.StatusBadRequest,
+	ErrInvalidContactEmail:       http.StatusBadRequest,
+	ErrInvalidContactPhone:       http.StatusBadRequest,
+	ErrWebsiteVerificationFailed: http.StatusBadRequest,
+
+	// Images
+	ErrInvalidImage:            http.StatusBadRequest,
+	ErrUnsupportedImageType:    http.StatusUnsupportedMediaType,
+	ErrImageTooLarge:           http.StatusRequestEntityTooLarge,
+	ErrImageDimensionsTooLarge: http.StatusRequestEntityTooLarge,
+	ErrImageNotFound:           http.StatusNotFound,
+	ErrVideoTooLong:            http.StatusRequestEntityTooLarge,
 
 	// WebSocket
 	ErrUpgradeToWebSocket: http.StatusBadRequest,
@@ -66,6 +100,13 @@ var httpCodes = map[error]int{
 	ErrFollowingAlreadyExists: http.StatusConflict,
 	ErrChatAlreadyExists:      http.StatusConflict,
 	ErrPinAlreadyAdded:        http.StatusConflict,
+
+	// Short links
+	ErrInvalidAlias:      http.StatusBadRequest,
+	ErrReservedAlias:     http.StatusBadRequest,
+	ErrAliasTaken:        http.StatusConflict,
+	ErrInvalidLinkLimits: http.StatusBadRequest,
+	ErrLinkExpired:       http.StatusGone,
 }
 
 func GetHTTPCodeByError(err error) (int, bool) {
@@ -0,0 +1,128 @@
+package image
+
+import (
+	"image"
+	"math"
+	"strings"
+)
+
+// BlurHash is computed on a copy of the image of at most blurHashSampleSize pixels per side,
+// the placeholder is a few cosine components anyway.
+const blurHashSampleSize = 32
+
+const base83Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// BlurHash encodes img as a short string the client decodes into a blurred placeholder,
+// see https://github.com/woltapp/blurhash. Both component counts must be from 1 to 9.
+func BlurHash(img image.Image, xComponents, yComponents int) string {
+	if xComponents < 1 || xComponents > 9 || yComponents < 1 || yComponents > 9 {
+		return ""
+	}
+
+	small := fit(img, blurHashSampleSize)
+	w, h := small.Bounds().Dx(), small.Bounds().Dy()
+
+	// transparent pixels are flattened onto white like in the JPEG renditions, the color is premultiplied
+	linear := make([][3]float64, w*h)
+	for i := range linear {
+		alpha := small.Pix[i*4+3]
+		for ch := 0; ch < 3; ch++ {
+			linear[i][ch] = sRGBToLinear(small.Pix[i*4+ch] + (255 - alpha))
+		}
+	}
+
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for j := 0; j < yComponents; j++ {
+		for i := 0; i < xComponents; i++ {
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1
+			}
+
+			var factor [3]float64
+			for y := 0; y < h; y++ {
+				for x := 0; x < w; x++ {
+					basis := normalisation *
+						math.Cos(math.Pi*float64(i*x)/float64(w)) *
+						math.Cos(math.Pi*float64(j*y)/float64(h))
+					for ch := range factor {
+						factor[ch] += basis * linear[y*w+x][ch]
+					}
+				}
+			}
+			for ch := range factor {
+				factor[ch] /= float64(w * h)
+			}
+			factors = append(factors, factor)
+		}
+	}
+
+	hash := strings.Builder{}
+	hash.WriteString(encode83((xComponents-1)+(yComponents-1)*9, 1))
+
+	maxValue := 1.0
+	if len(factors) > 1 {
+		var actualMax float64
+		for _, factor := range factors[1:] {
+			for _, v := range factor {
+				actualMax = math.Max(actualMax, math.Abs(v))
+			}
+		}
+		quantisedMax := clampInt(int(math.Floor(actualMax*166-0.5)), 0, 82)
+		maxValue = float64(quantisedMax+1) / 166
+		hash.WriteString(encode83(quantisedMax, 1))
+	} else {
+		hash.WriteString(encode83(0, 1))
+	}
+
+	dc := factors[0]
+	hash.WriteString(encode83(linearToSRGB(dc[0])<<16+linearToSRGB(dc[1])<<8+linearToSRGB(dc[2]), 4))
+
+	for _, factor := range factors[1:] {
+		var quantised [3]int
+		for ch, v := range factor {
+			quantised[ch] = clampInt(int(math.Floor(signPow(v/maxValue, 0.5)*9+9.5)), 0, 18)
+		}
+		hash.WriteString(encode83(quantised[0]*19*19+quantised[1]*19+quantised[2], 2))
+	}
+	return hash.String()
+}
+
+func encode83(value, length int) string {
+	result := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		result[i] = base83Chars[value%83]
+		value /= 83
+	}
+	return string(result)
+}
+
+func sRGBToLinear(value uint8) float64 {
+	v := float64(value) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(value float64) int {
+	v := math.Max(0, math.Min(1, value))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(value, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(value), exp), value)
+}
+
+func clampInt(value, low, high int) int {
+	if value < low {
+		return low
+	}
+	if value > high {
+		return high
+	}
+	return value
+}
@@ -0,0 +1,101 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image/imagetest"
+)
+
+func uniform(c color.Color, width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+	return img
+}
+
+func TestBlurHash(t *testing.T) {
+	// The images are not larger than the sample size, so they are encoded as they are. The expected
+	// hashes are computed by a port of the reference encoder from https://github.com/woltapp/blurhash.
+	type testCase struct {
+		img         image.Image
+		xComponents int
+		yComponents int
+		hash        string
+	}
+
+	tests := map[string]testCase{
+		"gradient": {
+			img:         imagetest.Gradient(16, 12),
+			xComponents: 4,
+			yComponents: 3,
+			hash:        "L$Hx+i2?wxoyqSR-jte=g0fjfQfj",
+		},
+		"max components": {
+			img:         imagetest.Gradient(32, 8),
+			xComponents: 9,
+			yComponents: 2,
+			hash:        "H$He:h2swxX8a|ofWpofWpuvR-o1ahfQjIa|jIa|",
+		},
+		"average color only": {
+			img:         imagetest.Gradient(20, 30),
+			xComponents: 1,
+			yComponents: 1,
+			hash:        "00HoEv",
+		},
+		"white": {
+			img:         uniform(color.White, 4, 4),
+			xComponents: 4,
+			yComponents: 3,
+			hash:        "L~TSUA~qfQ~q~q%MfQ%MfQfQfQfQ",
+		},
+		"transparent as white": {
+			img:         image.NewNRGBA(image.Rect(0, 0, 4, 4)),
+			xComponents: 4,
+			yComponents: 3,
+			hash:        "L~TSUA~qfQ~q~q%MfQ%MfQfQfQfQ",
+		},
+		"single color": {
+			img:         uniform(color.RGBA{R: 200, G: 50, B: 100, A: 255}, 5, 3),
+			xComponents: 3,
+			yComponents: 3,
+			hash:        "K$M_ff=LfQ||w|fQfQfQfQ",
+		},
+		"too few components": {
+			img:         imagetest.Gradient(4, 4),
+			xComponents: 0,
+			yComponents: 3,
+			hash:        "",
+		},
+		"too many components": {
+			img:         imagetest.Gradient(4, 4),
+			xComponents: 4,
+			yComponents: 10,
+			hash:        "",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if hash := BlurHash(test.img, test.xComponents, test.yComponents); hash != test.hash {
+				t.Errorf("BlurHash = %q, expected %q", hash, test.hash)
+			}
+		})
+	}
+}
+
+func TestBlurHash_Length(t *testing.T) {
+	// 4 characters of the size and the maximum, 4 of the average color and 2 of every other component
+	img := imagetest.Gradient(300, 200)
+	for x := 1; x <= 9; x++ {
+		for y := 1; y <= 9; y++ {
+			if hash := BlurHash(img, x, y); len(hash) != 6+2*(x*y-1) {
+				t.Errorf("BlurHash with %dx%d components is %d characters long", x, y, len(hash))
+			}
+		}
+	}
+}
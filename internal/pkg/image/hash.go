@@ -0,0 +1,41 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"math/bits"
+)
+
+// dHash works on a grayscale copy of 9x8 pixels, comparing each pixel with its right neighbour gives 64 bits.
+const (
+	dHashWidth  = 9
+	dHashHeight = 8
+)
+
+// DHash computes the difference hash of img. Resized, recompressed or slightly edited copies of
+// an image have hashes within a small HammingDistance of each other.
+func DHash(img image.Image) uint64 {
+	small := scale(img, dHashWidth, dHashHeight)
+
+	var gray [dHashHeight][dHashWidth]uint8
+	for y := 0; y < dHashHeight; y++ {
+		for x := 0; x < dHashWidth; x++ {
+			gray[y][x] = color.GrayModel.Convert(small.RGBAAt(x, y)).(color.Gray).Y
+		}
+	}
+
+	var hash uint64
+	for y := 0; y < dHashHeight; y++ {
+		for x := 0; x < dHashWidth-1; x++ {
+			hash <<= 1
+			if gray[y][x] < gray[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
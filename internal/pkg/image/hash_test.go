@@ -0,0 +1,129 @@
+package image
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
+)
+
+// scene draws random rectangles over a random background, different seeds give unrelated images.
+func scene(seed int64, width, height int) *image.RGBA {
+	rnd := rand.New(rand.NewSource(seed))
+	randomColor := func() color.RGBA {
+		return color.RGBA{R: uint8(rnd.Intn(256)), G: uint8(rnd.Intn(256)), B: uint8(rnd.Intn(256)), A: 255}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(randomColor()), image.Point{}, draw.Src)
+	for i := 0; i < 12; i++ {
+		x, y := rnd.Intn(width), rnd.Intn(height)
+		rect := image.Rect(x, y, x+width/8+rnd.Intn(width/3), y+height/8+rnd.Intn(height/3))
+		draw.Draw(img, rect, image.NewUniform(randomColor()), image.Point{}, draw.Src)
+	}
+	return img
+}
+
+func reencode(t *testing.T, img image.Image, quality int) image.Image {
+	t.Helper()
+	buf := bytes.NewBuffer(nil)
+	if err := jpeg.Encode(buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		t.Fatalf("can't encode jpeg: %v", err)
+	}
+	decoded, err := jpeg.Decode(buf)
+	if err != nil {
+		t.Fatalf("can't decode jpeg: %v", err)
+	}
+	return decoded
+}
+
+func TestDHash(t *testing.T) {
+	original := scene(1, 640, 480)
+	hash := DHash(original)
+
+	pngBuf := bytes.NewBuffer(nil)
+	if err := png.Encode(pngBuf, original); err != nil {
+		t.Fatalf("can't encode png: %v", err)
+	}
+	fromPNG, err := BytesToImage(pngBuf.Bytes())
+	if err != nil {
+		t.Fatalf("can't decode png: %v", err)
+	}
+
+	type testCase struct {
+		img       image.Image
+		duplicate bool
+	}
+
+	tests := map[string]testCase{
+		"same image":        {img: original, duplicate: true},
+		"lossless copy":     {img: fromPNG, duplicate: true},
+		"resized":           {img: Resize(original, 236), duplicate: true},
+		"resized twice":     {img: Resize(Resize(original, 474), 100), duplicate: true},
+		"re-encoded":        {img: reencode(t, original, 85), duplicate: true},
+		"low quality":       {img: reencode(t, Resize(original, 320), 30), duplicate: true},
+		"unrelated":         {img: scene(2, 640, 480), duplicate: false},
+		"another unrelated": {img: scene(3, 640, 480), duplicate: false},
+		"mirrored":          {img: ApplyOrientation(original, 2), duplicate: false},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			distance := HammingDistance(hash, DHash(test.img))
+			if duplicate := distance <= constants.DuplicateImageMaxDistance; duplicate != test.duplicate {
+				t.Errorf("distance = %d, duplicate = %v, expected %v", distance, duplicate, test.duplicate)
+			}
+		})
+	}
+}
+
+func TestDHash_Bounds(t *testing.T) {
+	// a sub-image is hashed as the image of its pixels, wherever its bounds start
+	original := scene(4, 90, 80)
+	shifted := image.NewRGBA(image.Rect(-50, 30, 40, 110))
+	draw.Draw(shifted, shifted.Bounds(), original, image.Point{}, draw.Src)
+
+	if DHash(shifted) != DHash(original) {
+		t.Errorf("hash depends on the image bounds")
+	}
+}
+
+func TestHammingDistance(t *testing.T) {
+	type testCase struct {
+		a, b     uint64
+		distance int
+	}
+
+	tests := map[string]testCase{
+		"equal":         {a: 0xDEADBEEF, b: 0xDEADBEEF, distance: 0},
+		"zero":          {a: 0, b: 0, distance: 0},
+		"one bit":       {a: 0b1000, b: 0, distance: 1},
+		"several bits":  {a: 0b1011, b: 0b0001, distance: 2},
+		"highest bit":   {a: 1 << 63, b: 0, distance: 1},
+		"all bits":      {a: ^uint64(0), b: 0, distance: 64},
+		"complementary": {a: 0xF0F0F0F0F0F0F0F0, b: 0x0F0F0F0F0F0F0F0F, distance: 64},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if distance := HammingDistance(test.a, test.b); distance != test.distance {
+				t.Errorf("HammingDistance = %d, expected %d", distance, test.distance)
+			}
+			if distance := HammingDistance(test.b, test.a); distance != test.distance {
+				t.Errorf("HammingDistance is not symmetric")
+			}
+		})
+	}
+}
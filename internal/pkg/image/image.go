@@ -2,11 +2,14 @@ package image
 
 import (
 	"bytes"
+	"fmt"
 	"image"
 	"image/color"
+	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
 	"math"
+	"strconv"
 )
 
 type Color struct {
@@ -15,6 +18,46 @@ type Color struct {
 	Blue  uint8
 }
 
+// CSS formats the color the way pin colors are stored, e.g. rgb(39, 102, 120).
+func (c Color) CSS() string {
+	return fmt.Sprintf("rgb(%d, %d, %d)", c.Red, c.Green, c.Blue)
+}
+
+// ParseHexColor parses colors written as #rrggbb.
+func ParseHexColor(s string) (Color, error) {
+	var c Color
+	if len(s) != 7 || s[0] != '#' {
+		return c, fmt.Errorf("invalid color %q", s)
+	}
+	rgb, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return c, fmt.Errorf("invalid color %q", s)
+	}
+	return ColorFromRGB(int(rgb)), nil
+}
+
+// Hex formats the color as #rrggbb.
+func (c Color) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.Red, c.Green, c.Blue)
+}
+
+// RGB packs the color into 0xRRGGBB, the way the dominant colors of pins are stored.
+func (c Color) RGB() int {
+	return int(c.Red)<<16 | int(c.Green)<<8 | int(c.Blue)
+}
+
+func ColorFromRGB(rgb int) Color {
+	return Color{Red: uint8(rgb >> 16), Green: uint8(rgb >> 8), Blue: uint8(rgb)}
+}
+
+// Distance is the Euclidean distance between the colors in RGB space, from 0 to about 441.
+func (c Color) Distance(other Color) float64 {
+	dr := float64(c.Red) - float64(other.Red)
+	dg := float64(c.Green) - float64(other.Green)
+	db := float64(c.Blue) - float64(other.Blue)
+	return math.Sqrt(dr*dr + dg*dg + db*db)
+}
+
 func BytesToImage(b []byte) (image.Image, error) {
 	img, _, err := image.Decode(bytes.NewReader(b))
 	if err != nil {
@@ -24,14 +67,15 @@ func BytesToImage(b []byte) (image.Image, error) {
 }
 
 func CalcAvgColor(img image.Image) (result Color) {
-	imgSize := img.Bounds().Size()
+	bounds := img.Bounds()
+	imgSize := bounds.Size()
 
 	var redSum float64
 	var greenSum float64
 	var blueSum float64
 
-	for x := 0; x <= imgSize.X; x++ {
-		for y := 0; y <= imgSize.Y; y++ {
+	for x := bounds.Min.X; x < bounds.Max.X; x++ {
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
 			pixel := img.At(x, y)
 			col := color.RGBAModel.Convert(pixel).(color.RGBA)
 
@@ -0,0 +1,43 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestCalcAvgColor(t *testing.T) {
+	red := color.RGBA{R: 200, G: 30, B: 40, A: 255}
+	blue := color.RGBA{R: 20, G: 50, B: 210, A: 255}
+
+	halves := image.NewRGBA(image.Rect(0, 0, 20, 10))
+	stripes(halves, []int{10, 10}, []color.Color{red, blue})
+
+	offOrigin := image.NewRGBA(image.Rect(-10, 5, 10, 15))
+	stripes(offOrigin, []int{10, 10}, []color.Color{red, blue})
+
+	type testCase struct {
+		img   image.Image
+		color Color
+	}
+
+	tests := map[string]testCase{
+		"whole image":        {img: halves, color: Color{110, 40, 125}},
+		"off origin":         {img: offOrigin, color: Color{110, 40, 125}},
+		"right sub-image":    {img: halves.SubImage(image.Rect(10, 0, 20, 10)), color: Color{20, 50, 210}},
+		"left sub-image":     {img: offOrigin.SubImage(image.Rect(-10, 5, 0, 15)), color: Color{200, 30, 40}},
+		"sub-image in a row": {img: halves.SubImage(image.Rect(5, 9, 15, 10)), color: Color{110, 40, 125}},
+		"single pixel":       {img: halves.SubImage(image.Rect(19, 9, 20, 10)), color: Color{20, 50, 210}},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if avg := CalcAvgColor(test.img); avg != test.color {
+				t.Errorf("CalcAvgColor = %v, expected %v", avg, test.color)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// Package imagetest builds small images with metadata for the tests of image parsing and sanitizing.
+package imagetest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// JPEG markers of the segments the tests put into images
+const (
+	MarkerAPP0 = 0xE0 // JFIF
+	MarkerAPP1 = 0xE1 // EXIF and XMP
+	MarkerAPP2 = 0xE2 // ICC profile
+	MarkerAPPD = 0xED // IPTC
+	MarkerCOM  = 0xFE
+)
+
+type Segment struct {
+	Marker byte
+	Data   []byte
+}
+
+func JFIF() Segment {
+	return Segment{Marker: MarkerAPP0, Data: []byte("JFIF\x00\x01\x01\x00\x00\x01\x00\x01\x00\x00")}
+}
+
+// EXIF is an EXIF segment with the orientation tag and a GPS info pointer.
+func EXIF(order binary.ByteOrder, orientation int) Segment {
+	return Segment{Marker: MarkerAPP1, Data: append([]byte("Exif\x00\x00"), TIFF(order, orientation)...)}
+}
+
+func XMP() Segment {
+	return Segment{Marker: MarkerAPP1, Data: []byte("http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta/>")}
+}
+
+func ICC() Segment {
+	return Segment{Marker: MarkerAPP2, Data: []byte("ICC_PROFILE\x00\x01\x01profile")}
+}
+
+func IPTC() Segment {
+	return Segment{Marker: MarkerAPPD, Data: []byte("Photoshop 3.0\x00location")}
+}
+
+func Comment() Segment {
+	return Segment{Marker: MarkerCOM, Data: []byte("taken at home")}
+}
+
+// TIFF is the TIFF structure of EXIF with IFD0 of the orientation and GPS info pointer tags.
+func TIFF(order binary.ByteOrder, orientation int) []byte {
+	tiff := make([]byte, 8+2+2*12+4)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+
+	order.PutUint16(tiff[8:], 2)
+	// GPS info IFD pointer, the IFD itself is left out
+	gps := tiff[10:]
+	order.PutUint16(gps, 0x8825)
+	order.PutUint16(gps[2:], 4)
+	order.PutUint32(gps[4:], 1)
+	order.PutUint32(gps[8:], 0)
+	// orientation, a SHORT stored in the first bytes of the value
+	entry := tiff[22:]
+	order.PutUint16(entry, 0x0112)
+	order.PutUint16(entry[2:], 3)
+	order.PutUint32(entry[4:], 1)
+	order.PutUint16(entry[8:], uint16(orientation))
+	return tiff
+}
+
+// Gradient is an image with distinct colors along both axes.
+func Gradient(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{
+				R: uint8(x * 255 / max(width-1, 1)),
+				G: uint8(y * 255 / max(height-1, 1)),
+				B: 128,
+				A: 255,
+			})
+		}
+	}
+	return img
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// JPEG encodes a gradient of the given size with the segments inserted right after SOI.
+func JPEG(t testing.TB, width, height int, segments ...Segment) []byte {
+	t.Helper()
+	buf := bytes.NewBuffer(nil)
+	if err := jpeg.Encode(buf, Gradient(width, height), &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatalf("can't encode jpeg: %v", err)
+	}
+	return InsertSegments(t, buf.Bytes(), segments...)
+}
+
+// InsertSegments inserts the segments into the JPEG file right after SOI.
+func InsertSegments(t testing.TB, data []byte, segments ...Segment) []byte {
+	t.Helper()
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		t.Fatalf("not a jpeg")
+	}
+
+	out := append([]byte(nil), data[:2]...)
+	for _, segment := range segments {
+		out = append(out, 0xFF, segment.Marker)
+		out = binary.BigEndian.AppendUint16(out, uint16(len(segment.Data)+2))
+		out = append(out, segment.Data...)
+	}
+	return append(out, data[2:]...)
+}
+
+// GIF encodes a gradient of the given size with a frame for each delay in 100ths of a second,
+// or a single frame without delay if none is given.
+func GIF(t testing.TB, width, height int, delays ...int) []byte {
+	t.Helper()
+	frame := image.NewPaletted(image.Rect(0, 0, width, height), palette.Plan9)
+	src := Gradient(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			frame.Set(x, y, src.At(x, y))
+		}
+	}
+
+	anim := &gif.GIF{Image: []*image.Paletted{frame}, Delay: []int{0}}
+	if len(delays) > 0 {
+		anim.Image, anim.Delay = nil, delays
+		for range delays {
+			anim.Image = append(anim.Image, frame)
+		}
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := gif.EncodeAll(buf, anim); err != nil {
+		t.Fatalf("can't encode gif: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// GIFExtension is a GIF extension block with the label and data in sub-blocks of up to 255 bytes.
+func GIFExtension(label byte, data []byte) []byte {
+	out := []byte{0x21, label}
+	for len(data) > 0 {
+		n := len(data)
+		if n > 255 {
+			n = 255
+		}
+		out = append(out, byte(n))
+		out = append(out, data[:n]...)
+		data = data[n:]
+	}
+	return append(out, 0)
+}
+
+// InsertGIFBlocks inserts the blocks right after the global color table of the GIF.
+func InsertGIFBlocks(data []byte, blocks ...[]byte) []byte {
+	pos := 13
+	if data[10]&0x80 != 0 {
+		pos += 3 << (data[10]&7 + 1)
+	}
+	out := append([]byte(nil), data[:pos]...)
+	for _, block := range blocks {
+		out = append(out, block...)
+	}
+	return append(out, data[pos:]...)
+}
+
+type Chunk struct {
+	Type string
+	Data []byte
+}
+
+// PNG encodes a gradient of the given size with the chunks inserted right after IHDR.
+func PNG(t testing.TB, width, height int, chunks ...Chunk) []byte {
+	t.Helper()
+	buf := bytes.NewBuffer(nil)
+	if err := png.Encode(buf, Gradient(width, height)); err != nil {
+		t.Fatalf("can't encode png: %v", err)
+	}
+	data := buf.Bytes()
+
+	// signature and IHDR of 13 bytes
+	const ihdrEnd = 8 + 8 + 13 + 4
+	out := append([]byte(nil), data[:ihdrEnd]...)
+	for _, chunk := range chunks {
+		out = AppendChunk(out, chunk)
+	}
+	return append(out, data[ihdrEnd:]...)
+}
+
+// AppendChunk appends the PNG chunk with its length and crc to b.
+func AppendChunk(b []byte, chunk Chunk) []byte {
+	b = binary.BigEndian.AppendUint32(b, uint32(len(chunk.Data)))
+	start := len(b)
+	b = append(b, chunk.Type...)
+	b = append(b, chunk.Data...)
+	return binary.BigEndian.AppendUint32(b, crc32.ChecksumIEEE(b[start:]))
+}
+
+// ChunkTypes lists the chunk types of the PNG file, nil if it is malformed.
+func ChunkTypes(data []byte) []string {
+	if len(data) < 8 {
+		return nil
+	}
+	var types []string
+	for pos := 8; pos < len(data); {
+		if pos+8 > len(data) {
+			return nil
+		}
+		size := int(binary.BigEndian.Uint32(data[pos:]))
+		types = append(types, string(data[pos+4:pos+8]))
+		pos += 12 + size
+	}
+	return types
+}
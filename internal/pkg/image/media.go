@@ -0,0 +1,145 @@
+package image
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/gif"
+	"time"
+)
+
+// MediaInfo describes an uploaded file, Duration is zero for still images.
+type MediaInfo struct {
+	Format   string
+	Width    int
+	Height   int
+	Frames   int // frames of animated GIFs, 1 for other images and 0 for videos
+	Duration time.Duration
+}
+
+// Animated reports whether the file is a video or a GIF of several frames.
+func (info MediaInfo) Animated() bool {
+	return IsVideo(info.Format) || info.Frames > 1
+}
+
+func IsVideo(format string) bool {
+	return format == FormatMP4 || format == FormatWebM
+}
+
+// GIF blocks, see https://www.w3.org/Graphics/GIF/spec-gif89a.txt
+const (
+	gifExtension      = 0x21
+	gifImageSeparator = 0x2C
+	gifTrailer        = 0x3B
+	gifGraphicControl = 0xF9
+	gifColorTableFlag = 0x80
+)
+
+// GIF delays are in hundredths of a second. Browsers show frames with delays below gifMinDelay
+// for gifDefaultDelay instead, such animations would play too fast otherwise.
+const (
+	gifMinDelay     = 2
+	gifDefaultDelay = 10
+)
+
+// GIFInfo counts the frames of a GIF and sums their delays without decoding the pixels,
+// so animations of many large frames take no memory.
+func GIFInfo(data []byte) (MediaInfo, error) {
+	if DetectFormat(data) != FormatGIF || len(data) < 13 {
+		return MediaInfo{}, errMalformed
+	}
+
+	info := MediaInfo{
+		Format: FormatGIF,
+		Width:  int(data[6]) | int(data[7])<<8,
+		Height: int(data[8]) | int(data[9])<<8,
+	}
+	pos := 13
+	if data[10]&gifColorTableFlag != 0 {
+		pos += 3 << (data[10]&7 + 1)
+	}
+
+	delay := 0
+	for pos < len(data) {
+		switch data[pos] {
+		case gifExtension:
+			if pos+2 > len(data) {
+				return MediaInfo{}, errMalformed
+			}
+			if data[pos+1] == gifGraphicControl && pos+7 <= len(data) {
+				delay = int(data[pos+4]) | int(data[pos+5])<<8
+			}
+			end, err := skipGIFSubBlocks(data, pos+2)
+			if err != nil {
+				return MediaInfo{}, err
+			}
+			pos = end
+		case gifImageSeparator:
+			if pos+10 > len(data) {
+				return MediaInfo{}, errMalformed
+			}
+			flags := data[pos+9]
+			pos += 10
+			if flags&gifColorTableFlag != 0 {
+				pos += 3 << (flags&7 + 1)
+			}
+			// the LZW minimum code size precedes the image data
+			end, err := skipGIFSubBlocks(data, pos+1)
+			if err != nil {
+				return MediaInfo{}, err
+			}
+			pos = end
+
+			if delay < gifMinDelay {
+				delay = gifDefaultDelay
+			}
+			info.Frames++
+			info.Duration += time.Duration(delay) * 10 * time.Millisecond
+			delay = 0
+		case gifTrailer:
+			pos = len(data)
+		default:
+			return MediaInfo{}, errMalformed
+		}
+	}
+
+	if info.Frames == 0 {
+		return MediaInfo{}, errMalformed
+	}
+	if info.Frames == 1 {
+		info.Duration = 0
+	}
+	return info, nil
+}
+
+// skipGIFSubBlocks returns the position after the sub-blocks starting at pos and their terminator.
+func skipGIFSubBlocks(data []byte, pos int) (int, error) {
+	for {
+		if pos >= len(data) {
+			return 0, errMalformed
+		}
+		size := int(data[pos])
+		pos++
+		if size == 0 {
+			return pos, nil
+		}
+		pos += size
+	}
+}
+
+// GIFPoster returns the first frame of a GIF drawn on the whole canvas,
+// the frame itself may cover only a part of it.
+func GIFPoster(data []byte) (image.Image, error) {
+	conf, err := gif.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	frame, err := gif.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	poster := image.NewRGBA(image.Rect(0, 0, conf.Width, conf.Height))
+	draw.Draw(poster, frame.Bounds(), frame, frame.Bounds().Min, draw.Src)
+	return poster, nil
+}
@@ -0,0 +1,158 @@
+package image
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image/imagetest"
+)
+
+func TestGIFInfo(t *testing.T) {
+	// three frames with delays of 0, 1 and 25 hundredths of a second and a global color table of 4 colors
+	animated := readFixture(t, "animated.gif")
+	firstBlock := 13 + 3*4
+	withComment := append(append(append([]byte(nil), animated[:firstBlock]...),
+		0x21, 0xFE, 5, 'h', 'e', 'l', 'l', 'o', 0), animated[firstBlock:]...)
+	unknownBlock := append([]byte(nil), animated...)
+	unknownBlock[firstBlock] = 0x00
+
+	type testCase struct {
+		data []byte
+		info MediaInfo
+		err  error
+	}
+
+	tests := map[string]testCase{
+		"too short delays": {
+			data: animated,
+			info: MediaInfo{Format: FormatGIF, Width: 8, Height: 6, Frames: 3, Duration: 450 * time.Millisecond},
+		},
+		"local color table": {
+			data: readFixture(t, "still.gif"),
+			info: MediaInfo{Format: FormatGIF, Width: 5, Height: 3, Frames: 1},
+		},
+		"comment": {
+			data: withComment,
+			info: MediaInfo{Format: FormatGIF, Width: 8, Height: 6, Frames: 3, Duration: 450 * time.Millisecond},
+		},
+		"no trailer": {
+			data: animated[:len(animated)-1],
+			info: MediaInfo{Format: FormatGIF, Width: 8, Height: 6, Frames: 3, Duration: 450 * time.Millisecond},
+		},
+		"encoded": {
+			data: imagetest.GIF(t, 30, 20, 4, 4, 4, 4),
+			info: MediaInfo{Format: FormatGIF, Width: 30, Height: 20, Frames: 4, Duration: 160 * time.Millisecond},
+		},
+		"truncated frame":      {data: animated[:len(animated)-4], err: errMalformed},
+		"truncated descriptor": {data: animated[:len(animated)-12], err: errMalformed},
+		"truncated extension":  {data: animated[:firstBlock+1], err: errMalformed},
+		"unknown block":        {data: unknownBlock, err: errMalformed},
+		"no frames":            {data: append(append([]byte(nil), animated[:firstBlock]...), 0x3B), err: errMalformed},
+		"truncated header":     {data: animated[:10], err: errMalformed},
+		"not a gif":            {data: readFixture(t, "video.webm"), err: errMalformed},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			info, err := GIFInfo(test.data)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if info != test.info {
+				t.Errorf("GIFInfo = %+v, expected %+v", info, test.info)
+			}
+		})
+	}
+}
+
+func TestGIFPoster(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	green := color.RGBA{G: 255, A: 255}
+	transparent := color.RGBA{}
+
+	type testCase struct {
+		data   []byte
+		bounds image.Rectangle
+		pixels map[image.Point]color.RGBA
+	}
+
+	tests := map[string]testCase{
+		// the first frame covers only (2, 1)-(6, 4) of the canvas, the later frames are not drawn
+		"partial first frame": {
+			data:   readFixture(t, "animated.gif"),
+			bounds: image.Rect(0, 0, 8, 6),
+			pixels: map[image.Point]color.RGBA{
+				{2, 1}: red, {5, 3}: red, {0, 0}: transparent, {1, 1}: transparent, {6, 3}: transparent, {7, 5}: transparent,
+			},
+		},
+		"whole canvas": {
+			data:   readFixture(t, "still.gif"),
+			bounds: image.Rect(0, 0, 5, 3),
+			pixels: map[image.Point]color.RGBA{{0, 0}: green, {4, 2}: green},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			poster, err := GIFPoster(test.data)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if poster.Bounds() != test.bounds {
+				t.Errorf("bounds = %v, expected %v", poster.Bounds(), test.bounds)
+			}
+			for point, expected := range test.pixels {
+				if c := color.RGBAModel.Convert(poster.At(point.X, point.Y)); c != expected {
+					t.Errorf("pixel %v = %v, expected %v", point, c, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestGIFPoster_Malformed(t *testing.T) {
+	animated := readFixture(t, "animated.gif")
+
+	tests := map[string][]byte{
+		"truncated": animated[:30],
+		// the header and color table, the loop extension, the graphic control and the image descriptor
+		"truncated frame": animated[:13+3*4+19+8+10+3],
+		"not a gif":       readFixture(t, "video.mp4"),
+		"empty":           nil,
+	}
+
+	for name, data := range tests {
+		data := data
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := GIFPoster(data); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func FuzzGIFInfo(f *testing.F) {
+	f.Add(readFixture(f, "animated.gif"))
+	f.Add(readFixture(f, "still.gif"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		info, err := GIFInfo(data)
+		if err != nil {
+			return
+		}
+		if info.Frames < 1 || info.Duration < 0 || (info.Frames == 1) != (info.Duration == 0) {
+			t.Fatalf("GIFInfo = %+v", info)
+		}
+	})
+}
@@ -0,0 +1,469 @@
+package image
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	"io"
+)
+
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatGIF  = "gif"
+	FormatWebP = "webp"
+	FormatMP4  = "mp4"
+	FormatWebM = "webm"
+)
+
+var errMalformed = errors.New("malformed image")
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// DetectFormat recognizes the image or video format by its magic bytes, an empty string means unknown format.
+// Any EBML file is reported as WebM, the document type is checked by WebMInfo.
+func DetectFormat(b []byte) string {
+	switch {
+	case bytes.HasPrefix(b, []byte{0xFF, 0xD8, 0xFF}):
+		return FormatJPEG
+	case bytes.HasPrefix(b, pngSignature):
+		return FormatPNG
+	case bytes.HasPrefix(b, []byte("GIF87a")), bytes.HasPrefix(b, []byte("GIF89a")):
+		return FormatGIF
+	case len(b) >= 12 && bytes.Equal(b[:4], []byte("RIFF")) && bytes.Equal(b[8:12], []byte("WEBP")):
+		return FormatWebP
+	case len(b) >= 8 && bytes.Equal(b[4:8], []byte("ftyp")):
+		return FormatMP4
+	case bytes.HasPrefix(b, ebmlMagic):
+		return FormatWebM
+	}
+	return ""
+}
+
+// JPEG markers, see ITU T.81 B.1.1.3
+const (
+	markerSOI  = 0xD8
+	markerSOS  = 0xDA
+	markerAPP1 = 0xE1
+	markerAPPD = 0xED
+	markerCOM  = 0xFE
+)
+
+type jpegSegment struct {
+	marker byte
+	data   []byte // segment payload without marker and length
+}
+
+// jpegSegments splits the JPEG header into segments up to the SOS marker.
+// Truncated data gives the segments parsed so far together with the error.
+func jpegSegments(b []byte) ([]jpegSegment, error) {
+	if len(b) < 2 || b[0] != 0xFF || b[1] != markerSOI {
+		return nil, errMalformed
+	}
+
+	var segments []jpegSegment
+	pos := 2
+	for {
+		if pos >= len(b) || b[pos] != 0xFF {
+			return segments, errMalformed
+		}
+		for pos < len(b) && b[pos] == 0xFF {
+			pos++
+		}
+		if pos >= len(b) {
+			return segments, errMalformed
+		}
+		marker := b[pos]
+		pos++
+
+		if marker == markerSOS {
+			return segments, nil
+		}
+		if standaloneMarker(marker) {
+			segments = append(segments, jpegSegment{marker: marker})
+			continue
+		}
+
+		if pos+2 > len(b) {
+			return segments, errMalformed
+		}
+		length := int(binary.BigEndian.Uint16(b[pos:]))
+		if length < 2 || pos+length > len(b) {
+			return segments, errMalformed
+		}
+		segments = append(segments, jpegSegment{marker: marker, data: b[pos+2 : pos+length]})
+		pos += length
+	}
+}
+
+func standaloneMarker(marker byte) bool {
+	return marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7)
+}
+
+func metadataMarker(marker byte) bool {
+	return marker == markerAPP1 || marker == markerAPPD || marker == markerCOM
+}
+
+// StripJPEGMetadata removes EXIF, XMP, IPTC and comment segments without recompressing the image.
+// JFIF, ICC profile and Adobe segments are kept since they affect how colors are decoded.
+func StripJPEGMetadata(b []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(b)))
+	if err := StripJPEGMetadataStream(out, bytes.NewReader(b)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// StripJPEGMetadataStream is StripJPEGMetadata copying the image from src to dst,
+// only one header segment is held in memory at a time.
+func StripJPEGMetadataStream(dst io.Writer, src io.Reader) error {
+	r := bufio.NewReader(src)
+
+	soi := make([]byte, 2)
+	if _, err := io.ReadFull(r, soi); err != nil || soi[0] != 0xFF || soi[1] != markerSOI {
+		return streamError(err)
+	}
+	if _, err := dst.Write(soi); err != nil {
+		return err
+	}
+
+	for {
+		b, err := r.ReadByte()
+		if err != nil || b != 0xFF {
+			return streamError(err)
+		}
+		marker := b
+		for marker == 0xFF {
+			if marker, err = r.ReadByte(); err != nil {
+				return streamError(err)
+			}
+		}
+
+		if marker == markerSOS {
+			// entropy coded data up to the end of the file
+			if _, err = dst.Write([]byte{0xFF, marker}); err != nil {
+				return err
+			}
+			_, err = io.Copy(dst, r)
+			return err
+		}
+		if standaloneMarker(marker) {
+			if _, err = dst.Write([]byte{0xFF, marker}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		segment := make([]byte, 4, 4+0xFFFF)
+		segment[0], segment[1] = 0xFF, marker
+		if _, err = io.ReadFull(r, segment[2:4]); err != nil {
+			return streamError(err)
+		}
+		length := int(binary.BigEndian.Uint16(segment[2:4]))
+		if length < 2 {
+			return errMalformed
+		}
+		segment = segment[:2+length]
+		if _, err = io.ReadFull(r, segment[4:]); err != nil {
+			return streamError(err)
+		}
+
+		if !metadataMarker(marker) {
+			if _, err = dst.Write(segment); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// streamError reports truncated and malformed streams the same way, errors of src are kept.
+func streamError(err error) error {
+	if err == nil || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return errMalformed
+	}
+	return err
+}
+
+var exifHeader = []byte("Exif\x00\x00")
+
+const exifOrientationTag = 0x0112
+
+// JPEGOrientation returns the EXIF orientation of the image, 1 if it is missing or invalid.
+// b may be only the beginning of the file as long as it contains the EXIF segment.
+func JPEGOrientation(b []byte) int {
+	segments, _ := jpegSegments(b)
+	for _, segment := range segments {
+		if segment.marker == markerAPP1 && bytes.HasPrefix(segment.data, exifHeader) {
+			return tiffOrientation(segment.data[len(exifHeader):])
+		}
+	}
+	return 1
+}
+
+// tiffOrientation looks for the orientation tag in IFD0 of the TIFF structure EXIF is stored in.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != exifOrientationTag {
+			continue
+		}
+
+		orientation := int(order.Uint16(tiff[entry+8:]))
+		if orientation < 1 || orientation > 8 {
+			return 1
+		}
+		return orientation
+	}
+	return 1
+}
+
+// PNG ancillary chunks carrying metadata
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+// StripPNGMetadata removes EXIF, text and time chunks from the PNG file.
+func StripPNGMetadata(b []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(b)))
+	if err := StripPNGMetadataStream(out, bytes.NewReader(b)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// StripPNGMetadataStream is StripPNGMetadata copying the image from src to dst chunk by chunk.
+func StripPNGMetadataStream(dst io.Writer, src io.Reader) error {
+	r := bufio.NewReader(src)
+
+	signature := make([]byte, len(pngSignature))
+	if _, err := io.ReadFull(r, signature); err != nil || !bytes.Equal(signature, pngSignature) {
+		return streamError(err)
+	}
+	if _, err := dst.Write(signature); err != nil {
+		return err
+	}
+
+	// length and type, followed by data and crc
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return streamError(err)
+		}
+		size := int64(binary.BigEndian.Uint32(header)) + 4
+		chunkType := string(header[4:])
+
+		var err error
+		if pngMetadataChunks[chunkType] {
+			_, err = io.CopyN(io.Discard, r, size)
+		} else if _, err = dst.Write(header); err == nil {
+			_, err = io.CopyN(dst, r, size)
+		}
+		if err != nil {
+			return streamError(err)
+		}
+
+		if chunkType == "IEND" {
+			return nil
+		}
+	}
+}
+
+// GIF extensions kept by StripGIFMetadata: graphic control extensions hold the delays and disposal
+// of frames, the NETSCAPE application extension holds the loop count of the animation.
+const gifApplication = 0xFF
+
+var gifLoopingApplication = []byte("NETSCAPE2.0")
+
+// StripGIFMetadata removes comment, plain text and application extensions other than the looping one
+// from the GIF file, these carry XMP and other metadata. Frames are copied without re-encoding.
+func StripGIFMetadata(b []byte) ([]byte, error) {
+	out := bytes.NewBuffer(make([]byte, 0, len(b)))
+	if err := StripGIFMetadataStream(out, bytes.NewReader(b)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// StripGIFMetadataStream is StripGIFMetadata copying the image from src to dst block by block.
+// A missing trailer after the last frame is added, the data after the trailer is dropped.
+func StripGIFMetadataStream(dst io.Writer, src io.Reader) error {
+	r := bufio.NewReader(src)
+
+	// header and logical screen descriptor
+	header := make([]byte, 13)
+	if _, err := io.ReadFull(r, header); err != nil || DetectFormat(header) != FormatGIF {
+		return streamError(err)
+	}
+	if _, err := dst.Write(header); err != nil {
+		return err
+	}
+	if header[10]&gifColorTableFlag != 0 {
+		if _, err := io.CopyN(dst, r, 3<<(header[10]&7+1)); err != nil {
+			return streamError(err)
+		}
+	}
+
+	frames := 0
+	for {
+		block, err := r.ReadByte()
+		if errors.Is(err, io.EOF) && frames > 0 {
+			block, err = gifTrailer, nil
+		}
+		if err != nil {
+			return streamError(err)
+		}
+
+		switch block {
+		case gifExtension:
+			label, err := r.ReadByte()
+			if err != nil {
+				return streamError(err)
+			}
+			first, err := readGIFSubBlock(r)
+			if err != nil {
+				return streamError(err)
+			}
+
+			var out io.Writer = io.Discard
+			if label == gifGraphicControl ||
+				(label == gifApplication && bytes.Equal(first[1:], gifLoopingApplication)) {
+				out = dst
+			}
+			if _, err = out.Write(append([]byte{gifExtension, label}, first...)); err != nil {
+				return err
+			}
+			if first[0] != 0 {
+				if err = copyGIFSubBlocks(out, r); err != nil {
+					return err
+				}
+			}
+		case gifImageSeparator:
+			descriptor := make([]byte, 10)
+			descriptor[0] = block
+			if _, err = io.ReadFull(r, descriptor[1:]); err != nil {
+				return streamError(err)
+			}
+			if _, err = dst.Write(descriptor); err != nil {
+				return err
+			}
+			// the local color table and the LZW minimum code size precede the image data
+			size := int64(1)
+			if descriptor[9]&gifColorTableFlag != 0 {
+				size += 3 << (descriptor[9]&7 + 1)
+			}
+			if _, err = io.CopyN(dst, r, size); err != nil {
+				return streamError(err)
+			}
+			if err = copyGIFSubBlocks(dst, r); err != nil {
+				return err
+			}
+			frames++
+		case gifTrailer:
+			_, err = dst.Write([]byte{gifTrailer})
+			return err
+		default:
+			return errMalformed
+		}
+	}
+}
+
+// readGIFSubBlock returns the sub-block with its size byte, the terminator is a sub-block of zero size.
+func readGIFSubBlock(r *bufio.Reader) ([]byte, error) {
+	size, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	block := make([]byte, 1+int(size))
+	block[0] = size
+	if _, err = io.ReadFull(r, block[1:]); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// copyGIFSubBlocks copies the sub-blocks up to and including their terminator.
+func copyGIFSubBlocks(dst io.Writer, r *bufio.Reader) error {
+	for {
+		block, err := readGIFSubBlock(r)
+		if err != nil {
+			return streamError(err)
+		}
+		if _, err = dst.Write(block); err != nil {
+			return err
+		}
+		if block[0] == 0 {
+			return nil
+		}
+	}
+}
+
+// ApplyOrientation transforms img so that it is displayed upright for the given EXIF orientation.
+func ApplyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	src := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				dx, dy = w-1-x, y
+			case 3: // rotated 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, h-1-y
+			case 5: // transposed
+				dx, dy = y, x
+			case 6: // rotated 90 clockwise
+				dx, dy = h-1-y, x
+			case 7: // transversed
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotated 90 counterclockwise
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):][:4], src.Pix[src.PixOffset(x, y):][:4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,576 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image/imagetest"
+)
+
+func segmentMarkers(t *testing.T, data []byte) []byte {
+	t.Helper()
+	segments, err := jpegSegments(data)
+	if err != nil {
+		t.Fatalf("jpegSegments: unexpected error %v", err)
+	}
+	markers := make([]byte, 0, len(segments))
+	for _, segment := range segments {
+		markers = append(markers, segment.marker)
+	}
+	return markers
+}
+
+func TestJPEGSegments(t *testing.T) {
+	data := imagetest.JPEG(t, 4, 4, imagetest.JFIF(), imagetest.Comment())
+	segments, err := jpegSegments(data)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(segments) < 2 || segments[0].marker != imagetest.MarkerAPP0 || segments[1].marker != markerCOM {
+		t.Fatalf("segments do not start with APP0 and COM: %v", segments)
+	}
+	if !bytes.Equal(segments[1].data, imagetest.Comment().Data) {
+		t.Errorf("COM data = %q, expected %q", segments[1].data, imagetest.Comment().Data)
+	}
+
+	type testCase struct {
+		data     []byte
+		segments int
+	}
+
+	tests := map[string]testCase{
+		"empty":                {data: nil, segments: 0},
+		"no SOI":               {data: []byte{0xFF, 0xE0, 0x00, 0x02}, segments: 0},
+		"no SOS":               {data: []byte{0xFF, 0xD8, 0xFF, 0xFE, 0x00, 0x02}, segments: 1},
+		"truncated length":     {data: []byte{0xFF, 0xD8, 0xFF, 0xFE, 0x00}, segments: 0},
+		"truncated segment":    {data: []byte{0xFF, 0xD8, 0xFF, 0xFE, 0x00, 0x08, 'a'}, segments: 0},
+		"length below 2":       {data: []byte{0xFF, 0xD8, 0xFF, 0xFE, 0x00, 0x01, 0xFF, 0xDA}, segments: 0},
+		"garbage between":      {data: []byte{0xFF, 0xD8, 0xFF, 0xFE, 0x00, 0x02, 0x00, 0xFF, 0xDA}, segments: 1},
+		"only fill bytes left": {data: []byte{0xFF, 0xD8, 0xFF, 0xFF}, segments: 0},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			segments, err := jpegSegments(test.data)
+			if !errors.Is(err, errMalformed) {
+				t.Errorf("error = %v, expected %v", err, errMalformed)
+			}
+			// the segments before the error are returned
+			if len(segments) != test.segments {
+				t.Errorf("%d segments, expected %d", len(segments), test.segments)
+			}
+		})
+	}
+}
+
+func TestStripJPEGMetadata(t *testing.T) {
+	data := imagetest.JPEG(t, 8, 8, imagetest.JFIF(), imagetest.EXIF(binary.BigEndian, 6), imagetest.XMP(),
+		imagetest.ICC(), imagetest.IPTC(), imagetest.Comment())
+
+	stripped, err := StripJPEGMetadata(data)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	markers := segmentMarkers(t, stripped)
+	for _, marker := range markers {
+		if metadataMarker(marker) {
+			t.Errorf("segment %#x is kept", marker)
+		}
+	}
+	if !bytes.Contains(markers, []byte{imagetest.MarkerAPP0}) {
+		t.Errorf("JFIF segment is removed")
+	}
+	if !bytes.Contains(markers, []byte{imagetest.MarkerAPP2}) || !bytes.Contains(stripped, imagetest.ICC().Data) {
+		t.Errorf("ICC profile is removed")
+	}
+	for _, leaked := range [][]byte{[]byte("Exif"), []byte("xmpmeta"), []byte("location"), []byte("taken at home")} {
+		if bytes.Contains(stripped, leaked) {
+			t.Errorf("%q is kept", leaked)
+		}
+	}
+
+	// the entropy coded data is copied as is
+	sos := []byte{0xFF, markerSOS}
+	if !bytes.Equal(stripped[bytes.Index(stripped, sos):], data[bytes.Index(data, sos):]) {
+		t.Errorf("image data is changed")
+	}
+	if _, err = BytesToImage(stripped); err != nil {
+		t.Errorf("stripped image can't be decoded: %v", err)
+	}
+}
+
+func TestStripJPEGMetadata_Malformed(t *testing.T) {
+	data := imagetest.JPEG(t, 8, 8, imagetest.EXIF(binary.LittleEndian, 3))
+	sos := bytes.Index(data, []byte{0xFF, markerSOS})
+
+	type testCase struct {
+		data []byte
+	}
+
+	tests := map[string]testCase{
+		"empty":                {data: nil},
+		"not a jpeg":           {data: imagetest.PNG(t, 2, 2)},
+		"only SOI":             {data: data[:2]},
+		"truncated exif":       {data: data[:20]},
+		"truncated before SOS": {data: data[:sos]},
+		"length below 2":       {data: []byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x01, 0xFF, 0xDA}},
+		"garbage after SOI":    {data: []byte{0xFF, 0xD8, 0x00, 0xFF, 0xDA}},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := StripJPEGMetadata(test.data); !errors.Is(err, errMalformed) {
+				t.Errorf("error = %v, expected %v", err, errMalformed)
+			}
+		})
+	}
+}
+
+type failingReader struct {
+	data []byte
+	err  error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
+
+func TestStripMetadataStream_Errors(t *testing.T) {
+	errSource := errors.New("connection reset")
+	jpegData := imagetest.JPEG(t, 8, 8, imagetest.Comment())
+	pngData := imagetest.PNG(t, 8, 8)
+	gifData := imagetest.GIF(t, 8, 8, 10, 20)
+
+	type testCase struct {
+		strip func(dst io.Writer, src io.Reader) error
+		data  []byte
+	}
+
+	tests := map[string]testCase{
+		"jpeg": {strip: StripJPEGMetadataStream, data: jpegData},
+		"png":  {strip: StripPNGMetadataStream, data: pngData},
+		"gif":  {strip: StripGIFMetadataStream, data: gifData},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// errors of the source are not reported as malformed images
+			src := &failingReader{data: test.data[:len(test.data)/2], err: errSource}
+			if err := test.strip(io.Discard, src); !errors.Is(err, errSource) {
+				t.Errorf("source error = %v, expected %v", err, errSource)
+			}
+
+			errDest := errors.New("no space left")
+			if err := test.strip(failingWriter{err: errDest}, bytes.NewReader(test.data)); !errors.Is(err, errDest) {
+				t.Errorf("destination error = %v, expected %v", err, errDest)
+			}
+		})
+	}
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	type testCase struct {
+		data        []byte
+		orientation int
+	}
+
+	tests := map[string]testCase{
+		"no exif":     {data: imagetest.JPEG(t, 4, 4), orientation: 1},
+		"xmp only":    {data: imagetest.JPEG(t, 4, 4, imagetest.XMP()), orientation: 1},
+		"after xmp":   {data: imagetest.JPEG(t, 4, 4, imagetest.XMP(), imagetest.EXIF(binary.BigEndian, 8)), orientation: 8},
+		"after jfif":  {data: imagetest.JPEG(t, 4, 4, imagetest.JFIF(), imagetest.EXIF(binary.LittleEndian, 5)), orientation: 5},
+		"only header": {data: imagetest.JPEG(t, 4, 4, imagetest.EXIF(binary.BigEndian, 6))[:60], orientation: 6},
+	}
+	for orientation := 1; orientation <= 8; orientation++ {
+		for _, order := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+			tests[order.String()+" "+string(rune('0'+orientation))] = testCase{
+				data:        imagetest.JPEG(t, 4, 4, imagetest.EXIF(order, orientation)),
+				orientation: orientation,
+			}
+		}
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if orientation := JPEGOrientation(test.data); orientation != test.orientation {
+				t.Errorf("JPEGOrientation = %d, expected %d", orientation, test.orientation)
+			}
+		})
+	}
+}
+
+func TestTIFFOrientation(t *testing.T) {
+	valid := imagetest.TIFF(binary.BigEndian, 6)
+
+	withOffset := func(offset uint32) []byte {
+		tiff := append([]byte(nil), valid...)
+		binary.BigEndian.PutUint32(tiff[4:], offset)
+		return tiff
+	}
+	withCount := func(count uint16) []byte {
+		tiff := append([]byte(nil), valid...)
+		binary.BigEndian.PutUint16(tiff[8:], count)
+		return tiff
+	}
+
+	type testCase struct {
+		tiff        []byte
+		orientation int
+	}
+
+	tests := map[string]testCase{
+		"valid":              {tiff: valid, orientation: 6},
+		"out of range":       {tiff: imagetest.TIFF(binary.BigEndian, 9), orientation: 1},
+		"zero":               {tiff: imagetest.TIFF(binary.LittleEndian, 0), orientation: 1},
+		"short":              {tiff: valid[:7], orientation: 1},
+		"unknown byte order": {tiff: append([]byte("XX"), valid[2:]...), orientation: 1},
+		"offset in header":   {tiff: withOffset(4), orientation: 1},
+		"offset past end":    {tiff: withOffset(uint32(len(valid))), orientation: 1},
+		"huge offset":        {tiff: withOffset(0xFFFFFFFF), orientation: 1},
+		"entries past end":   {tiff: withCount(100)[:30], orientation: 1},
+		"no entries":         {tiff: withCount(0), orientation: 1},
+		"truncated entry":    {tiff: valid[:len(valid)-8], orientation: 1},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if orientation := tiffOrientation(test.tiff); orientation != test.orientation {
+				t.Errorf("tiffOrientation = %d, expected %d", orientation, test.orientation)
+			}
+		})
+	}
+}
+
+func TestStripPNGMetadata(t *testing.T) {
+	data := imagetest.PNG(t, 8, 8,
+		imagetest.Chunk{Type: "gAMA", Data: []byte{0, 0, 0xB1, 0x8F}},
+		imagetest.Chunk{Type: "eXIf", Data: imagetest.TIFF(binary.BigEndian, 6)},
+		imagetest.Chunk{Type: "tEXt", Data: []byte("Author\x00someone")},
+		imagetest.Chunk{Type: "zTXt", Data: []byte("Comment\x00\x00x")},
+		imagetest.Chunk{Type: "iTXt", Data: []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00<x:xmpmeta/>")},
+		imagetest.Chunk{Type: "tIME", Data: []byte{0x07, 0xE7, 5, 1, 12, 0, 0}},
+	)
+
+	stripped, err := StripPNGMetadata(data)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	types := imagetest.ChunkTypes(stripped)
+	expected := []string{"IHDR", "gAMA", "IDAT", "IEND"}
+	if !reflect.DeepEqual(types, expected) {
+		t.Errorf("chunks = %v, expected %v", types, expected)
+	}
+	if _, err = BytesToImage(stripped); err != nil {
+		t.Errorf("stripped image can't be decoded: %v", err)
+	}
+
+	// the data after IEND is dropped
+	if again, _ := StripPNGMetadata(append(stripped, "trailing"...)); !bytes.Equal(again, stripped) {
+		t.Errorf("data after IEND is kept")
+	}
+}
+
+func TestStripPNGMetadata_Malformed(t *testing.T) {
+	data := imagetest.PNG(t, 8, 8, imagetest.Chunk{Type: "tEXt", Data: []byte("Author\x00someone")})
+
+	type testCase struct {
+		data []byte
+	}
+
+	tests := map[string]testCase{
+		"empty":                {data: nil},
+		"not a png":            {data: imagetest.JPEG(t, 2, 2)},
+		"only signature":       {data: data[:8]},
+		"truncated header":     {data: data[:12]},
+		"truncated chunk":      {data: data[:20]},
+		"truncated text chunk": {data: data[:8+25+12+5]},
+		"no IEND":              {data: data[:len(data)-12]},
+		"huge chunk":           {data: append(append([]byte(nil), data[:8]...), 0xFF, 0xFF, 0xFF, 0xF0, 'I', 'H', 'D', 'R')},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := StripPNGMetadata(test.data); !errors.Is(err, errMalformed) {
+				t.Errorf("error = %v, expected %v", err, errMalformed)
+			}
+		})
+	}
+}
+
+// gifMetadata are the extensions StripGIFMetadata removes: a comment, plain text and XMP.
+func gifMetadata() [][]byte {
+	return [][]byte{
+		imagetest.GIFExtension(0xFE, []byte("taken at home")),
+		imagetest.GIFExtension(0x01, append(make([]byte, 12), "location"...)),
+		imagetest.GIFExtension(gifApplication, []byte("XMP DataXMP<x:xmpmeta/>")),
+	}
+}
+
+func TestStripGIFMetadata(t *testing.T) {
+	type testCase struct {
+		data []byte
+	}
+
+	tests := map[string]testCase{
+		"still":    {data: imagetest.GIF(t, 8, 8)},
+		"animated": {data: imagetest.GIF(t, 8, 8, 10, 20, 30)},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data := imagetest.InsertGIFBlocks(test.data, gifMetadata()...)
+			stripped, err := StripGIFMetadata(data)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			// the encoder writes only graphic control and looping extensions, which are kept with the frames
+			if !bytes.Equal(stripped, test.data) {
+				t.Errorf("stripped image differs from the image without metadata")
+			}
+			for _, leaked := range [][]byte{[]byte("taken at home"), []byte("location"), []byte("xmpmeta")} {
+				if bytes.Contains(stripped, leaked) {
+					t.Errorf("%q is kept", leaked)
+				}
+			}
+
+			// the data after the trailer is dropped, a missing trailer is added
+			if again, _ := StripGIFMetadata(append(stripped, "trailing"...)); !bytes.Equal(again, stripped) {
+				t.Errorf("data after the trailer is kept")
+			}
+			if again, _ := StripGIFMetadata(stripped[:len(stripped)-1]); !bytes.Equal(again, stripped) {
+				t.Errorf("missing trailer is not added")
+			}
+		})
+	}
+}
+
+func TestStripGIFMetadata_Animation(t *testing.T) {
+	data := imagetest.InsertGIFBlocks(imagetest.GIF(t, 8, 8, 10, 20, 30), gifMetadata()...)
+	stripped, err := StripGIFMetadata(data)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	info, err := GIFInfo(stripped)
+	if err != nil {
+		t.Fatalf("GIFInfo: unexpected error %v", err)
+	}
+	if info.Frames != 3 || info.Duration != 600*time.Millisecond {
+		t.Errorf("%d frames of %v, expected 3 frames of 600ms", info.Frames, info.Duration)
+	}
+	anim, err := gif.DecodeAll(bytes.NewReader(stripped))
+	if err != nil {
+		t.Fatalf("stripped image can't be decoded: %v", err)
+	}
+	if !reflect.DeepEqual(anim.Delay, []int{10, 20, 30}) || anim.LoopCount != 0 {
+		t.Errorf("delays %v and loop count %d, expected [10 20 30] and 0", anim.Delay, anim.LoopCount)
+	}
+}
+
+func TestStripGIFMetadata_Malformed(t *testing.T) {
+	data := imagetest.InsertGIFBlocks(imagetest.GIF(t, 8, 8), imagetest.GIFExtension(0xFE, []byte("comment")))
+	// header, logical screen descriptor and the global color table of 256 colors
+	const start = 13 + 3*256
+
+	type testCase struct {
+		data []byte
+	}
+
+	tests := map[string]testCase{
+		"empty":                {data: nil},
+		"not a gif":            {data: imagetest.PNG(t, 2, 2)},
+		"truncated header":     {data: data[:10]},
+		"truncated palette":    {data: data[:start-1]},
+		"no frames":            {data: data[:start]},
+		"truncated comment":    {data: data[:start+5]},
+		"truncated descriptor": {data: data[:start+11+5]},
+		"truncated frame":      {data: data[:len(data)-4]},
+		"unknown block":        {data: append(append([]byte(nil), data[:start]...), 0x00)},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := StripGIFMetadata(test.data); !errors.Is(err, errMalformed) {
+				t.Errorf("error = %v, expected %v", err, errMalformed)
+			}
+		})
+	}
+}
+
+// orientedCorners are the places the top-left and top-right pixels of a 3x2 image
+// are moved to for the orientations, and the size of the result.
+var orientedCorners = map[int]struct {
+	topLeft, topRight image.Point
+	size              image.Point
+}{
+	1: {topLeft: image.Pt(0, 0), topRight: image.Pt(2, 0), size: image.Pt(3, 2)},
+	2: {topLeft: image.Pt(2, 0), topRight: image.Pt(0, 0), size: image.Pt(3, 2)},
+	3: {topLeft: image.Pt(2, 1), topRight: image.Pt(0, 1), size: image.Pt(3, 2)},
+	4: {topLeft: image.Pt(0, 1), topRight: image.Pt(2, 1), size: image.Pt(3, 2)},
+	5: {topLeft: image.Pt(0, 0), topRight: image.Pt(0, 2), size: image.Pt(2, 3)},
+	6: {topLeft: image.Pt(1, 0), topRight: image.Pt(1, 2), size: image.Pt(2, 3)},
+	7: {topLeft: image.Pt(1, 2), topRight: image.Pt(1, 0), size: image.Pt(2, 3)},
+	8: {topLeft: image.Pt(0, 2), topRight: image.Pt(0, 0), size: image.Pt(2, 3)},
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// the image does not start at the origin to check that its bounds are respected
+	src := image.NewRGBA(image.Rect(10, 20, 13, 22))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			src.Set(10+x, 20+y, color.RGBA{R: uint8(x * 100), G: uint8(y * 100), A: 255})
+		}
+	}
+	topLeft, topRight := src.At(10, 20), src.At(12, 20)
+
+	for orientation, expected := range orientedCorners {
+		orientation, expected := orientation, expected
+		t.Run(string(rune('0'+orientation)), func(t *testing.T) {
+			t.Parallel()
+
+			dst := ApplyOrientation(src, orientation)
+			min := dst.Bounds().Min
+			if size := dst.Bounds().Size(); size != expected.size {
+				t.Fatalf("size = %v, expected %v", size, expected.size)
+			}
+			if c := dst.At(min.X+expected.topLeft.X, min.Y+expected.topLeft.Y); c != topLeft {
+				t.Errorf("top-left pixel is not at %v", expected.topLeft)
+			}
+			if c := dst.At(min.X+expected.topRight.X, min.Y+expected.topRight.Y); c != topRight {
+				t.Errorf("top-right pixel is not at %v", expected.topRight)
+			}
+		})
+	}
+
+	for _, orientation := range []int{0, 9, -1} {
+		if dst := ApplyOrientation(src, orientation); dst != image.Image(src) {
+			t.Errorf("orientation %d: image is changed", orientation)
+		}
+	}
+}
+
+func FuzzStripJPEGMetadata(f *testing.F) {
+	f.Add(imagetest.JPEG(f, 4, 4, imagetest.JFIF(), imagetest.EXIF(binary.BigEndian, 6), imagetest.XMP(),
+		imagetest.ICC(), imagetest.Comment()))
+	f.Add([]byte{0xFF, 0xD8, 0xFF, 0xFF, 0xFE, 0x00, 0x02, 0xFF, 0xD0, 0xFF, 0xDA, 0x00})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		JPEGOrientation(data)
+
+		stripped, err := StripJPEGMetadata(data)
+		if err != nil {
+			return
+		}
+		segments, err := jpegSegments(stripped)
+		if err != nil {
+			t.Fatalf("stripped image is malformed: %v", err)
+		}
+		for _, segment := range segments {
+			if metadataMarker(segment.marker) {
+				t.Fatalf("segment %#x is kept", segment.marker)
+			}
+		}
+		if again, err := StripJPEGMetadata(stripped); err != nil || !bytes.Equal(again, stripped) {
+			t.Fatalf("stripping is not idempotent: %v", err)
+		}
+	})
+}
+
+func FuzzTIFFOrientation(f *testing.F) {
+	f.Add(imagetest.TIFF(binary.BigEndian, 6))
+	f.Add(imagetest.TIFF(binary.LittleEndian, 3))
+
+	f.Fuzz(func(t *testing.T, tiff []byte) {
+		if orientation := tiffOrientation(tiff); orientation < 1 || orientation > 8 {
+			t.Fatalf("orientation %d is out of range", orientation)
+		}
+	})
+}
+
+func FuzzStripPNGMetadata(f *testing.F) {
+	f.Add(imagetest.PNG(f, 4, 4, imagetest.Chunk{Type: "tEXt", Data: []byte("Author\x00someone")},
+		imagetest.Chunk{Type: "eXIf", Data: imagetest.TIFF(binary.BigEndian, 6)}))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		stripped, err := StripPNGMetadata(data)
+		if err != nil {
+			return
+		}
+		types := imagetest.ChunkTypes(stripped)
+		if len(types) == 0 || types[len(types)-1] != "IEND" {
+			t.Fatalf("stripped image does not end with IEND: %v", types)
+		}
+		for _, chunkType := range types {
+			if pngMetadataChunks[chunkType] {
+				t.Fatalf("chunk %s is kept", chunkType)
+			}
+		}
+		if again, err := StripPNGMetadata(stripped); err != nil || !bytes.Equal(again, stripped) {
+			t.Fatalf("stripping is not idempotent: %v", err)
+		}
+	})
+}
+
+func FuzzStripGIFMetadata(f *testing.F) {
+	f.Add(imagetest.InsertGIFBlocks(imagetest.GIF(f, 4, 4, 10, 20), gifMetadata()...))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		stripped, err := StripGIFMetadata(data)
+		if err != nil {
+			return
+		}
+		if stripped[len(stripped)-1] != gifTrailer {
+			t.Fatalf("stripped image does not end with the trailer")
+		}
+		if again, err := StripGIFMetadata(stripped); err != nil || !bytes.Equal(again, stripped) {
+			t.Fatalf("stripping is not idempotent: %v", err)
+		}
+	})
+}
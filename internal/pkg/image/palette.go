@@ -0,0 +1,136 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"sort"
+)
+
+const (
+	// palette colors are picked from a copy of the image of at most paletteSampleSize pixels per side
+	paletteSampleSize = 64
+	paletteIterations = 10
+)
+
+// Palette returns up to k dominant colors of img found by k-means clustering, the most common first.
+// Transparent pixels are ignored, so a fully transparent image has no palette.
+func Palette(img image.Image, k int) []Color {
+	small := fit(img, paletteSampleSize)
+
+	var pixels [][3]float64
+	for i := 0; i < len(small.Pix); i += 4 {
+		c := color.NRGBAModel.Convert(color.RGBA{
+			R: small.Pix[i], G: small.Pix[i+1], B: small.Pix[i+2], A: small.Pix[i+3],
+		}).(color.NRGBA)
+		if c.A < 128 {
+			continue
+		}
+		pixels = append(pixels, [3]float64{float64(c.R), float64(c.G), float64(c.B)})
+	}
+	if len(pixels) == 0 || k <= 0 {
+		return nil
+	}
+
+	centroids := initialCentroids(pixels, k)
+	assignment := make([]int, len(pixels))
+	counts := make([]int, len(centroids))
+	for iteration := 0; iteration < paletteIterations; iteration++ {
+		changed := false
+		for i, p := range pixels {
+			nearest := nearestCentroid(centroids, p)
+			if nearest != assignment[i] || iteration == 0 {
+				assignment[i] = nearest
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		sums := make([][3]float64, len(centroids))
+		counts = make([]int, len(centroids))
+		for i, p := range pixels {
+			c := assignment[i]
+			for ch := range p {
+				sums[c][ch] += p[ch]
+			}
+			counts[c]++
+		}
+		for c := range centroids {
+			if counts[c] == 0 {
+				continue
+			}
+			for ch := range sums[c] {
+				centroids[c][ch] = sums[c][ch] / float64(counts[c])
+			}
+		}
+	}
+
+	order := make([]int, 0, len(centroids))
+	for c := range centroids {
+		if counts[c] > 0 {
+			order = append(order, c)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return counts[order[i]] > counts[order[j]]
+	})
+
+	palette := make([]Color, 0, len(order))
+	for _, c := range order {
+		palette = append(palette, Color{
+			Red:   uint8(centroids[c][0] + 0.5),
+			Green: uint8(centroids[c][1] + 0.5),
+			Blue:  uint8(centroids[c][2] + 0.5),
+		})
+	}
+	return palette
+}
+
+// initialCentroids spreads the starting centroids over the distinct colors of the pixels sorted by brightness,
+// so the result does not depend on random seeding and a large area of one color does not take
+// several centroids.
+func initialCentroids(pixels [][3]float64, k int) [][3]float64 {
+	sorted := make([][3]float64, len(pixels))
+	copy(sorted, pixels)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return luminance(sorted[i]) < luminance(sorted[j])
+	})
+
+	distinct := make([][3]float64, 0, len(sorted))
+	seen := make(map[[3]float64]bool, len(sorted))
+	for _, p := range sorted {
+		if !seen[p] {
+			seen[p] = true
+			distinct = append(distinct, p)
+		}
+	}
+	if k > len(distinct) {
+		k = len(distinct)
+	}
+
+	centroids := make([][3]float64, 0, k)
+	for i := 0; i < k; i++ {
+		centroids = append(centroids, distinct[(2*i+1)*len(distinct)/(2*k)])
+	}
+	return centroids
+}
+
+func nearestCentroid(centroids [][3]float64, p [3]float64) int {
+	nearest, best := 0, -1.0
+	for c, centroid := range centroids {
+		var dist float64
+		for ch := range p {
+			d := p[ch] - centroid[ch]
+			dist += d * d
+		}
+		if best < 0 || dist < best {
+			nearest, best = c, dist
+		}
+	}
+	return nearest
+}
+
+func luminance(p [3]float64) float64 {
+	return 0.299*p[0] + 0.587*p[1] + 0.114*p[2]
+}
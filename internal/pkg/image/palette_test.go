@@ -0,0 +1,110 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"reflect"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image/imagetest"
+)
+
+// stripes fills img with vertical stripes of the colors, each of the given width.
+func stripes(img draw.Image, widths []int, colors []color.Color) {
+	bounds := img.Bounds()
+	x := bounds.Min.X
+	for i, width := range widths {
+		rect := image.Rect(x, bounds.Min.Y, x+width, bounds.Max.Y)
+		draw.Draw(img, rect, image.NewUniform(colors[i]), image.Point{}, draw.Src)
+		x += width
+	}
+}
+
+func TestPalette(t *testing.T) {
+	red := color.RGBA{R: 200, G: 30, B: 40, A: 255}
+	green := color.RGBA{R: 40, G: 180, B: 60, A: 255}
+	blue := color.RGBA{R: 20, G: 50, B: 210, A: 255}
+
+	threeColors := image.NewRGBA(image.Rect(0, 0, 60, 10))
+	stripes(threeColors, []int{12, 30, 18}, []color.Color{red, green, blue})
+
+	// the same stripes in an image that does not start at the origin
+	offOrigin := image.NewRGBA(image.Rect(-30, 7, 30, 17))
+	stripes(offOrigin, []int{12, 30, 18}, []color.Color{red, green, blue})
+
+	singleColor := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	stripes(singleColor, []int{8}, []color.Color{blue})
+
+	halfTransparent := image.NewNRGBA(image.Rect(0, 0, 20, 10))
+	stripes(halfTransparent, []int{10, 10}, []color.Color{color.NRGBA{R: 255}, green})
+
+	type testCase struct {
+		img     image.Image
+		k       int
+		palette []Color
+	}
+
+	tests := map[string]testCase{
+		"most common first": {
+			img:     threeColors,
+			k:       3,
+			palette: []Color{{40, 180, 60}, {20, 50, 210}, {200, 30, 40}},
+		},
+		"off origin": {
+			img:     offOrigin,
+			k:       3,
+			palette: []Color{{40, 180, 60}, {20, 50, 210}, {200, 30, 40}},
+		},
+		"more clusters than colors": {
+			img:     threeColors,
+			k:       6,
+			palette: []Color{{40, 180, 60}, {20, 50, 210}, {200, 30, 40}},
+		},
+		"single color": {
+			img:     singleColor,
+			k:       5,
+			palette: []Color{{20, 50, 210}},
+		},
+		"transparent pixels ignored": {
+			img:     halfTransparent,
+			k:       2,
+			palette: []Color{{40, 180, 60}},
+		},
+		"fully transparent": {
+			img:     image.NewNRGBA(image.Rect(0, 0, 4, 4)),
+			k:       3,
+			palette: nil,
+		},
+		"no clusters": {
+			img:     threeColors,
+			k:       0,
+			palette: nil,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if palette := Palette(test.img, test.k); !reflect.DeepEqual(palette, test.palette) {
+				t.Errorf("Palette = %v, expected %v", palette, test.palette)
+			}
+		})
+	}
+}
+
+func TestPalette_Deterministic(t *testing.T) {
+	img := imagetest.Gradient(200, 150)
+
+	palette := Palette(img, 5)
+	if len(palette) != 5 {
+		t.Fatalf("%d colors, expected 5", len(palette))
+	}
+	for i := 0; i < 10; i++ {
+		if again := Palette(img, 5); !reflect.DeepEqual(again, palette) {
+			t.Fatalf("Palette = %v, then %v", palette, again)
+		}
+	}
+}
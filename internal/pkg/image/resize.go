@@ -0,0 +1,127 @@
+package image
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+)
+
+const (
+	OriginalRendition = "original"
+	// PosterRendition is the first frame of an animated GIF or a video in full size.
+	PosterRendition  = "poster"
+	renditionQuality = 85
+)
+
+// RenditionWidths are the widths of the resized copies made for every pin image.
+//
+// The copies are JPEG only, WebP copies asked for along with them are dropped for now: neither the
+// standard library nor golang.org/x/image can encode WebP, and the libwebp bindings need cgo, which
+// the images service is built without. Once an encoder is available, WebP copies of the same widths
+// can be uploaded next to the JPEG ones under their own rendition names.
+var RenditionWidths = []int{236, 474, 736}
+
+func RenditionName(width int) string {
+	return fmt.Sprintf("%dw", width)
+}
+
+// Resize scales img down to the given width keeping the aspect ratio.
+// Every destination pixel is the average of the source pixels it covers.
+// Images that are not wider than width are returned unchanged.
+func Resize(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if width <= 0 || srcW <= width {
+		return img
+	}
+
+	height := srcH * width / srcW
+	if height < 1 {
+		height = 1
+	}
+	return scale(img, width, height)
+}
+
+// scale resizes img to exactly width x height.
+func scale(img image.Image, width, height int) *image.RGBA {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	src := image.NewRGBA(image.Rect(0, 0, srcW, srcH))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for dy := 0; dy < height; dy++ {
+		y0 := dy * srcH / height
+		y1 := (dy + 1) * srcH / height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+
+		for dx := 0; dx < width; dx++ {
+			x0 := dx * srcW / width
+			x1 := (dx + 1) * srcW / width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n uint32
+			for y := y0; y < y1; y++ {
+				offset := src.PixOffset(x0, y)
+				for x := x0; x < x1; x++ {
+					r += uint32(src.Pix[offset])
+					g += uint32(src.Pix[offset+1])
+					b += uint32(src.Pix[offset+2])
+					a += uint32(src.Pix[offset+3])
+					offset += 4
+					n++
+				}
+			}
+
+			offset := dst.PixOffset(dx, dy)
+			dst.Pix[offset] = uint8(r / n)
+			dst.Pix[offset+1] = uint8(g / n)
+			dst.Pix[offset+2] = uint8(b / n)
+			dst.Pix[offset+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// fit scales img down so that neither side is longer than size, keeping the aspect ratio.
+func fit(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w > size || h > size {
+		if w >= h {
+			w, h = size, h*size/w
+		} else {
+			w, h = w*size/h, size
+		}
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return scale(img, w, h)
+}
+
+// EncodeJPEG flattens transparent images onto white, JPEG has no alpha channel.
+func EncodeJPEG(img image.Image) ([]byte, error) {
+	if opaque, ok := img.(interface{ Opaque() bool }); !ok || !opaque.Opaque() {
+		flat := image.NewRGBA(img.Bounds())
+		draw.Draw(flat, flat.Bounds(), image.White, image.Point{}, draw.Src)
+		draw.Draw(flat, flat.Bounds(), img, img.Bounds().Min, draw.Over)
+		img = flat
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := jpeg.Encode(buf, img, &jpeg.Options{Quality: renditionQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
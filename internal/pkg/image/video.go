@@ -0,0 +1,471 @@
+package image
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+// mp4Box is an ISO BMFF box, offsets point into the file.
+type mp4Box struct {
+	typ   string
+	start int
+	body  int // offset of the payload after the box header
+	end   int
+}
+
+// mp4Boxes splits data[start:end] into boxes, the size of the last box may be zero meaning up to end.
+func mp4Boxes(data []byte, start, end int) ([]mp4Box, error) {
+	var boxes []mp4Box
+	for pos := start; pos < end; {
+		if pos+8 > end {
+			return nil, errMalformed
+		}
+		size := uint64(binary.BigEndian.Uint32(data[pos:]))
+		box := mp4Box{typ: string(data[pos+4 : pos+8]), start: pos, body: pos + 8}
+		switch size {
+		case 0:
+			size = uint64(end - pos)
+		case 1:
+			if pos+16 > end {
+				return nil, errMalformed
+			}
+			size = binary.BigEndian.Uint64(data[pos+8:])
+			box.body += 8
+		}
+		if size < uint64(box.body-pos) || size > uint64(end-pos) {
+			return nil, errMalformed
+		}
+		box.end = pos + int(size)
+		boxes = append(boxes, box)
+		pos = box.end
+	}
+	return boxes, nil
+}
+
+func findMP4Box(boxes []mp4Box, typ string) (mp4Box, bool) {
+	for _, box := range boxes {
+		if box.typ == typ {
+			return box, true
+		}
+	}
+	return mp4Box{}, false
+}
+
+// MP4Info reads the duration from the movie header and the dimensions from the header of the first
+// video track, rotated tracks get their displayed dimensions. Fragmented files without the total
+// duration in the movie extends header are rejected since their length is unknown.
+func MP4Info(data []byte) (MediaInfo, error) {
+	top, err := mp4Boxes(data, 0, len(data))
+	if err != nil {
+		return MediaInfo{}, err
+	}
+	moov, found := findMP4Box(top, "moov")
+	if !found {
+		return MediaInfo{}, errMalformed
+	}
+	boxes, err := mp4Boxes(data, moov.body, moov.end)
+	if err != nil {
+		return MediaInfo{}, err
+	}
+
+	mvhd, found := findMP4Box(boxes, "mvhd")
+	if !found {
+		return MediaInfo{}, errMalformed
+	}
+	timescale, duration, err := mp4Duration(data[mvhd.body:mvhd.end])
+	if err != nil {
+		return MediaInfo{}, err
+	}
+	if duration == 0 {
+		duration, err = mp4FragmentDuration(data, boxes)
+		if err != nil {
+			return MediaInfo{}, err
+		}
+	}
+	if timescale == 0 {
+		return MediaInfo{}, errMalformed
+	}
+	length, err := mediaDuration(float64(duration), float64(time.Second)/float64(timescale))
+	if err != nil {
+		return MediaInfo{}, err
+	}
+
+	info := MediaInfo{Format: FormatMP4, Duration: length}
+	for _, trak := range boxes {
+		if trak.typ != "trak" {
+			continue
+		}
+		width, height, video, err := mp4VideoTrack(data, trak)
+		if err != nil {
+			return MediaInfo{}, err
+		}
+		if video {
+			if width == 0 || height == 0 {
+				return MediaInfo{}, errMalformed
+			}
+			info.Width, info.Height = width, height
+			return info, nil
+		}
+	}
+	return MediaInfo{}, errMalformed
+}
+
+// mediaDuration converts a duration in units of the given length, the duration must be positive
+// and fit time.Duration. Otherwise it would overflow and get past the duration limits.
+func mediaDuration(units, unit float64) (time.Duration, error) {
+	duration := units * unit
+	if !(duration > 0) || duration >= math.MaxInt64 {
+		return 0, errMalformed
+	}
+	return time.Duration(duration), nil
+}
+
+// mp4Duration parses the movie header, the duration is in timescale units per second.
+func mp4Duration(mvhd []byte) (timescale, duration uint64, err error) {
+	if len(mvhd) < 1 {
+		return 0, 0, errMalformed
+	}
+	if mvhd[0] == 1 {
+		if len(mvhd) < 32 {
+			return 0, 0, errMalformed
+		}
+		return uint64(binary.BigEndian.Uint32(mvhd[20:])), binary.BigEndian.Uint64(mvhd[24:]), nil
+	}
+	if len(mvhd) < 20 {
+		return 0, 0, errMalformed
+	}
+	return uint64(binary.BigEndian.Uint32(mvhd[12:])), uint64(binary.BigEndian.Uint32(mvhd[16:])), nil
+}
+
+func mp4FragmentDuration(data []byte, moov []mp4Box) (uint64, error) {
+	mvex, found := findMP4Box(moov, "mvex")
+	if !found {
+		return 0, nil
+	}
+	boxes, err := mp4Boxes(data, mvex.body, mvex.end)
+	if err != nil {
+		return 0, err
+	}
+	mehd, found := findMP4Box(boxes, "mehd")
+	if !found {
+		return 0, nil
+	}
+
+	body := data[mehd.body:mehd.end]
+	switch {
+	case len(body) >= 12 && body[0] == 1:
+		return binary.BigEndian.Uint64(body[4:]), nil
+	case len(body) >= 8 && body[0] == 0:
+		return uint64(binary.BigEndian.Uint32(body[4:])), nil
+	}
+	return 0, errMalformed
+}
+
+// mp4VideoTrack returns the dimensions of the track if its media handler is video.
+func mp4VideoTrack(data []byte, trak mp4Box) (width, height int, video bool, err error) {
+	boxes, err := mp4Boxes(data, trak.body, trak.end)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	mdia, found := findMP4Box(boxes, "mdia")
+	if !found {
+		return 0, 0, false, nil
+	}
+	mdiaBoxes, err := mp4Boxes(data, mdia.body, mdia.end)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	hdlr, found := findMP4Box(mdiaBoxes, "hdlr")
+	if !found || hdlr.end-hdlr.body < 12 || string(data[hdlr.body+8:hdlr.body+12]) != "vide" {
+		return 0, 0, false, nil
+	}
+
+	tkhd, found := findMP4Box(boxes, "tkhd")
+	if !found {
+		return 0, 0, false, errMalformed
+	}
+	body := data[tkhd.body:tkhd.end]
+	// version and flags, times, track ID and duration, then reserved fields, layer, group and volume
+	matrix := 4 + 20 + 16
+	if len(body) > 0 && body[0] == 1 {
+		matrix = 4 + 32 + 16
+	}
+	if len(body) < matrix+36+8 {
+		return 0, 0, false, errMalformed
+	}
+
+	// the dimensions are 16.16 fixed point numbers
+	width = int(binary.BigEndian.Uint32(body[matrix+36:]) >> 16)
+	height = int(binary.BigEndian.Uint32(body[matrix+40:]) >> 16)
+	// the matrix starts with a and b of the transformation, a is zero for rotations by 90 degrees
+	a, b := binary.BigEndian.Uint32(body[matrix:]), binary.BigEndian.Uint32(body[matrix+4:])
+	if a == 0 && b != 0 {
+		width, height = height, width
+	}
+	return width, height, true, nil
+}
+
+// Boxes that carry user data such as the recording location, their contents are not needed for playback.
+var mp4MetadataBoxes = map[string]bool{
+	"udta": true,
+	"meta": true,
+}
+
+// StripMP4Metadata hides the user data and metadata boxes of the file and its tracks by changing
+// their type to "free". The boxes are not removed, so the media data offsets stay valid.
+func StripMP4Metadata(data []byte) ([]byte, error) {
+	out := make([]byte, len(data))
+	copy(out, data)
+
+	top, err := mp4Boxes(out, 0, len(out))
+	if err != nil {
+		return nil, err
+	}
+	hideMP4Metadata(out, top)
+
+	moov, found := findMP4Box(top, "moov")
+	if !found {
+		return nil, errMalformed
+	}
+	boxes, err := mp4Boxes(out, moov.body, moov.end)
+	if err != nil {
+		return nil, err
+	}
+	hideMP4Metadata(out, boxes)
+
+	for _, trak := range boxes {
+		if trak.typ != "trak" {
+			continue
+		}
+		trakBoxes, err := mp4Boxes(out, trak.body, trak.end)
+		if err != nil {
+			return nil, err
+		}
+		hideMP4Metadata(out, trakBoxes)
+	}
+	return out, nil
+}
+
+func hideMP4Metadata(data []byte, boxes []mp4Box) {
+	for _, box := range boxes {
+		if mp4MetadataBoxes[box.typ] {
+			// the type follows the 32-bit size
+			copy(data[box.start+4:], "free")
+		}
+	}
+}
+
+var ebmlMagic = []byte{0x1A, 0x45, 0xDF, 0xA3}
+
+// EBML elements read by WebMInfo, see https://www.matroska.org/technical/elements.html
+const (
+	ebmlHeader       = 0x1A45DFA3
+	ebmlDocType      = 0x4282
+	mkvSegment       = 0x18538067
+	mkvInfo          = 0x1549A966
+	mkvTimecodeScale = 0x2AD7B1
+	mkvDuration      = 0x4489
+	mkvTracks        = 0x1654AE6B
+	mkvTrackEntry    = 0xAE
+	mkvVideo         = 0xE0
+	mkvPixelWidth    = 0xB0
+	mkvPixelHeight   = 0xBA
+	mkvCluster       = 0x1F43B675
+	mkvTimecode      = 0xE7
+	mkvBlockGroup    = 0xA0
+	mkvBlock         = 0xA1
+	mkvSimpleBlock   = 0xA3
+)
+
+// mkvDefaultTimecodeScale is the number of nanoseconds in a timecode unit if the file does not say.
+const mkvDefaultTimecodeScale = 1000000
+
+type ebmlElement struct {
+	id      uint64
+	body    int
+	end     int
+	unknown bool // the size is unknown, the element lasts until its parent ends
+}
+
+// readEBMLVint reads a variable length integer, IDs keep the length marker bit and sizes do not.
+func readEBMLVint(data []byte, pos int, id bool) (value uint64, length int, err error) {
+	if pos >= len(data) || data[pos] == 0 {
+		return 0, 0, errMalformed
+	}
+	length = 1
+	for mask := byte(0x80); data[pos]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if pos+length > len(data) || (id && length > 4) {
+		return 0, 0, errMalformed
+	}
+
+	value = uint64(data[pos])
+	if !id {
+		value &= 0xFF >> length
+	}
+	for i := 1; i < length; i++ {
+		value = value<<8 | uint64(data[pos+i])
+	}
+	return value, length, nil
+}
+
+func readEBMLElement(data []byte, pos, limit int) (ebmlElement, error) {
+	id, idLen, err := readEBMLVint(data[:limit], pos, true)
+	if err != nil {
+		return ebmlElement{}, err
+	}
+	size, sizeLen, err := readEBMLVint(data[:limit], pos+idLen, false)
+	if err != nil {
+		return ebmlElement{}, err
+	}
+
+	el := ebmlElement{id: id, body: pos + idLen + sizeLen}
+	if size == 1<<(7*sizeLen)-1 {
+		el.unknown = true
+		el.end = limit
+		return el, nil
+	}
+	if size > uint64(limit-el.body) {
+		return ebmlElement{}, errMalformed
+	}
+	el.end = el.body + int(size)
+	return el, nil
+}
+
+func ebmlUint(b []byte) uint64 {
+	var value uint64
+	for _, c := range b {
+		value = value<<8 | uint64(c)
+	}
+	return value
+}
+
+// webmReader collects the fields of WebMInfo while walking the segment.
+type webmReader struct {
+	data          []byte
+	timecodeScale uint64
+	duration      float64 // in timecode units
+	width         int
+	height        int
+	clusterTime   uint64
+	lastBlock     uint64 // timecode of the latest block, for files without duration
+}
+
+// WebMInfo reads the duration from the segment info and the dimensions from the first video track.
+// Recorded files often have no duration in the header, the time of the last block is used then.
+func WebMInfo(data []byte) (MediaInfo, error) {
+	header, err := readEBMLElement(data, 0, len(data))
+	if err != nil || header.id != ebmlHeader {
+		return MediaInfo{}, errMalformed
+	}
+	docType := ""
+	for pos := header.body; pos < header.end; {
+		el, err := readEBMLElement(data, pos, header.end)
+		if err != nil {
+			return MediaInfo{}, err
+		}
+		if el.id == ebmlDocType {
+			docType = string(data[el.body:el.end])
+		}
+		pos = el.end
+	}
+	if docType != FormatWebM {
+		return MediaInfo{}, errMalformed
+	}
+
+	r := webmReader{data: data, timecodeScale: mkvDefaultTimecodeScale}
+	for pos := header.end; pos < len(data); {
+		el, err := readEBMLElement(data, pos, len(data))
+		if err != nil {
+			return MediaInfo{}, err
+		}
+		if el.id == mkvSegment {
+			if _, err = r.walk(el.body, el.end); err != nil {
+				return MediaInfo{}, err
+			}
+			break
+		}
+		pos = el.end
+	}
+
+	duration := r.duration
+	if duration == 0 {
+		duration = float64(r.lastBlock)
+	}
+	// the sizes are unsigned and overflow int if too large
+	if r.width <= 0 || r.height <= 0 {
+		return MediaInfo{}, errMalformed
+	}
+	length, err := mediaDuration(duration, float64(r.timecodeScale))
+	if err != nil {
+		return MediaInfo{}, err
+	}
+	return MediaInfo{Format: FormatWebM, Width: r.width, Height: r.height, Duration: length}, nil
+}
+
+// walk reads the elements in data[start:end], it reports done once the clusters are reached
+// and everything needed is already known. Elements of unknown size are entered without skipping,
+// their children are read as if they were siblings.
+func (r *webmReader) walk(start, end int) (done bool, err error) {
+	for pos := start; pos < end; {
+		el, err := readEBMLElement(r.data, pos, end)
+		if err != nil {
+			return false, err
+		}
+		body := r.data[el.body:el.end]
+
+		switch el.id {
+		case mkvInfo, mkvTracks, mkvTrackEntry, mkvVideo, mkvBlockGroup, mkvCluster:
+			if el.id == mkvCluster && r.duration > 0 && r.width > 0 {
+				return true, nil
+			}
+			if el.unknown {
+				pos = el.body
+				continue
+			}
+			if done, err = r.walk(el.body, el.end); done || err != nil {
+				return done, err
+			}
+		case mkvTimecodeScale:
+			r.timecodeScale = ebmlUint(body)
+		case mkvDuration:
+			switch len(body) {
+			case 4:
+				r.duration = float64(math.Float32frombits(binary.BigEndian.Uint32(body)))
+			case 8:
+				r.duration = math.Float64frombits(binary.BigEndian.Uint64(body))
+			default:
+				return false, errMalformed
+			}
+		case mkvPixelWidth:
+			if r.width == 0 {
+				r.width = int(ebmlUint(body))
+			}
+		case mkvPixelHeight:
+			if r.height == 0 {
+				r.height = int(ebmlUint(body))
+			}
+		case mkvTimecode:
+			r.clusterTime = ebmlUint(body)
+		case mkvSimpleBlock, mkvBlock:
+			// the track number is followed by the timecode relative to the cluster
+			_, trackLen, err := readEBMLVint(r.data[:el.end], el.body, false)
+			if err != nil || el.body+trackLen+2 > el.end {
+				return false, errMalformed
+			}
+			relative := int64(int16(binary.BigEndian.Uint16(r.data[el.body+trackLen:])))
+			if t := int64(r.clusterTime) + relative; t > int64(r.lastBlock) {
+				r.lastBlock = uint64(t)
+			}
+		}
+
+		if el.unknown {
+			// only master elements may have unknown sizes
+			return false, errMalformed
+		}
+		pos = el.end
+	}
+	return false, nil
+}
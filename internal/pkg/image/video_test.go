@@ -0,0 +1,281 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// readFixture returns a file from testdata. The videos there have no playable frames, only the boxes
+// and elements the parsers read.
+func readFixture(t testing.TB, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("can't read fixture: %v", err)
+	}
+	return data
+}
+
+// replaced returns a copy of data with all the occurrences of old replaced by new.
+func replaced(data []byte, old, new string) []byte {
+	return bytes.ReplaceAll(data, []byte(old), []byte(new))
+}
+
+// withUint32 returns a copy of data with the big endian value written at the offset from
+// the first occurrence of after.
+func withUint32(data []byte, after string, offset int, value uint32) []byte {
+	out := append([]byte(nil), data...)
+	binary.BigEndian.PutUint32(out[bytes.Index(out, []byte(after))+offset:], value)
+	return out
+}
+
+func TestMP4Info(t *testing.T) {
+	video := readFixture(t, "video.mp4")
+	rotated := readFixture(t, "rotated.mp4")
+	fragmented := readFixture(t, "fragmented.mp4")
+
+	// the header of the video track is the second one, the width follows the matrix
+	zeroWidth := append([]byte(nil), video...)
+	binary.BigEndian.PutUint32(zeroWidth[bytes.LastIndex(zeroWidth, []byte("tkhd"))+80:], 0)
+
+	type testCase struct {
+		data []byte
+		info MediaInfo
+		err  error
+	}
+
+	tests := map[string]testCase{
+		"audio track first": {
+			data: video,
+			info: MediaInfo{Format: FormatMP4, Width: 320, Height: 240, Duration: 2500 * time.Millisecond},
+		},
+		"rotated": {
+			data: rotated,
+			info: MediaInfo{Format: FormatMP4, Width: 360, Height: 640, Duration: 3 * time.Second},
+		},
+		"fragmented": {
+			data: fragmented,
+			info: MediaInfo{Format: FormatMP4, Width: 1280, Height: 720, Duration: 5 * time.Second},
+		},
+		"last box up to the end": {
+			data: withUint32(video, "mdat", -4, 0),
+			info: MediaInfo{Format: FormatMP4, Width: 320, Height: 240, Duration: 2500 * time.Millisecond},
+		},
+		"fragmented without duration": {data: replaced(fragmented, "mehd", "skip"), err: errMalformed},
+		"no movie":                    {data: replaced(video, "moov", "skip"), err: errMalformed},
+		"no movie header":             {data: replaced(video, "mvhd", "skip"), err: errMalformed},
+		"no video track":              {data: replaced(video, "vide", "soun"), err: errMalformed},
+		"zero timescale":              {data: withUint32(video, "mvhd", 16, 0), err: errMalformed},
+		"zero duration":               {data: withUint32(video, "mvhd", 20, 0), err: errMalformed},
+		"zero width":                  {data: zeroWidth, err: errMalformed},
+		"duration overflow":           {data: withUint32(withUint32(rotated, "mvhd", 28, 0xFFFFFFFF), "mvhd", 24, 1), err: errMalformed},
+		"truncated":                   {data: video[:len(video)-1], err: errMalformed},
+		"truncated box header":        {data: video[:bytes.Index(video, []byte("moov"))], err: errMalformed},
+		"truncated 64-bit size":       {data: rotated[:bytes.Index(rotated, []byte("mdat"))+8], err: errMalformed},
+		"box smaller than its header": {data: withUint32(video, "ftyp", -4, 4), err: errMalformed},
+		"box larger than its parent":  {data: withUint32(video, "mvhd", -4, 0xFFFF), err: errMalformed},
+		"empty":                       {data: nil, err: errMalformed},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			info, err := MP4Info(test.data)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if info != test.info {
+				t.Errorf("MP4Info = %+v, expected %+v", info, test.info)
+			}
+		})
+	}
+}
+
+func TestStripMP4Metadata(t *testing.T) {
+	type testCase struct {
+		data []byte
+		info MediaInfo
+	}
+
+	tests := map[string]testCase{
+		"video": {
+			data: readFixture(t, "video.mp4"),
+			info: MediaInfo{Format: FormatMP4, Width: 320, Height: 240, Duration: 2500 * time.Millisecond},
+		},
+		"movie after media data": {
+			data: readFixture(t, "rotated.mp4"),
+			info: MediaInfo{Format: FormatMP4, Width: 360, Height: 640, Duration: 3 * time.Second},
+		},
+		"no metadata": {
+			data: readFixture(t, "fragmented.mp4"),
+			info: MediaInfo{Format: FormatMP4, Width: 1280, Height: 720, Duration: 5 * time.Second},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			original := append([]byte(nil), test.data...)
+			stripped, err := StripMP4Metadata(test.data)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !bytes.Equal(test.data, original) {
+				t.Errorf("input is modified")
+			}
+
+			// only the box types change, the media data stays where it was
+			expected := replaced(replaced(original, "udta", "free"), "meta", "free")
+			if !bytes.Equal(stripped, expected) {
+				t.Errorf("stripped file differs from the one with metadata boxes hidden")
+			}
+			if info, err := MP4Info(stripped); err != nil || info != test.info {
+				t.Errorf("MP4Info of the stripped file = %+v, %v, expected %+v", info, err, test.info)
+			}
+			if again, err := StripMP4Metadata(stripped); err != nil || !bytes.Equal(again, stripped) {
+				t.Errorf("stripping is not idempotent: %v", err)
+			}
+		})
+	}
+}
+
+func TestStripMP4Metadata_Malformed(t *testing.T) {
+	video := readFixture(t, "video.mp4")
+	rotated := readFixture(t, "rotated.mp4")
+
+	tests := map[string][]byte{
+		"truncated":             video[:len(video)-1],
+		"no movie":              replaced(video, "moov", "skip"),
+		"malformed track":       withUint32(video, "tkhd", -4, 0xFFFF),
+		"malformed movie":       withUint32(video, "mvhd", -4, 3),
+		"truncated 64-bit size": rotated[:bytes.Index(rotated, []byte("mdat"))+8],
+		"empty":                 {},
+	}
+
+	for name, data := range tests {
+		data := data
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := StripMP4Metadata(data); !errors.Is(err, errMalformed) {
+				t.Errorf("expected error %v, got %v", errMalformed, err)
+			}
+		})
+	}
+}
+
+func TestWebMInfo(t *testing.T) {
+	video := readFixture(t, "video.webm")
+	recorded := readFixture(t, "recorded.webm")
+	segment := bytes.Index(video, []byte{0x18, 0x53, 0x80, 0x67})
+
+	type testCase struct {
+		data []byte
+		info MediaInfo
+		err  error
+	}
+
+	tests := map[string]testCase{
+		"duration in the header": {
+			data: video,
+			info: MediaInfo{Format: FormatWebM, Width: 320, Height: 180, Duration: 1500 * time.Millisecond},
+		},
+		"recorded": {
+			data: recorded,
+			info: MediaInfo{Format: FormatWebM, Width: 640, Height: 480, Duration: 1960 * time.Millisecond},
+		},
+		"matroska":        {data: replaced(video, "webm", "mkv\x00"), err: errMalformed},
+		"no header":       {data: video[1:], err: errMalformed},
+		"no segment":      {data: video[:segment], err: errMalformed},
+		"invalid id":      {data: append(append(append([]byte(nil), video[:segment]...), 0), video[segment+1:]...), err: errMalformed},
+		"truncated":       {data: video[:len(video)-3], err: errMalformed},
+		"truncated block": {data: recorded[:len(recorded)-3], err: errMalformed},
+		// a zero duration is the same as none, the last block of the video ends at 400 units
+		"zero duration": {
+			data: replaced(video, "\x44\x89\x88\x40\xcd\x4c", "\x44\x89\x88\x00\x00\x00"),
+			info: MediaInfo{Format: FormatWebM, Width: 320, Height: 180, Duration: 40 * time.Millisecond},
+		},
+		"zero width":        {data: replaced(video, "\xb0\x82\x01\x40", "\xb0\x82\x00\x00"), err: errMalformed},
+		"negative duration": {data: replaced(video, "\x44\x89\x88\x40\xcd\x4c", "\x44\x89\x88\xc0\xcd\x4c"), err: errMalformed},
+		"NaN duration":      {data: replaced(video, "\x44\x89\x88\x40\xcd\x4c", "\x44\x89\x88\xff\xf8\x00"), err: errMalformed},
+		"empty":             {data: nil, err: errMalformed},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			info, err := WebMInfo(test.data)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if info != test.info {
+				t.Errorf("WebMInfo = %+v, expected %+v", info, test.info)
+			}
+		})
+	}
+}
+
+func FuzzMP4Info(f *testing.F) {
+	for _, name := range []string{"video.mp4", "rotated.mp4", "fragmented.mp4"} {
+		f.Add(readFixture(f, name))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		info, err := MP4Info(data)
+		if err != nil {
+			return
+		}
+		if info.Width <= 0 || info.Height <= 0 || info.Duration <= 0 {
+			t.Fatalf("MP4Info = %+v", info)
+		}
+	})
+}
+
+func FuzzStripMP4Metadata(f *testing.F) {
+	for _, name := range []string{"video.mp4", "rotated.mp4", "fragmented.mp4"} {
+		f.Add(readFixture(f, name))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		stripped, err := StripMP4Metadata(data)
+		if err != nil {
+			return
+		}
+		if len(stripped) != len(data) {
+			t.Fatalf("stripped file is %d bytes long, expected %d", len(stripped), len(data))
+		}
+		info, infoErr := MP4Info(data)
+		if strippedInfo, strippedErr := MP4Info(stripped); strippedInfo != info || (infoErr == nil) != (strippedErr == nil) {
+			t.Fatalf("MP4Info of the stripped file = %+v, %v, expected %+v, %v", strippedInfo, strippedErr, info, infoErr)
+		}
+		if again, err := StripMP4Metadata(stripped); err != nil || !bytes.Equal(again, stripped) {
+			t.Fatalf("stripping is not idempotent: %v", err)
+		}
+	})
+}
+
+func FuzzWebMInfo(f *testing.F) {
+	f.Add(readFixture(f, "video.webm"))
+	f.Add(readFixture(f, "recorded.webm"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		info, err := WebMInfo(data)
+		if err != nil {
+			return
+		}
+		if info.Width <= 0 || info.Height <= 0 || info.Duration <= 0 {
+			t.Fatalf("WebMInfo = %+v", info)
+		}
+	})
+}
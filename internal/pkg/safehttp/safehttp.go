@@ -0,0 +1,120 @@
+// Package safehttp provides an HTTP client for fetching URLs given by users. It connects to public
+// addresses on the standard ports only, so that users cannot make the server reach internal services.
+package safehttp
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const maxRedirects = 3
+
+var (
+	ErrForbiddenAddress = errors.New("address is not public")
+	ErrForbiddenURL     = errors.New("url is not a public http url")
+	ErrTooManyRedirects = errors.New("too many redirects")
+)
+
+// Ranges that are not reachable from the internet or may reach the server itself, besides the ones
+// netip.Addr reports as loopback, private, link-local, unspecified and multicast.
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+var allowedPorts = map[string]struct{}{"": {}, "80": {}, "443": {}}
+
+// IsPublicAddr reports whether the address is a public unicast address.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func isAllowedAddrPort(addr netip.AddrPort) bool {
+	return IsPublicAddr(addr.Addr()) && (addr.Port() == 80 || addr.Port() == 443)
+}
+
+// CheckURL checks that the URL is an http or https URL on a standard port whose host is not
+// an address of a non-public network. Hosts that are names are checked after they are resolved.
+func CheckURL(u *url.URL) error {
+	if _, ok := allowedPorts[u.Port()]; !ok {
+		return ErrForbiddenURL
+	}
+	return checkHost(u)
+}
+
+// checkHost checks the scheme and the host of the URL, the port is checked when connecting.
+func checkHost(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" || u.Hostname() == "" || u.User != nil {
+		return ErrForbiddenURL
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenURL
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublicAddr(addr) {
+		return ErrForbiddenURL
+	}
+	return nil
+}
+
+// NewClient returns a client that connects to public addresses on ports 80 and 443 only. The addresses
+// are checked after the host names are resolved, so names resolving to internal addresses are refused,
+// and the redirects are checked the same way.
+func NewClient(timeout time.Duration) *http.Client {
+	return newClient(timeout, isAllowedAddrPort)
+}
+
+func newClient(timeout time.Duration, allowed func(netip.AddrPort) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addr, err := netip.ParseAddrPort(address)
+			if err != nil || !allowed(addr) {
+				return ErrForbiddenAddress
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// a proxy would be checked instead of the addresses it connects to
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   timeout,
+			ResponseHeaderTimeout: timeout,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       90 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return ErrTooManyRedirects
+			}
+			return checkHost(req.URL)
+		},
+	}
+}
@@ -1,26 +1,83 @@
 package http
 
 import (
+	"time"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/xss"
 	pkgProfile "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/profile"
 )
 
 //go:generate easyjson -all -snake_case api_models.go
 
+// API requests
+type businessRequest struct {
+	CompanyName  string `json:"company_name"`
+	WebsiteUrl   string `json:"website_url"`
+	ContactEmail string `json:"contact_email"`
+	ContactPhone string `json:"contact_phone"`
+}
+
 // API responses
 type getResponse struct {
-	Username     string `json:"username"`
-	Name         string `json:"name"`
-	ProfileImage string `json:"profile_image"`
-	WebsiteUrl   string `json:"website_url"`
+	Username            string                  `json:"username"`
+	Name                string                  `json:"name"`
+	ProfileImage        string                  `json:"profile_image"`
+	WebsiteUrl          string                  `json:"website_url"`
+	AccountType         string                  `json:"account_type"`
+	CanViewPinAnalytics bool                    `json:"can_view_pin_analytics"`
+	CanPromotePins      bool                    `json:"can_promote_pins"`
+	Business            *publicBusinessResponse `json:"business,omitempty"`
+}
+
+type publicBusinessResponse struct {
+	CompanyName     string `json:"company_name"`
+	WebsiteUrl      string `json:"website_url"`
+	WebsiteVerified bool   `json:"website_verified"`
+	ContactEmail    string `json:"contact_email"`
+	ContactPhone    string `json:"contact_phone"`
 }
 
 func newGetResponse(profile *pkgProfile.Profile) *getResponse {
-	return &getResponse{
-		Username:     xss.Sanitize(profile.Username),
-		Name:         xss.Sanitize(profile.Name),
-		ProfileImage: profile.ProfileImage,
-		WebsiteUrl:   xss.Sanitize(profile.WebsiteUrl),
+	response := &getResponse{
+		Username:            xss.Sanitize(profile.Username),
+		Name:                xss.Sanitize(profile.Name),
+		ProfileImage:        profile.ProfileImage,
+		WebsiteUrl:          xss.Sanitize(profile.WebsiteUrl),
+		AccountType:         profile.AccountType,
+		CanViewPinAnalytics: models.CanViewPinAnalytics(profile.AccountType),
+		CanPromotePins:      models.CanPromotePins(profile.AccountType),
+	}
+	if profile.AccountType == constants.BusinessAccountType {
+		response.Business = &publicBusinessResponse{
+			CompanyName:     xss.Sanitize(profile.Business.CompanyName),
+			WebsiteUrl:      xss.Sanitize(profile.Business.WebsiteUrl),
+			WebsiteVerified: profile.Business.WebsiteVerified,
+			ContactEmail:    xss.Sanitize(profile.Business.ContactEmail),
+			ContactPhone:    xss.Sanitize(profile.Business.ContactPhone),
+		}
+	}
+	return response
+}
+
+type businessResponse struct {
+	CompanyName       string `json:"company_name"`
+	WebsiteUrl        string `json:"website_url"`
+	WebsiteVerified   bool   `json:"website_verified"`
+	ContactEmail      string `json:"contact_email"`
+	ContactPhone      string `json:"contact_phone"`
+	VerificationToken string `json:"verification_token"`
+}
+
+func newBusinessResponse(business *pkgProfile.BusinessProfile) *businessResponse {
+	return &businessResponse{
+		CompanyName:       xss.Sanitize(business.CompanyName),
+		WebsiteUrl:        xss.Sanitize(business.WebsiteUrl),
+		WebsiteVerified:   business.WebsiteVerified,
+		ContactEmail:      xss.Sanitize(business.ContactEmail),
+		ContactPhone:      xss.Sanitize(business.ContactPhone),
+		VerificationToken: business.VerificationToken,
 	}
 }
 
@@ -55,3 +112,56 @@ func newPartialUpdateResponse(profile *pkgProfile.Profile) *partialUpdateRespons
 		WebsiteUrl:   xss.Sanitize(profile.WebsiteUrl),
 	}
 }
+
+type deleteResponse struct {
+	DeleteAfter time.Time `json:"delete_after"`
+}
+
+func newDeleteResponse(deleteAfter time.Time) *deleteResponse {
+	return &deleteResponse{DeleteAfter: deleteAfter}
+}
+
+// Data export archive files
+type exportPins struct {
+	Items []models.Pin `json:"items"`
+}
+
+type exportBoards struct {
+	Items []models.Board `json:"items"`
+}
+
+type exportComments struct {
+	Items []models.Comment `json:"items"`
+}
+
+type exportLikes struct {
+	Items []models.Like `json:"items"`
+}
+
+type exportFollowing struct {
+	UserID    int       `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type exportFollowings struct {
+	Followers []exportFollowing `json:"followers"`
+	Followees []exportFollowing `json:"followees"`
+}
+
+func newExportFollowings(followers, followees []pkgProfile.Following) *exportFollowings {
+	res := &exportFollowings{
+		Followers: make([]exportFollowing, 0, len(followers)),
+		Followees: make([]exportFollowing, 0, len(followees)),
+	}
+	for _, f := range followers {
+		res.Followers = append(res.Followers, exportFollowing{UserID: f.UserId, CreatedAt: f.CreatedAt})
+	}
+	for _, f := range followees {
+		res.Followees = append(res.Followees, exportFollowing{UserID: f.UserId, CreatedAt: f.CreatedAt})
+	}
+	return res
+}
+
+type exportMessages struct {
+	Items []models.Message `json:"items"`
+}
@@ -4,6 +4,7 @@ package http
 
 import (
 	json "encoding/json"
+	models "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
@@ -17,7 +18,101 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp(in *jlexer.Lexer, out *partialUpdateResponse) {
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp(in *jlexer.Lexer, out *publicBusinessResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "company_name":
+			out.CompanyName = string(in.String())
+		case "website_url":
+			out.WebsiteUrl = string(in.String())
+		case "website_verified":
+			out.WebsiteVerified = bool(in.Bool())
+		case "contact_email":
+			out.ContactEmail = string(in.String())
+		case "contact_phone":
+			out.ContactPhone = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp(out *jwriter.Writer, in publicBusinessResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"company_name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.CompanyName))
+	}
+	{
+		const prefix string = ",\"website_url\":"
+		out.RawString(prefix)
+		out.String(string(in.WebsiteUrl))
+	}
+	{
+		const prefix string = ",\"website_verified\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.WebsiteVerified))
+	}
+	{
+		const prefix string = ",\"contact_email\":"
+		out.RawString(prefix)
+		out.String(string(in.ContactEmail))
+	}
+	{
+		const prefix string = ",\"contact_phone\":"
+		out.RawString(prefix)
+		out.String(string(in.ContactPhone))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v publicBusinessResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v publicBusinessResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *publicBusinessResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *publicBusinessResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp1(in *jlexer.Lexer, out *partialUpdateResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -54,7 +149,7 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfile
 		in.Consumed()
 	}
 }
-func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp(out *jwriter.Writer, in partialUpdateResponse) {
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp1(out *jwriter.Writer, in partialUpdateResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -84,27 +179,27 @@ func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfile
 // MarshalJSON supports json.Marshaler interface
 func (v partialUpdateResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp(&w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v partialUpdateResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp(w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *partialUpdateResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp(&r, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *partialUpdateResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp(l, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp1(l, v)
 }
-func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp1(in *jlexer.Lexer, out *getResponse) {
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp2(in *jlexer.Lexer, out *getResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -131,6 +226,22 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfile
 			out.ProfileImage = string(in.String())
 		case "website_url":
 			out.WebsiteUrl = string(in.String())
+		case "account_type":
+			out.AccountType = string(in.String())
+		case "can_view_pin_analytics":
+			out.CanViewPinAnalytics = bool(in.Bool())
+		case "can_promote_pins":
+			out.CanPromotePins = bool(in.Bool())
+		case "business":
+			if in.IsNull() {
+				in.Skip()
+				out.Business = nil
+			} else {
+				if out.Business == nil {
+					out.Business = new(publicBusinessResponse)
+				}
+				(*out.Business).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -141,7 +252,7 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfile
 		in.Consumed()
 	}
 }
-func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp1(out *jwriter.Writer, in getResponse) {
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp2(out *jwriter.Writer, in getResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -165,33 +276,53 @@ func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfile
 		out.RawString(prefix)
 		out.String(string(in.WebsiteUrl))
 	}
+	{
+		const prefix string = ",\"account_type\":"
+		out.RawString(prefix)
+		out.String(string(in.AccountType))
+	}
+	{
+		const prefix string = ",\"can_view_pin_analytics\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.CanViewPinAnalytics))
+	}
+	{
+		const prefix string = ",\"can_promote_pins\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.CanPromotePins))
+	}
+	if in.Business != nil {
+		const prefix string = ",\"business\":"
+		out.RawString(prefix)
+		(*in.Business).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v getResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp1(&w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v getResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp1(w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *getResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp1(&r, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *getResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp1(l, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp2(l, v)
 }
-func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp2(in *jlexer.Lexer, out *fullUpdateResponse) {
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp3(in *jlexer.Lexer, out *fullUpdateResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -228,7 +359,7 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfile
 		in.Consumed()
 	}
 }
-func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp2(out *jwriter.Writer, in fullUpdateResponse) {
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp3(out *jwriter.Writer, in fullUpdateResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -258,23 +389,981 @@ func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfile
 // MarshalJSON supports json.Marshaler interface
 func (v fullUpdateResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp2(&w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v fullUpdateResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp2(w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *fullUpdateResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp2(&r, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *fullUpdateResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp2(l, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp3(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp4(in *jlexer.Lexer, out *exportPins) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]models.Pin, 0, 0)
+					} else {
+						out.Items = []models.Pin{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 models.Pin
+					(v1).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp4(out *jwriter.Writer, in exportPins) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix[1:])
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Items {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v exportPins) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v exportPins) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *exportPins) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *exportPins) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp4(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp5(in *jlexer.Lexer, out *exportMessages) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]models.Message, 0, 1)
+					} else {
+						out.Items = []models.Message{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 models.Message
+					(v4).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp5(out *jwriter.Writer, in exportMessages) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix[1:])
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Items {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v exportMessages) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v exportMessages) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *exportMessages) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *exportMessages) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp5(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp6(in *jlexer.Lexer, out *exportLikes) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]models.Like, 0, 1)
+					} else {
+						out.Items = []models.Like{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 models.Like
+					(v7).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp6(out *jwriter.Writer, in exportLikes) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix[1:])
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Items {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				(v9).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v exportLikes) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v exportLikes) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *exportLikes) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *exportLikes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp6(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp7(in *jlexer.Lexer, out *exportFollowings) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "followers":
+			if in.IsNull() {
+				in.Skip()
+				out.Followers = nil
+			} else {
+				in.Delim('[')
+				if out.Followers == nil {
+					if !in.IsDelim(']') {
+						out.Followers = make([]exportFollowing, 0, 2)
+					} else {
+						out.Followers = []exportFollowing{}
+					}
+				} else {
+					out.Followers = (out.Followers)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 exportFollowing
+					(v10).UnmarshalEasyJSON(in)
+					out.Followers = append(out.Followers, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "followees":
+			if in.IsNull() {
+				in.Skip()
+				out.Followees = nil
+			} else {
+				in.Delim('[')
+				if out.Followees == nil {
+					if !in.IsDelim(']') {
+						out.Followees = make([]exportFollowing, 0, 2)
+					} else {
+						out.Followees = []exportFollowing{}
+					}
+				} else {
+					out.Followees = (out.Followees)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v11 exportFollowing
+					(v11).UnmarshalEasyJSON(in)
+					out.Followees = append(out.Followees, v11)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp7(out *jwriter.Writer, in exportFollowings) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"followers\":"
+		out.RawString(prefix[1:])
+		if in.Followers == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v12, v13 := range in.Followers {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				(v13).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"followees\":"
+		out.RawString(prefix)
+		if in.Followees == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v14, v15 := range in.Followees {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				(v15).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v exportFollowings) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v exportFollowings) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *exportFollowings) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *exportFollowings) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp7(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp8(in *jlexer.Lexer, out *exportFollowing) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user_id":
+			out.UserID = int(in.Int())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp8(out *jwriter.Writer, in exportFollowing) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.UserID))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v exportFollowing) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v exportFollowing) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *exportFollowing) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *exportFollowing) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp8(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp9(in *jlexer.Lexer, out *exportComments) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]models.Comment, 0, 1)
+					} else {
+						out.Items = []models.Comment{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v16 models.Comment
+					(v16).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v16)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp9(out *jwriter.Writer, in exportComments) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix[1:])
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v17, v18 := range in.Items {
+				if v17 > 0 {
+					out.RawByte(',')
+				}
+				(v18).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v exportComments) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v exportComments) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *exportComments) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *exportComments) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp9(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp10(in *jlexer.Lexer, out *exportBoards) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]models.Board, 0, 1)
+					} else {
+						out.Items = []models.Board{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v19 models.Board
+					(v19).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v19)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp10(out *jwriter.Writer, in exportBoards) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix[1:])
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v20, v21 := range in.Items {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				(v21).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v exportBoards) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v exportBoards) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *exportBoards) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *exportBoards) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp10(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp11(in *jlexer.Lexer, out *deleteResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "delete_after":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.DeleteAfter).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp11(out *jwriter.Writer, in deleteResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"delete_after\":"
+		out.RawString(prefix[1:])
+		out.Raw((in.DeleteAfter).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v deleteResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v deleteResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *deleteResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *deleteResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp11(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp12(in *jlexer.Lexer, out *businessResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "company_name":
+			out.CompanyName = string(in.String())
+		case "website_url":
+			out.WebsiteUrl = string(in.String())
+		case "website_verified":
+			out.WebsiteVerified = bool(in.Bool())
+		case "contact_email":
+			out.ContactEmail = string(in.String())
+		case "contact_phone":
+			out.ContactPhone = string(in.String())
+		case "verification_token":
+			out.VerificationToken = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp12(out *jwriter.Writer, in businessResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"company_name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.CompanyName))
+	}
+	{
+		const prefix string = ",\"website_url\":"
+		out.RawString(prefix)
+		out.String(string(in.WebsiteUrl))
+	}
+	{
+		const prefix string = ",\"website_verified\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.WebsiteVerified))
+	}
+	{
+		const prefix string = ",\"contact_email\":"
+		out.RawString(prefix)
+		out.String(string(in.ContactEmail))
+	}
+	{
+		const prefix string = ",\"contact_phone\":"
+		out.RawString(prefix)
+		out.String(string(in.ContactPhone))
+	}
+	{
+		const prefix string = ",\"verification_token\":"
+		out.RawString(prefix)
+		out.String(string(in.VerificationToken))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v businessResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v businessResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *businessResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *businessResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp12(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp13(in *jlexer.Lexer, out *businessRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "company_name":
+			out.CompanyName = string(in.String())
+		case "website_url":
+			out.WebsiteUrl = string(in.String())
+		case "contact_email":
+			out.ContactEmail = string(in.String())
+		case "contact_phone":
+			out.ContactPhone = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp13(out *jwriter.Writer, in businessRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"company_name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.CompanyName))
+	}
+	{
+		const prefix string = ",\"website_url\":"
+		out.RawString(prefix)
+		out.String(string(in.WebsiteUrl))
+	}
+	{
+		const prefix string = ",\"contact_email\":"
+		out.RawString(prefix)
+		out.String(string(in.ContactEmail))
+	}
+	{
+		const prefix string = ",\"contact_phone\":"
+		out.RawString(prefix)
+		out.String(string(in.ContactPhone))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v businessRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v businessRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *businessRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *businessRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalProfileDeliveryHttp13(l, v)
 }
@@ -1,7 +1,9 @@
 package http
 
 import (
+	"archive/zip"
 	"bytes"
+	"fmt"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
 	"go.uber.org/zap"
 	"io"
@@ -17,14 +19,25 @@ import (
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/profile"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/utils"
 )
 
+// maxFormMemory is the part of a profile update form kept in memory. Larger profile images are spooled
+// to a temporary file by the form parser and streamed to the images service from there.
+const maxFormMemory = 1 << 20
+
 func RegisterHandlers(mux *httprouter.Router, logger *zap.Logger, authorizer mw.Authorizer, csrf mw.CSRFMiddleware, serv profile.Service, m *mw.HttpMetricsMiddleware) {
 	del := delivery{serv, logger}
 
 	mux.GET("/users/:id/profile", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.getProfileByUser))), logger), logger), logger))
 	mux.PUT("/profile", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.fullUpdate))), logger), logger), logger))
 	mux.PATCH("/profile", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.partialUpdate))), logger), logger), logger))
+	mux.GET("/profile/business", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.getBusinessProfile))), logger), logger), logger))
+	mux.POST("/profile/business", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.upgradeToBusiness))), logger), logger), logger))
+	mux.PUT("/profile/business", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.updateBusinessProfile))), logger), logger), logger))
+	mux.POST("/profile/business/verify", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.verifyWebsite))), logger), logger), logger))
+	mux.DELETE("/profile", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.deleteAccount))), logger), logger), logger))
+	mux.GET("/profile/export", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.export))), logger), logger), logger))
 }
 
 type delivery struct {
@@ -32,6 +45,12 @@ type delivery struct {
 	log  *zap.Logger
 }
 
+func (del *delivery) closeFile(file io.Closer) {
+	if err := file.Close(); err != nil {
+		del.log.Error(constants.FailedCloseFile, zap.Error(err))
+	}
+}
+
 func (del *delivery) getProfileByUser(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
 	strUserId := p.ByName("id")
 	userId, err := strconv.Atoi(strUserId)
@@ -65,6 +84,9 @@ func (del *delivery) fullUpdate(w http.ResponseWriter, r *http.Request, p httpro
 		return pkgErrors.ErrInvalidUserIdParam
 	}
 
+	if err = r.ParseMultipartForm(maxFormMemory); err != nil {
+		return pkgErrors.ErrParseForm
+	}
 	file, handler, err := r.FormFile("bytes")
 	if err != nil {
 		if errors.Is(err, http.ErrMissingFile) {
@@ -73,22 +95,11 @@ func (del *delivery) fullUpdate(w http.ResponseWriter, r *http.Request, p httpro
 			return pkgErrors.ErrParseForm
 		}
 	}
-	defer func() {
-		err := file.Close()
-		if err != nil {
-			del.log.Error(constants.FailedCloseFile, zap.Error(err))
-		}
-	}()
+	defer del.closeFile(file)
 
-	buf := bytes.NewBuffer(nil)
-	_, err = io.Copy(buf, file)
-	if err != nil {
-		return pkgErrors.ErrFileCopy
-	}
-
-	image := models.Image{
-		ID:    uuid.NewString() + filepath.Ext(handler.Filename),
-		Bytes: buf.Bytes(),
+	image := models.ImageStream{
+		ID:   uuid.NewString() + filepath.Ext(handler.Filename),
+		Body: file,
 	}
 
 	params := profile.FullUpdateParams{
@@ -126,21 +137,20 @@ func (del *delivery) partialUpdate(w http.ResponseWriter, r *http.Request, p htt
 
 	params := profile.PartialUpdateParams{Id: id}
 
+	if err = r.ParseMultipartForm(maxFormMemory); err != nil {
+		return pkgErrors.ErrParseForm
+	}
 	file, handler, err := r.FormFile("bytes")
 	if err != nil {
 		if err != http.ErrMissingFile {
 			return pkgErrors.ErrParseForm
 		}
 	} else {
-		buf := bytes.NewBuffer(nil)
-		_, err = io.Copy(buf, file)
-		if err != nil {
-			return pkgErrors.ErrFileCopy
-		}
+		defer del.closeFile(file)
 
-		image := models.Image{
-			ID:    uuid.NewString() + filepath.Ext(handler.Filename),
-			Bytes: buf.Bytes(),
+		image := models.ImageStream{
+			ID:   uuid.NewString() + filepath.Ext(handler.Filename),
+			Body: file,
 		}
 
 		params.UpdateProfileImage = true
@@ -178,3 +188,191 @@ func (del *delivery) partialUpdate(w http.ResponseWriter, r *http.Request, p htt
 	}
 	return nil
 }
+
+func writeBusinessResponse(w http.ResponseWriter, business *profile.BusinessProfile) error {
+	response := newBusinessResponse(business)
+	data, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+	return nil
+}
+
+func (del *delivery) parseBusinessParams(r *http.Request, p httprouter.Params) (profile.BusinessParams, error) {
+	id, err := strconv.Atoi(p.ByName("user-id"))
+	if err != nil {
+		return profile.BusinessParams{}, pkgErrors.ErrInvalidUserIdParam
+	}
+
+	body, err := utils.ReadBody(r, del.log)
+	if err != nil {
+		return profile.BusinessParams{}, err
+	}
+
+	var request businessRequest
+	if err = request.UnmarshalJSON(body); err != nil {
+		return profile.BusinessParams{}, errors.Wrap(pkgErrors.ErrParseJson, err.Error())
+	}
+
+	return profile.BusinessParams{
+		UserId:       id,
+		CompanyName:  request.CompanyName,
+		WebsiteUrl:   request.WebsiteUrl,
+		ContactEmail: request.ContactEmail,
+		ContactPhone: request.ContactPhone,
+	}, nil
+}
+
+func (del *delivery) getBusinessProfile(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+	id, err := strconv.Atoi(p.ByName("user-id"))
+	if err != nil {
+		return pkgErrors.ErrInvalidUserIdParam
+	}
+
+	business, err := del.serv.GetBusinessProfile(id)
+	if err != nil {
+		return err
+	}
+	return writeBusinessResponse(w, &business)
+}
+
+func (del *delivery) upgradeToBusiness(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+	params, err := del.parseBusinessParams(r, p)
+	if err != nil {
+		return err
+	}
+
+	business, err := del.serv.UpgradeToBusiness(&params)
+	if err != nil {
+		return err
+	}
+	return writeBusinessResponse(w, &business)
+}
+
+func (del *delivery) updateBusinessProfile(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+	params, err := del.parseBusinessParams(r, p)
+	if err != nil {
+		return err
+	}
+
+	business, err := del.serv.UpdateBusinessProfile(&params)
+	if err != nil {
+		return err
+	}
+	return writeBusinessResponse(w, &business)
+}
+
+func (del *delivery) verifyWebsite(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+	id, err := strconv.Atoi(p.ByName("user-id"))
+	if err != nil {
+		return pkgErrors.ErrInvalidUserIdParam
+	}
+
+	business, err := del.serv.VerifyWebsite(id)
+	if err != nil {
+		return err
+	}
+	return writeBusinessResponse(w, &business)
+}
+
+func (del *delivery) deleteAccount(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+	strId := p.ByName("user-id")
+	id, err := strconv.Atoi(strId)
+	if err != nil {
+		return pkgErrors.ErrInvalidUserIdParam
+	}
+
+	deleteAfter, err := del.serv.ScheduleDeletion(id)
+	if err != nil {
+		return err
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     "JSESSIONID",
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+
+	response := newDeleteResponse(deleteAfter)
+	data, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+	return nil
+}
+
+type jsonMarshaler interface {
+	MarshalJSON() ([]byte, error)
+}
+
+func writeExportArchive(w io.Writer, data *profile.ExportData) error {
+	files := []struct {
+		name    string
+		content jsonMarshaler
+	}{
+		{"profile.json", &data.User},
+		{"pins.json", &exportPins{Items: data.Pins}},
+		{"boards.json", &exportBoards{Items: data.Boards}},
+		{"comments.json", &exportComments{Items: data.Comments}},
+		{"likes.json", &exportLikes{Items: data.Likes}},
+		{"followings.json", newExportFollowings(data.Followers, data.Followees)},
+		{"messages.json", &exportMessages{Items: data.Messages}},
+	}
+
+	archive := zip.NewWriter(w)
+	for _, file := range files {
+		content, err := file.content.MarshalJSON()
+		if err != nil {
+			return err
+		}
+
+		fw, err := archive.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err = fw.Write(content); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+func (del *delivery) export(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+	strId := p.ByName("user-id")
+	id, err := strconv.Atoi(strId)
+	if err != nil {
+		return pkgErrors.ErrInvalidUserIdParam
+	}
+
+	data, err := del.serv.Export(id)
+	if err != nil {
+		return err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err = writeExportArchive(buf, &data); err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"pickpin-export-%d.zip\"", id))
+	_, err = w.Write(buf.Bytes())
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+	return nil
+}
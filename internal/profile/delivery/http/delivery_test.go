@@ -1,18 +1,22 @@
 package http
 
 import (
+	"archive/zip"
+	"bytes"
 	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/julienschmidt/httprouter"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/profile"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/profile/mocks"
@@ -49,11 +53,35 @@ func TestGetProfileByUser(t *testing.T) {
 					Name:         "n1",
 					ProfileImage: "pi1",
 					WebsiteUrl:   "wu1",
+					AccountType:  "personal",
 				}, nil)
 			},
-			params:   []httprouter.Param{{Key: "id", Value: "3"}},
-			response: `{"username":"un1","name":"n1","profile_image":"pi1","website_url":"wu1"}`,
-			err:      nil,
+			params: []httprouter.Param{{Key: "id", Value: "3"}},
+			response: `{"username":"un1","name":"n1","profile_image":"pi1","website_url":"wu1","account_type":"personal",` +
+				`"can_view_pin_analytics":false,"can_promote_pins":false}`,
+			err: nil,
+		},
+		"business account": {
+			prepare: func(f *fields) {
+				f.serv.EXPECT().GetProfileByUser(3).Return(profile.Profile{
+					Username:     "un1",
+					Name:         "n1",
+					ProfileImage: "pi1",
+					WebsiteUrl:   "wu1",
+					AccountType:  "business",
+					Business: profile.BusinessProfile{
+						CompanyName:       "cn1",
+						WebsiteUrl:        "https://cn1.com",
+						WebsiteVerified:   true,
+						VerificationToken: "secret",
+					},
+				}, nil)
+			},
+			params: []httprouter.Param{{Key: "id", Value: "3"}},
+			response: `{"username":"un1","name":"n1","profile_image":"pi1","website_url":"wu1","account_type":"business",` +
+				`"can_view_pin_analytics":true,"can_promote_pins":true,"business":{"company_name":"cn1",` +
+				`"website_url":"https://cn1.com","website_verified":true,"contact_email":"","contact_phone":""}}`,
+			err: nil,
 		},
 		"invalid user id param": {
 			prepare:  func(f *fields) {},
@@ -303,3 +331,135 @@ func TestPartialUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteAccount(t *testing.T) {
+	type fields struct {
+		serv *mocks.MockService
+	}
+
+	type testCase struct {
+		prepare  func(f *fields)
+		params   httprouter.Params
+		response string
+		err      error
+	}
+
+	deleteAfter := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				f.serv.EXPECT().ScheduleDeletion(3).Return(deleteAfter, nil)
+			},
+			params:   []httprouter.Param{{Key: "user-id", Value: "3"}},
+			response: `{"delete_after":"2023-06-01T12:00:00Z"}`,
+			err:      nil,
+		},
+		"invalid user id param": {
+			prepare:  func(f *fields) {},
+			params:   []httprouter.Param{{Key: "user-id", Value: "a"}},
+			response: ``,
+			err:      pkgErrors.ErrInvalidUserIdParam,
+		},
+		"service error": {
+			prepare: func(f *fields) {
+				f.serv.EXPECT().ScheduleDeletion(3).Return(time.Time{}, pkgErrors.ErrDb)
+			},
+			params:   []httprouter.Param{{Key: "user-id", Value: "3"}},
+			response: ``,
+			err:      pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{serv: mocks.NewMockService(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			del := delivery{
+				serv: f.serv,
+				log:  logger,
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/profile", nil)
+			rec := httptest.NewRecorder()
+			err := del.deleteAccount(rec, req, test.params)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			body, _ := io.ReadAll(rec.Body)
+			if strings.Trim(string(body), "\n") != test.response {
+				t.Errorf("\nExpected: %s\nGot: %s", test.response, string(body))
+			}
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serv := mocks.NewMockService(ctrl)
+	serv.EXPECT().Export(3).Return(profile.ExportData{
+		User:     models.User{Id: 3, Username: "un1", Email: "e1@vk.com"},
+		Pins:     []models.Pin{{Id: 1, Title: "t1", Author: 3}},
+		Boards:   []models.Board{},
+		Comments: []models.Comment{},
+		Likes:    []models.Like{},
+		Messages: []models.Message{},
+	}, nil)
+
+	del := delivery{
+		serv: serv,
+		log:  logger,
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/profile/export", nil)
+	rec := httptest.NewRecorder()
+	err := del.export(rec, req, []httprouter.Param{{Key: "user-id", Value: "3"}})
+	if err != nil {
+		t.Fatalf("\nExpected: nil\nGot: %s", err)
+	}
+	if contentType := rec.Header().Get("Content-Type"); contentType != "application/zip" {
+		t.Errorf("\nExpected: application/zip\nGot: %s", contentType)
+	}
+
+	body := rec.Body.Bytes()
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("can't read archive: %s", err)
+	}
+
+	expected := map[string]string{
+		"profile.json":    `{"id":3,"username":"un1","email":"e1@vk.com","name":"","profile_image":"","website_url":"","account_type":""}`,
+		"pins.json":       `{"items":[{"id":1,"title":"t1","description":"","media_source":"","media_source_color":"","n_likes":0,"liked":false,"author_id":3}]}`,
+		"boards.json":     `{"items":[]}`,
+		"comments.json":   `{"items":[]}`,
+		"likes.json":      `{"items":[]}`,
+		"followings.json": `{"followers":[],"followees":[]}`,
+		"messages.json":   `{"items":[]}`,
+	}
+	if len(archive.File) != len(expected) {
+		t.Errorf("\nExpected %d files\nGot: %d", len(expected), len(archive.File))
+	}
+	for _, file := range archive.File {
+		fr, err := file.Open()
+		if err != nil {
+			t.Fatalf("can't open %s: %s", file.Name, err)
+		}
+		content, _ := io.ReadAll(fr)
+		_ = fr.Close()
+
+		if string(content) != expected[file.Name] {
+			t.Errorf("\n%s\nExpected: %s\nGot: %s", file.Name, expected[file.Name], string(content))
+		}
+	}
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	profile "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/profile"
 	gomock "github.com/golang/mock/gomock"
@@ -34,6 +35,35 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// DeleteAccount mocks base method.
+func (m *MockRepository) DeleteAccount(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockRepositoryMockRecorder) DeleteAccount(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockRepository)(nil).DeleteAccount), userId)
+}
+
+// Export mocks base method.
+func (m *MockRepository) Export(userId int) (profile.ExportData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", userId)
+	ret0, _ := ret[0].(profile.ExportData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockRepositoryMockRecorder) Export(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockRepository)(nil).Export), userId)
+}
+
 // FullUpdate mocks base method.
 func (m *MockRepository) FullUpdate(params *profile.FullUpdateParams) (profile.Profile, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +79,21 @@ func (mr *MockRepositoryMockRecorder) FullUpdate(params interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FullUpdate", reflect.TypeOf((*MockRepository)(nil).FullUpdate), params)
 }
 
+// GetBusinessProfile mocks base method.
+func (m *MockRepository) GetBusinessProfile(userId int) (profile.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBusinessProfile", userId)
+	ret0, _ := ret[0].(profile.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBusinessProfile indicates an expected call of GetBusinessProfile.
+func (mr *MockRepositoryMockRecorder) GetBusinessProfile(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBusinessProfile", reflect.TypeOf((*MockRepository)(nil).GetBusinessProfile), userId)
+}
+
 // GetProfileByUser mocks base method.
 func (m *MockRepository) GetProfileByUser(userId int) (profile.Profile, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +124,21 @@ func (mr *MockRepositoryMockRecorder) IsUsernameAvailable(username, userId inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUsernameAvailable", reflect.TypeOf((*MockRepository)(nil).IsUsernameAvailable), username, userId)
 }
 
+// ListExpiredDeletions mocks base method.
+func (m *MockRepository) ListExpiredDeletions() ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredDeletions")
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredDeletions indicates an expected call of ListExpiredDeletions.
+func (mr *MockRepositoryMockRecorder) ListExpiredDeletions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredDeletions", reflect.TypeOf((*MockRepository)(nil).ListExpiredDeletions))
+}
+
 // PartialUpdate mocks base method.
 func (m *MockRepository) PartialUpdate(params *profile.PartialUpdateParams) (profile.Profile, error) {
 	m.ctrl.T.Helper()
@@ -93,3 +153,63 @@ func (mr *MockRepositoryMockRecorder) PartialUpdate(params interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartialUpdate", reflect.TypeOf((*MockRepository)(nil).PartialUpdate), params)
 }
+
+// ScheduleDeletion mocks base method.
+func (m *MockRepository) ScheduleDeletion(userId int, deleteAfter time.Time) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleDeletion", userId, deleteAfter)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleDeletion indicates an expected call of ScheduleDeletion.
+func (mr *MockRepositoryMockRecorder) ScheduleDeletion(userId, deleteAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleDeletion", reflect.TypeOf((*MockRepository)(nil).ScheduleDeletion), userId, deleteAfter)
+}
+
+// SetWebsiteVerified mocks base method.
+func (m *MockRepository) SetWebsiteVerified(userId int) (profile.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWebsiteVerified", userId)
+	ret0, _ := ret[0].(profile.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetWebsiteVerified indicates an expected call of SetWebsiteVerified.
+func (mr *MockRepositoryMockRecorder) SetWebsiteVerified(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWebsiteVerified", reflect.TypeOf((*MockRepository)(nil).SetWebsiteVerified), userId)
+}
+
+// UpdateBusinessProfile mocks base method.
+func (m *MockRepository) UpdateBusinessProfile(params *profile.BusinessParams, verificationToken string) (profile.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBusinessProfile", params, verificationToken)
+	ret0, _ := ret[0].(profile.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBusinessProfile indicates an expected call of UpdateBusinessProfile.
+func (mr *MockRepositoryMockRecorder) UpdateBusinessProfile(params, verificationToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBusinessProfile", reflect.TypeOf((*MockRepository)(nil).UpdateBusinessProfile), params, verificationToken)
+}
+
+// UpgradeToBusiness mocks base method.
+func (m *MockRepository) UpgradeToBusiness(params *profile.BusinessParams, verificationToken string) (profile.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeToBusiness", params, verificationToken)
+	ret0, _ := ret[0].(profile.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeToBusiness indicates an expected call of UpgradeToBusiness.
+func (mr *MockRepositoryMockRecorder) UpgradeToBusiness(params, verificationToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeToBusiness", reflect.TypeOf((*MockRepository)(nil).UpgradeToBusiness), params, verificationToken)
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	profile "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/profile"
 	gomock "github.com/golang/mock/gomock"
@@ -34,6 +35,21 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// Export mocks base method.
+func (m *MockService) Export(userId int) (profile.ExportData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", userId)
+	ret0, _ := ret[0].(profile.ExportData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockServiceMockRecorder) Export(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockService)(nil).Export), userId)
+}
+
 // FullUpdate mocks base method.
 func (m *MockService) FullUpdate(params *profile.FullUpdateParams) (profile.Profile, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +65,21 @@ func (mr *MockServiceMockRecorder) FullUpdate(params interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FullUpdate", reflect.TypeOf((*MockService)(nil).FullUpdate), params)
 }
 
+// GetBusinessProfile mocks base method.
+func (m *MockService) GetBusinessProfile(userId int) (profile.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBusinessProfile", userId)
+	ret0, _ := ret[0].(profile.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBusinessProfile indicates an expected call of GetBusinessProfile.
+func (mr *MockServiceMockRecorder) GetBusinessProfile(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBusinessProfile", reflect.TypeOf((*MockService)(nil).GetBusinessProfile), userId)
+}
+
 // GetProfileByUser mocks base method.
 func (m *MockService) GetProfileByUser(userId int) (profile.Profile, error) {
 	m.ctrl.T.Helper()
@@ -78,3 +109,78 @@ func (mr *MockServiceMockRecorder) PartialUpdate(params interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PartialUpdate", reflect.TypeOf((*MockService)(nil).PartialUpdate), params)
 }
+
+// PurgeDeletedAccounts mocks base method.
+func (m *MockService) PurgeDeletedAccounts() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedAccounts")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedAccounts indicates an expected call of PurgeDeletedAccounts.
+func (mr *MockServiceMockRecorder) PurgeDeletedAccounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedAccounts", reflect.TypeOf((*MockService)(nil).PurgeDeletedAccounts))
+}
+
+// ScheduleDeletion mocks base method.
+func (m *MockService) ScheduleDeletion(userId int) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleDeletion", userId)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleDeletion indicates an expected call of ScheduleDeletion.
+func (mr *MockServiceMockRecorder) ScheduleDeletion(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleDeletion", reflect.TypeOf((*MockService)(nil).ScheduleDeletion), userId)
+}
+
+// UpdateBusinessProfile mocks base method.
+func (m *MockService) UpdateBusinessProfile(params *profile.BusinessParams) (profile.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBusinessProfile", params)
+	ret0, _ := ret[0].(profile.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBusinessProfile indicates an expected call of UpdateBusinessProfile.
+func (mr *MockServiceMockRecorder) UpdateBusinessProfile(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBusinessProfile", reflect.TypeOf((*MockService)(nil).UpdateBusinessProfile), params)
+}
+
+// UpgradeToBusiness mocks base method.
+func (m *MockService) UpgradeToBusiness(params *profile.BusinessParams) (profile.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeToBusiness", params)
+	ret0, _ := ret[0].(profile.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeToBusiness indicates an expected call of UpgradeToBusiness.
+func (mr *MockServiceMockRecorder) UpgradeToBusiness(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeToBusiness", reflect.TypeOf((*MockService)(nil).UpgradeToBusiness), params)
+}
+
+// VerifyWebsite mocks base method.
+func (m *MockService) VerifyWebsite(userId int) (profile.BusinessProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyWebsite", userId)
+	ret0, _ := ret[0].(profile.BusinessProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyWebsite indicates an expected call of VerifyWebsite.
+func (mr *MockServiceMockRecorder) VerifyWebsite(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyWebsite", reflect.TypeOf((*MockService)(nil).VerifyWebsite), userId)
+}
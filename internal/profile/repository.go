@@ -1,6 +1,8 @@
 package profile
 
 import (
+	"time"
+
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 )
 
@@ -9,13 +11,33 @@ type Profile struct {
 	Name         string
 	ProfileImage string
 	WebsiteUrl   string
+	AccountType  string
+	Business     BusinessProfile // filled for business accounts only
+}
+
+type BusinessProfile struct {
+	CompanyName     string
+	WebsiteUrl      string
+	WebsiteVerified bool
+	ContactEmail    string
+	ContactPhone    string
+	// VerificationToken must be published at the website to verify it, it is shown to the owner only.
+	VerificationToken string
+}
+
+type BusinessParams struct {
+	UserId       int
+	CompanyName  string
+	WebsiteUrl   string
+	ContactEmail string
+	ContactPhone string
 }
 
 type FullUpdateParams struct {
 	Id           int
 	Username     string
 	Name         string
-	ProfileImage models.Image
+	ProfileImage models.ImageStream
 	WebsiteUrl   string
 }
 
@@ -25,16 +47,48 @@ type PartialUpdateParams struct {
 	UpdateUsername     bool
 	Name               string
 	UpdateName         bool
-	ProfileImage       models.Image
+	ProfileImage       models.ImageStream
 	UpdateProfileImage bool
 	WebsiteUrl         string
 	UpdateWebsiteUrl   bool
 }
 
+type Following struct {
+	UserId    int
+	CreatedAt time.Time
+}
+
+// ExportData is everything stored about the user. Messages are those of the chats of the user,
+// including the messages written to the user.
+type ExportData struct {
+	User      models.User
+	Pins      []models.Pin
+	Boards    []models.Board
+	Comments  []models.Comment
+	Likes     []models.Like
+	Followers []Following
+	Followees []Following
+	Messages  []models.Message
+}
+
 type Repository interface {
 	GetProfileByUser(userId int) (Profile, error)
 	FullUpdate(params *FullUpdateParams) (Profile, error)
 	PartialUpdate(params *PartialUpdateParams) (Profile, error)
 
 	IsUsernameAvailable(username string, userId int) (bool, error)
+
+	GetBusinessProfile(userId int) (BusinessProfile, error)
+	UpgradeToBusiness(params *BusinessParams, verificationToken string) (BusinessProfile, error)
+	// UpdateBusinessProfile resets the website verification if the website has changed.
+	UpdateBusinessProfile(params *BusinessParams, verificationToken string) (BusinessProfile, error)
+	SetWebsiteVerified(userId int) (BusinessProfile, error)
+
+	Export(userId int) (ExportData, error)
+	// ScheduleDeletion returns the time after which the account will be deleted.
+	// Repeated calls keep the originally scheduled time.
+	ScheduleDeletion(userId int, deleteAfter time.Time) (time.Time, error)
+	ListExpiredDeletions() ([]int, error)
+	// DeleteAccount deletes the user with all the data and the uploaded images.
+	DeleteAccount(userId int) error
 }
@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"go.uber.org/zap"
+	"time"
 
 	images "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/client"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/profile"
 	"github.com/pkg/errors"
@@ -21,16 +24,20 @@ func NewPostgresRepository(db *sql.DB, imgServ images.ImageClient, log *zap.Logg
 	return &postgresRepository{db, log, imgServ}
 }
 
-func (rep *postgresRepository) GetProfileByUser(userId int) (profile.Profile, error) {
-	const getCmd = `SELECT username, name, profile_image, website_url 
-					FROM users 
-					WHERE id = $1;`
+const getProfileCmd = `SELECT u.username, u.name, u.profile_image, u.website_url, u.account_type,
+						b.company_name, b.website_url, COALESCE(b.website_verified, false), b.contact_email, b.contact_phone
+						FROM users u
+						LEFT JOIN business_profiles b ON b.user_id = u.id
+						WHERE u.id = $1;`
 
-	row := rep.db.QueryRow(getCmd, userId)
+func (rep *postgresRepository) GetProfileByUser(userId int) (profile.Profile, error) {
+	row := rep.db.QueryRow(getProfileCmd, userId)
 
 	var prof profile.Profile
 	var profileImage, websiteUrl sql.NullString
-	err := row.Scan(&prof.Username, &prof.Name, &profileImage, &websiteUrl)
+	var companyName, businessWebsiteUrl, contactEmail, contactPhone sql.NullString
+	err := row.Scan(&prof.Username, &prof.Name, &profileImage, &websiteUrl, &prof.AccountType,
+		&companyName, &businessWebsiteUrl, &prof.Business.WebsiteVerified, &contactEmail, &contactPhone)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return profile.Profile{}, errors.Wrap(pkgErrors.ErrProfileNotFound, err.Error())
@@ -41,6 +48,10 @@ func (rep *postgresRepository) GetProfileByUser(userId int) (profile.Profile, er
 
 	prof.ProfileImage = profileImage.String
 	prof.WebsiteUrl = websiteUrl.String
+	prof.Business.CompanyName = companyName.String
+	prof.Business.WebsiteUrl = businessWebsiteUrl.String
+	prof.Business.ContactEmail = contactEmail.String
+	prof.Business.ContactPhone = contactPhone.String
 	return prof, nil
 }
 
@@ -53,9 +64,9 @@ const fullUpdateCmd = `UPDATE users
 						RETURNING username, name, profile_image, website_url;`
 
 func (rep *postgresRepository) FullUpdate(params *profile.FullUpdateParams) (profile.Profile, error) {
-	url, err := rep.imgServ.UploadImage(context.TODO(), &params.ProfileImage)
+	url, err := rep.imgServ.UploadImageStream(context.TODO(), &params.ProfileImage)
 	if err != nil {
-		return profile.Profile{}, errors.Wrap(pkgErrors.ErrImageService, err.Error())
+		return profile.Profile{}, err
 	}
 
 	row := rep.db.QueryRow(fullUpdateCmd,
@@ -89,9 +100,9 @@ func (rep *postgresRepository) PartialUpdate(params *profile.PartialUpdateParams
 	var url string
 	var err error
 	if params.UpdateProfileImage {
-		url, err = rep.imgServ.UploadImage(context.TODO(), &params.ProfileImage)
+		url, err = rep.imgServ.UploadImageStream(context.TODO(), &params.ProfileImage)
 		if err != nil {
-			return profile.Profile{}, errors.Wrap(pkgErrors.ErrImageService, err.Error())
+			return profile.Profile{}, err
 		}
 	}
 
@@ -131,3 +142,399 @@ func (rep *postgresRepository) IsUsernameAvailable(username string, userId int)
 	}
 	return available, nil
 }
+
+const (
+	getBusinessProfileCmd = `SELECT company_name, website_url, website_verified, contact_email, contact_phone, verification_token
+							FROM business_profiles
+							WHERE user_id = $1;`
+	upgradeToBusinessCmd = `UPDATE users
+							SET account_type = 'business'
+							WHERE id = $1 AND account_type = 'personal'
+							RETURNING id;`
+	createBusinessProfileCmd = `INSERT INTO business_profiles (user_id, company_name, website_url, contact_email, contact_phone,
+													verification_token)
+							VALUES ($1, $2, $3, $4, $5, $6)
+							ON CONFLICT (user_id) DO UPDATE
+							SET company_name = excluded.company_name,
+								website_url = excluded.website_url,
+								website_verified = false,
+								contact_email = excluded.contact_email,
+								contact_phone = excluded.contact_phone,
+								verification_token = excluded.verification_token
+							RETURNING company_name, website_url, website_verified, contact_email, contact_phone,
+								verification_token;`
+	updateBusinessProfileCmd = `UPDATE business_profiles
+							SET company_name = $2,
+								website_verified = website_verified AND website_url IS NOT DISTINCT FROM $3,
+								verification_token = CASE WHEN website_url IS NOT DISTINCT FROM $3
+															THEN verification_token ELSE $6 END,
+								website_url = $3,
+								contact_email = $4,
+								contact_phone = $5
+							WHERE user_id = $1
+							RETURNING company_name, website_url, website_verified, contact_email, contact_phone,
+								verification_token;`
+	setWebsiteVerifiedCmd = `UPDATE business_profiles
+							SET website_verified = true
+							WHERE user_id = $1
+							RETURNING company_name, website_url, website_verified, contact_email, contact_phone,
+								verification_token;`
+)
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func scanBusinessProfile(row *sql.Row) (profile.BusinessProfile, error) {
+	var business profile.BusinessProfile
+	var websiteUrl, contactEmail, contactPhone sql.NullString
+	err := row.Scan(&business.CompanyName, &websiteUrl, &business.WebsiteVerified, &contactEmail, &contactPhone,
+		&business.VerificationToken)
+	if err != nil {
+		return profile.BusinessProfile{}, err
+	}
+
+	business.WebsiteUrl = websiteUrl.String
+	business.ContactEmail = contactEmail.String
+	business.ContactPhone = contactPhone.String
+	return business, nil
+}
+
+func (rep *postgresRepository) GetBusinessProfile(userId int) (profile.BusinessProfile, error) {
+	business, err := scanBusinessProfile(rep.db.QueryRow(getBusinessProfileCmd, userId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return profile.BusinessProfile{}, errors.Wrap(pkgErrors.ErrNotBusinessAccount, err.Error())
+		}
+		rep.log.Error(constants.DBScanError, zap.String("sql_query", getBusinessProfileCmd),
+			zap.Int("user_id", userId), zap.Error(err))
+		return profile.BusinessProfile{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return business, nil
+}
+
+func (rep *postgresRepository) UpgradeToBusiness(params *profile.BusinessParams,
+	verificationToken string) (profile.BusinessProfile, error) {
+	tx, err := rep.db.Begin()
+	if err != nil {
+		return profile.BusinessProfile{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var id int
+	err = tx.QueryRow(upgradeToBusinessCmd, params.UserId).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return profile.BusinessProfile{}, errors.Wrap(pkgErrors.ErrAlreadyBusinessAccount, err.Error())
+		}
+		rep.log.Error(constants.DBScanError, zap.String("sql_query", upgradeToBusinessCmd),
+			zap.Int("user_id", params.UserId), zap.Error(err))
+		return profile.BusinessProfile{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+
+	business, err := scanBusinessProfile(tx.QueryRow(createBusinessProfileCmd, params.UserId, params.CompanyName,
+		nullString(params.WebsiteUrl), nullString(params.ContactEmail), nullString(params.ContactPhone),
+		verificationToken))
+	if err != nil {
+		rep.log.Error(constants.DBScanError, zap.String("sql_query", createBusinessProfileCmd),
+			zap.Int("user_id", params.UserId), zap.Error(err))
+		return profile.BusinessProfile{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		return profile.BusinessProfile{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return business, nil
+}
+
+func (rep *postgresRepository) UpdateBusinessProfile(params *profile.BusinessParams,
+	verificationToken string) (profile.BusinessProfile, error) {
+	business, err := scanBusinessProfile(rep.db.QueryRow(updateBusinessProfileCmd, params.UserId, params.CompanyName,
+		nullString(params.WebsiteUrl), nullString(params.ContactEmail), nullString(params.ContactPhone),
+		verificationToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return profile.BusinessProfile{}, errors.Wrap(pkgErrors.ErrNotBusinessAccount, err.Error())
+		}
+		rep.log.Error(constants.DBScanError, zap.String("sql_query", updateBusinessProfileCmd),
+			zap.Int("user_id", params.UserId), zap.Error(err))
+		return profile.BusinessProfile{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return business, nil
+}
+
+func (rep *postgresRepository) SetWebsiteVerified(userId int) (profile.BusinessProfile, error) {
+	business, err := scanBusinessProfile(rep.db.QueryRow(setWebsiteVerifiedCmd, userId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return profile.BusinessProfile{}, errors.Wrap(pkgErrors.ErrNotBusinessAccount, err.Error())
+		}
+		rep.log.Error(constants.DBScanError, zap.String("sql_query", setWebsiteVerifiedCmd),
+			zap.Int("user_id", userId), zap.Error(err))
+		return profile.BusinessProfile{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return business, nil
+}
+
+const (
+	exportUserCmd = `SELECT id, username, email, name, profile_image, website_url, account_type
+					FROM users
+					WHERE id = $1;`
+	exportPinsCmd = `SELECT id, COALESCE(title, ''), COALESCE(description, ''), media_source, media_source_color, n_likes, author_id
+					FROM pins
+					WHERE author_id = $1
+					ORDER BY created_at;`
+	exportBoardsCmd = `SELECT id, name, COALESCE(description, ''), privacy, user_id
+					FROM boards
+					WHERE user_id = $1
+					ORDER BY id;`
+	exportCommentsCmd = `SELECT id, author_id, pin_id, text, created_at
+					FROM comments
+					WHERE author_id = $1
+					ORDER BY created_at;`
+	exportLikesCmd = `SELECT pin_id, author_id, created_at
+					FROM pin_likes
+					WHERE author_id = $1
+					ORDER BY created_at;`
+	exportFollowersCmd = `SELECT follower_id, created_at
+					FROM followings
+					WHERE followee_id = $1
+					ORDER BY created_at;`
+	exportFolloweesCmd = `SELECT followee_id, created_at
+					FROM followings
+					WHERE follower_id = $1
+					ORDER BY created_at;`
+	exportMessagesCmd = `SELECT m.id, m.author_id, m.chat_id, m.text, m.created_at
+					FROM messages m
+						JOIN chats c ON c.id = m.chat_id
+					WHERE c.user1_id = $1 OR c.user2_id = $1
+					ORDER BY m.created_at, m.id;`
+)
+
+func queryRows(tx *sql.Tx, query string, userId int, scan func(rows *sql.Rows) error) error {
+	rows, err := tx.Query(query, userId)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (rep *postgresRepository) Export(userId int) (profile.ExportData, error) {
+	tx, err := rep.db.BeginTx(context.TODO(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return profile.ExportData{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	data := profile.ExportData{
+		Pins:      []models.Pin{},
+		Boards:    []models.Board{},
+		Comments:  []models.Comment{},
+		Likes:     []models.Like{},
+		Followers: []profile.Following{},
+		Followees: []profile.Following{},
+		Messages:  []models.Message{},
+	}
+
+	var profileImage, websiteUrl sql.NullString
+	err = tx.QueryRow(exportUserCmd, userId).Scan(&data.User.Id, &data.User.Username, &data.User.Email,
+		&data.User.Name, &profileImage, &websiteUrl, &data.User.AccountType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return profile.ExportData{}, errors.Wrap(pkgErrors.ErrProfileNotFound, err.Error())
+		}
+		return profile.ExportData{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	data.User.ProfileImage = profileImage.String
+	data.User.WebsiteUrl = websiteUrl.String
+
+	queries := []struct {
+		query string
+		scan  func(rows *sql.Rows) error
+	}{
+		{exportPinsCmd, func(rows *sql.Rows) error {
+			var pin models.Pin
+			err := rows.Scan(&pin.Id, &pin.Title, &pin.Description, &pin.MediaSource, &pin.MediaSourceColor,
+				&pin.NumLikes, &pin.Author)
+			data.Pins = append(data.Pins, pin)
+			return err
+		}},
+		{exportBoardsCmd, func(rows *sql.Rows) error {
+			var board models.Board
+			err := rows.Scan(&board.Id, &board.Name, &board.Description, &board.Privacy, &board.UserId)
+			data.Boards = append(data.Boards, board)
+			return err
+		}},
+		{exportCommentsCmd, func(rows *sql.Rows) error {
+			var comment models.Comment
+			err := rows.Scan(&comment.ID, &comment.AuthorID, &comment.PinID, &comment.Text, &comment.CreatedAt)
+			data.Comments = append(data.Comments, comment)
+			return err
+		}},
+		{exportLikesCmd, func(rows *sql.Rows) error {
+			var like models.Like
+			err := rows.Scan(&like.PinId, &like.AuthorId, &like.CreatedAt)
+			data.Likes = append(data.Likes, like)
+			return err
+		}},
+		{exportFollowersCmd, func(rows *sql.Rows) error {
+			var following profile.Following
+			err := rows.Scan(&following.UserId, &following.CreatedAt)
+			data.Followers = append(data.Followers, following)
+			return err
+		}},
+		{exportFolloweesCmd, func(rows *sql.Rows) error {
+			var following profile.Following
+			err := rows.Scan(&following.UserId, &following.CreatedAt)
+			data.Followees = append(data.Followees, following)
+			return err
+		}},
+		{exportMessagesCmd, func(rows *sql.Rows) error {
+			var message models.Message
+			err := rows.Scan(&message.ID, &message.AuthorID, &message.ChatID, &message.Text, &message.CreatedAt)
+			data.Messages = append(data.Messages, message)
+			return err
+		}},
+	}
+
+	for _, q := range queries {
+		if err = queryRows(tx, q.query, userId, q.scan); err != nil {
+			rep.log.Error(constants.DBQueryError, zap.String("sql_query", q.query), zap.Int("user_id", userId),
+				zap.Error(err))
+			return profile.ExportData{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+	}
+
+	return data, nil
+}
+
+const (
+	scheduleDeletionCmd = `INSERT INTO account_deletions (user_id, delete_after)
+							VALUES ($1, $2)
+							ON CONFLICT (user_id) DO UPDATE SET delete_after = account_deletions.delete_after
+							RETURNING delete_after;`
+	deleteAccessTokensCmd = `DELETE FROM access_tokens
+							WHERE user_id = $1;`
+)
+
+func (rep *postgresRepository) ScheduleDeletion(userId int, deleteAfter time.Time) (time.Time, error) {
+	tx, err := rep.db.Begin()
+	if err != nil {
+		return time.Time{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var scheduled time.Time
+	err = tx.QueryRow(scheduleDeletionCmd, userId, deleteAfter).Scan(&scheduled)
+	if err != nil {
+		rep.log.Error(constants.DBScanError, zap.String("sql_query", scheduleDeletionCmd),
+			zap.Int("user_id", userId), zap.Error(err))
+		return time.Time{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+
+	_, err = tx.Exec(deleteAccessTokensCmd, userId)
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", deleteAccessTokensCmd),
+			zap.Int("user_id", userId), zap.Error(err))
+		return time.Time{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		return time.Time{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return scheduled, nil
+}
+
+const listExpiredDeletionsCmd = `SELECT user_id
+								FROM account_deletions
+								WHERE delete_after <= now();`
+
+func (rep *postgresRepository) ListExpiredDeletions() ([]int, error) {
+	rows, err := rep.db.Query(listExpiredDeletionsCmd)
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", listExpiredDeletionsCmd), zap.Error(err))
+		return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer rows.Close()
+
+	userIds := []int{}
+	for rows.Next() {
+		var userId int
+		if err = rows.Scan(&userId); err != nil {
+			rep.log.Error(constants.DBScanError, zap.String("sql_query", listExpiredDeletionsCmd), zap.Error(err))
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		userIds = append(userIds, userId)
+	}
+	return userIds, nil
+}
+
+const (
+	// equal images share the URL, so images still used by other users are kept
+	listUserImagesCmd = `SELECT url
+						FROM (SELECT profile_image AS url FROM users WHERE id = $1 AND profile_image IS NOT NULL
+							  UNION
+							  SELECT media_source FROM pins WHERE author_id = $1) own
+						WHERE NOT EXISTS (SELECT 1 FROM users WHERE profile_image = own.url AND id <> $1)
+						  AND NOT EXISTS (SELECT 1 FROM pins WHERE media_source = own.url AND author_id <> $1);`
+	deleteUserCmd = `DELETE FROM users
+					WHERE id = $1;`
+)
+
+func (rep *postgresRepository) DeleteAccount(userId int) error {
+	tx, err := rep.db.Begin()
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	images := []string{}
+	err = queryRows(tx, listUserImagesCmd, userId, func(rows *sql.Rows) error {
+		var url string
+		err := rows.Scan(&url)
+		if url != "" && url != constants.DefaultAvatar {
+			images = append(images, url)
+		}
+		return err
+	})
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", listUserImagesCmd),
+			zap.Int("user_id", userId), zap.Error(err))
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+
+	_, err = tx.Exec(deleteUserCmd, userId)
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", deleteUserCmd),
+			zap.Int("user_id", userId), zap.Error(err))
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+
+	// the account is already deleted, so failing to remove an image must not fail the deletion
+	for _, url := range images {
+		if err = rep.imgServ.DeleteImage(context.TODO(), url); err != nil {
+			rep.log.Error("Failed to delete image of deleted account", zap.String("url", url),
+				zap.Int("user_id", userId), zap.Error(err))
+		}
+	}
+	return nil
+}
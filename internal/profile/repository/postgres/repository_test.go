@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
@@ -14,6 +16,7 @@ import (
 
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/images/client/mocks"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/profile"
 )
@@ -40,28 +43,43 @@ func TestGetProfileByUser(t *testing.T) {
 		err     error
 	}
 
-	const getCmd = `SELECT username, name, profile_image, website_url 
-					FROM users 
-					WHERE id = $1;`
-
 	tests := map[string]testCase{
 		"good query": {
 			prepare: func(f *fields) {
-				rows := sqlmock.NewRows([]string{"username", "name", "profile_image", "website_url"})
-				rows = rows.AddRow("un1", "n1", "pi1", "wu1")
+				rows := sqlmock.NewRows([]string{"username", "name", "profile_image", "website_url", "account_type",
+					"company_name", "website_url", "website_verified", "contact_email", "contact_phone"})
+				rows = rows.AddRow("un1", "n1", "pi1", "wu1", "personal", nil, nil, false, nil, nil)
 				f.mock.
-					ExpectQuery(regexp.QuoteMeta(getCmd)).
+					ExpectQuery(regexp.QuoteMeta(getProfileCmd)).
 					WithArgs(3).
 					WillReturnRows(rows)
 			},
-			id:      3,
-			profile: profile.Profile{Username: "un1", Name: "n1", ProfileImage: "pi1", WebsiteUrl: "wu1"},
-			err:     nil,
+			id: 3,
+			profile: profile.Profile{Username: "un1", Name: "n1", ProfileImage: "pi1", WebsiteUrl: "wu1",
+				AccountType: "personal"},
+			err: nil,
+		},
+		"business account": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"username", "name", "profile_image", "website_url", "account_type",
+					"company_name", "website_url", "website_verified", "contact_email", "contact_phone"})
+				rows = rows.AddRow("un1", "n1", "pi1", "wu1", "business", "cn1", "https://cn1.com", true,
+					"info@cn1.com", nil)
+				f.mock.
+					ExpectQuery(regexp.QuoteMeta(getProfileCmd)).
+					WithArgs(3).
+					WillReturnRows(rows)
+			},
+			id: 3,
+			profile: profile.Profile{Username: "un1", Name: "n1", ProfileImage: "pi1", WebsiteUrl: "wu1",
+				AccountType: "business", Business: profile.BusinessProfile{CompanyName: "cn1",
+					WebsiteUrl: "https://cn1.com", WebsiteVerified: true, ContactEmail: "info@cn1.com"}},
+			err: nil,
 		},
 		"query error": {
 			prepare: func(f *fields) {
 				f.mock.
-					ExpectQuery(regexp.QuoteMeta(getCmd)).
+					ExpectQuery(regexp.QuoteMeta(getProfileCmd)).
 					WithArgs(3).
 					WillReturnError(fmt.Errorf("db error"))
 			},
@@ -73,7 +91,7 @@ func TestGetProfileByUser(t *testing.T) {
 			prepare: func(f *fields) {
 				rows := sqlmock.NewRows([]string{"username", "name"}).AddRow("un1", "n1")
 				f.mock.
-					ExpectQuery(regexp.QuoteMeta(getCmd)).
+					ExpectQuery(regexp.QuoteMeta(getProfileCmd)).
 					WithArgs(3).
 					WillReturnRows(rows)
 			},
@@ -135,7 +153,7 @@ func TestFullUpdate(t *testing.T) {
 	tests := map[string]testCase{
 		"good query": {
 			prepare: func(f *fields) {
-				f.s3mock.EXPECT().UploadImage(context.Background(), &models.Image{}).Return("pi_url", nil)
+				f.s3mock.EXPECT().UploadImageStream(context.Background(), &models.ImageStream{}).Return("pi_url", nil)
 
 				rows := sqlmock.NewRows([]string{"username", "name", "profile_image", "website_url"})
 				rows = rows.AddRow("un1", "n1", "pi_url", "wu1")
@@ -148,7 +166,7 @@ func TestFullUpdate(t *testing.T) {
 				Id:           3,
 				Username:     "un1",
 				Name:         "n1",
-				ProfileImage: models.Image{},
+				ProfileImage: models.ImageStream{},
 				WebsiteUrl:   "wu1",
 			},
 			profile: profile.Profile{Username: "un1", Name: "n1", ProfileImage: "pi_url", WebsiteUrl: "wu1"},
@@ -156,7 +174,7 @@ func TestFullUpdate(t *testing.T) {
 		},
 		"query error": {
 			prepare: func(f *fields) {
-				f.s3mock.EXPECT().UploadImage(context.Background(), &models.Image{}).Return("pi_url", nil)
+				f.s3mock.EXPECT().UploadImageStream(context.Background(), &models.ImageStream{}).Return("pi_url", nil)
 
 				f.mock.
 					ExpectQuery(regexp.QuoteMeta(fullUpdateCmd)).
@@ -167,7 +185,7 @@ func TestFullUpdate(t *testing.T) {
 				Id:           3,
 				Username:     "un1",
 				Name:         "n1",
-				ProfileImage: models.Image{},
+				ProfileImage: models.ImageStream{},
 				WebsiteUrl:   "wu1",
 			},
 			profile: profile.Profile{},
@@ -227,7 +245,7 @@ func TestPartialUpdate(t *testing.T) {
 	tests := map[string]testCase{
 		"good query": {
 			prepare: func(f *fields) {
-				f.s3mock.EXPECT().UploadImage(context.Background(), &models.Image{}).Return("pi_url", nil)
+				f.s3mock.EXPECT().UploadImageStream(context.Background(), &models.ImageStream{}).Return("pi_url", nil)
 
 				rows := sqlmock.NewRows([]string{"username", "name", "profile_image", "website_url"})
 				rows = rows.AddRow("un1", "n1", "pi_url", "wu1")
@@ -242,7 +260,7 @@ func TestPartialUpdate(t *testing.T) {
 				UpdateUsername:     true,
 				Name:               "n1",
 				UpdateName:         true,
-				ProfileImage:       models.Image{},
+				ProfileImage:       models.ImageStream{},
 				UpdateProfileImage: true,
 				WebsiteUrl:         "wu1",
 				UpdateWebsiteUrl:   true,
@@ -252,7 +270,7 @@ func TestPartialUpdate(t *testing.T) {
 		},
 		"query error": {
 			prepare: func(f *fields) {
-				f.s3mock.EXPECT().UploadImage(context.Background(), &models.Image{}).Return("pi_url", nil)
+				f.s3mock.EXPECT().UploadImageStream(context.Background(), &models.ImageStream{}).Return("pi_url", nil)
 
 				f.mock.
 					ExpectQuery(regexp.QuoteMeta(partialUpdateCmd)).
@@ -265,7 +283,7 @@ func TestPartialUpdate(t *testing.T) {
 				UpdateUsername:     true,
 				Name:               "n1",
 				UpdateName:         true,
-				ProfileImage:       models.Image{},
+				ProfileImage:       models.ImageStream{},
 				UpdateProfileImage: true,
 				WebsiteUrl:         "wu1",
 				UpdateWebsiteUrl:   true,
@@ -310,3 +328,397 @@ func TestPartialUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestExport(t *testing.T) {
+	type fields struct {
+		mock sqlmock.Sqlmock
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		data    profile.ExportData
+		err     error
+	}
+
+	sent := time.Date(2023, 5, 20, 12, 0, 0, 0, time.UTC)
+	received := sent.Add(time.Minute)
+	user := models.User{Id: 3, Username: "kotov", Email: "kotov@example.com", Name: "Иван Котов",
+		AccountType: "personal"}
+
+	expectUser := func(f *fields) {
+		f.mock.ExpectBegin()
+		f.mock.ExpectQuery(regexp.QuoteMeta(exportUserCmd)).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "name", "profile_image",
+				"website_url", "account_type"}).
+				AddRow(3, "kotov", "kotov@example.com", "Иван Котов", nil, nil, "personal"))
+	}
+	expectEmpty := func(f *fields, queries ...string) {
+		for _, query := range queries {
+			f.mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(3).WillReturnRows(sqlmock.NewRows(nil))
+		}
+	}
+
+	tests := map[string]testCase{
+		"messages of both sides of chats": {
+			prepare: func(f *fields) {
+				expectUser(f)
+				expectEmpty(f, exportPinsCmd, exportBoardsCmd, exportCommentsCmd, exportLikesCmd,
+					exportFollowersCmd, exportFolloweesCmd)
+				f.mock.ExpectQuery(regexp.QuoteMeta(exportMessagesCmd)).
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "chat_id", "text", "created_at"}).
+						AddRow(10, 3, 7, "Привет", sent).
+						AddRow(11, 5, 7, "Здравствуй", received))
+				f.mock.ExpectRollback()
+			},
+			data: profile.ExportData{
+				User:      user,
+				Pins:      []models.Pin{},
+				Boards:    []models.Board{},
+				Comments:  []models.Comment{},
+				Likes:     []models.Like{},
+				Followers: []profile.Following{},
+				Followees: []profile.Following{},
+				Messages: []models.Message{
+					{ID: 10, AuthorID: 3, ChatID: 7, Text: "Привет", CreatedAt: sent},
+					{ID: 11, AuthorID: 5, ChatID: 7, Text: "Здравствуй", CreatedAt: received},
+				},
+			},
+			err: nil,
+		},
+		"profile not found": {
+			prepare: func(f *fields) {
+				f.mock.ExpectBegin()
+				f.mock.ExpectQuery(regexp.QuoteMeta(exportUserCmd)).
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "name", "profile_image",
+						"website_url", "account_type"}))
+				f.mock.ExpectRollback()
+			},
+			data: profile.ExportData{},
+			err:  pkgErrors.ErrProfileNotFound,
+		},
+		"query error": {
+			prepare: func(f *fields) {
+				expectUser(f)
+				expectEmpty(f, exportPinsCmd, exportBoardsCmd, exportCommentsCmd, exportLikesCmd,
+					exportFollowersCmd, exportFolloweesCmd)
+				f.mock.ExpectQuery(regexp.QuoteMeta(exportMessagesCmd)).
+					WithArgs(3).
+					WillReturnError(fmt.Errorf("db error"))
+				f.mock.ExpectRollback()
+			},
+			data: profile.ExportData{},
+			err:  pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewPostgresRepository(db, mocks.NewMockImageClient(ctrl), logger)
+
+			f := fields{mock: sqlMock}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			data, err := repo.Export(3)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(data, test.data) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.data, data)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestScheduleDeletion(t *testing.T) {
+	type fields struct {
+		mock sqlmock.Sqlmock
+	}
+
+	type testCase struct {
+		prepare     func(f *fields)
+		deleteAfter time.Time
+		err         error
+	}
+
+	requested := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	scheduled := time.Date(2023, 5, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				f.mock.ExpectBegin()
+				f.mock.ExpectQuery(regexp.QuoteMeta(scheduleDeletionCmd)).
+					WithArgs(3, requested).
+					WillReturnRows(sqlmock.NewRows([]string{"delete_after"}).AddRow(requested))
+				f.mock.ExpectExec(regexp.QuoteMeta(deleteAccessTokensCmd)).
+					WithArgs(3).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				f.mock.ExpectCommit()
+			},
+			deleteAfter: requested,
+			err:         nil,
+		},
+		"already scheduled": {
+			prepare: func(f *fields) {
+				f.mock.ExpectBegin()
+				f.mock.ExpectQuery(regexp.QuoteMeta(scheduleDeletionCmd)).
+					WithArgs(3, requested).
+					WillReturnRows(sqlmock.NewRows([]string{"delete_after"}).AddRow(scheduled))
+				f.mock.ExpectExec(regexp.QuoteMeta(deleteAccessTokensCmd)).
+					WithArgs(3).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				f.mock.ExpectCommit()
+			},
+			deleteAfter: scheduled,
+			err:         nil,
+		},
+		"query error": {
+			prepare: func(f *fields) {
+				f.mock.ExpectBegin()
+				f.mock.ExpectQuery(regexp.QuoteMeta(scheduleDeletionCmd)).
+					WithArgs(3, requested).
+					WillReturnError(fmt.Errorf("db error"))
+				f.mock.ExpectRollback()
+			},
+			deleteAfter: time.Time{},
+			err:         pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewPostgresRepository(db, mocks.NewMockImageClient(ctrl), logger)
+
+			f := fields{mock: sqlMock}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			deleteAfter, err := repo.ScheduleDeletion(3, requested)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !deleteAfter.Equal(test.deleteAfter) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.deleteAfter, deleteAfter)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestDeleteAccount(t *testing.T) {
+	type fields struct {
+		mock   sqlmock.Sqlmock
+		s3mock *mocks.MockImageClient
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				f.mock.ExpectBegin()
+				f.mock.ExpectQuery(regexp.QuoteMeta(listUserImagesCmd)).
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"profile_image"}).
+						AddRow("https://pickpin.hb.bizmrg.com/avatar.png").
+						AddRow("https://pickpin.hb.bizmrg.com/pin.png"))
+				f.mock.ExpectExec(regexp.QuoteMeta(deleteUserCmd)).
+					WithArgs(3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				f.mock.ExpectCommit()
+				f.s3mock.EXPECT().DeleteImage(gomock.Any(), "https://pickpin.hb.bizmrg.com/avatar.png").Return(nil)
+				f.s3mock.EXPECT().DeleteImage(gomock.Any(), "https://pickpin.hb.bizmrg.com/pin.png").
+					Return(pkgErrors.ErrImageService)
+			},
+			err: nil,
+		},
+		"default avatar is kept": {
+			prepare: func(f *fields) {
+				f.mock.ExpectBegin()
+				f.mock.ExpectQuery(regexp.QuoteMeta(listUserImagesCmd)).
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"profile_image"}).AddRow(constants.DefaultAvatar))
+				f.mock.ExpectExec(regexp.QuoteMeta(deleteUserCmd)).
+					WithArgs(3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				f.mock.ExpectCommit()
+			},
+			err: nil,
+		},
+		"delete error": {
+			prepare: func(f *fields) {
+				f.mock.ExpectBegin()
+				f.mock.ExpectQuery(regexp.QuoteMeta(listUserImagesCmd)).
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"profile_image"}).
+						AddRow("https://pickpin.hb.bizmrg.com/avatar.png"))
+				f.mock.ExpectExec(regexp.QuoteMeta(deleteUserCmd)).
+					WithArgs(3).
+					WillReturnError(fmt.Errorf("db error"))
+				f.mock.ExpectRollback()
+			},
+			err: pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			s3Serv := mocks.NewMockImageClient(ctrl)
+
+			repo := NewPostgresRepository(db, s3Serv, logger)
+
+			f := fields{mock: sqlMock, s3mock: s3Serv}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			err = repo.DeleteAccount(3)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestUpgradeToBusiness(t *testing.T) {
+	type fields struct {
+		mock sqlmock.Sqlmock
+	}
+
+	type testCase struct {
+		prepare  func(f *fields)
+		business profile.BusinessProfile
+		err      error
+	}
+
+	params := profile.BusinessParams{UserId: 3, CompanyName: "cn1", WebsiteUrl: "https://cn1.com"}
+	businessColumns := []string{"company_name", "website_url", "website_verified", "contact_email", "contact_phone",
+		"verification_token"}
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				f.mock.ExpectBegin()
+				f.mock.ExpectQuery(regexp.QuoteMeta(upgradeToBusinessCmd)).
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				f.mock.ExpectQuery(regexp.QuoteMeta(createBusinessProfileCmd)).
+					WithArgs(3, "cn1", "https://cn1.com", nil, nil, "token").
+					WillReturnRows(sqlmock.NewRows(businessColumns).
+						AddRow("cn1", "https://cn1.com", false, nil, nil, "token"))
+				f.mock.ExpectCommit()
+			},
+			business: profile.BusinessProfile{CompanyName: "cn1", WebsiteUrl: "https://cn1.com",
+				VerificationToken: "token"},
+			err: nil,
+		},
+		"already business": {
+			prepare: func(f *fields) {
+				f.mock.ExpectBegin()
+				f.mock.ExpectQuery(regexp.QuoteMeta(upgradeToBusinessCmd)).
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				f.mock.ExpectRollback()
+			},
+			business: profile.BusinessProfile{},
+			err:      pkgErrors.ErrAlreadyBusinessAccount,
+		},
+		"query error": {
+			prepare: func(f *fields) {
+				f.mock.ExpectBegin()
+				f.mock.ExpectQuery(regexp.QuoteMeta(upgradeToBusinessCmd)).
+					WithArgs(3).
+					WillReturnError(fmt.Errorf("db error"))
+				f.mock.ExpectRollback()
+			},
+			business: profile.BusinessProfile{},
+			err:      pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, sqlMock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewPostgresRepository(db, mocks.NewMockImageClient(ctrl), logger)
+
+			f := fields{mock: sqlMock}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			business, err := repo.UpgradeToBusiness(&params, "token")
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if business != test.business {
+				t.Errorf("\nExpected: %v\nGot: %v", test.business, business)
+			}
+			if err = sqlMock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -1,7 +1,23 @@
 package profile
 
+import "time"
+
 type Service interface {
 	GetProfileByUser(userId int) (Profile, error)
 	FullUpdate(params *FullUpdateParams) (Profile, error)
 	PartialUpdate(params *PartialUpdateParams) (Profile, error)
+
+	GetBusinessProfile(userId int) (BusinessProfile, error)
+	UpgradeToBusiness(params *BusinessParams) (BusinessProfile, error)
+	UpdateBusinessProfile(params *BusinessParams) (BusinessProfile, error)
+	// VerifyWebsite checks that the verification token is published at <website>/pickpin-verification.txt.
+	VerifyWebsite(userId int) (BusinessProfile, error)
+
+	Export(userId int) (ExportData, error)
+	// ScheduleDeletion revokes all sessions and access tokens of the user
+	// and deletes the account after the grace period unless the user logs in again.
+	ScheduleDeletion(userId int) (time.Time, error)
+	// PurgeDeletedAccounts deletes accounts whose grace period is over and returns their number.
+	// Accounts that fail to be deleted do not stop the others, their errors are joined.
+	PurgeDeletedAccounts() (int, error)
 }
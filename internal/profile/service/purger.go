@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/profile"
+)
+
+// StartPurger periodically deletes accounts whose deletion grace period is over until ctx is done.
+func StartPurger(ctx context.Context, serv profile.Service, interval time.Duration, log *zap.Logger) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := serv.PurgeDeletedAccounts()
+				if err != nil {
+					log.Error("Failed to purge deleted accounts", zap.Error(err), zap.Int("deleted", deleted))
+				} else if deleted > 0 {
+					log.Info("Purged deleted accounts", zap.Int("deleted", deleted))
+				}
+			}
+		}
+	}()
+}
@@ -1,16 +1,46 @@
 package service
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	stdErrors "errors"
+	"io"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/auth"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/safehttp"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/profile"
 )
 
+const (
+	verificationFile          = "pickpin-verification.txt"
+	verificationTimeout       = 5 * time.Second
+	verificationTokenLen      = 16
+	maxVerificationFileLength = 1024
+)
+
 type profileService struct {
-	rep profile.Repository
+	rep         profile.Repository
+	authServ    auth.Service
+	gracePeriod time.Duration
+	client      *http.Client
 }
 
-func NewProfileService(rep profile.Repository) profile.Service {
-	return &profileService{rep}
+func NewProfileService(rep profile.Repository, authServ auth.Service, gracePeriod time.Duration) profile.Service {
+	return &profileService{
+		rep:         rep,
+		authServ:    authServ,
+		gracePeriod: gracePeriod,
+		client:      safehttp.NewClient(verificationTimeout),
+	}
 }
 
 func validateUsername(username string) error {
@@ -31,6 +61,38 @@ func validateName(name string) error {
 	return nil
 }
 
+func validateBusinessParams(params *profile.BusinessParams) error {
+	if len(params.CompanyName) < 1 {
+		return pkgErrors.ErrEmptyCompanyName
+	} else if len(params.CompanyName) > constants.MaxCompanyNameLen {
+		return pkgErrors.ErrTooLongCompanyName
+	}
+
+	if params.WebsiteUrl != "" {
+		u, err := url.Parse(params.WebsiteUrl)
+		if err != nil || safehttp.CheckURL(u) != nil {
+			return pkgErrors.ErrInvalidWebsiteUrl
+		}
+	}
+	if params.ContactEmail != "" {
+		if _, err := mail.ParseAddress(params.ContactEmail); err != nil {
+			return pkgErrors.ErrInvalidContactEmail
+		}
+	}
+	if len(params.ContactPhone) > constants.MaxContactPhoneLen {
+		return pkgErrors.ErrInvalidContactPhone
+	}
+	return nil
+}
+
+func generateVerificationToken() (string, error) {
+	buf := make([]byte, verificationTokenLen)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
 func (serv *profileService) GetProfileByUser(userId int) (profile.Profile, error) {
 	return serv.rep.GetProfileByUser(userId)
 }
@@ -59,3 +121,96 @@ func (serv *profileService) PartialUpdate(params *profile.PartialUpdateParams) (
 
 	return serv.rep.PartialUpdate(params)
 }
+
+func (serv *profileService) GetBusinessProfile(userId int) (profile.BusinessProfile, error) {
+	return serv.rep.GetBusinessProfile(userId)
+}
+
+func (serv *profileService) UpgradeToBusiness(params *profile.BusinessParams) (profile.BusinessProfile, error) {
+	if err := validateBusinessParams(params); err != nil {
+		return profile.BusinessProfile{}, err
+	}
+
+	token, err := generateVerificationToken()
+	if err != nil {
+		return profile.BusinessProfile{}, errors.Wrap(pkgErrors.ErrService, err.Error())
+	}
+	return serv.rep.UpgradeToBusiness(params, token)
+}
+
+func (serv *profileService) UpdateBusinessProfile(params *profile.BusinessParams) (profile.BusinessProfile, error) {
+	if err := validateBusinessParams(params); err != nil {
+		return profile.BusinessProfile{}, err
+	}
+
+	token, err := generateVerificationToken()
+	if err != nil {
+		return profile.BusinessProfile{}, errors.Wrap(pkgErrors.ErrService, err.Error())
+	}
+	return serv.rep.UpdateBusinessProfile(params, token)
+}
+
+func (serv *profileService) VerifyWebsite(userId int) (profile.BusinessProfile, error) {
+	business, err := serv.rep.GetBusinessProfile(userId)
+	if err != nil {
+		return profile.BusinessProfile{}, err
+	}
+	if business.WebsiteVerified {
+		return business, nil
+	}
+	if business.WebsiteUrl == "" {
+		return profile.BusinessProfile{}, pkgErrors.ErrInvalidWebsiteUrl
+	}
+
+	// the reason of a failure is not returned, so the verification can't be used to probe other hosts
+	resp, err := serv.client.Get(strings.TrimRight(business.WebsiteUrl, "/") + "/" + verificationFile)
+	if err != nil {
+		return profile.BusinessProfile{}, pkgErrors.ErrWebsiteVerificationFailed
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return profile.BusinessProfile{}, pkgErrors.ErrWebsiteVerificationFailed
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxVerificationFileLength))
+	if err != nil || strings.TrimSpace(string(content)) != business.VerificationToken {
+		return profile.BusinessProfile{}, pkgErrors.ErrWebsiteVerificationFailed
+	}
+
+	return serv.rep.SetWebsiteVerified(userId)
+}
+
+func (serv *profileService) Export(userId int) (profile.ExportData, error) {
+	return serv.rep.Export(userId)
+}
+
+func (serv *profileService) ScheduleDeletion(userId int) (time.Time, error) {
+	deleteAfter, err := serv.rep.ScheduleDeletion(userId, time.Now().Add(serv.gracePeriod))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if err = serv.authServ.DeleteUserSessions(userId); err != nil {
+		return time.Time{}, errors.Wrap(err, "ScheduleDeletion")
+	}
+	return deleteAfter, nil
+}
+
+func (serv *profileService) PurgeDeletedAccounts() (int, error) {
+	userIds, err := serv.rep.ListExpiredDeletions()
+	if err != nil {
+		return 0, err
+	}
+
+	// a failing account is retried on the next purge, the others are deleted anyway
+	deleted := 0
+	var errs []error
+	for _, userId := range userIds {
+		if err = serv.rep.DeleteAccount(userId); err != nil {
+			errs = append(errs, errors.Wrapf(err, "DeleteAccount %d", userId))
+			continue
+		}
+		deleted++
+	}
+	return deleted, stdErrors.Join(errs...)
+}
@@ -1,11 +1,15 @@
 package service
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
 
+	authMocks "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/auth/mocks"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/profile"
@@ -69,7 +73,7 @@ func TestGetProfileByUser(t *testing.T) {
 				test.prepare(&f)
 			}
 
-			serv := NewProfileService(f.repo)
+			serv := NewProfileService(f.repo, nil, time.Hour)
 
 			prof, err := serv.GetProfileByUser(test.id)
 			if !errors.Is(err, test.err) {
@@ -101,7 +105,7 @@ func TestFullUpdate(t *testing.T) {
 					Id:           3,
 					Username:     "username1",
 					Name:         "n1",
-					ProfileImage: models.Image{},
+					ProfileImage: models.ImageStream{},
 					WebsiteUrl:   "wu1",
 				}).Return(profile.Profile{
 					Username:     "username1",
@@ -114,7 +118,7 @@ func TestFullUpdate(t *testing.T) {
 				Id:           3,
 				Username:     "username1",
 				Name:         "n1",
-				ProfileImage: models.Image{},
+				ProfileImage: models.ImageStream{},
 				WebsiteUrl:   "wu1",
 			},
 			profile: profile.Profile{Username: "username1", Name: "n1", ProfileImage: "pi_url", WebsiteUrl: "wu1"},
@@ -126,7 +130,7 @@ func TestFullUpdate(t *testing.T) {
 				Id:           3,
 				Username:     "un1",
 				Name:         "n1",
-				ProfileImage: models.Image{},
+				ProfileImage: models.ImageStream{},
 				WebsiteUrl:   "wu1",
 			},
 			profile: profile.Profile{},
@@ -138,7 +142,7 @@ func TestFullUpdate(t *testing.T) {
 				Id:           3,
 				Username:     "username12_123456789_123456789_",
 				Name:         "n1",
-				ProfileImage: models.Image{},
+				ProfileImage: models.ImageStream{},
 				WebsiteUrl:   "wu1",
 			},
 			profile: profile.Profile{},
@@ -159,7 +163,7 @@ func TestFullUpdate(t *testing.T) {
 				test.prepare(&f)
 			}
 
-			serv := NewProfileService(f.repo)
+			serv := NewProfileService(f.repo, nil, time.Hour)
 
 			prof, err := serv.FullUpdate(&test.params)
 			if !errors.Is(err, test.err) {
@@ -193,7 +197,7 @@ func TestPartialUpdate(t *testing.T) {
 					UpdateUsername:     true,
 					Name:               "n1",
 					UpdateName:         true,
-					ProfileImage:       models.Image{},
+					ProfileImage:       models.ImageStream{},
 					UpdateProfileImage: true,
 					WebsiteUrl:         "wu1",
 					UpdateWebsiteUrl:   true,
@@ -210,7 +214,7 @@ func TestPartialUpdate(t *testing.T) {
 				UpdateUsername:     true,
 				Name:               "n1",
 				UpdateName:         true,
-				ProfileImage:       models.Image{},
+				ProfileImage:       models.ImageStream{},
 				UpdateProfileImage: true,
 				WebsiteUrl:         "wu1",
 				UpdateWebsiteUrl:   true,
@@ -233,7 +237,7 @@ func TestPartialUpdate(t *testing.T) {
 				test.prepare(&f)
 			}
 
-			serv := NewProfileService(f.repo)
+			serv := NewProfileService(f.repo, nil, time.Hour)
 
 			prof, err := serv.PartialUpdate(&test.params)
 			if !errors.Is(err, test.err) {
@@ -245,3 +249,327 @@ func TestPartialUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestScheduleDeletion(t *testing.T) {
+	type fields struct {
+		repo     *mocks.MockRepository
+		authServ *authMocks.MockService
+	}
+
+	type testCase struct {
+		prepare     func(f *fields)
+		deleteAfter time.Time
+		err         error
+	}
+
+	scheduled := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().ScheduleDeletion(3, gomock.Any()).Return(scheduled, nil),
+					f.authServ.EXPECT().DeleteUserSessions(3).Return(nil))
+			},
+			deleteAfter: scheduled,
+			err:         nil,
+		},
+		"repository error": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().ScheduleDeletion(3, gomock.Any()).Return(time.Time{}, pkgErrors.ErrDb)
+			},
+			deleteAfter: time.Time{},
+			err:         pkgErrors.ErrDb,
+		},
+		"sessions revocation error": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().ScheduleDeletion(3, gomock.Any()).Return(scheduled, nil),
+					f.authServ.EXPECT().DeleteUserSessions(3).Return(pkgErrors.ErrDb))
+			},
+			deleteAfter: time.Time{},
+			err:         pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{repo: mocks.NewMockRepository(ctrl), authServ: authMocks.NewMockService(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			serv := NewProfileService(f.repo, f.authServ, time.Hour)
+
+			deleteAfter, err := serv.ScheduleDeletion(3)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !deleteAfter.Equal(test.deleteAfter) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.deleteAfter, deleteAfter)
+			}
+		})
+	}
+}
+
+func TestPurgeDeletedAccounts(t *testing.T) {
+	type fields struct {
+		repo *mocks.MockRepository
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		deleted int
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().ListExpiredDeletions().Return([]int{3, 5}, nil),
+					f.repo.EXPECT().DeleteAccount(3).Return(nil),
+					f.repo.EXPECT().DeleteAccount(5).Return(nil))
+			},
+			deleted: 2,
+			err:     nil,
+		},
+		"nothing to delete": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().ListExpiredDeletions().Return([]int{}, nil)
+			},
+			deleted: 0,
+			err:     nil,
+		},
+		"delete error": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().ListExpiredDeletions().Return([]int{3, 5, 7}, nil),
+					f.repo.EXPECT().DeleteAccount(3).Return(pkgErrors.ErrDb),
+					f.repo.EXPECT().DeleteAccount(5).Return(nil),
+					f.repo.EXPECT().DeleteAccount(7).Return(pkgErrors.ErrUserNotFound))
+			},
+			deleted: 1,
+			err:     pkgErrors.ErrDb,
+		},
+		"list error": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().ListExpiredDeletions().Return(nil, pkgErrors.ErrDb)
+			},
+			deleted: 0,
+			err:     pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{repo: mocks.NewMockRepository(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			serv := NewProfileService(f.repo, nil, time.Hour)
+
+			deleted, err := serv.PurgeDeletedAccounts()
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if deleted != test.deleted {
+				t.Errorf("\nExpected: %d\nGot: %d", test.deleted, deleted)
+			}
+		})
+	}
+}
+
+func TestUpgradeToBusiness(t *testing.T) {
+	type fields struct {
+		repo *mocks.MockRepository
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		params  profile.BusinessParams
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().UpgradeToBusiness(gomock.Any(), gomock.Any()).
+					Return(profile.BusinessProfile{CompanyName: "cn1"}, nil)
+			},
+			params: profile.BusinessParams{UserId: 3, CompanyName: "cn1", WebsiteUrl: "https://cn1.com",
+				ContactEmail: "info@cn1.com"},
+			err: nil,
+		},
+		"empty company name": {
+			prepare: func(f *fields) {},
+			params:  profile.BusinessParams{UserId: 3},
+			err:     pkgErrors.ErrEmptyCompanyName,
+		},
+		"invalid website url": {
+			prepare: func(f *fields) {},
+			params:  profile.BusinessParams{UserId: 3, CompanyName: "cn1", WebsiteUrl: "ftp://cn1.com"},
+			err:     pkgErrors.ErrInvalidWebsiteUrl,
+		},
+		"internal website url": {
+			prepare: func(f *fields) {},
+			params:  profile.BusinessParams{UserId: 3, CompanyName: "cn1", WebsiteUrl: "http://169.254.169.254/"},
+			err:     pkgErrors.ErrInvalidWebsiteUrl,
+		},
+		"invalid contact email": {
+			prepare: func(f *fields) {},
+			params:  profile.BusinessParams{UserId: 3, CompanyName: "cn1", ContactEmail: "cn1.com"},
+			err:     pkgErrors.ErrInvalidContactEmail,
+		},
+		"already business": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().UpgradeToBusiness(gomock.Any(), gomock.Any()).
+					Return(profile.BusinessProfile{}, pkgErrors.ErrAlreadyBusinessAccount)
+			},
+			params: profile.BusinessParams{UserId: 3, CompanyName: "cn1"},
+			err:    pkgErrors.ErrAlreadyBusinessAccount,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{repo: mocks.NewMockRepository(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			serv := NewProfileService(f.repo, nil, time.Hour)
+
+			_, err := serv.UpgradeToBusiness(&test.params)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+		})
+	}
+}
+
+func TestVerifyWebsite(t *testing.T) {
+	type fields struct {
+		repo *mocks.MockRepository
+	}
+
+	type testCase struct {
+		prepare func(f *fields, websiteUrl string)
+		content string
+		status  int
+		// the site is reached with the client of the service, that refuses loopback addresses
+		safeClient bool
+		err        error
+	}
+
+	verified := profile.BusinessProfile{CompanyName: "cn1", WebsiteVerified: true, VerificationToken: "token"}
+
+	tests := map[string]testCase{
+		"token published": {
+			prepare: func(f *fields, websiteUrl string) {
+				gomock.InOrder(
+					f.repo.EXPECT().GetBusinessProfile(3).Return(profile.BusinessProfile{CompanyName: "cn1",
+						WebsiteUrl: websiteUrl, VerificationToken: "token"}, nil),
+					f.repo.EXPECT().SetWebsiteVerified(3).Return(verified, nil))
+			},
+			content: "token\n",
+			err:     nil,
+		},
+		"token mismatch": {
+			prepare: func(f *fields, websiteUrl string) {
+				f.repo.EXPECT().GetBusinessProfile(3).Return(profile.BusinessProfile{CompanyName: "cn1",
+					WebsiteUrl: websiteUrl, VerificationToken: "token"}, nil)
+			},
+			content: "another",
+			err:     pkgErrors.ErrWebsiteVerificationFailed,
+		},
+		"not found": {
+			prepare: func(f *fields, websiteUrl string) {
+				f.repo.EXPECT().GetBusinessProfile(3).Return(profile.BusinessProfile{CompanyName: "cn1",
+					WebsiteUrl: websiteUrl, VerificationToken: "token"}, nil)
+			},
+			content: "token",
+			status:  http.StatusNotFound,
+			err:     pkgErrors.ErrWebsiteVerificationFailed,
+		},
+		"internal address": {
+			prepare: func(f *fields, websiteUrl string) {
+				f.repo.EXPECT().GetBusinessProfile(3).Return(profile.BusinessProfile{CompanyName: "cn1",
+					WebsiteUrl: websiteUrl, VerificationToken: "token"}, nil)
+			},
+			content:    "token",
+			safeClient: true,
+			err:        pkgErrors.ErrWebsiteVerificationFailed,
+		},
+		"no website": {
+			prepare: func(f *fields, websiteUrl string) {
+				f.repo.EXPECT().GetBusinessProfile(3).Return(profile.BusinessProfile{CompanyName: "cn1",
+					VerificationToken: "token"}, nil)
+			},
+			err: pkgErrors.ErrInvalidWebsiteUrl,
+		},
+		"personal account": {
+			prepare: func(f *fields, websiteUrl string) {
+				f.repo.EXPECT().GetBusinessProfile(3).Return(profile.BusinessProfile{}, pkgErrors.ErrNotBusinessAccount)
+			},
+			err: pkgErrors.ErrNotBusinessAccount,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/pickpin-verification.txt" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				if test.status != 0 {
+					w.WriteHeader(test.status)
+				}
+				_, _ = w.Write([]byte(test.content))
+			}))
+			defer site.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{repo: mocks.NewMockRepository(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f, site.URL+"/")
+			}
+
+			serv := NewProfileService(f.repo, nil, time.Hour)
+			if !test.safeClient {
+				serv.(*profileService).client = site.Client()
+			}
+
+			_, err := serv.VerifyWebsite(3)
+			// the reason of a failure is not returned to the user
+			if err != test.err {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+		})
+	}
+}
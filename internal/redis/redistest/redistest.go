@@ -0,0 +1,345 @@
+// Package redistest provides an in-memory Redis for tests. It serves the commands of the repositories
+// from a hook of the client, so the client never connects anywhere.
+package redistest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Server stores the keys of a client created by NewClient. Keys expire by the time of its clock.
+type Server struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	strings map[string]string
+	zsets   map[string]map[string]float64
+	expires map[string]time.Time
+
+	// Err is returned by all commands if it is set.
+	Err error
+}
+
+// NewClient returns a client served by a new in-memory server, now is the clock of the server.
+func NewClient(now func() time.Time) (*redis.Client, *Server) {
+	s := &Server{
+		now:     now,
+		strings: make(map[string]string),
+		zsets:   make(map[string]map[string]float64),
+		expires: make(map[string]time.Time),
+	}
+	rdb := redis.NewClient(&redis.Options{Addr: "redistest:6379"})
+	rdb.AddHook(s)
+	return rdb, s
+}
+
+// Clock is a clock of tests that moves only when it is told to.
+type Clock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func NewClock() *Clock {
+	return &Clock{t: time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+// Exists reports whether the key is stored and has not expired.
+func (s *Server) Exists(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.exists(key)
+}
+
+// TTL returns the time until the key expires, 0 for keys without expiration or missing keys.
+func (s *Server) TTL(key string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.exists(key) {
+		return 0
+	}
+	if exp, ok := s.expires[key]; ok {
+		return exp.Sub(s.now())
+	}
+	return 0
+}
+
+// Get returns the string value of the key.
+func (s *Server) Get(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.exists(key) {
+		return "", false
+	}
+	val, ok := s.strings[key]
+	return val, ok
+}
+
+func (s *Server) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, fmt.Errorf("redistest: dial %s: the client must not connect", addr)
+	}
+}
+
+func (s *Server) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.process(cmd)
+	}
+}
+
+// ProcessPipelineHook serves pipelines and transactions, commands of transactions are run one by one
+// under the lock of the server, so they are atomic.
+func (s *Server) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		var firstErr error
+		for _, cmd := range cmds {
+			if name := cmd.Name(); name == "multi" || name == "exec" {
+				continue
+			}
+			if err := s.process(cmd); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+}
+
+func (s *Server) process(cmd redis.Cmder) error {
+	if s.Err != nil {
+		cmd.SetErr(s.Err)
+		return s.Err
+	}
+
+	args := make([]string, 0, len(cmd.Args()))
+	for _, arg := range cmd.Args() {
+		switch v := arg.(type) {
+		case []byte:
+			args = append(args, string(v))
+		default:
+			args = append(args, fmt.Sprint(v))
+		}
+	}
+	for _, key := range keys(args) {
+		s.exists(key)
+	}
+
+	err := s.run(cmd, args)
+	if err != nil {
+		cmd.SetErr(err)
+	}
+	return err
+}
+
+// keys returns the keys of the command, they are expired before it runs.
+func keys(args []string) []string {
+	switch args[0] {
+	case "del", "exists":
+		return args[1:]
+	case "scan":
+		return nil
+	}
+	if len(args) > 1 {
+		return args[1:2]
+	}
+	return nil
+}
+
+func (s *Server) run(cmd redis.Cmder, args []string) error {
+	switch args[0] {
+	case "ping":
+		cmd.(*redis.StatusCmd).SetVal("PONG")
+	case "exists":
+		var n int64
+		for _, key := range args[1:] {
+			if s.exists(key) {
+				n++
+			}
+		}
+		cmd.(*redis.IntCmd).SetVal(n)
+	case "del":
+		var n int64
+		for _, key := range args[1:] {
+			if s.exists(key) {
+				s.delete(key)
+				n++
+			}
+		}
+		cmd.(*redis.IntCmd).SetVal(n)
+	case "get":
+		val, ok := s.strings[args[1]]
+		if !ok {
+			return redis.Nil
+		}
+		cmd.(*redis.StringCmd).SetVal(val)
+	case "set":
+		key := args[1]
+		s.delete(key)
+		s.strings[key] = args[2]
+		if len(args) == 5 {
+			n, err := strconv.ParseInt(args[4], 10, 64)
+			if err != nil {
+				return err
+			}
+			unit := time.Second
+			if strings.EqualFold(args[3], "px") {
+				unit = time.Millisecond
+			}
+			s.expires[key] = s.now().Add(time.Duration(n) * unit)
+		}
+		cmd.(*redis.StatusCmd).SetVal("OK")
+	case "expire", "pexpire":
+		n, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return err
+		}
+		unit := time.Second
+		if args[0] == "pexpire" {
+			unit = time.Millisecond
+		}
+		ok := s.exists(args[1])
+		if ok {
+			s.expires[args[1]] = s.now().Add(time.Duration(n) * unit)
+		}
+		cmd.(*redis.BoolCmd).SetVal(ok)
+	case "zadd":
+		zset := s.zsets[args[1]]
+		if zset == nil {
+			zset = make(map[string]float64)
+			s.zsets[args[1]] = zset
+		}
+		var added int64
+		for i := 2; i+1 < len(args); i += 2 {
+			score, err := strconv.ParseFloat(args[i], 64)
+			if err != nil {
+				return err
+			}
+			if _, ok := zset[args[i+1]]; !ok {
+				added++
+			}
+			zset[args[i+1]] = score
+		}
+		cmd.(*redis.IntCmd).SetVal(added)
+	case "zcard":
+		cmd.(*redis.IntCmd).SetVal(int64(len(s.zsets[args[1]])))
+	case "zcount", "zremrangebyscore":
+		min, err := parseBound(args[2])
+		if err != nil {
+			return err
+		}
+		max, err := parseBound(args[3])
+		if err != nil {
+			return err
+		}
+		var n int64
+		for member, score := range s.zsets[args[1]] {
+			if min.below(score) && max.above(score) {
+				n++
+				if args[0] == "zremrangebyscore" {
+					delete(s.zsets[args[1]], member)
+				}
+			}
+		}
+		if len(s.zsets[args[1]]) == 0 {
+			s.delete(args[1])
+		}
+		cmd.(*redis.IntCmd).SetVal(n)
+	case "scan":
+		pattern := "*"
+		for i := 2; i+1 < len(args); i += 2 {
+			if args[i] == "match" {
+				pattern = args[i+1]
+			}
+		}
+		var page []string
+		for _, key := range s.allKeys() {
+			if ok, _ := path.Match(pattern, key); ok {
+				page = append(page, key)
+			}
+		}
+		cmd.(*redis.ScanCmd).SetVal(page, 0)
+	default:
+		return fmt.Errorf("redistest: unsupported command %s", args[0])
+	}
+	return nil
+}
+
+// exists expires the key if its time has come and reports whether it is still stored.
+func (s *Server) exists(key string) bool {
+	if exp, ok := s.expires[key]; ok && !s.now().Before(exp) {
+		s.delete(key)
+	}
+	_, isString := s.strings[key]
+	_, isZSet := s.zsets[key]
+	return isString || isZSet
+}
+
+func (s *Server) delete(key string) {
+	delete(s.strings, key)
+	delete(s.zsets, key)
+	delete(s.expires, key)
+}
+
+func (s *Server) allKeys() []string {
+	var res []string
+	for key := range s.strings {
+		if s.exists(key) {
+			res = append(res, key)
+		}
+	}
+	for key := range s.zsets {
+		if s.exists(key) {
+			res = append(res, key)
+		}
+	}
+	return res
+}
+
+// bound is a score bound of sorted set commands: -inf, +inf, a score or an exclusive (score.
+type bound struct {
+	score     float64
+	exclusive bool
+}
+
+func parseBound(arg string) (bound, error) {
+	b := bound{}
+	if strings.HasPrefix(arg, "(") {
+		b.exclusive = true
+		arg = arg[1:]
+	}
+	score, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return bound{}, err
+	}
+	b.score = score
+	return b, nil
+}
+
+func (b bound) below(score float64) bool {
+	return b.score < score || !b.exclusive && b.score == score
+}
+
+func (b bound) above(score float64) bool {
+	return score < b.score || !b.exclusive && b.score == score
+}
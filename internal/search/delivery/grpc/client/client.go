@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	pkgPins "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pins"
@@ -10,29 +11,56 @@ import (
 	grpcModels "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/delivery/grpc/models"
 	proto "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/delivery/grpc/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type client struct {
 	searchClient proto.SearchEngineClient
 
-	pinServ pkgPins.Service
+	pinServ        pkgPins.Service
+	suggestTimeout time.Duration
 }
 
-func NewSearchClient(con *grpc.ClientConn, pinServ pkgPins.Service) search.Service {
+// NewSearchClient drops suggestions that take longer than suggestTimeout, they would come too late to be shown.
+func NewSearchClient(con *grpc.ClientConn, pinServ pkgPins.Service, suggestTimeout time.Duration) search.Service {
 	return &client{
-		searchClient: proto.NewSearchEngineClient(con),
-		pinServ:      pinServ,
+		searchClient:   proto.NewSearchEngineClient(con),
+		pinServ:        pinServ,
+		suggestTimeout: suggestTimeout,
 	}
 }
 
-func (c *client) Get(userId int, query string) (models.SearchRes, error) {
-	q := grpcModels.NewProtoQuery(query)
+func (c *client) Get(userId int, params search.Params) (models.SearchRes, error) {
+	params.UserId = userId
+	q := grpcModels.NewProtoQuery(&params)
 
 	resp, err := c.searchClient.Get(context.TODO(), q)
+	if err != nil {
+		return models.SearchRes{}, errors.RestoreHTTPError(errors.GRPCUnwrapper(err))
+	}
 	res := *grpcModels.NewQueryResult(resp)
+
+	for i := range res.Pins {
+		err := c.pinServ.SetLikedField(&res.Pins[i], userId)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	err = c.pinServ.SignPrivateMedia(res.Pins, userId)
+	return res, err
+}
+
+func (c *client) Similar(userId int, params search.SimilarParams) (models.SearchRes, error) {
+	params.UserId = userId
+	q := grpcModels.NewProtoSimilarQuery(&params)
+
+	resp, err := c.searchClient.Similar(context.TODO(), q)
 	if err != nil {
-		return res, errors.RestoreHTTPError(errors.GRPCUnwrapper(err))
+		return models.SearchRes{}, errors.RestoreHTTPError(errors.GRPCUnwrapper(err))
 	}
+	res := *grpcModels.NewQueryResult(resp)
 
 	for i := range res.Pins {
 		err := c.pinServ.SetLikedField(&res.Pins[i], userId)
@@ -41,5 +69,47 @@ func (c *client) Get(userId int, query string) (models.SearchRes, error) {
 		}
 	}
 
-	return res, nil
+	err = c.pinServ.SignPrivateMedia(res.Pins, userId)
+	return res, err
+}
+
+func (c *client) Suggest(prefix string) (models.SearchSuggestions, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.suggestTimeout)
+	defer cancel()
+
+	resp, err := c.searchClient.Suggest(ctx, &proto.Prefix{Prefix: prefix})
+	if status.Code(err) == codes.DeadlineExceeded {
+		return *grpcModels.NewSuggestions(&proto.Suggestions{}), nil
+	}
+	if err != nil {
+		return models.SearchSuggestions{}, errors.RestoreHTTPError(errors.GRPCUnwrapper(err))
+	}
+	return *grpcModels.NewSuggestions(resp), nil
+}
+
+func (c *client) Trending(window string) ([]models.TrendingQuery, error) {
+	resp, err := c.searchClient.Trending(context.TODO(), &proto.Window{Window: window})
+	if err != nil {
+		return nil, errors.RestoreHTTPError(errors.GRPCUnwrapper(err))
+	}
+	return grpcModels.NewTrendingQueries(resp), nil
+}
+
+func (c *client) Recent(userId int) ([]string, error) {
+	resp, err := c.searchClient.Recent(context.TODO(), &proto.User{Id: int64(userId)})
+	if err != nil {
+		return nil, errors.RestoreHTTPError(errors.GRPCUnwrapper(err))
+	}
+	if resp.Queries == nil {
+		return []string{}, nil
+	}
+	return resp.Queries, nil
+}
+
+func (c *client) ClearRecent(userId int) error {
+	_, err := c.searchClient.ClearRecent(context.TODO(), &proto.User{Id: int64(userId)})
+	if err != nil {
+		return errors.RestoreHTTPError(errors.GRPCUnwrapper(err))
+	}
+	return nil
 }
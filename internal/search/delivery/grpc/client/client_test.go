@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pins/mocks"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+	proto "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/delivery/grpc/proto"
+)
+
+// fakeEngine answers searches with the same pins.
+type fakeEngine struct {
+	proto.SearchEngineClient
+	pins []*proto.Pin
+}
+
+func (e *fakeEngine) Get(context.Context, *proto.Query, ...grpc.CallOption) (*proto.QueryResult, error) {
+	return &proto.QueryResult{Pins: e.pins, Counts: &proto.Counts{}, Cursors: &proto.Cursors{}}, nil
+}
+
+func (e *fakeEngine) Similar(context.Context, *proto.SimilarQuery, ...grpc.CallOption) (*proto.QueryResult, error) {
+	return &proto.QueryResult{Pins: e.pins, Counts: &proto.Counts{}, Cursors: &proto.Cursors{}}, nil
+}
+
+// signSecret signs the URLs of the pins on secret boards the way the pins repository does.
+func signSecret(secret map[int]bool) func(pins []models.Pin, userId int) error {
+	return func(pins []models.Pin, userId int) error {
+		for i := range pins {
+			if secret[pins[i].Id] {
+				pins[i].MediaSource += "?signature=1"
+			}
+		}
+		return nil
+	}
+}
+
+func TestSignPrivateMedia(t *testing.T) {
+	type fields struct {
+		pinServ *mocks.MockService
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		search  func(c *client) (models.SearchRes, error)
+		urls    []string
+		err     error
+	}
+
+	engine := &fakeEngine{pins: []*proto.Pin{{Id: 3, MediaSource: "ms_url3"}, {Id: 5, MediaSource: "ms_url5"}}}
+	liked := func(f *fields) {
+		f.pinServ.EXPECT().SetLikedField(gomock.Any(), 12).Return(nil).Times(2)
+	}
+
+	tests := map[string]testCase{
+		"search": {
+			prepare: func(f *fields) {
+				liked(f)
+				f.pinServ.EXPECT().SignPrivateMedia(gomock.Len(2), 12).DoAndReturn(signSecret(map[int]bool{5: true}))
+			},
+			search: func(c *client) (models.SearchRes, error) {
+				return c.Get(12, search.Params{Query: "cats"})
+			},
+			urls: []string{"ms_url3", "ms_url5?signature=1"},
+		},
+		"similar pins": {
+			prepare: func(f *fields) {
+				liked(f)
+				f.pinServ.EXPECT().SignPrivateMedia(gomock.Len(2), 12).DoAndReturn(signSecret(map[int]bool{3: true}))
+			},
+			search: func(c *client) (models.SearchRes, error) {
+				return c.Similar(12, search.SimilarParams{PinId: 7})
+			},
+			urls: []string{"ms_url3?signature=1", "ms_url5"},
+		},
+		"signing failed": {
+			prepare: func(f *fields) {
+				liked(f)
+				f.pinServ.EXPECT().SignPrivateMedia(gomock.Len(2), 12).Return(pkgErrors.ErrImageService)
+			},
+			search: func(c *client) (models.SearchRes, error) {
+				return c.Get(12, search.Params{Query: "cats"})
+			},
+			urls: []string{"ms_url3", "ms_url5"},
+			err:  pkgErrors.ErrImageService,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{pinServ: mocks.NewMockService(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			c := &client{searchClient: engine, pinServ: f.pinServ}
+			res, err := test.search(c)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if len(res.Pins) != len(test.urls) {
+				t.Fatalf("%d pins, expected %d", len(res.Pins), len(test.urls))
+			}
+			for i, pin := range res.Pins {
+				if pin.MediaSource != test.urls[i] {
+					t.Errorf("pin %d: media source = %q, expected %q", pin.Id, pin.MediaSource, test.urls[i])
+				}
+			}
+		})
+	}
+}
@@ -1,18 +1,73 @@
 package models
 
 import (
+	"time"
+
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
 	proto "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/delivery/grpc/proto"
 )
 
-func NewProtoQuery(q string) *proto.Query {
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func fromUnixTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+func NewProtoQuery(params *search.Params) *proto.Query {
 	return &proto.Query{
-		Query: q,
+		UserId:   int64(params.UserId),
+		Query:    params.Query,
+		Type:     params.Type,
+		Sort:     params.Sort,
+		Limit:    int64(params.Limit),
+		Cursor:   params.Cursor,
+		AuthorId: int64(params.AuthorId),
+		From:     unixTime(params.From),
+		To:       unixTime(params.To),
+		Color:    params.Color,
+	}
+}
+
+func NewQuery(q *proto.Query) search.Params {
+	return search.Params{
+		UserId:   int(q.UserId),
+		Query:    q.Query,
+		Type:     q.Type,
+		Sort:     q.Sort,
+		Limit:    int(q.Limit),
+		Cursor:   q.Cursor,
+		AuthorId: int(q.AuthorId),
+		From:     fromUnixTime(q.From),
+		To:       fromUnixTime(q.To),
+		Color:    q.Color,
+	}
+}
+
+func NewProtoSimilarQuery(params *search.SimilarParams) *proto.SimilarQuery {
+	return &proto.SimilarQuery{
+		UserId: int64(params.UserId),
+		PinId:  int64(params.PinId),
+		Limit:  int64(params.Limit),
+		Cursor: params.Cursor,
 	}
 }
 
-func NewQuery(q *proto.Query) string {
-	return q.Query
+func NewSimilarQuery(q *proto.SimilarQuery) search.SimilarParams {
+	return search.SimilarParams{
+		UserId: int(q.UserId),
+		PinId:  int(q.PinId),
+		Limit:  int(q.Limit),
+		Cursor: q.Cursor,
+	}
 }
 
 func NewProtoQueryResult(q *models.SearchRes) *proto.QueryResult {
@@ -46,12 +101,24 @@ func NewProtoQueryResult(q *models.SearchRes) *proto.QueryResult {
 			Name:         user.Name,
 			ProfileImage: user.ProfileImage,
 			WebsiteUrl:   user.WebsiteUrl,
+			AccountType:  user.AccountType,
 		})
 	}
 	return &proto.QueryResult{
 		Pins:   pins,
 		Boards: boards,
 		Users:  users,
+		Counts: &proto.Counts{
+			Pins:   int64(q.Counts.Pins),
+			Boards: int64(q.Counts.Boards),
+			Users:  int64(q.Counts.Users),
+		},
+		Cursors: &proto.Cursors{
+			Pins:   q.Cursors.Pins,
+			Boards: q.Cursors.Boards,
+			Users:  q.Cursors.Users,
+		},
+		DidYouMean: q.DidYouMean,
 	}
 }
 
@@ -80,17 +147,78 @@ func NewQueryResult(q *proto.QueryResult) *models.SearchRes {
 	}
 	users := make([]models.Profile, 0)
 	for _, user := range q.Users {
-		users = append(users, models.Profile{
+		profile := models.Profile{
 			Id:           int(user.Id),
 			Username:     user.Username,
 			Name:         user.Name,
 			ProfileImage: user.ProfileImage,
 			WebsiteUrl:   user.WebsiteUrl,
-		})
+		}
+		profile.SetAccountType(user.AccountType)
+		users = append(users, profile)
 	}
 	return &models.SearchRes{
 		Users:  users,
 		Boards: boards,
 		Pins:   pins,
+		Counts: models.SearchCounts{
+			Pins:   int(q.GetCounts().GetPins()),
+			Boards: int(q.GetCounts().GetBoards()),
+			Users:  int(q.GetCounts().GetUsers()),
+		},
+		Cursors: models.SearchCursors{
+			Pins:   q.GetCursors().GetPins(),
+			Boards: q.GetCursors().GetBoards(),
+			Users:  q.GetCursors().GetUsers(),
+		},
+		DidYouMean: q.GetDidYouMean(),
+	}
+}
+
+func NewProtoSuggestions(s *models.SearchSuggestions) *proto.Suggestions {
+	return &proto.Suggestions{
+		Users:   s.Users,
+		Boards:  s.Boards,
+		Tags:    s.Tags,
+		Queries: s.Queries,
+	}
+}
+
+func NewSuggestions(s *proto.Suggestions) *models.SearchSuggestions {
+	return &models.SearchSuggestions{
+		Users:   nonNil(s.Users),
+		Boards:  nonNil(s.Boards),
+		Tags:    nonNil(s.Tags),
+		Queries: nonNil(s.Queries),
+	}
+}
+
+// nonNil keeps empty suggestions empty arrays in responses, protobuf does not tell them from missing ones.
+func nonNil(strs []string) []string {
+	if strs == nil {
+		return []string{}
+	}
+	return strs
+}
+
+func NewProtoTrendingQueries(queries []models.TrendingQuery) *proto.TrendingQueries {
+	res := make([]*proto.TrendingQuery, 0, len(queries))
+	for _, query := range queries {
+		res = append(res, &proto.TrendingQuery{
+			Query:    query.Query,
+			Searches: int64(query.Searches),
+		})
+	}
+	return &proto.TrendingQueries{Queries: res}
+}
+
+func NewTrendingQueries(q *proto.TrendingQueries) []models.TrendingQuery {
+	res := make([]models.TrendingQuery, 0, len(q.Queries))
+	for _, query := range q.Queries {
+		res = append(res, models.TrendingQuery{
+			Query:    query.Query,
+			Searches: int(query.Searches),
+		})
 	}
+	return res
 }
@@ -1,385 +1,1509 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.12.4
 // source: search.proto
 
 package search
 
 import (
-	fmt "fmt"
-	proto "github.com/golang/protobuf/proto"
-	math "math"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
 )
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
 
+// Type is "pins", "boards", "users" or empty for all of them, Sort is "relevance", "recent" or "popular".
+// Cursor comes from Cursors of a previous result of the same type and sort.
+// From and To are unix times of the pins creation range, 0 leaves it open.
+// UserId is the user who searches, only the pins and boards they may read are found.
+// Color is #rrggbb, only the pins of a close dominant color are found, empty for any
 type Query struct {
-	Query                string   `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query    string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Type     string `protobuf:"bytes,2,opt,name=Type,proto3" json:"Type,omitempty"`
+	Sort     string `protobuf:"bytes,3,opt,name=Sort,proto3" json:"Sort,omitempty"`
+	Limit    int64  `protobuf:"varint,4,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	Cursor   string `protobuf:"bytes,5,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	AuthorId int64  `protobuf:"varint,6,opt,name=AuthorId,proto3" json:"AuthorId,omitempty"`
+	From     int64  `protobuf:"varint,7,opt,name=From,proto3" json:"From,omitempty"`
+	To       int64  `protobuf:"varint,8,opt,name=To,proto3" json:"To,omitempty"`
+	UserId   int64  `protobuf:"varint,9,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	Color    string `protobuf:"bytes,10,opt,name=Color,proto3" json:"Color,omitempty"`
+}
+
+func (x *Query) Reset() {
+	*x = Query{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Query) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Query) ProtoMessage() {}
+
+func (x *Query) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (m *Query) Reset()         { *m = Query{} }
-func (m *Query) String() string { return proto.CompactTextString(m) }
-func (*Query) ProtoMessage()    {}
+// Deprecated: Use Query.ProtoReflect.Descriptor instead.
 func (*Query) Descriptor() ([]byte, []int) {
-	return fileDescriptor_453745cff914010e, []int{0}
+	return file_search_proto_rawDescGZIP(), []int{0}
 }
 
-func (m *Query) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Query.Unmarshal(m, b)
+func (x *Query) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
 }
-func (m *Query) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Query.Marshal(b, m, deterministic)
+
+func (x *Query) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
 }
-func (m *Query) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Query.Merge(m, src)
+
+func (x *Query) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
 }
-func (m *Query) XXX_Size() int {
-	return xxx_messageInfo_Query.Size(m)
+
+func (x *Query) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
 }
-func (m *Query) XXX_DiscardUnknown() {
-	xxx_messageInfo_Query.DiscardUnknown(m)
+
+func (x *Query) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
 }
 
-var xxx_messageInfo_Query proto.InternalMessageInfo
+func (x *Query) GetAuthorId() int64 {
+	if x != nil {
+		return x.AuthorId
+	}
+	return 0
+}
+
+func (x *Query) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
 
-func (m *Query) GetQuery() string {
-	if m != nil {
-		return m.Query
+func (x *Query) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *Query) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Query) GetColor() string {
+	if x != nil {
+		return x.Color
 	}
 	return ""
 }
 
-type Pin struct {
-	Id                   int64    `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
-	Title                string   `protobuf:"bytes,2,opt,name=Title,proto3" json:"Title,omitempty"`
-	Description          string   `protobuf:"bytes,3,opt,name=Description,proto3" json:"Description,omitempty"`
-	MediaSource          string   `protobuf:"bytes,4,opt,name=MediaSource,proto3" json:"MediaSource,omitempty"`
-	NumLikes             int64    `protobuf:"varint,5,opt,name=NumLikes,proto3" json:"NumLikes,omitempty"`
-	Liked                bool     `protobuf:"varint,6,opt,name=Liked,proto3" json:"Liked,omitempty"`
-	Author               int64    `protobuf:"varint,7,opt,name=Author,proto3" json:"Author,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *Pin) Reset()         { *m = Pin{} }
-func (m *Pin) String() string { return proto.CompactTextString(m) }
-func (*Pin) ProtoMessage()    {}
-func (*Pin) Descriptor() ([]byte, []int) {
-	return fileDescriptor_453745cff914010e, []int{1}
+// Pins similar to the pin, Cursor comes from Cursors.Pins of a previous result of the same pin
+type SimilarQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId int64  `protobuf:"varint,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
+	PinId  int64  `protobuf:"varint,2,opt,name=PinId,proto3" json:"PinId,omitempty"`
+	Limit  int64  `protobuf:"varint,3,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	Cursor string `protobuf:"bytes,4,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
 }
 
-func (m *Pin) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Pin.Unmarshal(m, b)
+func (x *SimilarQuery) Reset() {
+	*x = SimilarQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *Pin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Pin.Marshal(b, m, deterministic)
+
+func (x *SimilarQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *Pin) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Pin.Merge(m, src)
+
+func (*SimilarQuery) ProtoMessage() {}
+
+func (x *SimilarQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-func (m *Pin) XXX_Size() int {
-	return xxx_messageInfo_Pin.Size(m)
+
+// Deprecated: Use SimilarQuery.ProtoReflect.Descriptor instead.
+func (*SimilarQuery) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{1}
 }
-func (m *Pin) XXX_DiscardUnknown() {
-	xxx_messageInfo_Pin.DiscardUnknown(m)
+
+func (x *SimilarQuery) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
 }
 
-var xxx_messageInfo_Pin proto.InternalMessageInfo
+func (x *SimilarQuery) GetPinId() int64 {
+	if x != nil {
+		return x.PinId
+	}
+	return 0
+}
 
-func (m *Pin) GetId() int64 {
-	if m != nil {
-		return m.Id
+func (x *SimilarQuery) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
 	}
 	return 0
 }
 
-func (m *Pin) GetTitle() string {
-	if m != nil {
-		return m.Title
+func (x *SimilarQuery) GetCursor() string {
+	if x != nil {
+		return x.Cursor
 	}
 	return ""
 }
 
-func (m *Pin) GetDescription() string {
-	if m != nil {
-		return m.Description
+type Pin struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          int64  `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Title       string `protobuf:"bytes,2,opt,name=Title,proto3" json:"Title,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=Description,proto3" json:"Description,omitempty"`
+	MediaSource string `protobuf:"bytes,4,opt,name=MediaSource,proto3" json:"MediaSource,omitempty"`
+	NumLikes    int64  `protobuf:"varint,5,opt,name=NumLikes,proto3" json:"NumLikes,omitempty"`
+	Liked       bool   `protobuf:"varint,6,opt,name=Liked,proto3" json:"Liked,omitempty"`
+	Author      int64  `protobuf:"varint,7,opt,name=Author,proto3" json:"Author,omitempty"`
+}
+
+func (x *Pin) Reset() {
+	*x = Pin{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pin) ProtoMessage() {}
+
+func (x *Pin) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pin.ProtoReflect.Descriptor instead.
+func (*Pin) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Pin) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Pin) GetTitle() string {
+	if x != nil {
+		return x.Title
 	}
 	return ""
 }
 
-func (m *Pin) GetMediaSource() string {
-	if m != nil {
-		return m.MediaSource
+func (x *Pin) GetDescription() string {
+	if x != nil {
+		return x.Description
 	}
 	return ""
 }
 
-func (m *Pin) GetNumLikes() int64 {
-	if m != nil {
-		return m.NumLikes
+func (x *Pin) GetMediaSource() string {
+	if x != nil {
+		return x.MediaSource
+	}
+	return ""
+}
+
+func (x *Pin) GetNumLikes() int64 {
+	if x != nil {
+		return x.NumLikes
 	}
 	return 0
 }
 
-func (m *Pin) GetLiked() bool {
-	if m != nil {
-		return m.Liked
+func (x *Pin) GetLiked() bool {
+	if x != nil {
+		return x.Liked
 	}
 	return false
 }
 
-func (m *Pin) GetAuthor() int64 {
-	if m != nil {
-		return m.Author
+func (x *Pin) GetAuthor() int64 {
+	if x != nil {
+		return x.Author
 	}
 	return 0
 }
 
 type Board struct {
-	Id                   int64    `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
-	Description          string   `protobuf:"bytes,3,opt,name=Description,proto3" json:"Description,omitempty"`
-	Privacy              string   `protobuf:"bytes,4,opt,name=Privacy,proto3" json:"Privacy,omitempty"`
-	UserId               int64    `protobuf:"varint,5,opt,name=UserId,proto3" json:"UserId,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *Board) Reset()         { *m = Board{} }
-func (m *Board) String() string { return proto.CompactTextString(m) }
-func (*Board) ProtoMessage()    {}
-func (*Board) Descriptor() ([]byte, []int) {
-	return fileDescriptor_453745cff914010e, []int{2}
-}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (m *Board) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Board.Unmarshal(m, b)
-}
-func (m *Board) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Board.Marshal(b, m, deterministic)
+	Id          int64  `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=Description,proto3" json:"Description,omitempty"`
+	Privacy     string `protobuf:"bytes,4,opt,name=Privacy,proto3" json:"Privacy,omitempty"`
+	UserId      int64  `protobuf:"varint,5,opt,name=UserId,proto3" json:"UserId,omitempty"`
 }
-func (m *Board) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Board.Merge(m, src)
+
+func (x *Board) Reset() {
+	*x = Board{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-func (m *Board) XXX_Size() int {
-	return xxx_messageInfo_Board.Size(m)
+
+func (x *Board) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-func (m *Board) XXX_DiscardUnknown() {
-	xxx_messageInfo_Board.DiscardUnknown(m)
+
+func (*Board) ProtoMessage() {}
+
+func (x *Board) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var xxx_messageInfo_Board proto.InternalMessageInfo
+// Deprecated: Use Board.ProtoReflect.Descriptor instead.
+func (*Board) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{3}
+}
 
-func (m *Board) GetId() int64 {
-	if m != nil {
-		return m.Id
+func (x *Board) GetId() int64 {
+	if x != nil {
+		return x.Id
 	}
 	return 0
 }
 
-func (m *Board) GetName() string {
-	if m != nil {
-		return m.Name
+func (x *Board) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-func (m *Board) GetDescription() string {
-	if m != nil {
-		return m.Description
+func (x *Board) GetDescription() string {
+	if x != nil {
+		return x.Description
 	}
 	return ""
 }
 
-func (m *Board) GetPrivacy() string {
-	if m != nil {
-		return m.Privacy
+func (x *Board) GetPrivacy() string {
+	if x != nil {
+		return x.Privacy
 	}
 	return ""
 }
 
-func (m *Board) GetUserId() int64 {
-	if m != nil {
-		return m.UserId
+func (x *Board) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
 	}
 	return 0
 }
 
 type Profile struct {
-	Id                   int64    `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
-	Username             string   `protobuf:"bytes,2,opt,name=Username,proto3" json:"Username,omitempty"`
-	Name                 string   `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"`
-	ProfileImage         string   `protobuf:"bytes,4,opt,name=ProfileImage,proto3" json:"ProfileImage,omitempty"`
-	WebsiteUrl           string   `protobuf:"bytes,5,opt,name=WebsiteUrl,proto3" json:"WebsiteUrl,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *Profile) Reset()         { *m = Profile{} }
-func (m *Profile) String() string { return proto.CompactTextString(m) }
-func (*Profile) ProtoMessage()    {}
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           int64  `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Username     string `protobuf:"bytes,2,opt,name=Username,proto3" json:"Username,omitempty"`
+	Name         string `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"`
+	ProfileImage string `protobuf:"bytes,4,opt,name=ProfileImage,proto3" json:"ProfileImage,omitempty"`
+	WebsiteUrl   string `protobuf:"bytes,5,opt,name=WebsiteUrl,proto3" json:"WebsiteUrl,omitempty"`
+	AccountType  string `protobuf:"bytes,6,opt,name=AccountType,proto3" json:"AccountType,omitempty"`
+}
+
+func (x *Profile) Reset() {
+	*x = Profile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Profile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Profile) ProtoMessage() {}
+
+func (x *Profile) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Profile.ProtoReflect.Descriptor instead.
 func (*Profile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_453745cff914010e, []int{3}
+	return file_search_proto_rawDescGZIP(), []int{4}
 }
 
-func (m *Profile) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Profile.Unmarshal(m, b)
+func (x *Profile) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
 }
-func (m *Profile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Profile.Marshal(b, m, deterministic)
+
+func (x *Profile) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
 }
-func (m *Profile) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Profile.Merge(m, src)
+
+func (x *Profile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
-func (m *Profile) XXX_Size() int {
-	return xxx_messageInfo_Profile.Size(m)
+
+func (x *Profile) GetProfileImage() string {
+	if x != nil {
+		return x.ProfileImage
+	}
+	return ""
 }
-func (m *Profile) XXX_DiscardUnknown() {
-	xxx_messageInfo_Profile.DiscardUnknown(m)
+
+func (x *Profile) GetWebsiteUrl() string {
+	if x != nil {
+		return x.WebsiteUrl
+	}
+	return ""
+}
+
+func (x *Profile) GetAccountType() string {
+	if x != nil {
+		return x.AccountType
+	}
+	return ""
+}
+
+// Totals of each type
+type Counts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pins   int64 `protobuf:"varint,1,opt,name=Pins,proto3" json:"Pins,omitempty"`
+	Boards int64 `protobuf:"varint,2,opt,name=Boards,proto3" json:"Boards,omitempty"`
+	Users  int64 `protobuf:"varint,3,opt,name=Users,proto3" json:"Users,omitempty"`
+}
+
+func (x *Counts) Reset() {
+	*x = Counts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Counts) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var xxx_messageInfo_Profile proto.InternalMessageInfo
+func (*Counts) ProtoMessage() {}
 
-func (m *Profile) GetId() int64 {
-	if m != nil {
-		return m.Id
+func (x *Counts) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Counts.ProtoReflect.Descriptor instead.
+func (*Counts) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Counts) GetPins() int64 {
+	if x != nil {
+		return x.Pins
 	}
 	return 0
 }
 
-func (m *Profile) GetUsername() string {
-	if m != nil {
-		return m.Username
+func (x *Counts) GetBoards() int64 {
+	if x != nil {
+		return x.Boards
 	}
-	return ""
+	return 0
+}
+
+func (x *Counts) GetUsers() int64 {
+	if x != nil {
+		return x.Users
+	}
+	return 0
+}
+
+// Cursors of the next pages of each type, empty on the last page
+type Cursors struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pins   string `protobuf:"bytes,1,opt,name=Pins,proto3" json:"Pins,omitempty"`
+	Boards string `protobuf:"bytes,2,opt,name=Boards,proto3" json:"Boards,omitempty"`
+	Users  string `protobuf:"bytes,3,opt,name=Users,proto3" json:"Users,omitempty"`
+}
+
+func (x *Cursors) Reset() {
+	*x = Cursors{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cursors) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cursors) ProtoMessage() {}
+
+func (x *Cursors) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cursors.ProtoReflect.Descriptor instead.
+func (*Cursors) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{6}
 }
 
-func (m *Profile) GetName() string {
-	if m != nil {
-		return m.Name
+func (x *Cursors) GetPins() string {
+	if x != nil {
+		return x.Pins
 	}
 	return ""
 }
 
-func (m *Profile) GetProfileImage() string {
-	if m != nil {
-		return m.ProfileImage
+func (x *Cursors) GetBoards() string {
+	if x != nil {
+		return x.Boards
 	}
 	return ""
 }
 
-func (m *Profile) GetWebsiteUrl() string {
-	if m != nil {
-		return m.WebsiteUrl
+func (x *Cursors) GetUsers() string {
+	if x != nil {
+		return x.Users
 	}
 	return ""
 }
 
 type QueryResult struct {
-	Users                []*Profile `protobuf:"bytes,1,rep,name=Users,proto3" json:"Users,omitempty"`
-	Boards               []*Board   `protobuf:"bytes,2,rep,name=Boards,proto3" json:"Boards,omitempty"`
-	Pins                 []*Pin     `protobuf:"bytes,3,rep,name=Pins,proto3" json:"Pins,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Users      []*Profile `protobuf:"bytes,1,rep,name=Users,proto3" json:"Users,omitempty"`
+	Boards     []*Board   `protobuf:"bytes,2,rep,name=Boards,proto3" json:"Boards,omitempty"`
+	Pins       []*Pin     `protobuf:"bytes,3,rep,name=Pins,proto3" json:"Pins,omitempty"`
+	Counts     *Counts    `protobuf:"bytes,4,opt,name=Counts,proto3" json:"Counts,omitempty"`
+	Cursors    *Cursors   `protobuf:"bytes,5,opt,name=Cursors,proto3" json:"Cursors,omitempty"`
+	DidYouMean string     `protobuf:"bytes,6,opt,name=DidYouMean,proto3" json:"DidYouMean,omitempty"`
 }
 
-func (m *QueryResult) Reset()         { *m = QueryResult{} }
-func (m *QueryResult) String() string { return proto.CompactTextString(m) }
-func (*QueryResult) ProtoMessage()    {}
+func (x *QueryResult) Reset() {
+	*x = QueryResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryResult) ProtoMessage() {}
+
+func (x *QueryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryResult.ProtoReflect.Descriptor instead.
 func (*QueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_453745cff914010e, []int{4}
+	return file_search_proto_rawDescGZIP(), []int{7}
 }
 
-func (m *QueryResult) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_QueryResult.Unmarshal(m, b)
+func (x *QueryResult) GetUsers() []*Profile {
+	if x != nil {
+		return x.Users
+	}
+	return nil
 }
-func (m *QueryResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_QueryResult.Marshal(b, m, deterministic)
+
+func (x *QueryResult) GetBoards() []*Board {
+	if x != nil {
+		return x.Boards
+	}
+	return nil
 }
-func (m *QueryResult) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_QueryResult.Merge(m, src)
+
+func (x *QueryResult) GetPins() []*Pin {
+	if x != nil {
+		return x.Pins
+	}
+	return nil
+}
+
+func (x *QueryResult) GetCounts() *Counts {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *QueryResult) GetCursors() *Cursors {
+	if x != nil {
+		return x.Cursors
+	}
+	return nil
+}
+
+func (x *QueryResult) GetDidYouMean() string {
+	if x != nil {
+		return x.DidYouMean
+	}
+	return ""
 }
-func (m *QueryResult) XXX_Size() int {
-	return xxx_messageInfo_QueryResult.Size(m)
+
+type Prefix struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prefix string `protobuf:"bytes,1,opt,name=Prefix,proto3" json:"Prefix,omitempty"`
 }
-func (m *QueryResult) XXX_DiscardUnknown() {
-	xxx_messageInfo_QueryResult.DiscardUnknown(m)
+
+func (x *Prefix) Reset() {
+	*x = Prefix{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var xxx_messageInfo_QueryResult proto.InternalMessageInfo
+func (x *Prefix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Prefix) ProtoMessage() {}
 
-func (m *QueryResult) GetUsers() []*Profile {
-	if m != nil {
-		return m.Users
+func (x *Prefix) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Prefix.ProtoReflect.Descriptor instead.
+func (*Prefix) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Prefix) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+// Usernames, names of public boards, hashtags of pins and popular past queries starting with the prefix
+type Suggestions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Users   []string `protobuf:"bytes,1,rep,name=Users,proto3" json:"Users,omitempty"`
+	Boards  []string `protobuf:"bytes,2,rep,name=Boards,proto3" json:"Boards,omitempty"`
+	Tags    []string `protobuf:"bytes,3,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	Queries []string `protobuf:"bytes,4,rep,name=Queries,proto3" json:"Queries,omitempty"`
+}
+
+func (x *Suggestions) Reset() {
+	*x = Suggestions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Suggestions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suggestions) ProtoMessage() {}
+
+func (x *Suggestions) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suggestions.ProtoReflect.Descriptor instead.
+func (*Suggestions) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Suggestions) GetUsers() []string {
+	if x != nil {
+		return x.Users
 	}
 	return nil
 }
 
-func (m *QueryResult) GetBoards() []*Board {
-	if m != nil {
-		return m.Boards
+func (x *Suggestions) GetBoards() []string {
+	if x != nil {
+		return x.Boards
+	}
+	return nil
+}
+
+func (x *Suggestions) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Suggestions) GetQueries() []string {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+// Window is a duration such as "24h", one of those configured in the search service, empty for the default one
+type Window struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Window string `protobuf:"bytes,1,opt,name=Window,proto3" json:"Window,omitempty"`
+}
+
+func (x *Window) Reset() {
+	*x = Window{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Window) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Window) ProtoMessage() {}
+
+func (x *Window) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Window.ProtoReflect.Descriptor instead.
+func (*Window) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Window) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+type TrendingQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query    string `protobuf:"bytes,1,opt,name=Query,proto3" json:"Query,omitempty"`
+	Searches int64  `protobuf:"varint,2,opt,name=Searches,proto3" json:"Searches,omitempty"`
+}
+
+func (x *TrendingQuery) Reset() {
+	*x = TrendingQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrendingQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendingQuery) ProtoMessage() {}
+
+func (x *TrendingQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendingQuery.ProtoReflect.Descriptor instead.
+func (*TrendingQuery) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TrendingQuery) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *TrendingQuery) GetSearches() int64 {
+	if x != nil {
+		return x.Searches
+	}
+	return 0
+}
+
+type TrendingQueries struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Queries []*TrendingQuery `protobuf:"bytes,1,rep,name=Queries,proto3" json:"Queries,omitempty"`
+}
+
+func (x *TrendingQueries) Reset() {
+	*x = TrendingQueries{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrendingQueries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendingQueries) ProtoMessage() {}
+
+func (x *TrendingQueries) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendingQueries.ProtoReflect.Descriptor instead.
+func (*TrendingQueries) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TrendingQueries) GetQueries() []*TrendingQuery {
+	if x != nil {
+		return x.Queries
 	}
 	return nil
 }
 
-func (m *QueryResult) GetPins() []*Pin {
-	if m != nil {
-		return m.Pins
+type User struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *User) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type Queries struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Queries []string `protobuf:"bytes,1,rep,name=Queries,proto3" json:"Queries,omitempty"`
+}
+
+func (x *Queries) Reset() {
+	*x = Queries{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Queries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Queries) ProtoMessage() {}
+
+func (x *Queries) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Queries.ProtoReflect.Descriptor instead.
+func (*Queries) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Queries) GetQueries() []string {
+	if x != nil {
+		return x.Queries
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Query)(nil), "search.Query")
-	proto.RegisterType((*Pin)(nil), "search.Pin")
-	proto.RegisterType((*Board)(nil), "search.Board")
-	proto.RegisterType((*Profile)(nil), "search.Profile")
-	proto.RegisterType((*QueryResult)(nil), "search.QueryResult")
-}
-
-func init() {
-	proto.RegisterFile("search.proto", fileDescriptor_453745cff914010e)
-}
-
-var fileDescriptor_453745cff914010e = []byte{
-	// 393 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xdf, 0xea, 0xd3, 0x30,
-	0x14, 0xc7, 0x6d, 0xbb, 0xf6, 0xb7, 0x9d, 0x4e, 0x85, 0x28, 0x12, 0x06, 0x6a, 0x29, 0x0c, 0x06,
-	0xc2, 0x84, 0x79, 0xb9, 0x2b, 0x87, 0x22, 0x05, 0x1d, 0x33, 0x73, 0x08, 0xde, 0x75, 0x6d, 0xdc,
-	0x82, 0x5d, 0x3b, 0x93, 0x56, 0x18, 0x08, 0x3e, 0x81, 0x0f, 0xe4, 0xdb, 0x49, 0x4e, 0xd2, 0xda,
-	0xb1, 0x1b, 0xef, 0xf2, 0xfd, 0xd3, 0x73, 0x3e, 0xa4, 0x81, 0xb1, 0xe2, 0xa9, 0xcc, 0x8e, 0xf3,
-	0xb3, 0xac, 0xea, 0x8a, 0x04, 0x46, 0xc5, 0x4f, 0xc1, 0xff, 0xd8, 0x70, 0x79, 0x21, 0x8f, 0xc1,
-	0xff, 0xae, 0x0f, 0xd4, 0x89, 0x9c, 0xd9, 0x88, 0x19, 0x11, 0xff, 0x71, 0xc0, 0xdb, 0x88, 0x92,
-	0x3c, 0x00, 0x37, 0xc9, 0x31, 0xf2, 0x98, 0x9b, 0xe4, 0xba, 0xfd, 0x49, 0xd4, 0x05, 0xa7, 0xae,
-	0x69, 0xa3, 0x20, 0x11, 0x84, 0x6f, 0xb8, 0xca, 0xa4, 0x38, 0xd7, 0xa2, 0x2a, 0xa9, 0x87, 0x59,
-	0xdf, 0xd2, 0x8d, 0x0f, 0x3c, 0x17, 0xe9, 0xb6, 0x6a, 0x64, 0xc6, 0xe9, 0xc0, 0x34, 0x7a, 0x16,
-	0x99, 0xc0, 0x70, 0xdd, 0x9c, 0xde, 0x8b, 0x6f, 0x5c, 0x51, 0x1f, 0xf7, 0x75, 0x5a, 0x6f, 0xd5,
-	0x87, 0x9c, 0x06, 0x91, 0x33, 0x1b, 0x32, 0x23, 0xc8, 0x13, 0x08, 0x5e, 0x37, 0xf5, 0xb1, 0x92,
-	0xf4, 0x0e, 0xfb, 0x56, 0xc5, 0xbf, 0xc0, 0x5f, 0x55, 0xa9, 0xcc, 0x6f, 0xe0, 0x09, 0x0c, 0xd6,
-	0xe9, 0xa9, 0x65, 0xc7, 0xf3, 0x7f, 0xa0, 0x53, 0xb8, 0xdb, 0x48, 0xf1, 0x23, 0xcd, 0x2e, 0x16,
-	0xbb, 0x95, 0x1a, 0x60, 0xa7, 0xb8, 0x4c, 0x72, 0x0b, 0x6c, 0x55, 0xfc, 0xdb, 0xd1, 0x9f, 0x54,
-	0x5f, 0x45, 0xc1, 0x6f, 0x18, 0x26, 0x30, 0xd4, 0xad, 0xf2, 0x1f, 0x47, 0xa7, 0x3b, 0x3e, 0xaf,
-	0xc7, 0x17, 0xc3, 0xd8, 0x8e, 0x4a, 0x4e, 0xe9, 0xa1, 0xbd, 0xb9, 0x2b, 0x8f, 0x3c, 0x03, 0xf8,
-	0xcc, 0xf7, 0x4a, 0xd4, 0x7c, 0x27, 0x0b, 0x64, 0x19, 0xb1, 0x9e, 0x13, 0xff, 0x84, 0x10, 0xff,
-	0x35, 0xe3, 0xaa, 0x29, 0x6a, 0x32, 0x05, 0x5f, 0xaf, 0x54, 0xd4, 0x89, 0xbc, 0x59, 0xb8, 0x78,
-	0x38, 0xb7, 0x0f, 0xc4, 0xce, 0x64, 0x26, 0x25, 0x53, 0x08, 0xf0, 0x1a, 0x15, 0x75, 0xb1, 0x77,
-	0xbf, 0xed, 0xa1, 0xcb, 0x6c, 0x48, 0x9e, 0xc3, 0x60, 0x23, 0x4a, 0x45, 0x3d, 0x2c, 0x85, 0xdd,
-	0x30, 0x51, 0x32, 0x0c, 0x16, 0x4b, 0x18, 0x6f, 0xd1, 0x7b, 0x5b, 0x1e, 0x44, 0xc9, 0xc9, 0x0b,
-	0xf0, 0xde, 0xf1, 0x9a, 0x74, 0xe3, 0x10, 0x6d, 0xf2, 0xe8, 0x4a, 0x1a, 0xd2, 0xf8, 0xde, 0x2a,
-	0xfc, 0x32, 0x9a, 0xbf, 0x5c, 0x9a, 0x68, 0x1f, 0xe0, 0x13, 0x7e, 0xf5, 0x37, 0x00, 0x00, 0xff,
-	0xff, 0xd5, 0x2a, 0xc7, 0x6c, 0xd2, 0x02, 0x00, 0x00,
+type Nothing struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dummy bool `protobuf:"varint,1,opt,name=dummy,proto3" json:"dummy,omitempty"`
+}
+
+func (x *Nothing) Reset() {
+	*x = Nothing{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_search_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Nothing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Nothing) ProtoMessage() {}
+
+func (x *Nothing) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Nothing.ProtoReflect.Descriptor instead.
+func (*Nothing) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Nothing) GetDummy() bool {
+	if x != nil {
+		return x.Dummy
+	}
+	return false
+}
+
+var File_search_proto protoreflect.FileDescriptor
+
+var file_search_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x22, 0xe1, 0x01, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x6f,
+	0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x53, 0x6f, 0x72, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08,
+	0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x72, 0x6f, 0x6d,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02,
+	0x54, 0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x54, 0x6f, 0x12, 0x16, 0x0a, 0x06,
+	0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x55, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x6a, 0x0a, 0x0c, 0x53, 0x69,
+	0x6d, 0x69, 0x6c, 0x61, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x55, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x55, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x50, 0x69, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x50, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0xb9, 0x01, 0x0a, 0x03, 0x50, 0x69, 0x6e, 0x12, 0x0e,
+	0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54,
+	0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x44, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x4d, 0x65, 0x64,
+	0x69, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4e, 0x75, 0x6d, 0x4c,
+	0x69, 0x6b, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x4e, 0x75, 0x6d, 0x4c,
+	0x69, 0x6b, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x41, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x22, 0x7f, 0x0a, 0x05, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x50, 0x72, 0x69, 0x76, 0x61, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x55, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x22, 0xaf, 0x01, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x22, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x57, 0x65, 0x62, 0x73, 0x69, 0x74, 0x65, 0x55, 0x72,
+	0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x57, 0x65, 0x62, 0x73, 0x69, 0x74, 0x65,
+	0x55, 0x72, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x4a, 0x0a, 0x06, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x50,
+	0x69, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x55,
+	0x73, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x55, 0x73, 0x65, 0x72,
+	0x73, 0x22, 0x4b, 0x0a, 0x07, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x50, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x69, 0x6e, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x55, 0x73, 0x65, 0x72,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x55, 0x73, 0x65, 0x72, 0x73, 0x22, 0xef,
+	0x01, 0x0a, 0x0b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x25,
+	0x0a, 0x05, 0x55, 0x73, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x05,
+	0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x06, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x42,
+	0x6f, 0x61, 0x72, 0x64, 0x52, 0x06, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x04,
+	0x50, 0x69, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x2e, 0x50, 0x69, 0x6e, 0x52, 0x04, 0x50, 0x69, 0x6e, 0x73, 0x12, 0x26, 0x0a,
+	0x06, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52, 0x06, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e,
+	0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x73, 0x52, 0x07, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x44, 0x69, 0x64, 0x59, 0x6f, 0x75, 0x4d, 0x65, 0x61, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x44, 0x69, 0x64, 0x59, 0x6f, 0x75, 0x4d, 0x65, 0x61, 0x6e,
+	0x22, 0x20, 0x0a, 0x06, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x50, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x22, 0x69, 0x0a, 0x0b, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x55, 0x73, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x42, 0x6f, 0x61, 0x72, 0x64,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x54,
+	0x61, 0x67, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0x20, 0x0a,
+	0x06, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x57, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22,
+	0x41, 0x0a, 0x0d, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x65, 0x73, 0x22, 0x42, 0x0a, 0x0f, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x51, 0x75,
+	0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e,
+	0x54, 0x72, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x07, 0x51,
+	0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0x16, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x0e,
+	0x0a, 0x02, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x64, 0x22, 0x23,
+	0x0a, 0x07, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x51, 0x75, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x51, 0x75, 0x65, 0x72,
+	0x69, 0x65, 0x73, 0x22, 0x1f, 0x0a, 0x07, 0x4e, 0x6f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x14,
+	0x0a, 0x05, 0x64, 0x75, 0x6d, 0x6d, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x64,
+	0x75, 0x6d, 0x6d, 0x79, 0x32, 0xb7, 0x02, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x45,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x2b, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x0d, 0x2e, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x13, 0x2e, 0x73, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x22, 0x00, 0x12, 0x36, 0x0a, 0x07, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x12, 0x14, 0x2e,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x53, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x1a, 0x13, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x07, 0x53, 0x75,
+	0x67, 0x67, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x1a, 0x13, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x53,
+	0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x08,
+	0x54, 0x72, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x0e, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x1a, 0x17, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x2e, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x06, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x0c, 0x2e,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x1a, 0x0f, 0x2e, 0x73, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0x00, 0x12, 0x2e,
+	0x0a, 0x0b, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x0c, 0x2e,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x1a, 0x0f, 0x2e, 0x73, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x2e, 0x4e, 0x6f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x22, 0x00, 0x42, 0x0b,
+	0x5a, 0x09, 0x2e, 0x2f, 0x3b, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_search_proto_rawDescOnce sync.Once
+	file_search_proto_rawDescData = file_search_proto_rawDesc
+)
+
+func file_search_proto_rawDescGZIP() []byte {
+	file_search_proto_rawDescOnce.Do(func() {
+		file_search_proto_rawDescData = protoimpl.X.CompressGZIP(file_search_proto_rawDescData)
+	})
+	return file_search_proto_rawDescData
+}
+
+var file_search_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_search_proto_goTypes = []interface{}{
+	(*Query)(nil),           // 0: search.Query
+	(*SimilarQuery)(nil),    // 1: search.SimilarQuery
+	(*Pin)(nil),             // 2: search.Pin
+	(*Board)(nil),           // 3: search.Board
+	(*Profile)(nil),         // 4: search.Profile
+	(*Counts)(nil),          // 5: search.Counts
+	(*Cursors)(nil),         // 6: search.Cursors
+	(*QueryResult)(nil),     // 7: search.QueryResult
+	(*Prefix)(nil),          // 8: search.Prefix
+	(*Suggestions)(nil),     // 9: search.Suggestions
+	(*Window)(nil),          // 10: search.Window
+	(*TrendingQuery)(nil),   // 11: search.TrendingQuery
+	(*TrendingQueries)(nil), // 12: search.TrendingQueries
+	(*User)(nil),            // 13: search.User
+	(*Queries)(nil),         // 14: search.Queries
+	(*Nothing)(nil),         // 15: search.Nothing
+}
+var file_search_proto_depIdxs = []int32{
+	4,  // 0: search.QueryResult.Users:type_name -> search.Profile
+	3,  // 1: search.QueryResult.Boards:type_name -> search.Board
+	2,  // 2: search.QueryResult.Pins:type_name -> search.Pin
+	5,  // 3: search.QueryResult.Counts:type_name -> search.Counts
+	6,  // 4: search.QueryResult.Cursors:type_name -> search.Cursors
+	11, // 5: search.TrendingQueries.Queries:type_name -> search.TrendingQuery
+	0,  // 6: search.SearchEngine.Get:input_type -> search.Query
+	1,  // 7: search.SearchEngine.Similar:input_type -> search.SimilarQuery
+	8,  // 8: search.SearchEngine.Suggest:input_type -> search.Prefix
+	10, // 9: search.SearchEngine.Trending:input_type -> search.Window
+	13, // 10: search.SearchEngine.Recent:input_type -> search.User
+	13, // 11: search.SearchEngine.ClearRecent:input_type -> search.User
+	7,  // 12: search.SearchEngine.Get:output_type -> search.QueryResult
+	7,  // 13: search.SearchEngine.Similar:output_type -> search.QueryResult
+	9,  // 14: search.SearchEngine.Suggest:output_type -> search.Suggestions
+	12, // 15: search.SearchEngine.Trending:output_type -> search.TrendingQueries
+	14, // 16: search.SearchEngine.Recent:output_type -> search.Queries
+	15, // 17: search.SearchEngine.ClearRecent:output_type -> search.Nothing
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_search_proto_init() }
+func file_search_proto_init() {
+	if File_search_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_search_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Query); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SimilarQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Board); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Profile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Counts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cursors); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Prefix); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Suggestions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Window); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrendingQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrendingQueries); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*User); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Queries); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_search_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Nothing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_search_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_search_proto_goTypes,
+		DependencyIndexes: file_search_proto_depIdxs,
+		MessageInfos:      file_search_proto_msgTypes,
+	}.Build()
+	File_search_proto = out.File
+	file_search_proto_rawDesc = nil
+	file_search_proto_goTypes = nil
+	file_search_proto_depIdxs = nil
 }
@@ -19,7 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	SearchEngine_Get_FullMethodName = "/search.SearchEngine/Get"
+	SearchEngine_Get_FullMethodName         = "/search.SearchEngine/Get"
+	SearchEngine_Similar_FullMethodName     = "/search.SearchEngine/Similar"
+	SearchEngine_Suggest_FullMethodName     = "/search.SearchEngine/Suggest"
+	SearchEngine_Trending_FullMethodName    = "/search.SearchEngine/Trending"
+	SearchEngine_Recent_FullMethodName      = "/search.SearchEngine/Recent"
+	SearchEngine_ClearRecent_FullMethodName = "/search.SearchEngine/ClearRecent"
 )
 
 // SearchEngineClient is the client API for SearchEngine service.
@@ -27,6 +32,11 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type SearchEngineClient interface {
 	Get(ctx context.Context, in *Query, opts ...grpc.CallOption) (*QueryResult, error)
+	Similar(ctx context.Context, in *SimilarQuery, opts ...grpc.CallOption) (*QueryResult, error)
+	Suggest(ctx context.Context, in *Prefix, opts ...grpc.CallOption) (*Suggestions, error)
+	Trending(ctx context.Context, in *Window, opts ...grpc.CallOption) (*TrendingQueries, error)
+	Recent(ctx context.Context, in *User, opts ...grpc.CallOption) (*Queries, error)
+	ClearRecent(ctx context.Context, in *User, opts ...grpc.CallOption) (*Nothing, error)
 }
 
 type searchEngineClient struct {
@@ -46,11 +56,61 @@ func (c *searchEngineClient) Get(ctx context.Context, in *Query, opts ...grpc.Ca
 	return out, nil
 }
 
+func (c *searchEngineClient) Similar(ctx context.Context, in *SimilarQuery, opts ...grpc.CallOption) (*QueryResult, error) {
+	out := new(QueryResult)
+	err := c.cc.Invoke(ctx, SearchEngine_Similar_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchEngineClient) Suggest(ctx context.Context, in *Prefix, opts ...grpc.CallOption) (*Suggestions, error) {
+	out := new(Suggestions)
+	err := c.cc.Invoke(ctx, SearchEngine_Suggest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchEngineClient) Trending(ctx context.Context, in *Window, opts ...grpc.CallOption) (*TrendingQueries, error) {
+	out := new(TrendingQueries)
+	err := c.cc.Invoke(ctx, SearchEngine_Trending_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchEngineClient) Recent(ctx context.Context, in *User, opts ...grpc.CallOption) (*Queries, error) {
+	out := new(Queries)
+	err := c.cc.Invoke(ctx, SearchEngine_Recent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchEngineClient) ClearRecent(ctx context.Context, in *User, opts ...grpc.CallOption) (*Nothing, error) {
+	out := new(Nothing)
+	err := c.cc.Invoke(ctx, SearchEngine_ClearRecent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SearchEngineServer is the server API for SearchEngine service.
 // All implementations must embed UnimplementedSearchEngineServer
 // for forward compatibility
 type SearchEngineServer interface {
 	Get(context.Context, *Query) (*QueryResult, error)
+	Similar(context.Context, *SimilarQuery) (*QueryResult, error)
+	Suggest(context.Context, *Prefix) (*Suggestions, error)
+	Trending(context.Context, *Window) (*TrendingQueries, error)
+	Recent(context.Context, *User) (*Queries, error)
+	ClearRecent(context.Context, *User) (*Nothing, error)
 	mustEmbedUnimplementedSearchEngineServer()
 }
 
@@ -61,6 +121,21 @@ type UnimplementedSearchEngineServer struct {
 func (UnimplementedSearchEngineServer) Get(context.Context, *Query) (*QueryResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (UnimplementedSearchEngineServer) Similar(context.Context, *SimilarQuery) (*QueryResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Similar not implemented")
+}
+func (UnimplementedSearchEngineServer) Suggest(context.Context, *Prefix) (*Suggestions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Suggest not implemented")
+}
+func (UnimplementedSearchEngineServer) Trending(context.Context, *Window) (*TrendingQueries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Trending not implemented")
+}
+func (UnimplementedSearchEngineServer) Recent(context.Context, *User) (*Queries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Recent not implemented")
+}
+func (UnimplementedSearchEngineServer) ClearRecent(context.Context, *User) (*Nothing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearRecent not implemented")
+}
 func (UnimplementedSearchEngineServer) mustEmbedUnimplementedSearchEngineServer() {}
 
 // UnsafeSearchEngineServer may be embedded to opt out of forward compatibility for this service.
@@ -92,6 +167,96 @@ func _SearchEngine_Get_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SearchEngine_Similar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimilarQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).Similar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_Similar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).Similar(ctx, req.(*SimilarQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchEngine_Suggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Prefix)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).Suggest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_Suggest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).Suggest(ctx, req.(*Prefix))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchEngine_Trending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Window)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).Trending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_Trending_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).Trending(ctx, req.(*Window))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchEngine_Recent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).Recent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_Recent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).Recent(ctx, req.(*User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchEngine_ClearRecent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchEngineServer).ClearRecent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchEngine_ClearRecent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchEngineServer).ClearRecent(ctx, req.(*User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SearchEngine_ServiceDesc is the grpc.ServiceDesc for SearchEngine service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -103,6 +268,26 @@ var SearchEngine_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _SearchEngine_Get_Handler,
 		},
+		{
+			MethodName: "Similar",
+			Handler:    _SearchEngine_Similar_Handler,
+		},
+		{
+			MethodName: "Suggest",
+			Handler:    _SearchEngine_Suggest_Handler,
+		},
+		{
+			MethodName: "Trending",
+			Handler:    _SearchEngine_Trending_Handler,
+		},
+		{
+			MethodName: "Recent",
+			Handler:    _SearchEngine_Recent_Handler,
+		},
+		{
+			MethodName: "ClearRecent",
+			Handler:    _SearchEngine_ClearRecent_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "search.proto",
@@ -3,6 +3,8 @@ package server
 import (
 	"context"
 
+	"go.uber.org/zap"
+
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
 	grpcModels "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/delivery/grpc/models"
@@ -12,22 +14,100 @@ import (
 type server struct {
 	proto.UnimplementedSearchEngineServer
 
-	rep search.Repository
+	rep       search.Repository
+	suggester *search.Suggester
+	history   *search.History
+	log       *zap.Logger
 }
 
-func NewSearchServer(rep search.Repository) proto.SearchEngineServer {
+func NewSearchServer(rep search.Repository, suggester *search.Suggester, history *search.History,
+	log *zap.Logger) proto.SearchEngineServer {
 	return &server{
-		rep: rep,
+		rep:       rep,
+		suggester: suggester,
+		history:   history,
+		log:       log,
 	}
 }
 
 func (s *server) Get(ctx context.Context, q *proto.Query) (*proto.QueryResult, error) {
-	req := grpcModels.NewQuery(q)
+	params := grpcModels.NewQuery(q)
+	err := search.CheckParams(&params)
+	if err != nil {
+		return &proto.QueryResult{}, errors.GRPCWrapper(err)
+	}
+	offset, err := search.Offset(params)
+	if err != nil {
+		return &proto.QueryResult{}, errors.GRPCWrapper(err)
+	}
+
+	didYouMean, err := search.Correct(s.rep, &params)
+	if err != nil {
+		return &proto.QueryResult{}, errors.GRPCWrapper(err)
+	}
+
+	res, err := s.rep.Get(params, offset)
+	if err != nil {
+		return &proto.QueryResult{}, errors.GRPCWrapper(err)
+	}
+	search.SetCursors(&res, params, offset)
+	res.DidYouMean = didYouMean
+
+	if err = s.history.Log(params, &res); err != nil {
+		s.log.Error("Failed to log search query", zap.Error(err))
+	}
+
+	return grpcModels.NewProtoQueryResult(&res), nil
+}
+
+func (s *server) Similar(ctx context.Context, q *proto.SimilarQuery) (*proto.QueryResult, error) {
+	params := grpcModels.NewSimilarQuery(q)
+	err := search.CheckSimilarParams(&params)
+	if err != nil {
+		return &proto.QueryResult{}, errors.GRPCWrapper(err)
+	}
+	offset, err := search.SimilarOffset(params)
+	if err != nil {
+		return &proto.QueryResult{}, errors.GRPCWrapper(err)
+	}
 
-	res, err := s.rep.Get(req)
+	res, err := s.rep.Similar(params, offset)
 	if err != nil {
 		return &proto.QueryResult{}, errors.GRPCWrapper(err)
 	}
+	search.SetSimilarCursor(&res, params, offset)
+
+	return grpcModels.NewProtoQueryResult(&res), nil
+}
+
+func (s *server) Suggest(ctx context.Context, p *proto.Prefix) (*proto.Suggestions, error) {
+	res, err := s.suggester.Suggest(p.Prefix)
+	if err != nil {
+		return &proto.Suggestions{}, errors.GRPCWrapper(err)
+	}
+	return grpcModels.NewProtoSuggestions(&res), nil
+}
 
-	return grpcModels.NewProtoQueryResult(&res), err
+func (s *server) Trending(ctx context.Context, w *proto.Window) (*proto.TrendingQueries, error) {
+	res, err := s.history.Trending(w.Window)
+	if err != nil {
+		return &proto.TrendingQueries{}, errors.GRPCWrapper(err)
+	}
+	return grpcModels.NewProtoTrendingQueries(res), nil
+}
+
+func (s *server) Recent(ctx context.Context, u *proto.User) (*proto.Queries, error) {
+	res, err := s.history.Recent(int(u.Id))
+	if err != nil {
+		return &proto.Queries{}, errors.GRPCWrapper(err)
+	}
+	return &proto.Queries{Queries: res}, nil
+}
+
+func (s *server) ClearRecent(ctx context.Context, u *proto.User) (*proto.Nothing, error) {
+	err := s.history.ClearRecent(int(u.Id))
+	if err != nil {
+		return &proto.Nothing{}, errors.GRPCWrapper(err)
+	}
+	return &proto.Nothing{}, nil
 }
@@ -9,9 +9,12 @@ import (
 
 // API responses
 type searchResponse struct {
-	Pins   []models.Pin     `json:"pins"`
-	Boards []models.Board   `json:"boards"`
-	Users  []models.Profile `json:"users"`
+	Pins       []models.Pin         `json:"pins"`
+	Boards     []models.Board       `json:"boards"`
+	Users      []models.Profile     `json:"users"`
+	Counts     models.SearchCounts  `json:"counts"`
+	Cursors    models.SearchCursors `json:"cursors"`
+	DidYouMean string               `json:"did_you_mean,omitempty"`
 }
 
 func newSearchResponse(searchRes *models.SearchRes) *searchResponse {
@@ -31,8 +34,57 @@ func newSearchResponse(searchRes *models.SearchRes) *searchResponse {
 	}
 
 	return &searchResponse{
-		Pins:   searchRes.Pins,
-		Boards: searchRes.Boards,
-		Users:  searchRes.Users,
+		Pins:       searchRes.Pins,
+		Boards:     searchRes.Boards,
+		Users:      searchRes.Users,
+		Counts:     searchRes.Counts,
+		Cursors:    searchRes.Cursors,
+		DidYouMean: xss.Sanitize(searchRes.DidYouMean),
 	}
 }
+
+type suggestResponse struct {
+	Users   []string `json:"users"`
+	Boards  []string `json:"boards"`
+	Tags    []string `json:"tags"`
+	Queries []string `json:"queries"`
+}
+
+// sanitizeAll copies strs, suggestions may be shared with the cache of the search service.
+func sanitizeAll(strs []string) []string {
+	sanitized := make([]string, 0, len(strs))
+	for _, str := range strs {
+		sanitized = append(sanitized, xss.Sanitize(str))
+	}
+	return sanitized
+}
+
+func newSuggestResponse(suggestions *models.SearchSuggestions) *suggestResponse {
+	return &suggestResponse{
+		Users:   sanitizeAll(suggestions.Users),
+		Boards:  sanitizeAll(suggestions.Boards),
+		Tags:    sanitizeAll(suggestions.Tags),
+		Queries: sanitizeAll(suggestions.Queries),
+	}
+}
+
+type trendingResponse struct {
+	Queries []models.TrendingQuery `json:"queries"`
+}
+
+func newTrendingResponse(queries []models.TrendingQuery) *trendingResponse {
+	sanitized := make([]models.TrendingQuery, 0, len(queries))
+	for _, query := range queries {
+		query.Query = xss.Sanitize(query.Query)
+		sanitized = append(sanitized, query)
+	}
+	return &trendingResponse{Queries: sanitized}
+}
+
+type recentResponse struct {
+	Queries []string `json:"queries"`
+}
+
+func newRecentResponse(queries []string) *recentResponse {
+	return &recentResponse{Queries: sanitizeAll(queries)}
+}
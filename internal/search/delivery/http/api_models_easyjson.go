@@ -18,7 +18,320 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp(in *jlexer.Lexer, out *searchResponse) {
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp(in *jlexer.Lexer, out *trendingResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "queries":
+			if in.IsNull() {
+				in.Skip()
+				out.Queries = nil
+			} else {
+				in.Delim('[')
+				if out.Queries == nil {
+					if !in.IsDelim(']') {
+						out.Queries = make([]models.TrendingQuery, 0, 2)
+					} else {
+						out.Queries = []models.TrendingQuery{}
+					}
+				} else {
+					out.Queries = (out.Queries)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 models.TrendingQuery
+					(v1).UnmarshalEasyJSON(in)
+					out.Queries = append(out.Queries, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp(out *jwriter.Writer, in trendingResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"queries\":"
+		out.RawString(prefix[1:])
+		if in.Queries == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Queries {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v trendingResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v trendingResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *trendingResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *trendingResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp1(in *jlexer.Lexer, out *suggestResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "users":
+			if in.IsNull() {
+				in.Skip()
+				out.Users = nil
+			} else {
+				in.Delim('[')
+				if out.Users == nil {
+					if !in.IsDelim(']') {
+						out.Users = make([]string, 0, 4)
+					} else {
+						out.Users = []string{}
+					}
+				} else {
+					out.Users = (out.Users)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.Users = append(out.Users, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "boards":
+			if in.IsNull() {
+				in.Skip()
+				out.Boards = nil
+			} else {
+				in.Delim('[')
+				if out.Boards == nil {
+					if !in.IsDelim(']') {
+						out.Boards = make([]string, 0, 4)
+					} else {
+						out.Boards = []string{}
+					}
+				} else {
+					out.Boards = (out.Boards)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 string
+					v5 = string(in.String())
+					out.Boards = append(out.Boards, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v6 string
+					v6 = string(in.String())
+					out.Tags = append(out.Tags, v6)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "queries":
+			if in.IsNull() {
+				in.Skip()
+				out.Queries = nil
+			} else {
+				in.Delim('[')
+				if out.Queries == nil {
+					if !in.IsDelim(']') {
+						out.Queries = make([]string, 0, 4)
+					} else {
+						out.Queries = []string{}
+					}
+				} else {
+					out.Queries = (out.Queries)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 string
+					v7 = string(in.String())
+					out.Queries = append(out.Queries, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp1(out *jwriter.Writer, in suggestResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"users\":"
+		out.RawString(prefix[1:])
+		if in.Users == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Users {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v9))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"boards\":"
+		out.RawString(prefix)
+		if in.Boards == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v10, v11 := range in.Boards {
+				if v10 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v11))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v12, v13 := range in.Tags {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v13))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"queries\":"
+		out.RawString(prefix)
+		if in.Queries == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v14, v15 := range in.Queries {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v15))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v suggestResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v suggestResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *suggestResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *suggestResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp1(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp2(in *jlexer.Lexer, out *searchResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -53,9 +366,9 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchD
 					out.Pins = (out.Pins)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v1 models.Pin
-					(v1).UnmarshalEasyJSON(in)
-					out.Pins = append(out.Pins, v1)
+					var v16 models.Pin
+					(v16).UnmarshalEasyJSON(in)
+					out.Pins = append(out.Pins, v16)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -76,9 +389,9 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchD
 					out.Boards = (out.Boards)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v2 models.Board
-					(v2).UnmarshalEasyJSON(in)
-					out.Boards = append(out.Boards, v2)
+					var v17 models.Board
+					(v17).UnmarshalEasyJSON(in)
+					out.Boards = append(out.Boards, v17)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -99,13 +412,19 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchD
 					out.Users = (out.Users)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v3 models.Profile
-					(v3).UnmarshalEasyJSON(in)
-					out.Users = append(out.Users, v3)
+					var v18 models.Profile
+					(v18).UnmarshalEasyJSON(in)
+					out.Users = append(out.Users, v18)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "counts":
+			(out.Counts).UnmarshalEasyJSON(in)
+		case "cursors":
+			(out.Cursors).UnmarshalEasyJSON(in)
+		case "did_you_mean":
+			out.DidYouMean = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -116,7 +435,7 @@ func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchD
 		in.Consumed()
 	}
 }
-func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp(out *jwriter.Writer, in searchResponse) {
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp2(out *jwriter.Writer, in searchResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -127,11 +446,11 @@ func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchD
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v4, v5 := range in.Pins {
-				if v4 > 0 {
+			for v19, v20 := range in.Pins {
+				if v19 > 0 {
 					out.RawByte(',')
 				}
-				(v5).MarshalEasyJSON(out)
+				(v20).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -143,11 +462,11 @@ func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchD
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v6, v7 := range in.Boards {
-				if v6 > 0 {
+			for v21, v22 := range in.Boards {
+				if v21 > 0 {
 					out.RawByte(',')
 				}
-				(v7).MarshalEasyJSON(out)
+				(v22).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -159,38 +478,151 @@ func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchD
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v8, v9 := range in.Users {
-				if v8 > 0 {
+			for v23, v24 := range in.Users {
+				if v23 > 0 {
 					out.RawByte(',')
 				}
-				(v9).MarshalEasyJSON(out)
+				(v24).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
+	{
+		const prefix string = ",\"counts\":"
+		out.RawString(prefix)
+		(in.Counts).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"cursors\":"
+		out.RawString(prefix)
+		(in.Cursors).MarshalEasyJSON(out)
+	}
+	if in.DidYouMean != "" {
+		const prefix string = ",\"did_you_mean\":"
+		out.RawString(prefix)
+		out.String(string(in.DidYouMean))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v searchResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp(&w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v searchResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp(w, v)
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *searchResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp(&r, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *searchResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp(l, v)
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp2(l, v)
+}
+func easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp3(in *jlexer.Lexer, out *recentResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "queries":
+			if in.IsNull() {
+				in.Skip()
+				out.Queries = nil
+			} else {
+				in.Delim('[')
+				if out.Queries == nil {
+					if !in.IsDelim(']') {
+						out.Queries = make([]string, 0, 4)
+					} else {
+						out.Queries = []string{}
+					}
+				} else {
+					out.Queries = (out.Queries)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v25 string
+					v25 = string(in.String())
+					out.Queries = append(out.Queries, v25)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp3(out *jwriter.Writer, in recentResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"queries\":"
+		out.RawString(prefix[1:])
+		if in.Queries == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v26, v27 := range in.Queries {
+				if v26 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v27))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v recentResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v recentResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC0ea9389EncodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *recentResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *recentResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC0ea9389DecodeGithubComGoParkMailRu20231PracticalDevInternalSearchDeliveryHttp3(l, v)
 }
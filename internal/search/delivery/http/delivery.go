@@ -5,6 +5,7 @@ import (
 	"go.uber.org/zap"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 
@@ -13,25 +14,102 @@ import (
 	serv "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
 )
 
-func RegisterHandlers(mux *httprouter.Router, logger *zap.Logger, authorizer mw.Authorizer, serv serv.Service) {
+func RegisterHandlers(mux *httprouter.Router, logger *zap.Logger, authorizer mw.Authorizer, csrf mw.CSRFMiddleware,
+	serv serv.Service) {
 	del := delivery{serv, logger}
-	mux.GET("/search/:query", mw.HandleLogger(mw.ErrorHandler(authorizer(mw.Cors(del.get)), logger), logger))
+	mux.GET("/search", mw.HandleLogger(mw.ErrorHandler(authorizer(mw.Cors(del.get)), logger), logger))
+	mux.GET("/search/:query", mw.HandleLogger(mw.ErrorHandler(authorizer(mw.Cors(del.route)), logger), logger))
+	mux.DELETE("/search/recent", mw.HandleLogger(mw.ErrorHandler(authorizer(mw.Cors(csrf(del.clearRecent))), logger), logger))
 }
 
+// httprouter does not allow static paths next to the :query wildcard,
+// so the paths under /search that are not queries are dispatched by route.
+// These words are searched with GET /search?q= instead.
+const (
+	suggestPath  = "suggest"
+	trendingPath = "trending"
+	recentPath   = "recent"
+	similarPath  = "similar"
+)
+
+// Dates of the from and to params, both days are included
+const dateLayout = "2006-01-02"
+
 type delivery struct {
 	serv.Service
 	log *zap.Logger
 }
 
-func (del delivery) get(w http.ResponseWriter, _ *http.Request, p httprouter.Params) error {
+func (del delivery) route(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+	switch p.ByName("query") {
+	case suggestPath:
+		return del.suggest(w, r, p)
+	case trendingPath:
+		return del.trending(w, r, p)
+	case recentPath:
+		return del.recent(w, r, p)
+	case similarPath:
+		return del.similar(w, r, p)
+	}
+	return del.get(w, r, p)
+}
+
+func (del delivery) get(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
 	strUserId := p.ByName("user-id")
 	userId, err := strconv.Atoi(strUserId)
 	if err != nil {
 		return errors.Wrap(pkgErrors.ErrInvalidUserIdParam, err.Error())
 	}
 
+	queryValues := r.URL.Query()
 	query := p.ByName("query")
-	res, err := del.Service.Get(userId, query)
+	if query == "" {
+		query = queryValues.Get("q")
+	}
+	params := serv.Params{
+		Query:  query,
+		Type:   queryValues.Get("type"),
+		Sort:   queryValues.Get("sort"),
+		Limit:  serv.DefaultLimit,
+		Cursor: queryValues.Get("cursor"),
+	}
+
+	strLimit := queryValues.Get("limit")
+	if strLimit != "" {
+		params.Limit, err = strconv.Atoi(strLimit)
+		if err != nil {
+			return pkgErrors.ErrInvalidLimitParam
+		}
+	}
+
+	strAuthorId := queryValues.Get("author")
+	if strAuthorId != "" {
+		params.AuthorId, err = strconv.Atoi(strAuthorId)
+		if err != nil || params.AuthorId < 1 {
+			return pkgErrors.ErrInvalidUserIdParam
+		}
+	}
+
+	strFrom := queryValues.Get("from")
+	if strFrom != "" {
+		params.From, err = time.Parse(dateLayout, strFrom)
+		if err != nil {
+			return pkgErrors.ErrInvalidDateParam
+		}
+	}
+
+	strTo := queryValues.Get("to")
+	if strTo != "" {
+		params.To, err = time.Parse(dateLayout, strTo)
+		if err != nil {
+			return pkgErrors.ErrInvalidDateParam
+		}
+		params.To = params.To.AddDate(0, 0, 1)
+	}
+
+	params.Color = queryValues.Get("color")
+
+	res, err := del.Service.Get(userId, params)
 	if err != nil {
 		return err
 	}
@@ -49,3 +127,125 @@ func (del delivery) get(w http.ResponseWriter, _ *http.Request, p httprouter.Par
 	}
 	return nil
 }
+
+// similar finds the pins that look like the pin given by the pin param.
+func (del delivery) similar(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+	userId, err := strconv.Atoi(p.ByName("user-id"))
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrInvalidUserIdParam, err.Error())
+	}
+
+	queryValues := r.URL.Query()
+	params := serv.SimilarParams{
+		Limit:  serv.DefaultLimit,
+		Cursor: queryValues.Get("cursor"),
+	}
+	params.PinId, err = strconv.Atoi(queryValues.Get("pin"))
+	if err != nil {
+		return pkgErrors.ErrInvalidPinIdParam
+	}
+
+	strLimit := queryValues.Get("limit")
+	if strLimit != "" {
+		params.Limit, err = strconv.Atoi(strLimit)
+		if err != nil {
+			return pkgErrors.ErrInvalidLimitParam
+		}
+	}
+
+	res, err := del.Service.Similar(userId, params)
+	if err != nil {
+		return err
+	}
+
+	response := newSearchResponse(&res)
+	data, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+	return nil
+}
+
+func (del delivery) suggest(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	res, err := del.Service.Suggest(r.URL.Query().Get("q"))
+	if err != nil {
+		return err
+	}
+
+	response := newSuggestResponse(&res)
+	data, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+	return nil
+}
+
+func (del delivery) trending(w http.ResponseWriter, r *http.Request, _ httprouter.Params) error {
+	res, err := del.Service.Trending(r.URL.Query().Get("window"))
+	if err != nil {
+		return err
+	}
+
+	response := newTrendingResponse(res)
+	data, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+	return nil
+}
+
+func (del delivery) recent(w http.ResponseWriter, _ *http.Request, p httprouter.Params) error {
+	userId, err := strconv.Atoi(p.ByName("user-id"))
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrInvalidUserIdParam, err.Error())
+	}
+
+	res, err := del.Service.Recent(userId)
+	if err != nil {
+		return err
+	}
+
+	response := newRecentResponse(res)
+	data, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+	return nil
+}
+
+func (del delivery) clearRecent(_ http.ResponseWriter, _ *http.Request, p httprouter.Params) error {
+	userId, err := strconv.Atoi(p.ByName("user-id"))
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrInvalidUserIdParam, err.Error())
+	}
+
+	err = del.Service.ClearRecent(userId)
+	if err != nil {
+		return err
+	}
+	return pkgErrors.ErrNoContent
+}
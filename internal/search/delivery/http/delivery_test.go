@@ -0,0 +1,159 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	serv "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/mocks"
+)
+
+func TestRoute(t *testing.T) {
+	type fields struct {
+		serv *mocks.MockService
+	}
+
+	type testCase struct {
+		prepare  func(f *fields)
+		url      string
+		params   httprouter.Params
+		response string
+		err      error
+	}
+
+	tests := map[string]testCase{
+		"query": {
+			prepare: func(f *fields) {
+				f.serv.EXPECT().Get(12, serv.Params{Query: "cats", Limit: serv.DefaultLimit}).
+					Return(models.SearchRes{}, nil)
+			},
+			url:      "/search/cats",
+			params:   httprouter.Params{{Key: "query", Value: "cats"}, {Key: "user-id", Value: "12"}},
+			response: `{"pins":null,"boards":null,"users":null,"counts":{"pins":0,"boards":0,"users":0},"cursors":{}}`,
+		},
+		"suggestions": {
+			prepare: func(f *fields) {
+				f.serv.EXPECT().Suggest("ca").Return(models.SearchSuggestions{Tags: []string{"cats"}}, nil)
+			},
+			url:      "/search/suggest?q=ca",
+			params:   httprouter.Params{{Key: "query", Value: "suggest"}, {Key: "user-id", Value: "12"}},
+			response: `{"users":[],"boards":[],"tags":["cats"],"queries":[]}`,
+		},
+		"recent searches": {
+			prepare: func(f *fields) {
+				f.serv.EXPECT().Recent(12).Return([]string{"cats"}, nil)
+			},
+			url:      "/search/recent",
+			params:   httprouter.Params{{Key: "query", Value: "recent"}, {Key: "user-id", Value: "12"}},
+			response: `{"queries":["cats"]}`,
+		},
+		"trending searches": {
+			prepare: func(f *fields) {
+				f.serv.EXPECT().Trending("day").Return(nil, nil)
+			},
+			url:      "/search/trending?window=day",
+			params:   httprouter.Params{{Key: "query", Value: "trending"}, {Key: "user-id", Value: "12"}},
+			response: `{"queries":[]}`,
+		},
+		"similar pins": {
+			prepare: func(f *fields) {
+				f.serv.EXPECT().Similar(12, serv.SimilarParams{PinId: 3, Limit: serv.DefaultLimit}).
+					Return(models.SearchRes{}, nil)
+			},
+			url:      "/search/similar?pin=3",
+			params:   httprouter.Params{{Key: "query", Value: "similar"}, {Key: "user-id", Value: "12"}},
+			response: `{"pins":null,"boards":null,"users":null,"counts":{"pins":0,"boards":0,"users":0},"cursors":{}}`,
+		},
+		"invalid user id param": {
+			prepare: func(f *fields) {},
+			url:     "/search/cats",
+			params:  httprouter.Params{{Key: "query", Value: "cats"}, {Key: "user-id", Value: "a"}},
+			err:     pkgErrors.ErrInvalidUserIdParam,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{serv: mocks.NewMockService(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			del := delivery{Service: f.serv, log: zap.NewNop()}
+			req := httptest.NewRequest(http.MethodGet, test.url, nil)
+			rec := httptest.NewRecorder()
+			err := del.route(rec, req, test.params)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if rec.Body.String() != test.response {
+				t.Errorf("\nExpected: %s\nGot: %s", test.response, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGet_QueryParam(t *testing.T) {
+	type testCase struct {
+		url    string
+		params httprouter.Params
+		query  string
+	}
+
+	// the words dispatched by route are searched with GET /search?q=
+	tests := map[string]testCase{
+		"reserved word": {
+			url:    "/search?q=recent",
+			params: httprouter.Params{{Key: "user-id", Value: "12"}},
+			query:  "recent",
+		},
+		"several words": {
+			url:    "/search?q=similar+cats&type=pins",
+			params: httprouter.Params{{Key: "user-id", Value: "12"}},
+			query:  "similar cats",
+		},
+		"path first": {
+			url:    "/search/dogs?q=cats",
+			params: httprouter.Params{{Key: "query", Value: "dogs"}, {Key: "user-id", Value: "12"}},
+			query:  "dogs",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mocks.NewMockService(ctrl)
+			service.EXPECT().Get(12, gomock.Any()).DoAndReturn(func(_ int, params serv.Params) (models.SearchRes, error) {
+				if params.Query != test.query {
+					t.Errorf("query = %q, expected %q", params.Query, test.query)
+				}
+				return models.SearchRes{}, nil
+			})
+
+			del := delivery{Service: service, log: zap.NewNop()}
+			req := httptest.NewRequest(http.MethodGet, test.url, nil)
+			if err := del.get(httptest.NewRecorder(), req, test.params); err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package search
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/config"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+type HistoryParams struct {
+	TrendingWindows  []time.Duration // the first one is the default
+	TrendingLimit    int
+	TrendingMinCount int // searches of a query within the window before it is trending
+	RecentLimit      int // recent searches kept per user
+}
+
+func HistoryParamsFromConfig() (HistoryParams, error) {
+	params := HistoryParams{
+		TrendingLimit:    viper.GetInt(config.SearchHistoryConfig.TrendingLimit),
+		TrendingMinCount: viper.GetInt(config.SearchHistoryConfig.TrendingMinCount),
+		RecentLimit:      viper.GetInt(config.SearchHistoryConfig.RecentLimit),
+	}
+	for _, str := range viper.GetStringSlice(config.SearchHistoryConfig.TrendingWindows) {
+		window, err := time.ParseDuration(str)
+		if err != nil {
+			return HistoryParams{}, err
+		}
+		params.TrendingWindows = append(params.TrendingWindows, window)
+	}
+	return params, nil
+}
+
+// History logs searches, the log is kept for the longest trending window.
+type History struct {
+	queries QueriesRepository
+	params  HistoryParams
+}
+
+func NewHistory(queries QueriesRepository, params HistoryParams) *History {
+	return &History{queries, params}
+}
+
+// Log records the query of the first page of results that found anything, so that queries
+// are counted once per search and queries finding nothing are not suggested.
+func (h *History) Log(params Params, res *models.SearchRes) error {
+	if params.Cursor != "" || res.Counts.Pins+res.Counts.Boards+res.Counts.Users == 0 {
+		return nil
+	}
+	return h.queries.Log(NormalizeQuery(params.Query), params.UserId, h.params.RecentLimit)
+}
+
+func (h *History) Trending(window string) ([]models.TrendingQuery, error) {
+	if len(h.params.TrendingWindows) == 0 {
+		return []models.TrendingQuery{}, nil
+	}
+
+	duration := h.params.TrendingWindows[0]
+	if window != "" {
+		var err error
+		duration, err = time.ParseDuration(window)
+		if err != nil || !h.isWindow(duration) {
+			return nil, pkgErrors.ErrInvalidWindowParam
+		}
+	}
+	return h.queries.Trending(time.Now().Add(-duration), h.params.TrendingMinCount, h.params.TrendingLimit)
+}
+
+func (h *History) isWindow(duration time.Duration) bool {
+	for _, window := range h.params.TrendingWindows {
+		if window == duration {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *History) Recent(userId int) ([]string, error) {
+	return h.queries.Recent(userId, h.params.RecentLimit)
+}
+
+func (h *History) ClearRecent(userId int) error {
+	return h.queries.ClearRecent(userId)
+}
+
+// Purge deletes the searches older than the longest trending window from the log.
+func (h *History) Purge() (int, error) {
+	var retention time.Duration
+	for _, window := range h.params.TrendingWindows {
+		if window > retention {
+			retention = window
+		}
+	}
+	return h.queries.PurgeLog(time.Now().Add(-retention))
+}
+
+// StartPurger periodically purges the log of searches until ctx is done.
+func StartPurger(ctx context.Context, h *History, interval time.Duration, log *zap.Logger) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := h.Purge()
+				if err != nil {
+					log.Error("Failed to purge search log", zap.Error(err))
+				} else if deleted > 0 {
+					log.Info("Purged search log", zap.Int("deleted", deleted))
+				}
+			}
+		}
+	}()
+}
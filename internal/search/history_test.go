@@ -0,0 +1,190 @@
+package search_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/mocks"
+)
+
+// windowStart matches the times a window ago, give or take the time the test takes.
+type windowStart time.Duration
+
+func (w windowStart) Matches(x interface{}) bool {
+	since, ok := x.(time.Time)
+	if !ok {
+		return false
+	}
+	expected := time.Now().Add(-time.Duration(w))
+	return since.After(expected.Add(-time.Minute)) && !since.After(expected)
+}
+
+func (w windowStart) String() string {
+	return fmt.Sprintf("is %s ago", time.Duration(w))
+}
+
+func TestHistory_Trending(t *testing.T) {
+	type testCase struct {
+		prepare func(queries *mocks.MockQueriesRepository)
+		windows []time.Duration
+		window  string
+		res     []models.TrendingQuery
+		err     error
+	}
+
+	windows := []time.Duration{24 * time.Hour, time.Hour, 7 * 24 * time.Hour}
+	trending := []models.TrendingQuery{{Query: "cats", Searches: 12}}
+
+	tests := map[string]testCase{
+		"default window": {
+			prepare: func(queries *mocks.MockQueriesRepository) {
+				queries.EXPECT().Trending(windowStart(24*time.Hour), 2, 10).Return(trending, nil)
+			},
+			windows: windows,
+			res:     trending,
+		},
+		"configured window": {
+			prepare: func(queries *mocks.MockQueriesRepository) {
+				queries.EXPECT().Trending(windowStart(7*24*time.Hour), 2, 10).Return(trending, nil)
+			},
+			windows: windows,
+			window:  "168h",
+			res:     trending,
+		},
+		"window written otherwise": {
+			prepare: func(queries *mocks.MockQueriesRepository) {
+				queries.EXPECT().Trending(windowStart(time.Hour), 2, 10).Return(trending, nil)
+			},
+			windows: windows,
+			window:  "60m",
+			res:     trending,
+		},
+		"not configured window": {
+			windows: windows,
+			window:  "2h",
+			err:     pkgErrors.ErrInvalidWindowParam,
+		},
+		"invalid window": {
+			windows: windows,
+			window:  "day",
+			err:     pkgErrors.ErrInvalidWindowParam,
+		},
+		"no windows": {
+			window: "24h",
+			res:    []models.TrendingQuery{},
+		},
+		"db error": {
+			prepare: func(queries *mocks.MockQueriesRepository) {
+				queries.EXPECT().Trending(windowStart(24*time.Hour), 2, 10).Return(nil, pkgErrors.ErrDb)
+			},
+			windows: windows,
+			err:     pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			queries := mocks.NewMockQueriesRepository(ctrl)
+			if test.prepare != nil {
+				test.prepare(queries)
+			}
+
+			h := search.NewHistory(queries, search.HistoryParams{TrendingWindows: test.windows, TrendingLimit: 10,
+				TrendingMinCount: 2, RecentLimit: 5})
+			res, err := h.Trending(test.window)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(res, test.res) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.res, res)
+			}
+		})
+	}
+}
+
+func TestHistory_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the log is kept for the longest window, whichever is the default
+	queries := mocks.NewMockQueriesRepository(ctrl)
+	queries.EXPECT().PurgeLog(windowStart(7*24*time.Hour)).Return(3, nil)
+
+	h := search.NewHistory(queries, search.HistoryParams{
+		TrendingWindows: []time.Duration{24 * time.Hour, 7 * 24 * time.Hour, time.Hour},
+	})
+	deleted, err := h.Purge()
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if deleted != 3 {
+		t.Errorf("\nExpected: %d\nGot: %d", 3, deleted)
+	}
+}
+
+func TestHistory_Log(t *testing.T) {
+	type testCase struct {
+		prepare func(queries *mocks.MockQueriesRepository)
+		params  search.Params
+		counts  models.SearchCounts
+	}
+
+	tests := map[string]testCase{
+		"first page": {
+			prepare: func(queries *mocks.MockQueriesRepository) {
+				queries.EXPECT().Log("black cats", 12, 5).Return(nil)
+			},
+			params: search.Params{UserId: 12, Query: " Black  Cats"},
+			counts: models.SearchCounts{Boards: 1},
+		},
+		"anonymous": {
+			prepare: func(queries *mocks.MockQueriesRepository) {
+				queries.EXPECT().Log("cats", 0, 5).Return(nil)
+			},
+			params: search.Params{Query: "cats"},
+			counts: models.SearchCounts{Pins: 3},
+		},
+		"next page": {
+			params: search.Params{UserId: 12, Query: "cats", Cursor: "cGluczpyZWxldmFuY2U6MzA"},
+			counts: models.SearchCounts{Pins: 40},
+		},
+		"nothing found": {
+			params: search.Params{UserId: 12, Query: "cats"},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			queries := mocks.NewMockQueriesRepository(ctrl)
+			if test.prepare != nil {
+				test.prepare(queries)
+			}
+
+			h := search.NewHistory(queries, search.HistoryParams{RecentLimit: 5})
+			err := h.Log(test.params, &models.SearchRes{Counts: test.counts})
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
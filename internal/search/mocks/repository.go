@@ -0,0 +1,375 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/search/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	search "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Correct mocks base method.
+func (m *MockRepository) Correct(words []string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Correct", words)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Correct indicates an expected call of Correct.
+func (mr *MockRepositoryMockRecorder) Correct(words interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Correct", reflect.TypeOf((*MockRepository)(nil).Correct), words)
+}
+
+// Get mocks base method.
+func (m *MockRepository) Get(params search.Params, offset int) (models.SearchRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", params, offset)
+	ret0, _ := ret[0].(models.SearchRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRepositoryMockRecorder) Get(params, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepository)(nil).Get), params, offset)
+}
+
+// Similar mocks base method.
+func (m *MockRepository) Similar(params search.SimilarParams, offset int) (models.SearchRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Similar", params, offset)
+	ret0, _ := ret[0].(models.SearchRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Similar indicates an expected call of Similar.
+func (mr *MockRepositoryMockRecorder) Similar(params, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Similar", reflect.TypeOf((*MockRepository)(nil).Similar), params, offset)
+}
+
+// Suggest mocks base method.
+func (m *MockRepository) Suggest(prefix string, limit int) (models.SearchSuggestions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", prefix, limit)
+	ret0, _ := ret[0].(models.SearchSuggestions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockRepositoryMockRecorder) Suggest(prefix, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockRepository)(nil).Suggest), prefix, limit)
+}
+
+// MockIndex is a mock of Index interface.
+type MockIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockIndexMockRecorder
+}
+
+// MockIndexMockRecorder is the mock recorder for MockIndex.
+type MockIndexMockRecorder struct {
+	mock *MockIndex
+}
+
+// NewMockIndex creates a new mock instance.
+func NewMockIndex(ctrl *gomock.Controller) *MockIndex {
+	mock := &MockIndex{ctrl: ctrl}
+	mock.recorder = &MockIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIndex) EXPECT() *MockIndexMockRecorder {
+	return m.recorder
+}
+
+// Correct mocks base method.
+func (m *MockIndex) Correct(words []string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Correct", words)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Correct indicates an expected call of Correct.
+func (mr *MockIndexMockRecorder) Correct(words interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Correct", reflect.TypeOf((*MockIndex)(nil).Correct), words)
+}
+
+// DeleteBoard mocks base method.
+func (m *MockIndex) DeleteBoard(id int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteBoard", id)
+}
+
+// DeleteBoard indicates an expected call of DeleteBoard.
+func (mr *MockIndexMockRecorder) DeleteBoard(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoard", reflect.TypeOf((*MockIndex)(nil).DeleteBoard), id)
+}
+
+// DeletePin mocks base method.
+func (m *MockIndex) DeletePin(id int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeletePin", id)
+}
+
+// DeletePin indicates an expected call of DeletePin.
+func (mr *MockIndexMockRecorder) DeletePin(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePin", reflect.TypeOf((*MockIndex)(nil).DeletePin), id)
+}
+
+// DeleteUser mocks base method.
+func (m *MockIndex) DeleteUser(id int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteUser", id)
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockIndexMockRecorder) DeleteUser(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockIndex)(nil).DeleteUser), id)
+}
+
+// Get mocks base method.
+func (m *MockIndex) Get(params search.Params, offset int) (models.SearchRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", params, offset)
+	ret0, _ := ret[0].(models.SearchRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIndexMockRecorder) Get(params, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIndex)(nil).Get), params, offset)
+}
+
+// Load mocks base method.
+func (m *MockIndex) Load(pins []search.PinDocument, boards []search.BoardDocument, users []search.UserDocument) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Load", pins, boards, users)
+}
+
+// Load indicates an expected call of Load.
+func (mr *MockIndexMockRecorder) Load(pins, boards, users interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockIndex)(nil).Load), pins, boards, users)
+}
+
+// SetBoard mocks base method.
+func (m *MockIndex) SetBoard(doc search.BoardDocument) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetBoard", doc)
+}
+
+// SetBoard indicates an expected call of SetBoard.
+func (mr *MockIndexMockRecorder) SetBoard(doc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBoard", reflect.TypeOf((*MockIndex)(nil).SetBoard), doc)
+}
+
+// SetPin mocks base method.
+func (m *MockIndex) SetPin(doc search.PinDocument) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPin", doc)
+}
+
+// SetPin indicates an expected call of SetPin.
+func (mr *MockIndexMockRecorder) SetPin(doc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPin", reflect.TypeOf((*MockIndex)(nil).SetPin), doc)
+}
+
+// SetUser mocks base method.
+func (m *MockIndex) SetUser(doc search.UserDocument) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetUser", doc)
+}
+
+// SetUser indicates an expected call of SetUser.
+func (mr *MockIndexMockRecorder) SetUser(doc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUser", reflect.TypeOf((*MockIndex)(nil).SetUser), doc)
+}
+
+// Similar mocks base method.
+func (m *MockIndex) Similar(params search.SimilarParams, offset int) (models.SearchRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Similar", params, offset)
+	ret0, _ := ret[0].(models.SearchRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Similar indicates an expected call of Similar.
+func (mr *MockIndexMockRecorder) Similar(params, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Similar", reflect.TypeOf((*MockIndex)(nil).Similar), params, offset)
+}
+
+// Suggest mocks base method.
+func (m *MockIndex) Suggest(prefix string, limit int) (models.SearchSuggestions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", prefix, limit)
+	ret0, _ := ret[0].(models.SearchSuggestions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockIndexMockRecorder) Suggest(prefix, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockIndex)(nil).Suggest), prefix, limit)
+}
+
+// MockQueriesRepository is a mock of QueriesRepository interface.
+type MockQueriesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueriesRepositoryMockRecorder
+}
+
+// MockQueriesRepositoryMockRecorder is the mock recorder for MockQueriesRepository.
+type MockQueriesRepositoryMockRecorder struct {
+	mock *MockQueriesRepository
+}
+
+// NewMockQueriesRepository creates a new mock instance.
+func NewMockQueriesRepository(ctrl *gomock.Controller) *MockQueriesRepository {
+	mock := &MockQueriesRepository{ctrl: ctrl}
+	mock.recorder = &MockQueriesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueriesRepository) EXPECT() *MockQueriesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClearRecent mocks base method.
+func (m *MockQueriesRepository) ClearRecent(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearRecent", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearRecent indicates an expected call of ClearRecent.
+func (mr *MockQueriesRepositoryMockRecorder) ClearRecent(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearRecent", reflect.TypeOf((*MockQueriesRepository)(nil).ClearRecent), userId)
+}
+
+// Log mocks base method.
+func (m *MockQueriesRepository) Log(query string, userId, keepRecent int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Log", query, userId, keepRecent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Log indicates an expected call of Log.
+func (mr *MockQueriesRepositoryMockRecorder) Log(query, userId, keepRecent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Log", reflect.TypeOf((*MockQueriesRepository)(nil).Log), query, userId, keepRecent)
+}
+
+// Popular mocks base method.
+func (m *MockQueriesRepository) Popular(prefix string, minCount, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Popular", prefix, minCount, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Popular indicates an expected call of Popular.
+func (mr *MockQueriesRepositoryMockRecorder) Popular(prefix, minCount, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Popular", reflect.TypeOf((*MockQueriesRepository)(nil).Popular), prefix, minCount, limit)
+}
+
+// PurgeLog mocks base method.
+func (m *MockQueriesRepository) PurgeLog(before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeLog", before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeLog indicates an expected call of PurgeLog.
+func (mr *MockQueriesRepositoryMockRecorder) PurgeLog(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeLog", reflect.TypeOf((*MockQueriesRepository)(nil).PurgeLog), before)
+}
+
+// Recent mocks base method.
+func (m *MockQueriesRepository) Recent(userId, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recent", userId, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recent indicates an expected call of Recent.
+func (mr *MockQueriesRepositoryMockRecorder) Recent(userId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recent", reflect.TypeOf((*MockQueriesRepository)(nil).Recent), userId, limit)
+}
+
+// Trending mocks base method.
+func (m *MockQueriesRepository) Trending(since time.Time, minCount, limit int) ([]models.TrendingQuery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trending", since, minCount, limit)
+	ret0, _ := ret[0].([]models.TrendingQuery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Trending indicates an expected call of Trending.
+func (mr *MockQueriesRepositoryMockRecorder) Trending(since, minCount, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trending", reflect.TypeOf((*MockQueriesRepository)(nil).Trending), since, minCount, limit)
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/search/service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	search "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// ClearRecent mocks base method.
+func (m *MockService) ClearRecent(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearRecent", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearRecent indicates an expected call of ClearRecent.
+func (mr *MockServiceMockRecorder) ClearRecent(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearRecent", reflect.TypeOf((*MockService)(nil).ClearRecent), userId)
+}
+
+// Get mocks base method.
+func (m *MockService) Get(userId int, params search.Params) (models.SearchRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userId, params)
+	ret0, _ := ret[0].(models.SearchRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockServiceMockRecorder) Get(userId, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockService)(nil).Get), userId, params)
+}
+
+// Recent mocks base method.
+func (m *MockService) Recent(userId int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recent", userId)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recent indicates an expected call of Recent.
+func (mr *MockServiceMockRecorder) Recent(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recent", reflect.TypeOf((*MockService)(nil).Recent), userId)
+}
+
+// Similar mocks base method.
+func (m *MockService) Similar(userId int, params search.SimilarParams) (models.SearchRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Similar", userId, params)
+	ret0, _ := ret[0].(models.SearchRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Similar indicates an expected call of Similar.
+func (mr *MockServiceMockRecorder) Similar(userId, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Similar", reflect.TypeOf((*MockService)(nil).Similar), userId, params)
+}
+
+// Suggest mocks base method.
+func (m *MockService) Suggest(prefix string) (models.SearchSuggestions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", prefix)
+	ret0, _ := ret[0].(models.SearchSuggestions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockServiceMockRecorder) Suggest(prefix interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockService)(nil).Suggest), prefix)
+}
+
+// Trending mocks base method.
+func (m *MockService) Trending(window string) ([]models.TrendingQuery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trending", window)
+	ret0, _ := ret[0].([]models.TrendingQuery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Trending indicates an expected call of Trending.
+func (mr *MockServiceMockRecorder) Trending(window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trending", reflect.TypeOf((*MockService)(nil).Trending), window)
+}
@@ -0,0 +1,128 @@
+package search
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+)
+
+const (
+	DefaultLimit = 30
+	MaxLimit     = 100
+)
+
+const (
+	// ColorTolerance is the max distance in RGB space between the dominant color of a pin and the searched one.
+	ColorTolerance = 48
+	// SimilarMaxHashDistance is the max Hamming distance between perceptual hashes of similar pin images,
+	// it is greater than the distance between duplicates.
+	SimilarMaxHashDistance = 20
+)
+
+// CheckParams validates params and sets the default sort order.
+func CheckParams(params *Params) error {
+	switch params.Type {
+	case "", TypePins, TypeBoards, TypeUsers:
+	default:
+		return pkgErrors.ErrInvalidTypeParam
+	}
+
+	switch params.Sort {
+	case "":
+		params.Sort = SortRelevance
+	case SortRelevance, SortRecent, SortPopular:
+	default:
+		return pkgErrors.ErrInvalidSortParam
+	}
+
+	if params.Limit < 1 || params.Limit > MaxLimit {
+		return pkgErrors.ErrInvalidLimitParam
+	}
+	if params.AuthorId < 0 {
+		return pkgErrors.ErrInvalidUserIdParam
+	}
+	if !params.From.IsZero() && !params.To.IsZero() && !params.From.Before(params.To) {
+		return pkgErrors.ErrInvalidDateParam
+	}
+	if params.Color != "" {
+		color, err := pkgImage.ParseHexColor(params.Color)
+		if err != nil {
+			return pkgErrors.ErrInvalidColorParam
+		}
+		params.Color = color.Hex()
+	}
+	return nil
+}
+
+func CheckSimilarParams(params *SimilarParams) error {
+	if params.PinId < 1 {
+		return pkgErrors.ErrInvalidPinIdParam
+	}
+	if params.Limit < 1 || params.Limit > MaxLimit {
+		return pkgErrors.ErrInvalidLimitParam
+	}
+	return nil
+}
+
+// similarCursorParams lets the cursors of similar pins be issued and checked the same way as those of pins found
+// by queries, with a sort order of the pin they are similar to.
+func similarCursorParams(params SimilarParams) Params {
+	return Params{Type: TypePins, Sort: "similar" + strconv.Itoa(params.PinId), Cursor: params.Cursor}
+}
+
+// SimilarOffset returns the number of similar pins to skip for params.Cursor, 0 without a cursor.
+func SimilarOffset(params SimilarParams) (int, error) {
+	return Offset(similarCursorParams(params))
+}
+
+// SetSimilarCursor sets the cursor of the next page of similar pins if there are more of them.
+func SetSimilarCursor(res *models.SearchRes, params SimilarParams, offset int) {
+	SetCursors(res, similarCursorParams(params), offset)
+}
+
+// Cursors are opaque to clients, they hold the type and the sort order they were issued for
+// and the number of results to skip.
+func encodeCursor(typ string, sort string, offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(typ + ":" + sort + ":" + strconv.Itoa(offset)))
+}
+
+// Offset returns the number of results to skip for params.Cursor, 0 without a cursor.
+func Offset(params Params) (int, error) {
+	if params.Cursor == "" {
+		return 0, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(params.Cursor)
+	if err != nil {
+		return 0, pkgErrors.ErrInvalidCursorParam
+	}
+	fields := strings.Split(string(data), ":")
+	if len(fields) != 3 || params.Type == "" || fields[0] != params.Type || fields[1] != params.Sort {
+		return 0, pkgErrors.ErrInvalidCursorParam
+	}
+	offset, err := strconv.Atoi(fields[2])
+	if err != nil || offset < 0 {
+		return 0, pkgErrors.ErrInvalidCursorParam
+	}
+	return offset, nil
+}
+
+// SetCursors sets the cursors of the next pages of the types in res that have more results.
+func SetCursors(res *models.SearchRes, params Params, offset int) {
+	next := func(typ string, n int, total int) string {
+		if (params.Type != "" && params.Type != typ) || offset+n >= total {
+			return ""
+		}
+		return encodeCursor(typ, params.Sort, offset+n)
+	}
+
+	res.Cursors = models.SearchCursors{
+		Pins:   next(TypePins, len(res.Pins), res.Counts.Pins),
+		Boards: next(TypeBoards, len(res.Boards), res.Counts.Boards),
+		Users:  next(TypeUsers, len(res.Users), res.Counts.Users),
+	}
+}
@@ -0,0 +1,236 @@
+package search_test
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+)
+
+func TestCheckParams(t *testing.T) {
+	type testCase struct {
+		params   search.Params
+		expected search.Params
+		err      error
+	}
+
+	from := time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	tests := map[string]testCase{
+		"default sort": {
+			params:   search.Params{Query: "cats", Limit: 10},
+			expected: search.Params{Query: "cats", Limit: 10, Sort: search.SortRelevance},
+		},
+		"all set": {
+			params: search.Params{Query: "cats", Type: search.TypePins, Sort: search.SortPopular, Limit: search.MaxLimit,
+				AuthorId: 3, From: from, To: to, Color: "#336699"},
+			expected: search.Params{Query: "cats", Type: search.TypePins, Sort: search.SortPopular, Limit: search.MaxLimit,
+				AuthorId: 3, From: from, To: to, Color: "#336699"},
+		},
+		"color in upper case": {
+			params:   search.Params{Limit: 1, Sort: search.SortRecent, Color: "#ABCDEF"},
+			expected: search.Params{Limit: 1, Sort: search.SortRecent, Color: "#abcdef"},
+		},
+		"open date range": {
+			params:   search.Params{Limit: 1, From: from},
+			expected: search.Params{Limit: 1, Sort: search.SortRelevance, From: from},
+		},
+		"invalid type": {
+			params: search.Params{Limit: 10, Type: "tags"},
+			err:    pkgErrors.ErrInvalidTypeParam,
+		},
+		"invalid sort": {
+			params: search.Params{Limit: 10, Sort: "oldest"},
+			err:    pkgErrors.ErrInvalidSortParam,
+		},
+		"zero limit": {
+			params: search.Params{Limit: 0},
+			err:    pkgErrors.ErrInvalidLimitParam,
+		},
+		"too large limit": {
+			params: search.Params{Limit: search.MaxLimit + 1},
+			err:    pkgErrors.ErrInvalidLimitParam,
+		},
+		"negative author": {
+			params: search.Params{Limit: 10, AuthorId: -1},
+			err:    pkgErrors.ErrInvalidUserIdParam,
+		},
+		"empty date range": {
+			params: search.Params{Limit: 10, From: from, To: from},
+			err:    pkgErrors.ErrInvalidDateParam,
+		},
+		"reversed date range": {
+			params: search.Params{Limit: 10, From: to, To: from},
+			err:    pkgErrors.ErrInvalidDateParam,
+		},
+		"color without hash": {
+			params: search.Params{Limit: 10, Color: "336699"},
+			err:    pkgErrors.ErrInvalidColorParam,
+		},
+		"short color": {
+			params: search.Params{Limit: 10, Color: "#369"},
+			err:    pkgErrors.ErrInvalidColorParam,
+		},
+		"color of other digits": {
+			params: search.Params{Limit: 10, Color: "#33669g"},
+			err:    pkgErrors.ErrInvalidColorParam,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			params := test.params
+			err := search.CheckParams(&params)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if test.err == nil && !reflect.DeepEqual(params, test.expected) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.expected, params)
+			}
+		})
+	}
+}
+
+func TestSetCursors(t *testing.T) {
+	type testCase struct {
+		params  search.Params
+		res     models.SearchRes
+		offset  int
+		cursors []string // of pins, boards and users, "" for no cursor
+	}
+
+	page := models.SearchRes{
+		Pins:   make([]models.Pin, 2),
+		Boards: make([]models.Board, 2),
+		Users:  make([]models.Profile, 1),
+		Counts: models.SearchCounts{Pins: 5, Boards: 4, Users: 1},
+	}
+
+	tests := map[string]testCase{
+		"all types": {
+			params:  search.Params{Sort: search.SortRelevance},
+			res:     page,
+			cursors: []string{"pins:relevance:2", "boards:relevance:2", ""},
+		},
+		"one type": {
+			params:  search.Params{Type: search.TypePins, Sort: search.SortRecent},
+			res:     page,
+			offset:  2,
+			cursors: []string{"pins:recent:4", "", ""},
+		},
+		"last page": {
+			params:  search.Params{Type: search.TypeBoards, Sort: search.SortPopular},
+			res:     page,
+			offset:  2,
+			cursors: []string{"", "", ""},
+		},
+		"nothing found": {
+			params:  search.Params{Sort: search.SortRelevance},
+			cursors: []string{"", "", ""},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res := test.res
+			search.SetCursors(&res, test.params, test.offset)
+			cursors := []string{res.Cursors.Pins, res.Cursors.Boards, res.Cursors.Users}
+			for i, cursor := range cursors {
+				if cursor == "" {
+					continue
+				}
+				data, err := base64.RawURLEncoding.DecodeString(cursor)
+				if err != nil {
+					t.Fatalf("can't decode cursor %s: %v", cursor, err)
+				}
+				cursors[i] = string(data)
+			}
+			if !reflect.DeepEqual(cursors, test.cursors) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.cursors, cursors)
+			}
+		})
+	}
+}
+
+func TestOffset(t *testing.T) {
+	type testCase struct {
+		params search.Params
+		offset int
+		err    error
+	}
+
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+	// a cursor issued by SetCursors for the second page of pins sorted by recency
+	res := models.SearchRes{Pins: make([]models.Pin, 10), Counts: models.SearchCounts{Pins: 25}}
+	search.SetCursors(&res, search.Params{Type: search.TypePins, Sort: search.SortRecent}, 10)
+	issued := res.Cursors.Pins
+
+	tests := map[string]testCase{
+		"no cursor": {
+			params: search.Params{Type: search.TypePins, Sort: search.SortRecent},
+			offset: 0,
+		},
+		"issued cursor": {
+			params: search.Params{Type: search.TypePins, Sort: search.SortRecent, Cursor: issued},
+			offset: 20,
+		},
+		"cursor of another type": {
+			params: search.Params{Type: search.TypeBoards, Sort: search.SortRecent, Cursor: issued},
+			err:    pkgErrors.ErrInvalidCursorParam,
+		},
+		"cursor of another sort": {
+			params: search.Params{Type: search.TypePins, Sort: search.SortPopular, Cursor: issued},
+			err:    pkgErrors.ErrInvalidCursorParam,
+		},
+		"cursor without type": {
+			params: search.Params{Sort: search.SortRecent, Cursor: issued},
+			err:    pkgErrors.ErrInvalidCursorParam,
+		},
+		"not base64": {
+			params: search.Params{Type: search.TypePins, Sort: search.SortRecent, Cursor: "!!!"},
+			err:    pkgErrors.ErrInvalidCursorParam,
+		},
+		"missing offset": {
+			params: search.Params{Type: search.TypePins, Sort: search.SortRecent, Cursor: encode("pins:recent")},
+			err:    pkgErrors.ErrInvalidCursorParam,
+		},
+		"negative offset": {
+			params: search.Params{Type: search.TypePins, Sort: search.SortRecent, Cursor: encode("pins:recent:-10")},
+			err:    pkgErrors.ErrInvalidCursorParam,
+		},
+		"offset of letters": {
+			params: search.Params{Type: search.TypePins, Sort: search.SortRecent, Cursor: encode("pins:recent:ten")},
+			err:    pkgErrors.ErrInvalidCursorParam,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			offset, err := search.Offset(test.params)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if offset != test.offset {
+				t.Errorf("\nExpected: %d\nGot: %d", test.offset, offset)
+			}
+		})
+	}
+}
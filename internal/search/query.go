@@ -0,0 +1,112 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Query is a search query parsed the way websearch_to_tsquery parses it: words are required, words prefixed
+// with a minus are excluded and "or" separates alternatives. Quoted phrases are treated as separate words.
+type Query struct {
+	Text    string // lowercased query, substrings of titles and names are matched with it
+	Clauses []Clause
+}
+
+// Clause matches the documents with all of the Must terms and none of the Not terms.
+type Clause struct {
+	Must []Term
+	Not  []Term
+}
+
+// Term is a word of a query. Required words are also matched by their transliterations, their synonyms
+// and their spelling corrections, excluded words are matched as they are.
+type Term struct {
+	Word         string
+	Alternatives []string
+}
+
+// Words returns the word of the term followed by its alternatives.
+func (t Term) Words() []string {
+	return append([]string{t.Word}, t.Alternatives...)
+}
+
+// Words splits text into lowercased words of letters and digits, ё is the same letter as е.
+func Words(text string) []string {
+	return strings.FieldsFunc(strings.ReplaceAll(strings.ToLower(text), "ё", "е"), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// ParseQuery parses text, corrections are the spelling corrections of its words found by Correct.
+func ParseQuery(text string, corrections map[string]string) Query {
+	q := Query{Text: strings.ToLower(text)}
+	cur := Clause{}
+	for _, field := range strings.Fields(strings.ReplaceAll(text, `"`, " ")) {
+		if strings.EqualFold(field, "or") {
+			if len(cur.Must)+len(cur.Not) > 0 {
+				q.Clauses = append(q.Clauses, cur)
+				cur = Clause{}
+			}
+			continue
+		}
+
+		negated := strings.HasPrefix(field, "-")
+		for _, word := range Words(field) {
+			switch {
+			case negated:
+				cur.Not = append(cur.Not, Term{Word: word})
+			case utf8.RuneCountInString(word) < MinCorrectedLen:
+				cur.Must = append(cur.Must, Term{Word: word})
+			default:
+				cur.Must = append(cur.Must, Term{Word: word, Alternatives: alternatives(word, corrections[word])})
+			}
+		}
+	}
+	if len(cur.Must)+len(cur.Not) > 0 {
+		q.Clauses = append(q.Clauses, cur)
+	}
+	return q
+}
+
+// Required returns the distinct required words of the query in the order they are written.
+func (q Query) Required() []string {
+	seen := make(map[string]struct{})
+	var res []string
+	for _, c := range q.Clauses {
+		for _, t := range c.Must {
+			if _, ok := seen[t.Word]; !ok {
+				seen[t.Word] = struct{}{}
+				res = append(res, t.Word)
+			}
+		}
+	}
+	return res
+}
+
+// alternatives returns the distinct words other than word that match it: its transliteration, its correction,
+// the synonyms of both and their transliterations.
+func alternatives(word string, correction string) []string {
+	seen := map[string]struct{}{word: {}}
+	var res []string
+	add := func(w string) {
+		if _, ok := seen[w]; !ok && w != "" {
+			seen[w] = struct{}{}
+			res = append(res, w)
+		}
+	}
+
+	for _, w := range []string{word, correction} {
+		if w == "" {
+			continue
+		}
+		for _, spelling := range []string{w, Transliterate(w)} {
+			add(spelling)
+			for _, synonym := range synonyms[spelling] {
+				add(synonym)
+				add(Transliterate(synonym))
+			}
+		}
+	}
+	return res
+}
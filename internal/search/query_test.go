@@ -0,0 +1,116 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	type testCase struct {
+		text        string
+		corrections map[string]string
+		clauses     []Clause
+		required    []string
+	}
+
+	cat := Term{Word: "cat", Alternatives: []string{"kitten", "киттен", "kitty", "китты", "кат"}}
+	kot := Term{Word: "кот", Alternatives: []string{"кошка", "koshka", "котик", "kotik", "kot"}}
+
+	tests := map[string]testCase{
+		"words": {
+			text: "Black CATS",
+			clauses: []Clause{{Must: []Term{{Word: "black", Alternatives: []string{"блакк"}},
+				{Word: "cats", Alternatives: []string{"кац"}}}}},
+			required: []string{"black", "cats"},
+		},
+		"synonyms of latin words": {
+			text:     "cat",
+			clauses:  []Clause{{Must: []Term{cat}}},
+			required: []string{"cat"},
+		},
+		"synonyms of cyrillic words": {
+			text:     "Кот",
+			clauses:  []Clause{{Must: []Term{kot}}},
+			required: []string{"кот"},
+		},
+		"synonyms of transliterations": {
+			text: "kot",
+			clauses: []Clause{{Must: []Term{{Word: "kot",
+				Alternatives: []string{"кот", "кошка", "koshka", "котик", "kotik"}}}}},
+			required: []string{"kot"},
+		},
+		"ё is е": {
+			text:     "ёлка",
+			clauses:  []Clause{{Must: []Term{{Word: "елка", Alternatives: []string{"elka"}}}}},
+			required: []string{"елка"},
+		},
+		"short words": {
+			text:     "a ум",
+			clauses:  []Clause{{Must: []Term{{Word: "a"}, {Word: "ум"}}}},
+			required: []string{"a", "ум"},
+		},
+		"mixed scripts": {
+			text:     "котcat",
+			clauses:  []Clause{{Must: []Term{{Word: "котcat"}}}},
+			required: []string{"котcat"},
+		},
+		"excluded words": {
+			text:     "cat -dog",
+			clauses:  []Clause{{Must: []Term{cat}, Not: []Term{{Word: "dog"}}}},
+			required: []string{"cat"},
+		},
+		"alternatives": {
+			text:     "cat or кот OR -dog",
+			clauses:  []Clause{{Must: []Term{cat}}, {Must: []Term{kot}}, {Not: []Term{{Word: "dog"}}}},
+			required: []string{"cat", "кот"},
+		},
+		"dangling or": {
+			text:     "or cat or",
+			clauses:  []Clause{{Must: []Term{cat}}},
+			required: []string{"cat"},
+		},
+		"phrases and punctuation": {
+			text:     `"wi-fi" cat, cat`,
+			clauses:  []Clause{{Must: []Term{{Word: "wi"}, {Word: "fi"}, cat, cat}}},
+			required: []string{"wi", "fi", "cat"},
+		},
+		"correction": {
+			text:        "котк",
+			corrections: map[string]string{"котк": "кот"},
+			clauses: []Clause{{Must: []Term{{Word: "котк",
+				Alternatives: []string{"kotk", "кот", "кошка", "koshka", "котик", "kotik", "kot"}}}}},
+			required: []string{"котк"},
+		},
+		"correction to a transliteration": {
+			text:        "kat",
+			corrections: map[string]string{"kat": "cat"},
+			clauses: []Clause{{Must: []Term{{Word: "kat",
+				Alternatives: []string{"кат", "cat", "kitten", "киттен", "kitty", "китты"}}}}},
+			required: []string{"kat"},
+		},
+		"correction of other words": {
+			text:        "cat",
+			corrections: map[string]string{"котк": "кот"},
+			clauses:     []Clause{{Must: []Term{cat}}},
+			required:    []string{"cat"},
+		},
+		"empty": {
+			text: "  - or \"\" ",
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			q := ParseQuery(test.text, test.corrections)
+			if !reflect.DeepEqual(q.Clauses, test.clauses) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.clauses, q.Clauses)
+			}
+			if required := q.Required(); !reflect.DeepEqual(required, test.required) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.required, required)
+			}
+		})
+	}
+}
@@ -1,9 +1,119 @@
 package search
 
 import (
+	"time"
+
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+)
+
+// Search backends selectable with config.SearchIndexConfig.Backend
+const (
+	PostgresBackend = "postgres"
+	IndexBackend    = "index"
 )
 
+// Result types
+const (
+	TypePins   = "pins"
+	TypeBoards = "boards"
+	TypeUsers  = "users"
+)
+
+// Sort orders, users and boards have no creation time, so the recent ones are those with greater ids
+const (
+	SortRelevance = "relevance"
+	SortRecent    = "recent"
+	SortPopular   = "popular"
+)
+
+type Params struct {
+	UserId   int // the results are limited to pins and boards the user may read, 0 for anonymous users
+	Query    string
+	Type     string // one of the result types, empty for all of them
+	Sort     string
+	Limit    int    // page size of each type
+	Cursor   string // from models.SearchRes.Cursors, valid only with the type it was issued for
+	AuthorId int    // author of pins and owner of boards, 0 for any
+	From     time.Time
+	To       time.Time // pins created in [From, To), zero times leave the range open
+	Color    string    // #rrggbb, pins whose dominant color is within ColorTolerance of it, empty for any
+	// Corrections of misspelled words of Query, set by Correct
+	Corrections map[string]string
+}
+
+// SimilarParams ask for the pins that look like the pin: those with close perceptual hashes first,
+// then those of a close dominant color.
+type SimilarParams struct {
+	UserId int // the results are limited to pins the user may read, as is the pin itself
+	PinId  int
+	Limit  int
+	Cursor string // from models.SearchRes.Cursors.Pins of the same pin
+}
+
 type Repository interface {
-	Get(query string) (models.SearchRes, error)
+	// Get returns params.Limit results of params.Type, or of every type if it is empty, skipping offset of them.
+	// The query is parsed with ParseQuery. Counts are filled for every type, Cursors and DidYouMean are left empty.
+	Get(params Params, offset int) (models.SearchRes, error)
+	// Similar returns params.Limit pins similar to params.PinId skipping offset of them, and their count.
+	// It returns pkgErrors.ErrPinNotFound if the user may not read the pin.
+	Similar(params SimilarParams, offset int) (models.SearchRes, error)
+	// Suggest returns up to limit usernames, names of public boards and tags of public pins starting with prefix,
+	// the matches are case-insensitive. Queries are left empty.
+	Suggest(prefix string, limit int) (models.SearchSuggestions, error)
+	// Correct returns the corrections of the lowercased words that no pin, board or user readable by anonymous
+	// users has: the most similar words of those they have, with WordSimilarity of at least MinWordSimilarity.
+	// Words that have no such similar words are left out.
+	Correct(words []string) (map[string]string, error)
+}
+
+// PinDocument is a pin with what the index filters and orders pins by.
+type PinDocument struct {
+	Pin       models.Pin
+	CreatedAt time.Time
+	BoardIds  []int           // boards the pin is saved to, they decide who may read the pin
+	Hash      uint64          // perceptual hash of the image, 0 if it has none
+	Color     *pkgImage.Color // dominant color of the image, nil if it is unknown
+}
+
+type BoardDocument struct {
+	Board   models.Board
+	NumPins int
+}
+
+type UserDocument struct {
+	User         models.Profile
+	NumFollowers int
+}
+
+// Index is a Repository holding its documents in memory. It is filled with Load and then
+// kept up to date with the changes of pins, boards and users.
+type Index interface {
+	Repository
+	// Load replaces all the documents of the index.
+	Load(pins []PinDocument, boards []BoardDocument, users []UserDocument)
+	SetPin(doc PinDocument)
+	DeletePin(id int)
+	SetBoard(doc BoardDocument)
+	DeleteBoard(id int)
+	SetUser(doc UserDocument)
+	DeleteUser(id int)
+}
+
+// QueriesRepository keeps the numbers of times queries were searched and the log of searches without
+// the users who searched them, and the recent searches of each user apart from them.
+type QueriesRepository interface {
+	// Log adds a search of the normalized query by the user, 0 for anonymous users.
+	// Only keepRecent latest searches of the user are kept.
+	Log(query string, userId int, keepRecent int) error
+	// Popular returns up to limit queries starting with prefix that were searched at least minCount times,
+	// the most searched first.
+	Popular(prefix string, minCount int, limit int) ([]string, error)
+	// Trending returns up to limit queries searched at least minCount times since the time, the most searched first.
+	Trending(since time.Time, minCount int, limit int) ([]models.TrendingQuery, error)
+	// Recent returns up to limit latest queries of the user, the latest first.
+	Recent(userId int, limit int) ([]string, error)
+	ClearRecent(userId int) error
+	// PurgeLog deletes the searches made before the time from the log and returns their number.
+	PurgeLog(before time.Time) (int, error)
 }
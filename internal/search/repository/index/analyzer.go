@@ -0,0 +1,160 @@
+package index
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+)
+
+// The index mirrors the search vectors of the Postgres repository: words are matched either as they are,
+// like the simple configuration, or by their stems without stop words, like the russian one.
+
+// Weights of the fields, the same as the default weights of ts_rank
+const (
+	weightA = 1.0
+	weightB = 0.4
+	weightD = 0.1
+)
+
+// Posting keys of words and stems differ in their first byte
+const (
+	wordKey = "="
+	stemKey = "~"
+)
+
+var stopWords = map[string]struct{}{}
+
+func init() {
+	for _, word := range strings.Fields(`
+		и в во не что он на я с со как а то все она так его но да ты к у же вы за бы по только ее мне было
+		вот от меня еще нет о из ему теперь когда даже ну вдруг ли если уже или ни быть был него до вас
+		нибудь опять уж вам ведь там потом себя ничего ей может они тут где есть надо ней для мы тебя их
+		чем была сам чтоб без будто чего раз тоже себе под будет ж тогда кто этот того потому этого какой
+		совсем ним здесь этом один почти мой тем чтобы нее сейчас были куда зачем всех никогда можно при
+		наконец два об другой хоть после над больше тот через эти нас про всего них какая много разве три
+		эту моя впрочем хорошо свою этой перед иногда лучше чуть том нельзя такой им более всегда конечно
+		всю между
+		a an and are as at be but by for if in into is it no not of on or such that the their then there
+		these they this to was will with`) {
+		stopWords[word] = struct{}{}
+	}
+}
+
+// Endings are tried from the longest, a stem keeps at least minStemLen letters.
+var (
+	russianEndings = []string{
+		"иями", "ями", "ами", "ого", "его", "ому", "ему", "ыми", "ими", "иях", "ях", "ах", "ов", "ев", "ей",
+		"ой", "ий", "ый", "ая", "яя", "ое", "ее", "ие", "ые", "ом", "ем", "ам", "ям", "ую", "юю", "ть",
+		"а", "я", "о", "е", "и", "ы", "у", "ю", "ь", "й",
+	}
+	englishEndings = []string{"ing", "ies", "es", "ed", "ly", "s"}
+)
+
+const minStemLen = 3
+
+// stem strips the inflectional ending of a word, an empty stem means a stop word.
+func stem(word string) string {
+	if _, ok := stopWords[word]; ok {
+		return ""
+	}
+
+	endings := englishEndings
+	if r, _ := utf8.DecodeRuneInString(word); unicode.Is(unicode.Cyrillic, r) {
+		endings = russianEndings
+	}
+	for _, ending := range endings {
+		if strings.HasSuffix(word, ending) && utf8.RuneCountInString(word)-utf8.RuneCountInString(ending) >= minStemLen {
+			if ending == "s" && strings.HasSuffix(word, "ss") {
+				break
+			}
+			return strings.TrimSuffix(word, ending)
+		}
+	}
+	return word
+}
+
+// trigrams returns the distinct trigrams of text, substrings of text contain some of them.
+func trigrams(text string) []string {
+	runes := []rune(text)
+	seen := make(map[string]struct{})
+	var res []string
+	for i := 0; i+3 <= len(runes); i++ {
+		gram := string(runes[i : i+3])
+		if _, ok := seen[gram]; !ok {
+			seen[gram] = struct{}{}
+			res = append(res, gram)
+		}
+	}
+	return res
+}
+
+var tagRegexp = regexp.MustCompile(`#([\p{L}\p{N}_]+)`)
+
+// tags returns the distinct lowercased hashtags of the texts, the same as the pin_tags of a pin.
+func tags(texts ...string) []string {
+	seen := make(map[string]struct{})
+	var res []string
+	for _, text := range texts {
+		for _, match := range tagRegexp.FindAllStringSubmatch(text, -1) {
+			tag := strings.ToLower(match[1])
+			if _, ok := seen[tag]; !ok {
+				seen[tag] = struct{}{}
+				res = append(res, tag)
+			}
+		}
+	}
+	return res
+}
+
+// term is matched by any of its words, the word of the query followed by its alternatives.
+type term struct {
+	words []string
+	stems []string // distinct stems of the words that are not stop words
+}
+
+func newTerm(t search.Term) term {
+	res := term{words: t.Words()}
+	seen := make(map[string]struct{})
+	for _, word := range res.words {
+		if s := stem(word); s != "" {
+			if _, ok := seen[s]; !ok {
+				seen[s] = struct{}{}
+				res.stems = append(res.stems, s)
+			}
+		}
+	}
+	return res
+}
+
+// clause matches the documents with all of the must terms and none of the not terms.
+type clause struct {
+	must []term
+	not  []term
+}
+
+// query is a search.Query with the stems of its words.
+type query struct {
+	text    string // lowercased query, substrings of titles and names are matched with it
+	clauses []clause
+	terms   []term // required words of all the clauses, results are ranked by them
+}
+
+func parseQuery(text string, corrections map[string]string) query {
+	parsed := search.ParseQuery(text, corrections)
+	q := query{text: parsed.Text}
+	for _, c := range parsed.Clauses {
+		cur := clause{}
+		for _, t := range c.Must {
+			cur.must = append(cur.must, newTerm(t))
+		}
+		for _, t := range c.Not {
+			cur.not = append(cur.not, newTerm(t))
+		}
+		q.clauses = append(q.clauses, cur)
+		q.terms = append(q.terms, cur.must...)
+	}
+	return q
+}
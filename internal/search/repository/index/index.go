@@ -0,0 +1,468 @@
+package index
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+)
+
+// NewIndex returns an empty index, it finds the same results as the Postgres repository
+// for the same pins, boards and users.
+func NewIndex() search.Index {
+	idx := &index{}
+	idx.reset()
+	return idx
+}
+
+type pinDocument struct {
+	search.PinDocument
+	tags []string
+}
+
+type index struct {
+	pins       map[int]pinDocument
+	boards     map[int]search.BoardDocument
+	users      map[int]search.UserDocument
+	pinsText   *textIndex
+	boardsText *textIndex
+	usersText  *textIndex
+	mu         sync.RWMutex
+}
+
+func (idx *index) reset() {
+	idx.pins = make(map[int]pinDocument)
+	idx.boards = make(map[int]search.BoardDocument)
+	idx.users = make(map[int]search.UserDocument)
+	idx.pinsText = newTextIndex()
+	idx.boardsText = newTextIndex()
+	idx.usersText = newTextIndex()
+}
+
+func (idx *index) Load(pins []search.PinDocument, boards []search.BoardDocument, users []search.UserDocument) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.reset()
+	for _, doc := range pins {
+		idx.setPin(doc)
+	}
+	for _, doc := range boards {
+		idx.setBoard(doc)
+	}
+	for _, doc := range users {
+		idx.setUser(doc)
+	}
+}
+
+func (idx *index) SetPin(doc search.PinDocument) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.setPin(doc)
+}
+
+func (idx *index) setPin(doc search.PinDocument) {
+	pin := doc.Pin
+	idx.pins[pin.Id] = pinDocument{doc, tags(pin.Title, pin.Description)}
+	idx.pinsText.add(pin.Id, []field{{pin.Title, weightA}, {pin.Description, weightB}}, pin.Title)
+}
+
+func (idx *index) DeletePin(id int) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	delete(idx.pins, id)
+	idx.pinsText.remove(id)
+}
+
+func (idx *index) SetBoard(doc search.BoardDocument) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.setBoard(doc)
+}
+
+func (idx *index) setBoard(doc search.BoardDocument) {
+	board := doc.Board
+	idx.boards[board.Id] = doc
+	idx.boardsText.add(board.Id, []field{{board.Name, weightA}, {board.Description, weightB}}, board.Name)
+}
+
+func (idx *index) DeleteBoard(id int) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	delete(idx.boards, id)
+	idx.boardsText.remove(id)
+}
+
+func (idx *index) SetUser(doc search.UserDocument) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.setUser(doc)
+}
+
+func (idx *index) setUser(doc search.UserDocument) {
+	user := doc.User
+	idx.users[user.Id] = doc
+	idx.usersText.add(user.Id, []field{{user.Username + " " + user.Name, weightD}}, user.Username, user.Name)
+}
+
+func (idx *index) DeleteUser(id int) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	delete(idx.users, id)
+	idx.usersText.remove(id)
+}
+
+// pinVisible reports whether the user may read the pin: pins saved to secret boards only are readable
+// by their authors and the owners of the boards, userId is 0 for anonymous users.
+func (idx *index) pinVisible(doc pinDocument, userId int) bool {
+	if doc.Pin.Author == userId && userId != 0 {
+		return true
+	}
+
+	secret := false
+	for _, boardId := range doc.BoardIds {
+		board, ok := idx.boards[boardId]
+		if !ok {
+			continue
+		}
+		if board.Board.Privacy == "public" || (board.Board.UserId == userId && userId != 0) {
+			return true
+		}
+		secret = true
+	}
+	return !secret
+}
+
+func boardVisible(board models.Board, userId int) bool {
+	return board.Privacy == "public" || (board.UserId == userId && userId != 0)
+}
+
+// ranked is a matching document with what it is ordered by
+type ranked struct {
+	id      int
+	rank    float64
+	recent  int64 // creation time of pins, ids of boards and users
+	popular int
+}
+
+// sortRanked orders the documents the same way the Postgres repository does, ids break ties.
+func sortRanked(docs []ranked, sortBy string) {
+	sort.Slice(docs, func(i, j int) bool {
+		a, b := docs[i], docs[j]
+		switch sortBy {
+		case search.SortRecent:
+			if a.recent != b.recent {
+				return a.recent > b.recent
+			}
+			return a.id > b.id
+		case search.SortPopular:
+			if a.popular != b.popular {
+				return a.popular > b.popular
+			}
+			return a.id > b.id
+		default:
+			if a.rank != b.rank {
+				return a.rank > b.rank
+			}
+			return a.id < b.id
+		}
+	})
+}
+
+// page returns the ids of the limit documents after offset.
+func page(docs []ranked, limit int, offset int) []int {
+	if offset > len(docs) {
+		offset = len(docs)
+	}
+	if offset+limit < len(docs) {
+		docs = docs[offset : offset+limit]
+	} else {
+		docs = docs[offset:]
+	}
+
+	ids := make([]int, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.id)
+	}
+	return ids
+}
+
+// colorClose reports whether the dominant color of the pin is within search.ColorTolerance of color.
+func colorClose(doc pinDocument, color *pkgImage.Color) bool {
+	return doc.Color != nil && doc.Color.Distance(*color) <= search.ColorTolerance
+}
+
+func (idx *index) Get(params search.Params, offset int) (models.SearchRes, error) {
+	q := parseQuery(params.Query, params.Corrections)
+	var color *pkgImage.Color
+	if params.Color != "" {
+		c, err := pkgImage.ParseHexColor(params.Color)
+		if err != nil {
+			return models.SearchRes{}, pkgErrors.ErrInvalidColorParam
+		}
+		color = &c
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var pins, boards, users []ranked
+	for id, rank := range idx.pinsText.search(q) {
+		doc := idx.pins[id]
+		if params.AuthorId != 0 && doc.Pin.Author != params.AuthorId ||
+			!params.From.IsZero() && doc.CreatedAt.Before(params.From) ||
+			!params.To.IsZero() && !doc.CreatedAt.Before(params.To) ||
+			color != nil && !colorClose(doc, color) ||
+			!idx.pinVisible(doc, params.UserId) {
+			continue
+		}
+		pins = append(pins, ranked{id, rank, doc.CreatedAt.UnixNano(), doc.Pin.NumLikes})
+	}
+	for id, rank := range idx.boardsText.search(q) {
+		doc := idx.boards[id]
+		if params.AuthorId != 0 && doc.Board.UserId != params.AuthorId || !boardVisible(doc.Board, params.UserId) {
+			continue
+		}
+		boards = append(boards, ranked{id, rank, int64(id), doc.NumPins})
+	}
+	for id, rank := range idx.usersText.search(q) {
+		users = append(users, ranked{id, rank, int64(id), idx.users[id].NumFollowers})
+	}
+
+	res := models.SearchRes{}
+	res.Counts.Pins = len(pins)
+	res.Counts.Boards = len(boards)
+	res.Counts.Users = len(users)
+
+	if params.Type == "" || params.Type == search.TypePins {
+		sortRanked(pins, params.Sort)
+		for _, id := range page(pins, params.Limit, offset) {
+			res.Pins = append(res.Pins, idx.pins[id].Pin)
+		}
+	}
+	if params.Type == "" || params.Type == search.TypeBoards {
+		sortRanked(boards, params.Sort)
+		for _, id := range page(boards, params.Limit, offset) {
+			res.Boards = append(res.Boards, idx.boards[id].Board)
+		}
+	}
+	if params.Type == "" || params.Type == search.TypeUsers {
+		sortRanked(users, params.Sort)
+		for _, id := range page(users, params.Limit, offset) {
+			res.Users = append(res.Users, idx.users[id].User)
+		}
+	}
+	return res, nil
+}
+
+// Similar ranks the pins by the Hamming distance between their hashes and the hash of the pin, the pins
+// within search.SimilarMaxHashDistance come first, then by the distance between their dominant colors.
+func (idx *index) Similar(params search.SimilarParams, offset int) (models.SearchRes, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	src, ok := idx.pins[params.PinId]
+	if !ok || !idx.pinVisible(src, params.UserId) {
+		return models.SearchRes{}, pkgErrors.ErrPinNotFound
+	}
+
+	type similar struct {
+		id            int
+		hashDistance  int
+		colorDistance float64 // -1 if either color is unknown
+	}
+	var pins []similar
+	for id, doc := range idx.pins {
+		if id == src.Pin.Id || !idx.pinVisible(doc, params.UserId) {
+			continue
+		}
+
+		hashDistance := search.SimilarMaxHashDistance + 1
+		if src.Hash != 0 && doc.Hash != 0 {
+			if d := pkgImage.HammingDistance(src.Hash, doc.Hash); d <= search.SimilarMaxHashDistance {
+				hashDistance = d
+			}
+		}
+		colorDistance := -1.0
+		if src.Color != nil && doc.Color != nil {
+			colorDistance = doc.Color.Distance(*src.Color)
+		}
+		if hashDistance > search.SimilarMaxHashDistance &&
+			(colorDistance < 0 || colorDistance > search.ColorTolerance) {
+			continue
+		}
+		pins = append(pins, similar{id, hashDistance, colorDistance})
+	}
+
+	sort.Slice(pins, func(i, j int) bool {
+		a, b := pins[i], pins[j]
+		if a.hashDistance != b.hashDistance {
+			return a.hashDistance < b.hashDistance
+		}
+		if a.colorDistance != b.colorDistance {
+			if a.colorDistance < 0 || b.colorDistance < 0 {
+				return b.colorDistance < 0
+			}
+			return a.colorDistance < b.colorDistance
+		}
+		return a.id > b.id
+	})
+
+	res := models.SearchRes{}
+	res.Counts.Pins = len(pins)
+	for i := offset; i < len(pins) && i < offset+params.Limit; i++ {
+		res.Pins = append(res.Pins, idx.pins[pins[i].id].Pin)
+	}
+	return res, nil
+}
+
+// publicWord reports whether a pin, a board or a user readable by anonymous users has the word.
+func (idx *index) publicWord(word string) bool {
+	for id := range idx.pinsText.wordDocs(word) {
+		if idx.pinVisible(idx.pins[id], 0) {
+			return true
+		}
+	}
+	for id := range idx.boardsText.wordDocs(word) {
+		if boardVisible(idx.boards[id].Board, 0) {
+			return true
+		}
+	}
+	return len(idx.usersText.wordDocs(word)) > 0
+}
+
+// Correct picks the most similar of the public words sharing trigrams with each word,
+// the least of them in byte order if several are as similar.
+func (idx *index) Correct(words []string) (map[string]string, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	res := make(map[string]string)
+	for _, word := range words {
+		if idx.publicWord(word) {
+			continue
+		}
+
+		candidates := idx.pinsText.similarWords(word)
+		for _, ti := range []*textIndex{idx.boardsText, idx.usersText} {
+			for candidate := range ti.similarWords(word) {
+				candidates[candidate] = struct{}{}
+			}
+		}
+
+		best, bestSimilarity := "", 0.0
+		for candidate := range candidates {
+			similarity := search.WordSimilarity(word, candidate)
+			if similarity < search.MinWordSimilarity || similarity < bestSimilarity ||
+				similarity == bestSimilarity && candidate > best || !idx.publicWord(candidate) {
+				continue
+			}
+			best, bestSimilarity = candidate, similarity
+		}
+		if best != "" {
+			res[word] = best
+		}
+	}
+	return res, nil
+}
+
+// counted is a suggestion with the number of documents it was found in
+type counted struct {
+	str   string
+	count int
+}
+
+// topCounted returns up to limit strings, the most frequent first, then ordered by less.
+func topCounted(strs map[string]*counted, limit int, less func(a, b *counted) bool) []string {
+	list := make([]*counted, 0, len(strs))
+	for _, c := range strs {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].count != list[j].count {
+			return list[i].count > list[j].count
+		}
+		return less(list[i], list[j])
+	})
+
+	res := []string{}
+	for i := 0; i < len(list) && i < limit; i++ {
+		res = append(res, list[i].str)
+	}
+	return res
+}
+
+func (idx *index) Suggest(prefix string, limit int) (models.SearchSuggestions, error) {
+	prefix = strings.ToLower(prefix)
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var usernames []string
+	for _, doc := range idx.users {
+		if strings.HasPrefix(strings.ToLower(doc.User.Username), prefix) {
+			usernames = append(usernames, doc.User.Username)
+		}
+	}
+	sort.Slice(usernames, func(i, j int) bool {
+		a, b := usernames[i], usernames[j]
+		if utf8.RuneCountInString(a) != utf8.RuneCountInString(b) {
+			return utf8.RuneCountInString(a) < utf8.RuneCountInString(b)
+		}
+		return a < b
+	})
+	if len(usernames) > limit {
+		usernames = usernames[:limit]
+	}
+
+	// Names differing in case are suggested once, spelled as the least of them
+	names := make(map[string]*counted)
+	for _, doc := range idx.boards {
+		name := strings.ToLower(doc.Board.Name)
+		if doc.Board.Privacy != "public" || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if c, ok := names[name]; ok {
+			c.count++
+			if doc.Board.Name < c.str {
+				c.str = doc.Board.Name
+			}
+		} else {
+			names[name] = &counted{doc.Board.Name, 1}
+		}
+	}
+
+	// Tags of pins saved to secret boards only are not suggested
+	pinTags := make(map[string]*counted)
+	for _, doc := range idx.pins {
+		if !idx.pinVisible(doc, 0) {
+			continue
+		}
+		for _, tag := range doc.tags {
+			if !strings.HasPrefix(tag, prefix) {
+				continue
+			}
+			if c, ok := pinTags[tag]; ok {
+				c.count++
+			} else {
+				pinTags[tag] = &counted{tag, 1}
+			}
+		}
+	}
+
+	return models.SearchSuggestions{
+		Users: append([]string{}, usernames...),
+		Boards: topCounted(names, limit, func(a, b *counted) bool {
+			return strings.ToLower(a.str) < strings.ToLower(b.str)
+		}),
+		Tags: topCounted(pinTags, limit, func(a, b *counted) bool { return a.str < b.str }),
+	}, nil
+}
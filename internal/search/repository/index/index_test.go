@@ -0,0 +1,86 @@
+package index
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/searchtest"
+)
+
+func TestRepository(t *testing.T) {
+	searchtest.RunRepositoryTests(t, func(t *testing.T, f searchtest.Fixtures) search.Repository {
+		idx := NewIndex()
+		idx.Load(f.Documents())
+		return idx
+	})
+}
+
+func TestChanges(t *testing.T) {
+	idx := NewIndex()
+	idx.Load([]search.PinDocument{
+		{Pin: models.Pin{Id: 1, Title: "Red fox", Author: 1}, BoardIds: []int{1}},
+		{Pin: models.Pin{Id: 2, Title: "Arctic fox", Author: 2}},
+	}, []search.BoardDocument{
+		{Board: models.Board{Id: 1, Name: "Foxes", Privacy: "public", UserId: 1}, NumPins: 1},
+	}, nil)
+	params := search.Params{Query: "fox", Sort: search.SortRecent, Limit: 10}
+
+	type testCase struct {
+		change func()
+		pins   []int
+		boards []int
+	}
+
+	tests := []testCase{
+		{
+			change: func() {},
+			pins:   []int{2, 1},
+			boards: []int{1},
+		},
+		{
+			change: func() {
+				idx.SetBoard(search.BoardDocument{Board: models.Board{Id: 1, Name: "Foxes", Privacy: "secret", UserId: 1}, NumPins: 1})
+			},
+			pins: []int{2},
+		},
+		{
+			change: func() {
+				idx.SetPin(search.PinDocument{Pin: models.Pin{Id: 2, Title: "Arctic hare", Author: 2}})
+			},
+		},
+		{
+			change: func() {
+				idx.SetPin(search.PinDocument{Pin: models.Pin{Id: 3, Title: "Fox cub", Author: 2}})
+			},
+			pins: []int{3},
+		},
+		{
+			change: func() {
+				idx.DeletePin(3)
+				idx.DeleteBoard(1)
+			},
+			pins: []int{1},
+		},
+	}
+
+	for i, test := range tests {
+		test.change()
+		res, err := idx.Get(params, 0)
+		if err != nil {
+			t.Fatalf("change %d: unexpected error: %v", i, err)
+		}
+
+		var pins, boards []int
+		for _, pin := range res.Pins {
+			pins = append(pins, pin.Id)
+		}
+		for _, board := range res.Boards {
+			boards = append(boards, board.Id)
+		}
+		if !reflect.DeepEqual(pins, test.pins) || !reflect.DeepEqual(boards, test.boards) {
+			t.Errorf("change %d:\nExpected: %v %v\nGot: %v %v", i, test.pins, test.boards, pins, boards)
+		}
+	}
+}
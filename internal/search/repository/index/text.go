@@ -0,0 +1,269 @@
+package index
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+)
+
+type field struct {
+	text   string
+	weight float64
+}
+
+// textIndex is an inverted index of the words and stems of documents and of the trigrams of their titles,
+// documents are identified by their ids. The words are indexed by their trigrams as well, to correct
+// misspelled words of queries.
+type textIndex struct {
+	docs      map[int]struct{}
+	postings  map[string]map[int][]float64 // weights of the occurrences of a word or a stem in each document
+	grams     map[string]map[int]struct{}
+	wordGrams map[string]map[string]struct{} // search.WordTrigrams of the words
+	keys      map[int][]string               // posting keys of each document, to remove it
+	titles    map[int][]string               // lowercased, substrings of the query are matched in them
+}
+
+func newTextIndex() *textIndex {
+	return &textIndex{
+		docs:      make(map[int]struct{}),
+		postings:  make(map[string]map[int][]float64),
+		grams:     make(map[string]map[int]struct{}),
+		wordGrams: make(map[string]map[string]struct{}),
+		keys:      make(map[int][]string),
+		titles:    make(map[int][]string),
+	}
+}
+
+// add replaces the document with the id.
+func (ti *textIndex) add(id int, fields []field, titles ...string) {
+	ti.remove(id)
+	ti.docs[id] = struct{}{}
+
+	for _, f := range fields {
+		for _, word := range search.Words(f.text) {
+			ti.post(id, wordKey+word, f.weight)
+			if s := stem(word); s != "" {
+				ti.post(id, stemKey+s, f.weight)
+			}
+		}
+	}
+
+	for _, title := range titles {
+		title = strings.ToLower(title)
+		ti.titles[id] = append(ti.titles[id], title)
+		for _, gram := range trigrams(title) {
+			if ti.grams[gram] == nil {
+				ti.grams[gram] = make(map[int]struct{})
+			}
+			ti.grams[gram][id] = struct{}{}
+		}
+	}
+}
+
+func (ti *textIndex) post(id int, key string, weight float64) {
+	if ti.postings[key] == nil {
+		ti.postings[key] = make(map[int][]float64)
+		if word := strings.TrimPrefix(key, wordKey); word != key {
+			for _, gram := range search.WordTrigrams(word) {
+				if ti.wordGrams[gram] == nil {
+					ti.wordGrams[gram] = make(map[string]struct{})
+				}
+				ti.wordGrams[gram][word] = struct{}{}
+			}
+		}
+	}
+	if len(ti.postings[key][id]) == 0 {
+		ti.keys[id] = append(ti.keys[id], key)
+	}
+	ti.postings[key][id] = append(ti.postings[key][id], weight)
+}
+
+func (ti *textIndex) remove(id int) {
+	for _, key := range ti.keys[id] {
+		delete(ti.postings[key], id)
+		if len(ti.postings[key]) == 0 {
+			delete(ti.postings, key)
+			if word := strings.TrimPrefix(key, wordKey); word != key {
+				for _, gram := range search.WordTrigrams(word) {
+					delete(ti.wordGrams[gram], word)
+					if len(ti.wordGrams[gram]) == 0 {
+						delete(ti.wordGrams, gram)
+					}
+				}
+			}
+		}
+	}
+	for _, title := range ti.titles[id] {
+		for _, gram := range trigrams(title) {
+			delete(ti.grams[gram], id)
+			if len(ti.grams[gram]) == 0 {
+				delete(ti.grams, gram)
+			}
+		}
+	}
+	delete(ti.docs, id)
+	delete(ti.keys, id)
+	delete(ti.titles, id)
+}
+
+// search returns the ranks of the documents matching the words of the query or containing it in their titles.
+func (ti *textIndex) search(q query) map[int]float64 {
+	res := make(map[int]float64)
+	for _, c := range q.clauses {
+		for _, kind := range []string{wordKey, stemKey} {
+			for id := range ti.matchClause(c, kind) {
+				res[id] = 0
+			}
+		}
+	}
+	for id := range ti.matchSubstring(q.text) {
+		res[id] = 0
+	}
+
+	for id := range res {
+		res[id] = ti.rank(id, q.terms)
+	}
+	return res
+}
+
+// keys returns the posting keys of the words or of the stems of the term.
+func (t term) keys(kind string) []string {
+	strs := t.words
+	if kind == stemKey {
+		strs = t.stems
+	}
+
+	keys := make([]string, 0, len(strs))
+	for _, str := range strs {
+		keys = append(keys, kind+str)
+	}
+	return keys
+}
+
+// matchClause matches the clause by words or by stems, a term is matched by any of its keys.
+// Stop words are left out of stems, and a clause of stop words only matches nothing.
+func (ti *textIndex) matchClause(c clause, kind string) map[int]struct{} {
+	var must [][]string
+	var not []string
+	for _, t := range c.must {
+		if keys := t.keys(kind); len(keys) > 0 {
+			must = append(must, keys)
+		}
+	}
+	for _, t := range c.not {
+		not = append(not, t.keys(kind)...)
+	}
+	if len(must)+len(not) == 0 {
+		return nil
+	}
+
+	var candidates map[int]struct{}
+	if len(must) == 0 {
+		candidates = ti.docs
+	} else {
+		sort.Slice(must, func(i, j int) bool { return ti.numPostings(must[i]) < ti.numPostings(must[j]) })
+		candidates = make(map[int]struct{})
+		for _, key := range must[0] {
+			for id := range ti.postings[key] {
+				candidates[id] = struct{}{}
+			}
+		}
+	}
+
+	res := make(map[int]struct{})
+	for id := range candidates {
+		if ti.hasAll(id, must) && !ti.hasAny(id, not) {
+			res[id] = struct{}{}
+		}
+	}
+	return res
+}
+
+func (ti *textIndex) numPostings(keys []string) int {
+	n := 0
+	for _, key := range keys {
+		n += len(ti.postings[key])
+	}
+	return n
+}
+
+// hasAll reports whether the document has any of the keys of each term.
+func (ti *textIndex) hasAll(id int, terms [][]string) bool {
+	for _, keys := range terms {
+		if !ti.hasAny(id, keys) {
+			return false
+		}
+	}
+	return true
+}
+
+func (ti *textIndex) hasAny(id int, keys []string) bool {
+	for _, key := range keys {
+		if len(ti.postings[key][id]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSubstring finds the candidates by the trigrams of text, shorter texts are looked for in every title.
+func (ti *textIndex) matchSubstring(text string) map[int]struct{} {
+	candidates := ti.docs
+	if utf8.RuneCountInString(text) >= 3 {
+		grams := trigrams(text)
+		sort.Slice(grams, func(i, j int) bool { return len(ti.grams[grams[i]]) < len(ti.grams[grams[j]]) })
+		candidates = ti.grams[grams[0]]
+	}
+
+	res := make(map[int]struct{})
+	for id := range candidates {
+		for _, title := range ti.titles[id] {
+			if strings.Contains(title, text) {
+				res[id] = struct{}{}
+				break
+			}
+		}
+	}
+	return res
+}
+
+// rank sums the weights of the occurrences of each term, the same way ts_rank does:
+// the heaviest occurrence counts fully and the j-th one is divided by j squared.
+func (ti *textIndex) rank(id int, terms []term) float64 {
+	var res float64
+	seen := make(map[string]struct{})
+	for _, t := range terms {
+		if _, ok := seen[t.words[0]]; ok {
+			continue
+		}
+		seen[t.words[0]] = struct{}{}
+
+		var weights []float64
+		for _, key := range append(t.keys(wordKey), t.keys(stemKey)...) {
+			weights = append(weights, ti.postings[key][id]...)
+		}
+		sort.Sort(sort.Reverse(sort.Float64Slice(weights)))
+		for j, weight := range weights {
+			res += weight / float64((j+1)*(j+1))
+		}
+	}
+	return res
+}
+
+// similarWords returns the words of the documents sharing trigrams with word.
+func (ti *textIndex) similarWords(word string) map[string]struct{} {
+	res := make(map[string]struct{})
+	for _, gram := range search.WordTrigrams(word) {
+		for similar := range ti.wordGrams[gram] {
+			res[similar] = struct{}{}
+		}
+	}
+	return res
+}
+
+// wordDocs returns the ids of the documents with the word.
+func (ti *textIndex) wordDocs(word string) map[int][]float64 {
+	return ti.postings[wordKey+word]
+}
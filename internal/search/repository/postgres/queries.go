@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+)
+
+func NewQueriesRepository(db *sql.DB, log *zap.Logger) search.QueriesRepository {
+	return &queriesRepository{db, log}
+}
+
+type queriesRepository struct {
+	db  *sql.DB
+	log *zap.Logger
+}
+
+const countQueryCmd = `
+		INSERT INTO search_queries (query)
+		VALUES ($1)
+		ON CONFLICT (query) DO UPDATE
+			SET n_searches       = search_queries.n_searches + 1,
+				last_searched_at = now();`
+
+const logQueryCmd = `
+		INSERT INTO search_query_log (query)
+		VALUES ($1);`
+
+const addRecentCmd = `
+		INSERT INTO recent_searches (user_id, query)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, query) DO UPDATE
+			SET searched_at = now();`
+
+const trimRecentCmd = `
+		DELETE
+		FROM recent_searches
+		WHERE user_id = $1
+		  AND query NOT IN (SELECT query
+							FROM recent_searches
+							WHERE user_id = $1
+							ORDER BY searched_at DESC
+							LIMIT $2);`
+
+func (rep queriesRepository) Log(query string, userId int, keepRecent int) error {
+	tx, err := rep.db.Begin()
+	if err != nil {
+		rep.log.Error("DB begin transaction failed", zap.Error(err))
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	cmds := []string{countQueryCmd, logQueryCmd}
+	for _, cmd := range cmds {
+		if _, err = tx.Exec(cmd, query); err != nil {
+			rep.log.Error(constants.DBQueryError, zap.String("sql_query", cmd),
+				zap.String("search_query", query), zap.Error(err))
+			return errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+	}
+
+	if userId != 0 {
+		if _, err = tx.Exec(addRecentCmd, userId, query); err != nil {
+			rep.log.Error(constants.DBQueryError, zap.String("sql_query", addRecentCmd),
+				zap.Int("user_id", userId), zap.Error(err))
+			return errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		if _, err = tx.Exec(trimRecentCmd, userId, keepRecent); err != nil {
+			rep.log.Error(constants.DBQueryError, zap.String("sql_query", trimRecentCmd),
+				zap.Int("user_id", userId), zap.Error(err))
+			return errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		rep.log.Error("DB commit failed", zap.Error(err))
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return nil
+}
+
+const popularQueriesCmd = `
+		SELECT query
+		FROM search_queries
+		WHERE query LIKE $1 AND n_searches >= $2
+		ORDER BY n_searches DESC, query
+		LIMIT $3;`
+
+func (rep queriesRepository) Popular(prefix string, minCount int, limit int) ([]string, error) {
+	return listStrings(rep.db, rep.log, popularQueriesCmd, likePrefix(prefix), minCount, limit)
+}
+
+const trendingQueriesCmd = `
+		SELECT query, count(*) AS n_searches
+		FROM search_query_log
+		WHERE searched_at >= $1
+		GROUP BY query
+		HAVING count(*) >= $2
+		ORDER BY n_searches DESC, query
+		LIMIT $3;`
+
+func (rep queriesRepository) Trending(since time.Time, minCount int, limit int) ([]models.TrendingQuery, error) {
+	rows, err := rep.db.Query(trendingQueriesCmd, since, minCount, limit)
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", trendingQueriesCmd),
+			zap.Time("since", since), zap.Error(err))
+		return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer rows.Close()
+
+	trending := []models.TrendingQuery{}
+	for rows.Next() {
+		var query models.TrendingQuery
+		if err = rows.Scan(&query.Query, &query.Searches); err != nil {
+			rep.log.Error(constants.DBScanError, zap.String("sql_query", trendingQueriesCmd),
+				zap.Time("since", since), zap.Error(err))
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		trending = append(trending, query)
+	}
+	return trending, nil
+}
+
+const recentQueriesCmd = `
+		SELECT query
+		FROM recent_searches
+		WHERE user_id = $1
+		ORDER BY searched_at DESC
+		LIMIT $2;`
+
+func (rep queriesRepository) Recent(userId int, limit int) ([]string, error) {
+	return listStrings(rep.db, rep.log, recentQueriesCmd, userId, limit)
+}
+
+const clearRecentCmd = `
+		DELETE
+		FROM recent_searches
+		WHERE user_id = $1;`
+
+func (rep queriesRepository) ClearRecent(userId int) error {
+	_, err := rep.db.Exec(clearRecentCmd, userId)
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", clearRecentCmd),
+			zap.Int("user_id", userId), zap.Error(err))
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return nil
+}
+
+const purgeLogCmd = `
+		DELETE
+		FROM search_query_log
+		WHERE searched_at < $1;`
+
+func (rep queriesRepository) PurgeLog(before time.Time) (int, error) {
+	res, err := rep.db.Exec(purgeLogCmd, before)
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", purgeLogCmd),
+			zap.Time("before", before), zap.Error(err))
+		return 0, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return int(deleted), nil
+}
@@ -2,6 +2,9 @@ package postgres
 
 import (
 	"database/sql"
+	"strings"
+	"time"
+
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
 
 	"github.com/pkg/errors"
@@ -9,6 +12,7 @@ import (
 
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
 )
 
@@ -21,41 +25,210 @@ type repository struct {
 	log *zap.Logger
 }
 
-const getPinsCmd = `
+// The search vectors of pins, boards and users are stored in generated columns made with both
+// the russian and the simple configurations, the query matches either of them. It is the parsed query
+// with the alternatives of its words rendered by tsQueryText, and the second parameter is the LIKE pattern
+// of the lowercased query made by likeSubstring, substring matches that are not words are found with it
+// by trigram indexes.
+const tsQuery = `(to_tsquery('russian', $1) || to_tsquery('simple', $1))`
+
+// tsQueryText renders the query in the syntax of to_tsquery, the words are quoted
+// to be normalized by the configuration of to_tsquery.
+func tsQueryText(q search.Query) string {
+	quote := func(words []string) []string {
+		quoted := make([]string, 0, len(words))
+		for _, word := range words {
+			quoted = append(quoted, "'"+word+"'")
+		}
+		return quoted
+	}
+
+	clauses := make([]string, 0, len(q.Clauses))
+	for _, c := range q.Clauses {
+		var terms []string
+		for _, t := range c.Must {
+			terms = append(terms, "("+strings.Join(quote(t.Words()), " | ")+")")
+		}
+		for _, t := range c.Not {
+			terms = append(terms, "!"+quote([]string{t.Word})[0])
+		}
+		clauses = append(clauses, "("+strings.Join(terms, " & ")+")")
+	}
+	return strings.Join(clauses, " | ")
+}
+
+// Pins saved to secret boards only are readable by their authors and the owners of the boards,
+// the same as the pins whose media the pins service signs. The user is the parameter userParam.
+// Secret boards are readable by their owners only.
+func pinReadable(userParam string) string {
+	return `(author_id = ` + userParam + `
+		   OR NOT EXISTS (SELECT 1
+						  FROM boards_pins bp
+							  JOIN boards b ON b.id = bp.board_id
+						  WHERE bp.pin_id = pins.id AND b.privacy = 'secret')
+		   OR EXISTS (SELECT 1
+					  FROM boards_pins bp
+						  JOIN boards b ON b.id = bp.board_id
+					  WHERE bp.pin_id = pins.id AND (b.privacy = 'public' OR b.user_id = ` + userParam + `)))`
+}
+
+// Dominant colors are the first colors of the palettes of pins, stored in media_color as 0xRRGGBB
+var pinsCondition = `
+		WHERE (search_vector @@ ` + tsQuery + `
+		   OR lower(title) LIKE $2)
+		  AND ($3 = 0 OR author_id = $3)
+		  AND ($4::timestamp IS NULL OR created_at >= $4)
+		  AND ($5::timestamp IS NULL OR created_at < $5)
+		  AND ($7::int IS NULL OR rgb_distance(media_color, $7) <= $8)
+		  AND ` + pinReadable("$6")
+
+var getPinsCmd = `
 		SELECT id, title, description, media_source, n_likes, author_id
-		FROM pins
-		WHERE websearch_to_tsquery('russian', $1) @@ to_tsvector('russian', title)
-		   OR websearch_to_tsquery($1) @@ to_tsvector(title)
-		   OR lower(title) LIKE lower('%' || $1 || '%')
-		ORDER BY ts_rank(to_tsvector('russian', title), websearch_to_tsquery('russian', $1)),
-				 ts_rank(to_tsvector(title), websearch_to_tsquery($1)) DESC;`
+		FROM pins` + pinsCondition
+
+const pinsPageCmd = `
+		LIMIT $9 OFFSET $10;`
+
+var countPinsCmd = `
+		SELECT count(*)
+		FROM pins` + pinsCondition + `;`
+
+const boardsCondition = `
+		WHERE (search_vector @@ ` + tsQuery + `
+		   OR lower(name) LIKE $2)
+		  AND ($3 = 0 OR user_id = $3)
+		  AND (privacy = 'public' OR user_id = $4)`
 
 const getBoardsCmd = `
-		SELECT *
-		FROM boards
-		WHERE websearch_to_tsquery('russian', $1) @@ to_tsvector('russian', name)
-		   OR websearch_to_tsquery($1) @@ to_tsvector(name)
-		   OR lower(name) LIKE lower('%' || $1 || '%')
-		ORDER BY ts_rank(to_tsvector('russian', name), websearch_to_tsquery('russian', $1)),
-				 ts_rank(to_tsvector(name), websearch_to_tsquery($1)) DESC;`
+		SELECT id, name, description, privacy, user_id
+		FROM boards` + boardsCondition
+
+const boardsPageCmd = `
+		LIMIT $5 OFFSET $6;`
+
+const countBoardsCmd = `
+		SELECT count(*)
+		FROM boards` + boardsCondition + `;`
+
+const usersCondition = `
+		WHERE search_vector @@ ` + tsQuery + `
+		   OR lower(username) LIKE $2
+		   OR lower(name) LIKE $2`
 
 const getUsersCmd = `
-		SELECT id, username, name, profile_image, website_url
-		FROM users
-		WHERE websearch_to_tsquery('russian', $1) @@ (to_tsvector('russian', username) || to_tsvector('russian', name))
-		   OR websearch_to_tsquery($1) @@ (to_tsvector(username) || to_tsvector(name))
-		   OR lower(username) || lower(name) LIKE lower('%' || $1 || '%')
-		ORDER BY ts_rank(to_tsvector('russian', username) || to_tsvector('russian', name),
-						 websearch_to_tsquery('russian', $1)),
-				 ts_rank(to_tsvector(username) || to_tsvector(name), websearch_to_tsquery($1)) DESC;`
-
-func (rep repository) Get(query string) (models.SearchRes, error) {
-	rows, err := rep.db.Query(getPinsCmd, query)
+		SELECT id, username, name, profile_image, website_url, account_type
+		FROM users` + usersCondition
+
+const usersPageCmd = `
+		LIMIT $3 OFFSET $4;`
+
+const countUsersCmd = `
+		SELECT count(*)
+		FROM users` + usersCondition + `;`
+
+// Orders of each result type by sort, ids break ties so that pages do not overlap
+var pinsOrders = map[string]string{
+	search.SortRelevance: `ts_rank(search_vector, ` + tsQuery + `) DESC, id`,
+	search.SortRecent:    `created_at DESC, id DESC`,
+	search.SortPopular:   `n_likes DESC, id DESC`,
+}
+
+var boardsOrders = map[string]string{
+	search.SortRelevance: `ts_rank(search_vector, ` + tsQuery + `) DESC, id`,
+	search.SortRecent:    `id DESC`,
+	search.SortPopular:   `(SELECT count(*) FROM boards_pins WHERE board_id = boards.id) DESC, id DESC`,
+}
+
+var usersOrders = map[string]string{
+	search.SortRelevance: `ts_rank(search_vector, ` + tsQuery + `) DESC, id`,
+	search.SortRecent:    `id DESC`,
+	search.SortPopular:   `(SELECT count(*) FROM followings WHERE followee_id = users.id) DESC, id DESC`,
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// nullColor returns the #rrggbb color as 0xRRGGBB, colors are checked by search.CheckParams.
+func nullColor(color string) sql.NullInt32 {
+	if color == "" {
+		return sql.NullInt32{}
+	}
+	c, err := pkgImage.ParseHexColor(color)
 	if err != nil {
-		rep.log.Error(constants.DBQueryError, zap.String("sql_query", getPinsCmd),
-			zap.String("search_query", query), zap.Error(err))
-		return models.SearchRes{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(c.RGB()), Valid: true}
+}
+
+func (rep repository) Get(params search.Params, offset int) (models.SearchRes, error) {
+	res := models.SearchRes{}
+	var err error
+
+	text := tsQueryText(search.ParseQuery(params.Query, params.Corrections))
+	pattern := likeSubstring(strings.ToLower(params.Query))
+	pinsArgs := []any{text, pattern, params.AuthorId, nullTime(params.From), nullTime(params.To), params.UserId,
+		nullColor(params.Color), search.ColorTolerance}
+	res.Counts.Pins, err = rep.count(countPinsCmd, pinsArgs...)
+	if err != nil {
+		return models.SearchRes{}, err
+	}
+	boardsArgs := []any{text, pattern, params.AuthorId, params.UserId}
+	res.Counts.Boards, err = rep.count(countBoardsCmd, boardsArgs...)
+	if err != nil {
+		return models.SearchRes{}, err
+	}
+	res.Counts.Users, err = rep.count(countUsersCmd, text, pattern)
+	if err != nil {
+		return models.SearchRes{}, err
+	}
+
+	if params.Type == "" || params.Type == search.TypePins {
+		res.Pins, err = rep.getPins(params.Sort,
+			append(pinsArgs, params.Limit, offset)...)
+		if err != nil {
+			return models.SearchRes{}, err
+		}
+	}
+	if params.Type == "" || params.Type == search.TypeBoards {
+		res.Boards, err = rep.getBoards(params.Query, params.Sort,
+			append(boardsArgs, params.Limit, offset)...)
+		if err != nil {
+			return models.SearchRes{}, err
+		}
+	}
+	if params.Type == "" || params.Type == search.TypeUsers {
+		res.Users, err = rep.getUsers(params.Query, params.Sort, text, pattern, params.Limit, offset)
+		if err != nil {
+			return models.SearchRes{}, err
+		}
+	}
+	return res, nil
+}
+
+func (rep repository) count(query string, args ...any) (int, error) {
+	var count int
+	err := rep.db.QueryRow(query, args...).Scan(&count)
+	if err != nil {
+		rep.log.Error(constants.DBScanError, zap.String("sql_query", query),
+			zap.Any("params", args), zap.Error(err))
+		return 0, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return count, nil
+}
+
+func (rep repository) getPins(sort string, args ...any) ([]models.Pin, error) {
+	cmd := getPinsCmd + "\n\t\tORDER BY " + pinsOrders[sort] + pinsPageCmd
+	return rep.listPins(cmd, args...)
+}
+
+func (rep repository) listPins(cmd string, args ...any) ([]models.Pin, error) {
+	rows, err := rep.db.Query(cmd, args...)
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", cmd), zap.Any("params", args), zap.Error(err))
+		return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
 	}
+	defer rows.Close()
 
 	var pins []models.Pin
 	pin := models.Pin{}
@@ -63,9 +236,8 @@ func (rep repository) Get(query string) (models.SearchRes, error) {
 	for rows.Next() {
 		err = rows.Scan(&pin.Id, &title, &description, &mediaSource, &pin.NumLikes, &pin.Author)
 		if err != nil {
-			rep.log.Error(constants.DBScanError, zap.String("sql_query", getPinsCmd),
-				zap.String("search_query", query), zap.Error(err))
-			return models.SearchRes{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+			rep.log.Error(constants.DBScanError, zap.String("sql_query", cmd), zap.Any("params", args), zap.Error(err))
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
 		}
 
 		pin.Title = title.String
@@ -73,50 +245,194 @@ func (rep repository) Get(query string) (models.SearchRes, error) {
 		pin.MediaSource = mediaSource.String
 		pins = append(pins, pin)
 	}
+	return pins, nil
+}
 
-	rows, err = rep.db.Query(getUsersCmd, query)
+func (rep repository) getBoards(query string, sort string, args ...any) ([]models.Board, error) {
+	cmd := getBoardsCmd + "\n\t\tORDER BY " + boardsOrders[sort] + boardsPageCmd
+	rows, err := rep.db.Query(cmd, args...)
 	if err != nil {
-		rep.log.Error(constants.DBQueryError, zap.String("sql_query", getUsersCmd),
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", cmd),
 			zap.String("search_query", query), zap.Error(err))
-		return models.SearchRes{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
 	}
+	defer rows.Close()
+
+	var boards []models.Board
+	board := models.Board{}
+	var description sql.NullString
+	for rows.Next() {
+		err = rows.Scan(&board.Id, &board.Name, &description, &board.Privacy, &board.UserId)
+		if err != nil {
+			rep.log.Error(constants.DBScanError, zap.String("sql_query", cmd),
+				zap.String("search_query", query), zap.Error(err))
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+
+		board.Description = description.String
+		boards = append(boards, board)
+	}
+	return boards, nil
+}
+
+func (rep repository) getUsers(query string, sort string, args ...any) ([]models.Profile, error) {
+	cmd := getUsersCmd + "\n\t\tORDER BY " + usersOrders[sort] + usersPageCmd
+	rows, err := rep.db.Query(cmd, args...)
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", cmd),
+			zap.String("search_query", query), zap.Error(err))
+		return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer rows.Close()
 
 	var users []models.Profile
 	user := models.Profile{}
 	var profileImage, websiteUrl sql.NullString
+	var accountType string
 	for rows.Next() {
-		err = rows.Scan(&user.Id, &user.Username, &user.Name, &profileImage, &websiteUrl)
+		err = rows.Scan(&user.Id, &user.Username, &user.Name, &profileImage, &websiteUrl, &accountType)
 		if err != nil {
-			rep.log.Error(constants.DBScanError, zap.String("sql_query", getUsersCmd),
+			rep.log.Error(constants.DBScanError, zap.String("sql_query", cmd),
 				zap.String("search_query", query), zap.Error(err))
-			return models.SearchRes{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
 		}
 
 		user.ProfileImage = profileImage.String
 		user.WebsiteUrl = websiteUrl.String
+		user.SetAccountType(accountType)
 		users = append(users, user)
 	}
+	return users, nil
+}
+
+var getSimilarSourceCmd = `
+		SELECT media_hash, media_color
+		FROM pins
+		WHERE id = $1 AND ` + pinReadable("$2") + `;`
+
+// The Hamming distance between hashes is the number of ones in their xor, the same as for duplicates.
+// Pins with close hashes come first and then those of close colors.
+var similarCondition = `
+		FROM pins,
+			LATERAL (SELECT length(replace(((media_hash # $1)::bit(64))::text, '0', '')) AS hash_distance,
+							rgb_distance(media_color, $2)                                   AS color_distance) d
+		WHERE id <> $3
+		  AND (d.hash_distance <= $4 OR d.color_distance <= $5)
+		  AND ` + pinReadable("$6")
+
+var getSimilarCmd = `
+		SELECT id, title, description, media_source, n_likes, author_id` + similarCondition + `
+		ORDER BY CASE WHEN d.hash_distance <= $4 THEN d.hash_distance ELSE $4 + 1 END,
+				 d.color_distance NULLS LAST, id DESC
+		LIMIT $7 OFFSET $8;`
 
-	rows, err = rep.db.Query(getBoardsCmd, query)
+var countSimilarCmd = `
+		SELECT count(*)` + similarCondition + `;`
+
+func (rep repository) Similar(params search.SimilarParams, offset int) (models.SearchRes, error) {
+	var hash sql.NullInt64
+	var color sql.NullInt32
+	err := rep.db.QueryRow(getSimilarSourceCmd, params.PinId, params.UserId).Scan(&hash, &color)
 	if err != nil {
-		rep.log.Error(constants.DBQueryError, zap.String("sql_query", getBoardsCmd),
-			zap.String("search_query", query), zap.Error(err))
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.SearchRes{}, pkgErrors.ErrPinNotFound
+		}
+		rep.log.Error(constants.DBScanError, zap.String("sql_query", getSimilarSourceCmd),
+			zap.Int("pin_id", params.PinId), zap.Error(err))
 		return models.SearchRes{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
 	}
 
-	var boards []models.Board
-	board := models.Board{}
-	for rows.Next() {
-		err = rows.Scan(&board.Id, &board.Name, &description, &board.Privacy, &board.UserId)
-		if err != nil {
-			rep.log.Error(constants.DBScanError, zap.String("sql_query", getBoardsCmd),
-				zap.String("search_query", query), zap.Error(err))
-			return models.SearchRes{}, errors.Wrap(pkgErrors.ErrDb, err.Error())
-		}
+	res := models.SearchRes{}
+	args := []any{hash, color, params.PinId, search.SimilarMaxHashDistance, search.ColorTolerance, params.UserId}
+	res.Counts.Pins, err = rep.count(countSimilarCmd, args...)
+	if err != nil {
+		return models.SearchRes{}, err
+	}
+	res.Pins, err = rep.listPins(getSimilarCmd, append(args, params.Limit, offset)...)
+	if err != nil {
+		return models.SearchRes{}, err
+	}
+	return res, nil
+}
 
-		board.Description = description.String
-		boards = append(boards, board)
+const suggestUsersCmd = `
+		SELECT username
+		FROM users
+		WHERE lower(username) LIKE $1
+		ORDER BY length(username), username
+		LIMIT $2;`
+
+const suggestBoardsCmd = `
+		SELECT min(name)
+		FROM boards
+		WHERE lower(name) LIKE $1 AND privacy = 'public'
+		GROUP BY lower(name)
+		ORDER BY count(*) DESC, lower(name)
+		LIMIT $2;`
+
+// Tags of pins saved to secret boards only are not suggested
+const suggestTagsCmd = `
+		SELECT tag
+		FROM pin_tags pt
+		WHERE tag LIKE $1
+		  AND (NOT EXISTS (SELECT 1
+						   FROM boards_pins bp
+							   JOIN boards b ON b.id = bp.board_id
+						   WHERE bp.pin_id = pt.pin_id AND b.privacy = 'secret')
+		   OR EXISTS (SELECT 1
+					  FROM boards_pins bp
+						  JOIN boards b ON b.id = bp.board_id
+					  WHERE bp.pin_id = pt.pin_id AND b.privacy = 'public'))
+		GROUP BY tag
+		ORDER BY count(*) DESC, tag
+		LIMIT $2;`
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// likePrefix returns the LIKE pattern of strings starting with prefix.
+func likePrefix(prefix string) string {
+	return likeEscaper.Replace(prefix) + "%"
+}
+
+// likeSubstring returns the LIKE pattern of strings containing substr.
+func likeSubstring(substr string) string {
+	return "%" + likeEscaper.Replace(substr) + "%"
+}
+
+func (rep repository) Suggest(prefix string, limit int) (models.SearchSuggestions, error) {
+	pattern := likePrefix(strings.ToLower(prefix))
+
+	users, err := listStrings(rep.db, rep.log, suggestUsersCmd, pattern, limit)
+	if err != nil {
+		return models.SearchSuggestions{}, err
+	}
+	boards, err := listStrings(rep.db, rep.log, suggestBoardsCmd, pattern, limit)
+	if err != nil {
+		return models.SearchSuggestions{}, err
+	}
+	tags, err := listStrings(rep.db, rep.log, suggestTagsCmd, pattern, limit)
+	if err != nil {
+		return models.SearchSuggestions{}, err
+	}
+	return models.SearchSuggestions{Users: users, Boards: boards, Tags: tags}, nil
+}
+
+func listStrings(db *sql.DB, log *zap.Logger, query string, args ...any) ([]string, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		log.Error(constants.DBQueryError, zap.String("sql_query", query), zap.Any("params", args), zap.Error(err))
+		return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
 	}
+	defer rows.Close()
 
-	return models.SearchRes{Pins: pins, Boards: boards, Users: users}, nil
+	res := []string{}
+	for rows.Next() {
+		var str string
+		if err = rows.Scan(&str); err != nil {
+			log.Error(constants.DBScanError, zap.String("sql_query", query), zap.Any("params", args), zap.Error(err))
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		res = append(res, str)
+	}
+	return res, nil
 }
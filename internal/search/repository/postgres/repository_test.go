@@ -0,0 +1,293 @@
+package postgres
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/searchtest"
+)
+
+// The suite needs the full text search of Postgres, it runs against the database from SEARCH_TEST_DB,
+// a connection string of a database made with scripts/migrations/init.sql. Its tables are truncated.
+func TestRepository(t *testing.T) {
+	dsn := os.Getenv("SEARCH_TEST_DB")
+	if dsn == "" {
+		t.Skip("SEARCH_TEST_DB is not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	searchtest.RunRepositoryTests(t, func(t *testing.T, f searchtest.Fixtures) search.Repository {
+		insertFixtures(t, db, f)
+		return NewRepository(db, zap.NewNop())
+	})
+}
+
+func insertFixtures(t *testing.T, db *sql.DB, f searchtest.Fixtures) {
+	exec := func(query string, args ...any) {
+		if _, err := db.Exec(query, args...); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+	}
+
+	exec(`TRUNCATE users, boards, pins, boards_pins, followings, pin_tags CASCADE;`)
+	for _, user := range f.Users {
+		exec(`INSERT INTO users (id, username, email, hashed_password, name, account_type)
+			  VALUES ($1, $2, $3, '', $4, $5);`,
+			user.Id, user.Username, user.Username+"@example.com", user.Name, user.AccountType)
+	}
+	for _, board := range f.Boards {
+		exec(`INSERT INTO boards (id, name, description, privacy, user_id) VALUES ($1, $2, $3, $4, $5);`,
+			board.Id, board.Name, board.Description, board.Privacy, board.UserId)
+	}
+	for _, doc := range f.Pins {
+		pin := doc.Pin
+		palette := "[]"
+		if doc.Color != nil {
+			palette = `["` + doc.Color.CSS() + `"]`
+		}
+		exec(`INSERT INTO pins (id, title, description, media_source, n_likes, author_id, created_at, media_hash,
+								media_palette)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`,
+			pin.Id, pin.Title, pin.Description, pin.MediaSource, pin.NumLikes, pin.Author, doc.CreatedAt,
+			sql.NullInt64{Int64: int64(doc.Hash), Valid: doc.Hash != 0}, palette)
+		for _, boardId := range doc.BoardIds {
+			exec(`INSERT INTO boards_pins (board_id, pin_id) VALUES ($1, $2);`, boardId, pin.Id)
+		}
+	}
+	for _, following := range f.Followings {
+		exec(`INSERT INTO followings (follower_id, followee_id) VALUES ($1, $2);`,
+			following.FollowerId, following.FolloweeId)
+	}
+	if err := RefreshWords(db); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPinReadable(t *testing.T) {
+	cond := pinReadable("$6")
+
+	for _, clause := range []string{
+		// the author reads their pins
+		`author_id = $6`,
+		// pins not saved to secret boards are readable by anyone
+		`NOT EXISTS (SELECT 1
+						  FROM boards_pins bp
+							  JOIN boards b ON b.id = bp.board_id
+						  WHERE bp.pin_id = pins.id AND b.privacy = 'secret')`,
+		// the rest are readable if saved to a public board or to a board of the user
+		`WHERE bp.pin_id = pins.id AND (b.privacy = 'public' OR b.user_id = $6)`,
+	} {
+		if !strings.Contains(cond, clause) {
+			t.Errorf("\nExpected clause: %s\nGot: %s", clause, cond)
+		}
+	}
+	for _, param := range regexp.MustCompile(`\$\d+`).FindAllString(cond, -1) {
+		if param != "$6" {
+			t.Errorf("unexpected parameter %s in %s", param, cond)
+		}
+	}
+
+	const boardReadable = `(privacy = 'public' OR user_id = $4)`
+	if !strings.Contains(boardsCondition, boardReadable) {
+		t.Errorf("\nExpected clause: %s\nGot: %s", boardReadable, boardsCondition)
+	}
+}
+
+func TestLikePatterns(t *testing.T) {
+	type testCase struct {
+		str       string
+		prefix    string
+		substring string
+	}
+
+	tests := map[string]testCase{
+		"plain":       {str: "cats", prefix: "cats%", substring: "%cats%"},
+		"empty":       {str: "", prefix: "%", substring: "%%"},
+		"percent":     {str: "50%", prefix: `50\%%`, substring: `%50\%%`},
+		"underscore":  {str: "snake_case", prefix: `snake\_case%`, substring: `%snake\_case%`},
+		"backslash":   {str: `a\b`, prefix: `a\\b%`, substring: `%a\\b%`},
+		"all of them": {str: `%_\`, prefix: `\%\_\\%`, substring: `%\%\_\\%`},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if prefix := likePrefix(test.str); prefix != test.prefix {
+				t.Errorf("\nExpected: %s\nGot: %s", test.prefix, prefix)
+			}
+			if substring := likeSubstring(test.str); substring != test.substring {
+				t.Errorf("\nExpected: %s\nGot: %s", test.substring, substring)
+			}
+		})
+	}
+}
+
+func TestGet_Privacy(t *testing.T) {
+	type testCase struct {
+		userId int
+		typ    string
+	}
+
+	// user 1 is the author of the pins, user 2 the owner of a secret board they are saved to
+	tests := map[string]testCase{
+		"anonymous pins":         {userId: 0, typ: search.TypePins},
+		"author pins":            {userId: 1, typ: search.TypePins},
+		"board owner pins":       {userId: 2, typ: search.TypePins},
+		"anonymous boards":       {userId: 0, typ: search.TypeBoards},
+		"board owner boards":     {userId: 2, typ: search.TypeBoards},
+		"anonymous of all types": {userId: 0},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			params := search.Params{UserId: test.userId, Query: "cats", Type: test.typ,
+				Sort: search.SortRelevance, Limit: 10}
+			text := tsQueryText(search.ParseQuery(params.Query, nil))
+			pinsArgs := []driver.Value{text, "%cats%", 0, sql.NullTime{}, sql.NullTime{}, test.userId, sql.NullInt32{},
+				search.ColorTolerance}
+			boardsArgs := []driver.Value{text, "%cats%", 0, test.userId}
+			count := func() *sqlmock.Rows { return sqlmock.NewRows([]string{"count"}).AddRow(0) }
+
+			mock.ExpectQuery(regexp.QuoteMeta(countPinsCmd)).WithArgs(pinsArgs...).WillReturnRows(count())
+			mock.ExpectQuery(regexp.QuoteMeta(countBoardsCmd)).WithArgs(boardsArgs...).WillReturnRows(count())
+			mock.ExpectQuery(regexp.QuoteMeta(countUsersCmd)).WithArgs(text, "%cats%").WillReturnRows(count())
+			if test.typ == "" || test.typ == search.TypePins {
+				mock.ExpectQuery(regexp.QuoteMeta(getPinsCmd + "\n\t\tORDER BY " + pinsOrders[params.Sort] + pinsPageCmd)).
+					WithArgs(append(pinsArgs, 10, 0)...).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "media_source", "n_likes",
+						"author_id"}))
+			}
+			if test.typ == "" || test.typ == search.TypeBoards {
+				mock.ExpectQuery(regexp.QuoteMeta(getBoardsCmd + "\n\t\tORDER BY " + boardsOrders[params.Sort] +
+					boardsPageCmd)).
+					WithArgs(append(boardsArgs, 10, 0)...).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "privacy", "user_id"}))
+			}
+			if test.typ == "" {
+				mock.ExpectQuery(regexp.QuoteMeta(getUsersCmd+"\n\t\tORDER BY "+usersOrders[params.Sort]+
+					usersPageCmd)).
+					WithArgs(text, "%cats%", 10, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username", "name", "profile_image", "website_url",
+						"account_type"}))
+			}
+
+			_, err = NewRepository(db, zap.NewNop()).Get(params, 0)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if err = mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestGet_EscapesQuery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("can't create mock: %s", err)
+	}
+	defer db.Close()
+
+	// the wildcards of the query match themselves only
+	params := search.Params{Query: "100% Cotton_Socks", Type: search.TypeUsers, Sort: search.SortRelevance, Limit: 10}
+	text := tsQueryText(search.ParseQuery(params.Query, nil))
+	const pattern = `%100\% cotton\_socks%`
+	count := func() *sqlmock.Rows { return sqlmock.NewRows([]string{"count"}).AddRow(0) }
+
+	mock.ExpectQuery(regexp.QuoteMeta(countPinsCmd)).WithArgs(text, pattern, 0, sql.NullTime{}, sql.NullTime{}, 0,
+		sql.NullInt32{}, search.ColorTolerance).WillReturnRows(count())
+	mock.ExpectQuery(regexp.QuoteMeta(countBoardsCmd)).WithArgs(text, pattern, 0, 0).WillReturnRows(count())
+	mock.ExpectQuery(regexp.QuoteMeta(countUsersCmd)).WithArgs(text, pattern).WillReturnRows(count())
+	mock.ExpectQuery(regexp.QuoteMeta(getUsersCmd+"\n\t\tORDER BY "+usersOrders[params.Sort]+usersPageCmd)).
+		WithArgs(text, pattern, 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "name", "profile_image", "website_url",
+			"account_type"}))
+
+	_, err = NewRepository(db, zap.NewNop()).Get(params, 0)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("\nThere were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSimilar_Privacy(t *testing.T) {
+	type testCase struct {
+		userId   int
+		readable bool
+		err      error
+	}
+
+	// pin 5 is saved to a secret board of user 2 only
+	tests := map[string]testCase{
+		"anonymous":   {userId: 0, readable: false, err: pkgErrors.ErrPinNotFound},
+		"other user":  {userId: 3, readable: false, err: pkgErrors.ErrPinNotFound},
+		"board owner": {userId: 2, readable: true},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			source := sqlmock.NewRows([]string{"media_hash", "media_color"})
+			if test.readable {
+				source.AddRow(-2, 0x336699)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(getSimilarSourceCmd)).WithArgs(5, test.userId).WillReturnRows(source)
+			if test.readable {
+				args := []driver.Value{sql.NullInt64{Int64: -2, Valid: true}, sql.NullInt32{Int32: 0x336699, Valid: true},
+					5, search.SimilarMaxHashDistance, search.ColorTolerance, test.userId}
+				mock.ExpectQuery(regexp.QuoteMeta(countSimilarCmd)).WithArgs(args...).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery(regexp.QuoteMeta(getSimilarCmd)).WithArgs(append(args, 10, 0)...).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "media_source", "n_likes",
+						"author_id"}))
+			}
+
+			params := search.SimilarParams{UserId: test.userId, PinId: 5, Limit: 10}
+			_, err = NewRepository(db, zap.NewNop()).Similar(params, 0)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if err = mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\nThere were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+)
+
+// ChangesChannel is notified by the triggers of pins, boards, users and the tables they are counted in.
+// Payloads are the kind of the changed document and its id, such as "pin:42".
+const ChangesChannel = "search_changes"
+
+// Kinds of the changed documents
+const (
+	PinChange   = "pin"
+	BoardChange = "board"
+	UserChange  = "user"
+)
+
+const selectPinsCmd = `
+		SELECT pins.id, title, description, media_source, n_likes, author_id, created_at, media_hash, media_color,
+			   coalesce(array_agg(bp.board_id) FILTER (WHERE bp.board_id IS NOT NULL), '{}')
+		FROM pins
+			LEFT JOIN boards_pins bp ON bp.pin_id = pins.id`
+
+const groupPinsCmd = `
+		GROUP BY pins.id`
+
+const loadPinsCmd = selectPinsCmd + groupPinsCmd + `;`
+
+const loadPinCmd = selectPinsCmd + `
+		WHERE pins.id = $1` + groupPinsCmd + `;`
+
+const selectBoardsCmd = `
+		SELECT id, name, description, privacy, user_id,
+			   (SELECT count(*) FROM boards_pins WHERE board_id = boards.id)
+		FROM boards`
+
+const loadBoardsCmd = selectBoardsCmd + `;`
+
+const loadBoardCmd = selectBoardsCmd + `
+		WHERE id = $1;`
+
+const selectUsersCmd = `
+		SELECT id, username, name, profile_image, website_url, account_type,
+			   (SELECT count(*) FROM followings WHERE followee_id = users.id)
+		FROM users`
+
+const loadUsersCmd = selectUsersCmd + `;`
+
+const loadUserCmd = selectUsersCmd + `
+		WHERE id = $1;`
+
+// Syncer fills the index with the documents of the database and applies their changes to it.
+type Syncer struct {
+	db    *sql.DB
+	index search.Index
+	log   *zap.Logger
+}
+
+func NewSyncer(db *sql.DB, index search.Index, log *zap.Logger) *Syncer {
+	return &Syncer{db, index, log}
+}
+
+// Load replaces the documents of the index with all the pins, boards and users.
+func (s *Syncer) Load() error {
+	pins, err := s.loadPins(loadPinsCmd)
+	if err != nil {
+		return err
+	}
+	boards, err := s.loadBoards(loadBoardsCmd)
+	if err != nil {
+		return err
+	}
+	users, err := s.loadUsers(loadUsersCmd)
+	if err != nil {
+		return err
+	}
+
+	s.index.Load(pins, boards, users)
+	return nil
+}
+
+// Apply reloads the document of the change notified with the payload, or deletes it if it is gone.
+func (s *Syncer) Apply(payload string) error {
+	kind, idStr, _ := strings.Cut(payload, ":")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		s.log.Error("Invalid search change", zap.String("payload", payload), zap.Error(err))
+		return err
+	}
+
+	switch kind {
+	case PinChange:
+		pins, err := s.loadPins(loadPinCmd, id)
+		if err != nil {
+			return err
+		}
+		if len(pins) == 0 {
+			s.index.DeletePin(id)
+		} else {
+			s.index.SetPin(pins[0])
+		}
+	case BoardChange:
+		boards, err := s.loadBoards(loadBoardCmd, id)
+		if err != nil {
+			return err
+		}
+		if len(boards) == 0 {
+			s.index.DeleteBoard(id)
+		} else {
+			s.index.SetBoard(boards[0])
+		}
+	case UserChange:
+		users, err := s.loadUsers(loadUserCmd, id)
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			s.index.DeleteUser(id)
+		} else {
+			s.index.SetUser(users[0])
+		}
+	default:
+		s.log.Error("Unknown search change", zap.String("payload", payload))
+		return errors.New("unknown search change " + payload)
+	}
+	return nil
+}
+
+// Start listens to the changes, loads the index and applies the changes until ctx is done.
+// The index is reloaded whenever the listener reconnects, since the changes made in between are lost.
+func (s *Syncer) Start(ctx context.Context, listener *pq.Listener, pingInterval time.Duration) error {
+	err := listener.Listen(ChangesChannel)
+	if err != nil {
+		s.log.Error("Failed to listen to search changes", zap.Error(err))
+		return err
+	}
+	if err = s.Load(); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		defer listener.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n := <-listener.Notify:
+				var err error
+				if n == nil {
+					s.log.Info("Reloading search index after reconnection")
+					err = s.Load()
+				} else {
+					err = s.Apply(n.Extra)
+				}
+				if err != nil {
+					s.log.Error("Failed to sync search index", zap.Error(err))
+				}
+			case <-ticker.C:
+				go func() {
+					if err := listener.Ping(); err != nil {
+						s.log.Error("Search changes listener ping failed", zap.Error(err))
+					}
+				}()
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *Syncer) loadPins(query string, args ...any) ([]search.PinDocument, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		s.log.Error(constants.DBQueryError, zap.String("sql_query", query), zap.Any("params", args), zap.Error(err))
+		return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer rows.Close()
+
+	var pins []search.PinDocument
+	for rows.Next() {
+		doc := search.PinDocument{}
+		var title, description, mediaSource sql.NullString
+		var hash sql.NullInt64
+		var color sql.NullInt32
+		var boardIds []int64
+		err = rows.Scan(&doc.Pin.Id, &title, &description, &mediaSource, &doc.Pin.NumLikes, &doc.Pin.Author,
+			&doc.CreatedAt, &hash, &color, pq.Array(&boardIds))
+		if err != nil {
+			s.log.Error(constants.DBScanError, zap.String("sql_query", query), zap.Any("params", args), zap.Error(err))
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+
+		doc.Pin.Title = title.String
+		doc.Pin.Description = description.String
+		doc.Pin.MediaSource = mediaSource.String
+		doc.Hash = uint64(hash.Int64)
+		if color.Valid {
+			c := pkgImage.ColorFromRGB(int(color.Int32))
+			doc.Color = &c
+		}
+		for _, id := range boardIds {
+			doc.BoardIds = append(doc.BoardIds, int(id))
+		}
+		pins = append(pins, doc)
+	}
+	return pins, nil
+}
+
+func (s *Syncer) loadBoards(query string, args ...any) ([]search.BoardDocument, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		s.log.Error(constants.DBQueryError, zap.String("sql_query", query), zap.Any("params", args), zap.Error(err))
+		return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer rows.Close()
+
+	var boards []search.BoardDocument
+	for rows.Next() {
+		doc := search.BoardDocument{}
+		var description sql.NullString
+		err = rows.Scan(&doc.Board.Id, &doc.Board.Name, &description, &doc.Board.Privacy, &doc.Board.UserId, &doc.NumPins)
+		if err != nil {
+			s.log.Error(constants.DBScanError, zap.String("sql_query", query), zap.Any("params", args), zap.Error(err))
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+
+		doc.Board.Description = description.String
+		boards = append(boards, doc)
+	}
+	return boards, nil
+}
+
+func (s *Syncer) loadUsers(query string, args ...any) ([]search.UserDocument, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		s.log.Error(constants.DBQueryError, zap.String("sql_query", query), zap.Any("params", args), zap.Error(err))
+		return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer rows.Close()
+
+	var users []search.UserDocument
+	for rows.Next() {
+		doc := search.UserDocument{}
+		var profileImage, websiteUrl sql.NullString
+		var accountType string
+		err = rows.Scan(&doc.User.Id, &doc.User.Username, &doc.User.Name, &profileImage, &websiteUrl, &accountType,
+			&doc.NumFollowers)
+		if err != nil {
+			s.log.Error(constants.DBScanError, zap.String("sql_query", query), zap.Any("params", args), zap.Error(err))
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+
+		doc.User.ProfileImage = profileImage.String
+		doc.User.WebsiteUrl = websiteUrl.String
+		doc.User.SetAccountType(accountType)
+		users = append(users, doc)
+	}
+	return users, nil
+}
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/mocks"
+)
+
+func TestApply(t *testing.T) {
+	type fields struct {
+		mock  sqlmock.Sqlmock
+		index *mocks.MockIndex
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		payload string
+		err     error
+	}
+
+	createdAt := time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+	user := models.Profile{Id: 3, Username: "kotov", Name: "Иван Котов"}
+	user.SetAccountType("personal")
+
+	tests := map[string]testCase{
+		"pin": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "media_source", "n_likes", "author_id",
+					"created_at", "media_hash", "media_color", "boards"})
+				rows.AddRow(5, "Mountains", nil, "5.jpg", 2, 1, createdAt, -2, 0x336699, "{4,2}")
+				f.mock.ExpectQuery(regexp.QuoteMeta(loadPinCmd)).WithArgs(5).WillReturnRows(rows)
+				f.index.EXPECT().SetPin(search.PinDocument{
+					Pin:       models.Pin{Id: 5, Title: "Mountains", MediaSource: "5.jpg", NumLikes: 2, Author: 1},
+					CreatedAt: createdAt,
+					BoardIds:  []int{4, 2},
+					Hash:      0xfffffffffffffffe,
+					Color:     &pkgImage.Color{Red: 0x33, Green: 0x66, Blue: 0x99},
+				})
+			},
+			payload: "pin:5",
+		},
+		"deleted pin": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "media_source", "n_likes", "author_id",
+					"created_at", "media_hash", "media_color", "boards"})
+				f.mock.ExpectQuery(regexp.QuoteMeta(loadPinCmd)).WithArgs(5).WillReturnRows(rows)
+				f.index.EXPECT().DeletePin(5)
+			},
+			payload: "pin:5",
+		},
+		"board": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"id", "name", "description", "privacy", "user_id", "count"})
+				rows.AddRow(2, "Secret cats", nil, "secret", 1, 7)
+				f.mock.ExpectQuery(regexp.QuoteMeta(loadBoardCmd)).WithArgs(2).WillReturnRows(rows)
+				f.index.EXPECT().SetBoard(search.BoardDocument{
+					Board:   models.Board{Id: 2, Name: "Secret cats", Privacy: "secret", UserId: 1},
+					NumPins: 7,
+				})
+			},
+			payload: "board:2",
+		},
+		"deleted board": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"id", "name", "description", "privacy", "user_id", "count"})
+				f.mock.ExpectQuery(regexp.QuoteMeta(loadBoardCmd)).WithArgs(2).WillReturnRows(rows)
+				f.index.EXPECT().DeleteBoard(2)
+			},
+			payload: "board:2",
+		},
+		"user": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"id", "username", "name", "profile_image", "website_url", "account_type",
+					"count"})
+				rows.AddRow(3, "kotov", "Иван Котов", nil, nil, "personal", 2)
+				f.mock.ExpectQuery(regexp.QuoteMeta(loadUserCmd)).WithArgs(3).WillReturnRows(rows)
+				f.index.EXPECT().SetUser(search.UserDocument{User: user, NumFollowers: 2})
+			},
+			payload: "user:3",
+		},
+		"deleted user": {
+			prepare: func(f *fields) {
+				rows := sqlmock.NewRows([]string{"id", "username", "name", "profile_image", "website_url", "account_type",
+					"count"})
+				f.mock.ExpectQuery(regexp.QuoteMeta(loadUserCmd)).WithArgs(3).WillReturnRows(rows)
+				f.index.EXPECT().DeleteUser(3)
+			},
+			payload: "user:3",
+		},
+		"db error": {
+			prepare: func(f *fields) {
+				f.mock.ExpectQuery(regexp.QuoteMeta(loadPinCmd)).WithArgs(5).WillReturnError(pkgErrors.ErrDb)
+			},
+			payload: "pin:5",
+			err:     pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("can't create mock: %s", err)
+			}
+			defer db.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{mock: mock, index: mocks.NewMockIndex(ctrl)}
+			test.prepare(&f)
+
+			s := NewSyncer(db, f.index, zap.NewNop())
+			err = s.Apply(test.payload)
+			if errors.Cause(err) != test.err {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if err = mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestApplyInvalid(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("can't create mock: %s", err)
+	}
+	defer db.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := NewSyncer(db, mocks.NewMockIndex(ctrl), zap.NewNop())
+	for _, payload := range []string{"pin", "pin:x", "comment:1"} {
+		if err = s.Apply(payload); err == nil {
+			t.Errorf("%q: expected an error", payload)
+		}
+	}
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/constants"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+)
+
+// The words of public pins, boards and users are in the materialized view search_words, similar words
+// are found with its trigram index. Ties are broken in byte order, the same as in the index.
+const correctCmd = `
+		SELECT q.word, c.word
+		FROM unnest($1::text[]) q(word)
+			CROSS JOIN LATERAL (SELECT w.word
+								FROM search_words w
+								WHERE w.word % q.word AND similarity(w.word, q.word) >= $2
+								ORDER BY similarity(w.word, q.word) DESC, w.word COLLATE "C"
+								LIMIT 1) c
+		WHERE NOT EXISTS (SELECT 1 FROM search_words WHERE word = q.word);`
+
+const refreshWordsCmd = `
+		REFRESH MATERIALIZED VIEW CONCURRENTLY search_words;`
+
+func (rep repository) Correct(words []string) (map[string]string, error) {
+	rows, err := rep.db.Query(correctCmd, pq.Array(words), search.MinWordSimilarity)
+	if err != nil {
+		rep.log.Error(constants.DBQueryError, zap.String("sql_query", correctCmd),
+			zap.Strings("words", words), zap.Error(err))
+		return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	defer rows.Close()
+
+	res := make(map[string]string)
+	for rows.Next() {
+		var word, correction string
+		if err = rows.Scan(&word, &correction); err != nil {
+			rep.log.Error(constants.DBScanError, zap.String("sql_query", correctCmd),
+				zap.Strings("words", words), zap.Error(err))
+			return nil, errors.Wrap(pkgErrors.ErrDb, err.Error())
+		}
+		res[word] = correction
+	}
+	return res, nil
+}
+
+// RefreshWords rebuilds the words misspelled words of queries are corrected to.
+func RefreshWords(db *sql.DB) error {
+	_, err := db.Exec(refreshWordsCmd)
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrDb, err.Error())
+	}
+	return nil
+}
+
+// StartWordsRefresher periodically refreshes the words misspelled words of queries are corrected to
+// until ctx is done, words of new pins, boards and users are not suggested until then.
+func StartWordsRefresher(ctx context.Context, db *sql.DB, interval time.Duration, log *zap.Logger) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := RefreshWords(db); err != nil {
+					log.Error("Failed to refresh search words", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,420 @@
+// Package searchtest checks that implementations of search.Repository find the same results.
+package searchtest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	pkgImage "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/image"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+)
+
+type Following struct {
+	FollowerId int
+	FolloweeId int
+}
+
+// Fixtures are the rows the repository under test searches in. Pins hold only the fields
+// search results have, their colors are the first colors of their palettes.
+type Fixtures struct {
+	Users      []models.Profile
+	Boards     []models.Board
+	Pins       []search.PinDocument
+	Followings []Following
+}
+
+// Documents returns the fixtures as documents of an index.
+func (f Fixtures) Documents() ([]search.PinDocument, []search.BoardDocument, []search.UserDocument) {
+	numPins := make(map[int]int)
+	for _, pin := range f.Pins {
+		for _, boardId := range pin.BoardIds {
+			numPins[boardId]++
+		}
+	}
+	numFollowers := make(map[int]int)
+	for _, following := range f.Followings {
+		numFollowers[following.FolloweeId]++
+	}
+
+	var boards []search.BoardDocument
+	for _, board := range f.Boards {
+		boards = append(boards, search.BoardDocument{Board: board, NumPins: numPins[board.Id]})
+	}
+	var users []search.UserDocument
+	for _, user := range f.Users {
+		users = append(users, search.UserDocument{User: user, NumFollowers: numFollowers[user.Id]})
+	}
+	return f.Pins, boards, users
+}
+
+func user(id int, username string, name string) models.Profile {
+	user := models.Profile{Id: id, Username: username, Name: name}
+	user.SetAccountType("personal")
+	return user
+}
+
+func date(month time.Month) time.Time {
+	return time.Date(2023, month, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func color(red, green, blue uint8) *pkgImage.Color {
+	return &pkgImage.Color{Red: red, Green: green, Blue: blue}
+}
+
+var fixtures = Fixtures{
+	Users: []models.Profile{
+		user(1, "alice", "Alice Smith"),
+		user(2, "bob", "Bob Stone"),
+		user(3, "kotov", "Иван Котов"),
+		user(4, "carol", "Carol"),
+	},
+	Boards: []models.Board{
+		{Id: 1, Name: "Котики", Description: "Пушистые коты", Privacy: "public", UserId: 1},
+		{Id: 2, Name: "Secret cats", Privacy: "secret", UserId: 1},
+		{Id: 3, Name: "Dogs", Description: "cats and dogs", Privacy: "public", UserId: 2},
+		{Id: 4, Name: "Travel", Privacy: "public", UserId: 2},
+	},
+	Pins: []search.PinDocument{
+		{Pin: models.Pin{Id: 1, Title: "Рыжий кот", Description: "спит на диване", MediaSource: "1.jpg", NumLikes: 5, Author: 1},
+			CreatedAt: date(time.January), BoardIds: []int{1}, Hash: 0x0f0f0f0f0f0f0f0f, Color: color(200, 80, 30)},
+		{Pin: models.Pin{Id: 2, Title: "Cats sleeping", Description: "#cats #sleep", MediaSource: "2.jpg", NumLikes: 10, Author: 2},
+			CreatedAt: date(time.February), BoardIds: []int{3}, Hash: 0x0f0f0f0f0f0f0f0e, Color: color(120, 120, 120)},
+		{Pin: models.Pin{Id: 3, Title: "Secret cat", MediaSource: "3.jpg", NumLikes: 1, Author: 1},
+			CreatedAt: date(time.March), BoardIds: []int{2}, Hash: 0x0f0f0f0f0f0f00ff, Color: color(210, 90, 40)},
+		{Pin: models.Pin{Id: 4, Title: "Dog", Description: "a dog and a cat #dogs", MediaSource: "4.jpg", NumLikes: 3, Author: 2},
+			CreatedAt: date(time.April), Hash: 0xf0f0f0f0f0f0f0f0, Color: color(190, 70, 45)},
+		{Pin: models.Pin{Id: 5, Title: "Mountains", Description: "travel #travel", MediaSource: "5.jpg", Author: 2},
+			CreatedAt: date(time.May), BoardIds: []int{4, 2}, Color: color(30, 60, 200)},
+		{Pin: models.Pin{Id: 6, Title: "Travel notes", MediaSource: "6.jpg", Author: 1},
+			CreatedAt: date(time.June), BoardIds: []int{4}, Hash: 0x0f0f0f0f0f0f0fff},
+	},
+	Followings: []Following{
+		{FollowerId: 1, FolloweeId: 3},
+		{FollowerId: 2, FolloweeId: 3},
+		{FollowerId: 1, FolloweeId: 2},
+	},
+}
+
+type ids struct {
+	pins   []int
+	boards []int
+	users  []int
+}
+
+func resIds(res models.SearchRes) ids {
+	var got ids
+	for _, pin := range res.Pins {
+		got.pins = append(got.pins, pin.Id)
+	}
+	for _, board := range res.Boards {
+		got.boards = append(got.boards, board.Id)
+	}
+	for _, user := range res.Users {
+		got.users = append(got.users, user.Id)
+	}
+	return got
+}
+
+// RunRepositoryTests runs the suite against the repository returned by setup, which fills it with the fixtures.
+func RunRepositoryTests(t *testing.T, setup func(t *testing.T, f Fixtures) search.Repository) {
+	rep := setup(t, fixtures)
+
+	t.Run("Get", func(t *testing.T) {
+		type testCase struct {
+			params search.Params
+			offset int
+			ids    ids
+			counts models.SearchCounts
+		}
+
+		tests := map[string]testCase{
+			"words and substrings of titles": {
+				params: search.Params{Query: "cat", Type: search.TypePins, Sort: search.SortRecent, Limit: 10},
+				ids:    ids{pins: []int{4, 2}},
+				counts: models.SearchCounts{Pins: 2, Boards: 1},
+			},
+			"pins of secret boards for their owners": {
+				params: search.Params{UserId: 1, Query: "cat", Type: search.TypePins, Sort: search.SortRecent, Limit: 10},
+				ids:    ids{pins: []int{4, 3, 2}},
+				counts: models.SearchCounts{Pins: 3, Boards: 2},
+			},
+			"russian stems": {
+				params: search.Params{Query: "коты", Sort: search.SortRecent, Limit: 10},
+				ids:    ids{pins: []int{1}, boards: []int{1}, users: []int{3}},
+				counts: models.SearchCounts{Pins: 1, Boards: 1, Users: 1},
+			},
+			"case insensitive": {
+				params: search.Params{Query: "КОТ", Type: search.TypePins, Sort: search.SortRecent, Limit: 10},
+				ids:    ids{pins: []int{1}},
+				counts: models.SearchCounts{Pins: 1, Boards: 1, Users: 1},
+			},
+			"relevance": {
+				params: search.Params{Query: "travel", Sort: search.SortRelevance, Limit: 10},
+				ids:    ids{pins: []int{6, 5}, boards: []int{4}},
+				counts: models.SearchCounts{Pins: 2, Boards: 1},
+			},
+			"popular pins": {
+				params: search.Params{UserId: 1, Query: "cat", Type: search.TypePins, Sort: search.SortPopular, Limit: 10},
+				ids:    ids{pins: []int{2, 4, 3}},
+				counts: models.SearchCounts{Pins: 3, Boards: 2},
+			},
+			"author": {
+				params: search.Params{UserId: 1, Query: "cat", Sort: search.SortRecent, Limit: 10, AuthorId: 2},
+				ids:    ids{pins: []int{4, 2}, boards: []int{3}},
+				counts: models.SearchCounts{Pins: 2, Boards: 1},
+			},
+			"dates": {
+				params: search.Params{UserId: 1, Query: "cat", Type: search.TypePins, Sort: search.SortRecent, Limit: 10,
+					From: date(time.February), To: date(time.April)},
+				ids:    ids{pins: []int{3, 2}},
+				counts: models.SearchCounts{Pins: 2, Boards: 2},
+			},
+			"first page": {
+				params: search.Params{UserId: 1, Query: "cat", Type: search.TypePins, Sort: search.SortRecent, Limit: 2},
+				ids:    ids{pins: []int{4, 3}},
+				counts: models.SearchCounts{Pins: 3, Boards: 2},
+			},
+			"last page": {
+				params: search.Params{UserId: 1, Query: "cat", Type: search.TypePins, Sort: search.SortRecent, Limit: 2},
+				offset: 2,
+				ids:    ids{pins: []int{2}},
+				counts: models.SearchCounts{Pins: 3, Boards: 2},
+			},
+			"boards of their owners": {
+				params: search.Params{UserId: 1, Query: "cats", Type: search.TypeBoards, Sort: search.SortRecent, Limit: 10},
+				ids:    ids{boards: []int{3, 2}},
+				counts: models.SearchCounts{Pins: 3, Boards: 2},
+			},
+			"popular boards": {
+				params: search.Params{UserId: 1, Query: "cats", Type: search.TypeBoards, Sort: search.SortPopular, Limit: 10},
+				ids:    ids{boards: []int{2, 3}},
+				counts: models.SearchCounts{Pins: 3, Boards: 2},
+			},
+			"popular users": {
+				params: search.Params{Query: "o", Type: search.TypeUsers, Sort: search.SortPopular, Limit: 10},
+				ids:    ids{users: []int{3, 2, 4}},
+				counts: models.SearchCounts{Pins: 3, Boards: 1, Users: 3},
+			},
+			"recent users": {
+				params: search.Params{Query: "o", Type: search.TypeUsers, Sort: search.SortRecent, Limit: 10},
+				ids:    ids{users: []int{4, 3, 2}},
+				counts: models.SearchCounts{Pins: 3, Boards: 1, Users: 3},
+			},
+			"excluded words": {
+				params: search.Params{UserId: 1, Query: "cat -sleeping", Type: search.TypePins, Sort: search.SortRecent, Limit: 10},
+				ids:    ids{pins: []int{4, 3}},
+				counts: models.SearchCounts{Pins: 2, Boards: 2},
+			},
+			"alternatives": {
+				params: search.Params{Query: "кот or dog", Type: search.TypePins, Sort: search.SortRecent, Limit: 10},
+				ids:    ids{pins: []int{4, 1}},
+				counts: models.SearchCounts{Pins: 2, Boards: 2, Users: 1},
+			},
+			"dominant color": {
+				params: search.Params{UserId: 1, Query: "cat", Type: search.TypePins, Sort: search.SortRecent, Limit: 10,
+					Color: "#c85a28"},
+				ids:    ids{pins: []int{4, 3}},
+				counts: models.SearchCounts{Pins: 2, Boards: 2},
+			},
+			"pins without colors": {
+				params: search.Params{Query: "travel", Sort: search.SortRecent, Limit: 10, Color: "#1e3cc8"},
+				ids:    ids{pins: []int{5}, boards: []int{4}},
+				counts: models.SearchCounts{Pins: 1, Boards: 1},
+			},
+			"transliteration": {
+				params: search.Params{Query: "ryzhiy kot", Sort: search.SortRecent, Limit: 10},
+				ids:    ids{pins: []int{1}},
+				counts: models.SearchCounts{Pins: 1},
+			},
+			"transliterated usernames": {
+				params: search.Params{Query: "боб", Sort: search.SortRecent, Limit: 10},
+				ids:    ids{users: []int{2}},
+				counts: models.SearchCounts{Users: 1},
+			},
+			"synonyms": {
+				params: search.Params{Query: "кошка", Sort: search.SortRecent, Limit: 10},
+				ids:    ids{pins: []int{1}, boards: []int{1}, users: []int{3}},
+				counts: models.SearchCounts{Pins: 1, Boards: 1, Users: 1},
+			},
+			"corrections": {
+				params: search.Params{Query: "mountians", Sort: search.SortRecent, Limit: 10,
+					Corrections: map[string]string{"mountians": "mountains"}},
+				ids:    ids{pins: []int{5}},
+				counts: models.SearchCounts{Pins: 1},
+			},
+			"nothing": {
+				params: search.Params{Query: "giraffe", Sort: search.SortRelevance, Limit: 10},
+				counts: models.SearchCounts{},
+			},
+		}
+
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				res, err := rep.Get(test.params, test.offset)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got := resIds(res); !reflect.DeepEqual(got, test.ids) {
+					t.Errorf("\nExpected: %+v\nGot: %+v", test.ids, got)
+				}
+				if res.Counts != test.counts {
+					t.Errorf("\nExpected counts: %+v\nGot counts: %+v", test.counts, res.Counts)
+				}
+			})
+		}
+	})
+
+	t.Run("Results", func(t *testing.T) {
+		res, err := rep.Get(search.Params{Query: "Рыжий", Sort: search.SortRelevance, Limit: 10}, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if expected := []models.Pin{fixtures.Pins[0].Pin}; !reflect.DeepEqual(res.Pins, expected) {
+			t.Errorf("\nExpected: %+v\nGot: %+v", expected, res.Pins)
+		}
+
+		res, err = rep.Get(search.Params{UserId: 2, Query: "dogs", Sort: search.SortRelevance, Limit: 10}, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if expected := []models.Board{fixtures.Boards[2]}; !reflect.DeepEqual(res.Boards, expected) {
+			t.Errorf("\nExpected: %+v\nGot: %+v", expected, res.Boards)
+		}
+
+		res, err = rep.Get(search.Params{Query: "Иван", Sort: search.SortRelevance, Limit: 10}, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if expected := []models.Profile{fixtures.Users[2]}; !reflect.DeepEqual(res.Users, expected) {
+			t.Errorf("\nExpected: %+v\nGot: %+v", expected, res.Users)
+		}
+	})
+
+	t.Run("Similar", func(t *testing.T) {
+		type testCase struct {
+			params search.SimilarParams
+			offset int
+			pins   []int
+			count  int
+			err    error
+		}
+
+		tests := map[string]testCase{
+			"close hashes, then close colors": {
+				params: search.SimilarParams{PinId: 1, Limit: 10},
+				pins:   []int{2, 6, 4},
+				count:  3,
+			},
+			"pins of secret boards for their owners": {
+				params: search.SimilarParams{UserId: 1, PinId: 1, Limit: 10},
+				pins:   []int{2, 6, 3, 4},
+				count:  4,
+			},
+			"last page": {
+				params: search.SimilarParams{UserId: 1, PinId: 1, Limit: 2},
+				offset: 2,
+				pins:   []int{3, 4},
+				count:  4,
+			},
+			"nothing similar": {
+				params: search.SimilarParams{PinId: 5, Limit: 10},
+			},
+			"pin of a secret board": {
+				params: search.SimilarParams{PinId: 3, Limit: 10},
+				err:    pkgErrors.ErrPinNotFound,
+			},
+			"no pin": {
+				params: search.SimilarParams{PinId: 42, Limit: 10},
+				err:    pkgErrors.ErrPinNotFound,
+			},
+		}
+
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				res, err := rep.Similar(test.params, test.offset)
+				if errors.Cause(err) != test.err {
+					t.Fatalf("\nExpected: %v\nGot: %v", test.err, err)
+				}
+				if got := resIds(res).pins; !reflect.DeepEqual(got, test.pins) {
+					t.Errorf("\nExpected: %v\nGot: %v", test.pins, got)
+				}
+				if res.Counts.Pins != test.count {
+					t.Errorf("\nExpected count: %d\nGot count: %d", test.count, res.Counts.Pins)
+				}
+			})
+		}
+	})
+
+	t.Run("Correct", func(t *testing.T) {
+		corrections, err := rep.Correct([]string{"mountians", "travel", "secrte", "пушыстые"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := map[string]string{"mountians": "mountains", "пушыстые": "пушистые"}
+		if !reflect.DeepEqual(corrections, expected) {
+			t.Errorf("\nExpected: %v\nGot: %v", expected, corrections)
+		}
+
+		params := search.Params{Query: "Mountians travel", Sort: search.SortRecent, Limit: 10}
+		didYouMean, err := search.Correct(rep, &params)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if didYouMean != "mountains travel" {
+			t.Errorf("\nExpected: %q\nGot: %q", "mountains travel", didYouMean)
+		}
+		res, err := rep.Get(params, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := resIds(res).pins; !reflect.DeepEqual(got, []int{5}) {
+			t.Errorf("\nExpected: %v\nGot: %v", []int{5}, got)
+		}
+	})
+
+	t.Run("Suggest", func(t *testing.T) {
+		type testCase struct {
+			prefix      string
+			suggestions models.SearchSuggestions
+		}
+
+		tests := map[string]testCase{
+			"usernames and tags": {
+				prefix:      "ca",
+				suggestions: models.SearchSuggestions{Users: []string{"carol"}, Boards: []string{}, Tags: []string{"cats"}},
+			},
+			"public boards": {
+				prefix:      "D",
+				suggestions: models.SearchSuggestions{Users: []string{}, Boards: []string{"Dogs"}, Tags: []string{"dogs"}},
+			},
+			"tags of pins on public boards": {
+				prefix:      "t",
+				suggestions: models.SearchSuggestions{Users: []string{}, Boards: []string{"Travel"}, Tags: []string{"travel"}},
+			},
+			"secret boards": {
+				prefix:      "secret",
+				suggestions: models.SearchSuggestions{Users: []string{}, Boards: []string{}, Tags: []string{}},
+			},
+		}
+
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				suggestions, err := rep.Suggest(test.prefix, 5)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(suggestions, test.suggestions) {
+					t.Errorf("\nExpected: %+v\nGot: %+v", test.suggestions, suggestions)
+				}
+			})
+		}
+	})
+}
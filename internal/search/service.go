@@ -3,5 +3,13 @@ package search
 import "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 
 type Service interface {
-	Get(userId int, query string) (models.SearchRes, error)
+	Get(userId int, params Params) (models.SearchRes, error)
+	// Similar returns the pins that look like params.PinId, only Pins, Counts.Pins and Cursors.Pins are filled.
+	Similar(userId int, params SimilarParams) (models.SearchRes, error)
+	Suggest(prefix string) (models.SearchSuggestions, error)
+	// Trending returns the queries searched most within the window, one of the configured durations
+	// or empty for the default one.
+	Trending(window string) ([]models.TrendingQuery, error)
+	Recent(userId int) ([]string, error)
+	ClearRecent(userId int) error
 }
@@ -1,25 +1,86 @@
 package service
 
 import (
+	"go.uber.org/zap"
+
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
 	pkgPins "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pins"
 	pkgSearch "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
 )
 
 type service struct {
-	rep     pkgSearch.Repository
-	pinServ pkgPins.Service
+	rep       pkgSearch.Repository
+	suggester *pkgSearch.Suggester
+	history   *pkgSearch.History
+	pinServ   pkgPins.Service
+	log       *zap.Logger
 }
 
-func NewService(rep pkgSearch.Repository, serv pkgPins.Service) pkgSearch.Service {
-	return &service{rep, serv}
+func NewService(rep pkgSearch.Repository, suggester *pkgSearch.Suggester, history *pkgSearch.History,
+	serv pkgPins.Service, log *zap.Logger) pkgSearch.Service {
+	return &service{
+		rep:       rep,
+		suggester: suggester,
+		history:   history,
+		pinServ:   serv,
+		log:       log,
+	}
+}
+
+func (serv service) Get(userId int, params pkgSearch.Params) (models.SearchRes, error) {
+	params.UserId = userId
+	err := pkgSearch.CheckParams(&params)
+	if err != nil {
+		return models.SearchRes{}, err
+	}
+	offset, err := pkgSearch.Offset(params)
+	if err != nil {
+		return models.SearchRes{}, err
+	}
+
+	didYouMean, err := pkgSearch.Correct(serv.rep, &params)
+	if err != nil {
+		return models.SearchRes{}, err
+	}
+
+	res, err := serv.rep.Get(params, offset)
+	if err != nil {
+		return res, err
+	}
+	pkgSearch.SetCursors(&res, params, offset)
+	res.DidYouMean = didYouMean
+
+	if err = serv.history.Log(params, &res); err != nil {
+		serv.log.Error("Failed to log search query", zap.Error(err))
+	}
+
+	for i := range res.Pins {
+		err := serv.pinServ.SetLikedField(&res.Pins[i], userId)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	err = serv.pinServ.SignPrivateMedia(res.Pins, userId)
+	return res, err
 }
 
-func (serv service) Get(userId int, query string) (models.SearchRes, error) {
-	res, err := serv.rep.Get(query)
+func (serv service) Similar(userId int, params pkgSearch.SimilarParams) (models.SearchRes, error) {
+	params.UserId = userId
+	err := pkgSearch.CheckSimilarParams(&params)
+	if err != nil {
+		return models.SearchRes{}, err
+	}
+	offset, err := pkgSearch.SimilarOffset(params)
+	if err != nil {
+		return models.SearchRes{}, err
+	}
+
+	res, err := serv.rep.Similar(params, offset)
 	if err != nil {
 		return res, err
 	}
+	pkgSearch.SetSimilarCursor(&res, params, offset)
 
 	for i := range res.Pins {
 		err := serv.pinServ.SetLikedField(&res.Pins[i], userId)
@@ -28,5 +89,22 @@ func (serv service) Get(userId int, query string) (models.SearchRes, error) {
 		}
 	}
 
-	return res, nil
+	err = serv.pinServ.SignPrivateMedia(res.Pins, userId)
+	return res, err
+}
+
+func (serv service) Suggest(prefix string) (models.SearchSuggestions, error) {
+	return serv.suggester.Suggest(prefix)
+}
+
+func (serv service) Trending(window string) ([]models.TrendingQuery, error) {
+	return serv.history.Trending(window)
+}
+
+func (serv service) Recent(userId int) ([]string, error) {
+	return serv.history.Recent(userId)
+}
+
+func (serv service) ClearRecent(userId int) error {
+	return serv.history.ClearRecent(userId)
 }
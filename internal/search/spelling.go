@@ -0,0 +1,188 @@
+package search
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MinWordSimilarity is the least trigram similarity of a word and its spelling correction,
+	// the default pg_trgm.similarity_threshold.
+	MinWordSimilarity = 0.3
+	// MinCorrectedLen is the least number of letters of a word to be corrected or transliterated,
+	// shorter words are similar to too many others.
+	MinCorrectedLen = 3
+)
+
+// synonyms are matched in both directions, the groups are of words of the same language,
+// the other language is matched by transliteration.
+var synonyms = map[string][]string{}
+
+func init() {
+	for _, group := range [][]string{
+		{"кот", "кошка", "котик"},
+		{"собака", "пес", "щенок"},
+		{"фото", "фотография", "снимок"},
+		{"картинка", "изображение", "рисунок"},
+		{"машина", "автомобиль", "авто"},
+		{"путешествие", "поездка"},
+		{"еда", "блюдо"},
+		{"cat", "kitten", "kitty"},
+		{"dog", "puppy"},
+		{"photo", "picture", "image"},
+		{"car", "auto", "automobile"},
+		{"travel", "trip", "journey"},
+		{"food", "meal", "dish"},
+	} {
+		for _, word := range group {
+			for _, synonym := range group {
+				if synonym != word {
+					synonyms[word] = append(synonyms[word], synonym)
+				}
+			}
+		}
+	}
+}
+
+var cyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ж': "zh", 'з': "z", 'и': "i", 'й': "y",
+	'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e",
+	'ю': "yu", 'я': "ya",
+}
+
+// latinToCyrillic is tried from the longest spellings, y is handled apart from it.
+var latinToCyrillic = []struct {
+	latin    string
+	cyrillic string
+}{
+	{"shch", "щ"},
+	{"zh", "ж"}, {"kh", "х"}, {"ts", "ц"}, {"ch", "ч"}, {"sh", "ш"}, {"yu", "ю"}, {"ya", "я"}, {"yo", "е"},
+	{"ph", "ф"},
+	{"a", "а"}, {"b", "б"}, {"c", "к"}, {"d", "д"}, {"e", "е"}, {"f", "ф"}, {"g", "г"}, {"h", "х"}, {"i", "и"},
+	{"j", "дж"}, {"k", "к"}, {"l", "л"}, {"m", "м"}, {"n", "н"}, {"o", "о"}, {"p", "п"}, {"q", "к"}, {"r", "р"},
+	{"s", "с"}, {"t", "т"}, {"u", "у"}, {"v", "в"}, {"w", "в"}, {"x", "кс"}, {"z", "з"},
+}
+
+func isVowel(r rune) bool {
+	return strings.ContainsRune("aeiouаеиоуыэюя", r)
+}
+
+// Transliterate spells a lowercased word of Cyrillic letters with Latin ones and the other way round,
+// the way Russian words are commonly typed with the other keyboard layout missing. Words of both scripts
+// or of none are not transliterated, an empty string is returned for them.
+func Transliterate(word string) string {
+	var cyrillic, latin bool
+	for _, r := range word {
+		cyrillic = cyrillic || unicode.Is(unicode.Cyrillic, r)
+		latin = latin || unicode.Is(unicode.Latin, r)
+	}
+	if cyrillic == latin {
+		return ""
+	}
+
+	var b strings.Builder
+	if cyrillic {
+		for _, r := range word {
+			if latin, ok := cyrillicToLatin[r]; ok {
+				b.WriteString(latin)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+		return b.String()
+	}
+
+	// y is й after vowels and at the start of words, and ы after consonants
+	prev := rune(0)
+	for rest := word; rest != ""; {
+		matched := false
+		for _, l := range latinToCyrillic {
+			if strings.HasPrefix(rest, l.latin) {
+				b.WriteString(l.cyrillic)
+				rest = rest[len(l.latin):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			r, size := utf8.DecodeRuneInString(rest)
+			switch {
+			case r != 'y':
+				b.WriteRune(r)
+			case prev == 0 || isVowel(prev):
+				b.WriteRune('й')
+			default:
+				b.WriteRune('ы')
+			}
+			rest = rest[size:]
+		}
+		prev, _ = utf8.DecodeLastRuneInString(b.String())
+	}
+	return b.String()
+}
+
+// WordTrigrams returns the distinct trigrams of a word the way pg_trgm makes them,
+// with two spaces before the word and one after it.
+func WordTrigrams(word string) []string {
+	runes := []rune("  " + word + " ")
+	seen := make(map[string]struct{})
+	var res []string
+	for i := 0; i+3 <= len(runes); i++ {
+		gram := string(runes[i : i+3])
+		if _, ok := seen[gram]; !ok {
+			seen[gram] = struct{}{}
+			res = append(res, gram)
+		}
+	}
+	return res
+}
+
+// WordSimilarity is the pg_trgm similarity of words: the number of their shared trigrams divided
+// by the number of all their trigrams.
+func WordSimilarity(a, b string) float64 {
+	gramsA, gramsB := WordTrigrams(a), WordTrigrams(b)
+	inA := make(map[string]struct{}, len(gramsA))
+	for _, gram := range gramsA {
+		inA[gram] = struct{}{}
+	}
+	shared := 0
+	for _, gram := range gramsB {
+		if _, ok := inA[gram]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(gramsA)+len(gramsB)-shared)
+}
+
+var wordRegexp = regexp.MustCompile(`[\p{L}\p{Nd}]+`)
+
+// Correct looks up the spelling corrections of the required words of params.Query and sets them
+// to params.Corrections, the words are then matched by their corrections as well. It returns the query
+// with the words corrected to be suggested instead of it, or an empty string if no word was corrected.
+func Correct(rep Repository, params *Params) (string, error) {
+	var words []string
+	for _, word := range ParseQuery(params.Query, nil).Required() {
+		if utf8.RuneCountInString(word) >= MinCorrectedLen {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 {
+		return "", nil
+	}
+
+	corrections, err := rep.Correct(words)
+	if err != nil || len(corrections) == 0 {
+		return "", err
+	}
+	params.Corrections = corrections
+
+	return wordRegexp.ReplaceAllStringFunc(params.Query, func(word string) string {
+		if correction, ok := corrections[strings.ReplaceAll(strings.ToLower(word), "ё", "е")]; ok {
+			return correction
+		}
+		return word
+	}), nil
+}
@@ -0,0 +1,99 @@
+package search
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := map[string]string{
+		// cyrillic to latin
+		"кот":    "kot",
+		"щука":   "shchuka",
+		"борщ":   "borshch",
+		"жизнь":  "zhizn",
+		"хлеб":   "khleb",
+		"цирк":   "tsirk",
+		"юла":    "yula",
+		"яблоко": "yabloko",
+		"мышь":   "mysh",
+		"кот42":  "kot42",
+		// latin to cyrillic, the longest spellings first
+		"shchuka": "щука",
+		"borshch": "борщ",
+		"zhizn":   "жизн",
+		"khleb":   "хлеб",
+		"tsirk":   "цирк",
+		"photo":   "фото",
+		"jam":     "джам",
+		"xerox":   "ксерокс",
+		"yula":    "юла",
+		"yabloko": "яблоко",
+		"yolka":   "елка",
+		// y is й at the start and after vowels, ы after consonants
+		"yes":    "йес",
+		"moy":    "мой",
+		"syr":    "сыр",
+		"myshka": "мышка",
+		"krayy":  "крайы",
+		// words of both scripts or of none
+		"котcat": "",
+		"catкот": "",
+		"42":     "",
+		"":       "",
+	}
+
+	for word, expected := range tests {
+		if res := Transliterate(word); res != expected {
+			t.Errorf("%q\nExpected: %q\nGot: %q", word, expected, res)
+		}
+	}
+}
+
+func TestSynonyms(t *testing.T) {
+	if !reflect.DeepEqual(synonyms["кот"], []string{"кошка", "котик"}) {
+		t.Errorf("\nExpected: %v\nGot: %v", []string{"кошка", "котик"}, synonyms["кот"])
+	}
+
+	// synonyms are matched in both directions and a word is not a synonym of itself
+	for word, group := range synonyms {
+		for _, synonym := range group {
+			if synonym == word {
+				t.Errorf("%q is a synonym of itself", word)
+			}
+			found := false
+			for _, back := range synonyms[synonym] {
+				found = found || back == word
+			}
+			if !found {
+				t.Errorf("%q is a synonym of %q but not the other way round", synonym, word)
+			}
+		}
+	}
+}
+
+func TestWordSimilarity(t *testing.T) {
+	type testCase struct {
+		a, b       string
+		similarity float64
+	}
+
+	tests := map[string]testCase{
+		"same":      {a: "cat", b: "cat", similarity: 1},
+		"none":      {a: "cat", b: "dog", similarity: 0},
+		"misspelt":  {a: "kitten", b: "kiten", similarity: 5.0 / 8},
+		"substring": {a: "cat", b: "cats", similarity: 3.0 / 6},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if res := WordSimilarity(test.a, test.b); math.Abs(res-test.similarity) > 1e-9 {
+				t.Errorf("\nExpected: %v\nGot: %v", test.similarity, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package search
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/config"
+)
+
+type SuggestParams struct {
+	Limit         int // suggestions of each kind
+	MinQueryCount int // searches of a past query before it is suggested
+	CacheSize     int
+	CacheTTL      time.Duration
+}
+
+func SuggestParamsFromConfig() SuggestParams {
+	return SuggestParams{
+		Limit:         viper.GetInt(config.SearchSuggestConfig.Limit),
+		MinQueryCount: viper.GetInt(config.SearchSuggestConfig.MinQueryCount),
+		CacheSize:     viper.GetInt(config.SearchSuggestConfig.CacheSize),
+		CacheTTL:      viper.GetDuration(config.SearchSuggestConfig.CacheTTL),
+	}
+}
+
+// NormalizeQuery lowercases the query and collapses its spaces, so that queries differing
+// only in case and spacing are counted and suggested as one.
+func NormalizeQuery(query string) string {
+	return strings.ToLower(strings.Join(strings.Fields(query), " "))
+}
+
+// Suggester completes prefixes from the search repository and the popular past queries.
+// Suggestions are cached for a while by prefix, since the same prefixes are typed by many users.
+type Suggester struct {
+	rep     Repository
+	queries QueriesRepository
+	params  SuggestParams
+	cache   *suggestionsCache
+}
+
+func NewSuggester(rep Repository, queries QueriesRepository, params SuggestParams) *Suggester {
+	return &Suggester{
+		rep:     rep,
+		queries: queries,
+		params:  params,
+		cache:   newSuggestionsCache(params.CacheSize, params.CacheTTL),
+	}
+}
+
+func (s *Suggester) Suggest(prefix string) (models.SearchSuggestions, error) {
+	prefix = NormalizeQuery(prefix)
+	if prefix == "" {
+		return models.SearchSuggestions{}, nil
+	}
+	if res, ok := s.cache.get(prefix); ok {
+		return res, nil
+	}
+
+	res, err := s.rep.Suggest(strings.TrimPrefix(prefix, "#"), s.params.Limit)
+	if err != nil {
+		return models.SearchSuggestions{}, err
+	}
+	res.Queries, err = s.queries.Popular(prefix, s.params.MinQueryCount, s.params.Limit)
+	if err != nil {
+		return models.SearchSuggestions{}, err
+	}
+
+	s.cache.set(prefix, res)
+	return res, nil
+}
+
+type cachedSuggestions struct {
+	suggestions models.SearchSuggestions
+	expires     time.Time
+}
+
+// suggestionsCache holds up to size entries. When it is full, the expired entries are dropped,
+// and if none has expired, an arbitrary one.
+type suggestionsCache struct {
+	entries map[string]cachedSuggestions
+	size    int
+	ttl     time.Duration
+	mu      sync.Mutex
+}
+
+func newSuggestionsCache(size int, ttl time.Duration) *suggestionsCache {
+	return &suggestionsCache{
+		entries: make(map[string]cachedSuggestions),
+		size:    size,
+		ttl:     ttl,
+	}
+}
+
+func (c *suggestionsCache) get(prefix string) (models.SearchSuggestions, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[prefix]
+	if !ok || time.Now().After(entry.expires) {
+		return models.SearchSuggestions{}, false
+	}
+	return entry.suggestions, true
+}
+
+func (c *suggestionsCache) set(prefix string, suggestions models.SearchSuggestions) {
+	if c.size <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if _, ok := c.entries[prefix]; !ok && len(c.entries) >= c.size {
+		for key, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, key)
+			}
+		}
+		for key := range c.entries {
+			if len(c.entries) < c.size {
+				break
+			}
+			delete(c.entries, key)
+		}
+	}
+	c.entries[prefix] = cachedSuggestions{suggestions: suggestions, expires: now.Add(c.ttl)}
+}
@@ -0,0 +1,170 @@
+package search_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/models"
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/search/mocks"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	tests := map[string]string{
+		"Cats":              "cats",
+		"  Black   CATS \t": "black cats",
+		"#Котики":           "#котики",
+		"":                  "",
+		"   ":               "",
+	}
+
+	for query, expected := range tests {
+		if normalized := search.NormalizeQuery(query); normalized != expected {
+			t.Errorf("%q\nExpected: %q\nGot: %q", query, expected, normalized)
+		}
+	}
+}
+
+func TestSuggester(t *testing.T) {
+	type fields struct {
+		rep     *mocks.MockRepository
+		queries *mocks.MockQueriesRepository
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		params  search.SuggestParams
+		// prefixes suggested one after another with the last suggestions
+		prefixes []string
+		res      models.SearchSuggestions
+		err      error
+	}
+
+	params := search.SuggestParams{Limit: 5, MinQueryCount: 3, CacheSize: 10, CacheTTL: time.Hour}
+	cats := models.SearchSuggestions{Users: []string{"catlover"}, Boards: []string{"Cats"}, Tags: []string{"cats"}}
+	catsQueries := cats
+	catsQueries.Queries = []string{"cats in hats"}
+	dogs := models.SearchSuggestions{Tags: []string{"dogs"}}
+
+	tests := map[string]testCase{
+		"normalized prefix": {
+			prepare: func(f *fields) {
+				f.rep.EXPECT().Suggest("black ca", 5).Return(cats, nil)
+				f.queries.EXPECT().Popular("black ca", 3, 5).Return([]string{"cats in hats"}, nil)
+			},
+			params:   params,
+			prefixes: []string{"  Black  CA "},
+			res:      catsQueries,
+		},
+		"hashtag": {
+			prepare: func(f *fields) {
+				f.rep.EXPECT().Suggest("ca", 5).Return(cats, nil)
+				f.queries.EXPECT().Popular("#ca", 3, 5).Return([]string{"cats in hats"}, nil)
+			},
+			params:   params,
+			prefixes: []string{"#CA"},
+			res:      catsQueries,
+		},
+		"empty prefix": {
+			params:   params,
+			prefixes: []string{"   "},
+			res:      models.SearchSuggestions{},
+		},
+		"cached": {
+			prepare: func(f *fields) {
+				f.rep.EXPECT().Suggest("ca", 5).Return(cats, nil)
+				f.queries.EXPECT().Popular("ca", 3, 5).Return([]string{"cats in hats"}, nil)
+			},
+			params:   params,
+			prefixes: []string{"ca", "CA", " ca"},
+			res:      catsQueries,
+		},
+		"expired": {
+			prepare: func(f *fields) {
+				f.rep.EXPECT().Suggest("ca", 5).Return(cats, nil).Times(2)
+				f.queries.EXPECT().Popular("ca", 3, 5).Return([]string{"cats in hats"}, nil).Times(2)
+			},
+			params:   search.SuggestParams{Limit: 5, MinQueryCount: 3, CacheSize: 10, CacheTTL: -time.Second},
+			prefixes: []string{"ca", "ca"},
+			res:      catsQueries,
+		},
+		"cache disabled": {
+			prepare: func(f *fields) {
+				f.rep.EXPECT().Suggest("ca", 5).Return(cats, nil).Times(2)
+				f.queries.EXPECT().Popular("ca", 3, 5).Return([]string{"cats in hats"}, nil).Times(2)
+			},
+			params:   search.SuggestParams{Limit: 5, MinQueryCount: 3, CacheTTL: time.Hour},
+			prefixes: []string{"ca", "ca"},
+			res:      catsQueries,
+		},
+		"full cache": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.rep.EXPECT().Suggest("ca", 5).Return(cats, nil),
+					f.rep.EXPECT().Suggest("do", 5).Return(dogs, nil),
+					f.rep.EXPECT().Suggest("ca", 5).Return(cats, nil),
+				)
+				f.queries.EXPECT().Popular(gomock.Any(), 3, 5).Return([]string{}, nil).Times(3)
+			},
+			params:   search.SuggestParams{Limit: 5, MinQueryCount: 3, CacheSize: 1, CacheTTL: time.Hour},
+			prefixes: []string{"ca", "do", "ca"},
+			res: models.SearchSuggestions{Users: cats.Users, Boards: cats.Boards, Tags: cats.Tags,
+				Queries: []string{}},
+		},
+		"errors are not cached": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.rep.EXPECT().Suggest("ca", 5).Return(models.SearchSuggestions{}, pkgErrors.ErrDb),
+					f.rep.EXPECT().Suggest("ca", 5).Return(cats, nil),
+				)
+				f.queries.EXPECT().Popular("ca", 3, 5).Return([]string{"cats in hats"}, nil)
+			},
+			params:   params,
+			prefixes: []string{"ca", "ca"},
+			res:      catsQueries,
+		},
+		"error": {
+			prepare: func(f *fields) {
+				f.rep.EXPECT().Suggest("ca", 5).Return(cats, nil)
+				f.queries.EXPECT().Popular("ca", 3, 5).Return(nil, pkgErrors.ErrDb)
+			},
+			params:   params,
+			prefixes: []string{"ca"},
+			res:      models.SearchSuggestions{},
+			err:      pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{rep: mocks.NewMockRepository(ctrl), queries: mocks.NewMockQueriesRepository(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			s := search.NewSuggester(f.rep, f.queries, test.params)
+			var res models.SearchSuggestions
+			var err error
+			for _, prefix := range test.prefixes {
+				res, err = s.Suggest(prefix)
+			}
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(res, test.res) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.res, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package shortener
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+// User agent classes
+const (
+	UserAgentBot     = "bot"
+	UserAgentMobile  = "mobile"
+	UserAgentTablet  = "tablet"
+	UserAgentDesktop = "desktop"
+	UserAgentOther   = "other"
+)
+
+// Substrings of lowercased user agents, bots are recognized first since they often pretend to be browsers.
+var (
+	botAgents     = []string{"bot", "crawl", "spider", "slurp", "preview", "facebookexternalhit", "curl", "wget", "python"}
+	tabletAgents  = []string{"ipad", "tablet", "kindle", "silk"}
+	mobileAgents  = []string{"mobi", "iphone", "ipod", "android", "opera mini"}
+	desktopAgents = []string{"windows", "macintosh", "x11", "linux", "cros"}
+)
+
+func containsAny(s string, substrs []string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// UserAgentClass returns the class of the user agent. Android devices without "mobile"
+// in their user agents are tablets.
+func UserAgentClass(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case containsAny(ua, botAgents):
+		return UserAgentBot
+	case containsAny(ua, tabletAgents) || strings.Contains(ua, "android") && !strings.Contains(ua, "mobile"):
+		return UserAgentTablet
+	case containsAny(ua, mobileAgents):
+		return UserAgentMobile
+	case containsAny(ua, desktopAgents):
+		return UserAgentDesktop
+	}
+	return UserAgentOther
+}
+
+// ReferrerHost returns the lowercased host of the referring page without www, or an empty string
+// for direct visits and invalid referrers. Paths and queries of referrers are not kept.
+func ReferrerHost(referrer string) string {
+	u, err := url.Parse(referrer)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+func NewClick(t time.Time, referrer string, userAgent string) Click {
+	return Click{
+		Time:      t.UTC(),
+		Referrer:  ReferrerHost(referrer),
+		UserAgent: UserAgentClass(userAgent),
+	}
+}
+
+// CheckInterval validates the interval, the clicks are counted by days if it is empty.
+func CheckInterval(interval *string) error {
+	switch *interval {
+	case "":
+		*interval = IntervalDay
+	case IntervalHour, IntervalDay:
+	default:
+		return pkgErrors.ErrInvalidIntervalParam
+	}
+	return nil
+}
@@ -32,8 +32,8 @@ func (c *client) Get(hash string) (string, error) {
 	return res, nil
 }
 
-func (c *client) Create(url string) (string, error) {
-	resp, err := c.shortenerClient.Create(context.Background(), models.NewProtoStringMessage(url))
+func (c *client) Visit(hash string, click shortener.Click) (string, error) {
+	resp, err := c.shortenerClient.Visit(context.Background(), models.NewProtoClick(hash, &click))
 	if err != nil {
 		return "", errors.RestoreHTTPError(errors.GRPCUnwrapper(err))
 	}
@@ -42,9 +42,31 @@ func (c *client) Create(url string) (string, error) {
 	return res, nil
 }
 
-func (c *client) CreatePinLink(id int) (string, error) {
+func (c *client) Create(url string, userId int, opts shortener.LinkOptions) (string, error) {
+	resp, err := c.shortenerClient.Create(context.Background(), models.NewProtoNewLink(url, userId, &opts))
+	if err != nil {
+		return "", errors.RestoreHTTPError(errors.GRPCUnwrapper(err))
+	}
+
+	res := models.NewStringMessage(resp)
+	return res, nil
+}
+
+func (c *client) CreatePinLink(id int, userId int, opts shortener.LinkOptions) (string, error) {
 	if os.Getenv("SHORT_HOST") == "localhost:8091" {
-		return c.Create(fmt.Sprintf("http://localhost/pin/%d", id))
+		return c.Create(fmt.Sprintf("http://localhost/pin/%d", id), userId, opts)
+	}
+	return c.Create(fmt.Sprintf("https://pickpin.ru/pin/%d", id), userId, opts)
+}
+
+func (c *client) Stats(hash string, userId int, interval string) (shortener.Stats, error) {
+	resp, err := c.shortenerClient.Stats(context.Background(), &proto.StatsQuery{
+		Link:     hash,
+		UserId:   int64(userId),
+		Interval: interval,
+	})
+	if err != nil {
+		return shortener.Stats{}, errors.RestoreHTTPError(errors.GRPCUnwrapper(err))
 	}
-	return c.Create(fmt.Sprintf("https://pickpin.ru/pin/%d", id))
+	return models.NewLinkStats(resp), nil
 }
@@ -1,6 +1,11 @@
 package models
 
-import shortener "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/shortener/delivery/grpc/proto"
+import (
+	"time"
+
+	pkgShortener "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/shortener"
+	shortener "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/shortener/delivery/grpc/proto"
+)
 
 func NewProtoStringMessage(msg string) *shortener.StringMessage {
 	return &shortener.StringMessage{
@@ -11,3 +16,108 @@ func NewProtoStringMessage(msg string) *shortener.StringMessage {
 func NewStringMessage(msg *shortener.StringMessage) string {
 	return msg.GetURL()
 }
+
+// unixTime converts t to unix seconds, the zero time to 0.
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// fromUnixTime converts unix seconds to the time, 0 to the zero time.
+func fromUnixTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0).UTC()
+}
+
+func NewProtoNewLink(url string, userId int, opts *pkgShortener.LinkOptions) *shortener.NewLink {
+	return &shortener.NewLink{
+		URL:       url,
+		UserId:    int64(userId),
+		Alias:     opts.Alias,
+		ExpiresAt: unixTime(opts.ExpiresAt),
+		MaxClicks: int64(opts.MaxClicks),
+	}
+}
+
+func NewLinkOptions(link *shortener.NewLink) pkgShortener.LinkOptions {
+	return pkgShortener.LinkOptions{
+		Alias:     link.GetAlias(),
+		ExpiresAt: fromUnixTime(link.GetExpiresAt()),
+		MaxClicks: int(link.GetMaxClicks()),
+	}
+}
+
+func NewProtoClick(hash string, click *pkgShortener.Click) *shortener.Click {
+	return &shortener.Click{
+		Link:      hash,
+		Time:      click.Time.Unix(),
+		Referrer:  click.Referrer,
+		UserAgent: click.UserAgent,
+	}
+}
+
+func NewClick(c *shortener.Click) (string, pkgShortener.Click) {
+	return c.GetLink(), pkgShortener.Click{
+		Time:      time.Unix(c.GetTime(), 0).UTC(),
+		Referrer:  c.GetReferrer(),
+		UserAgent: c.GetUserAgent(),
+	}
+}
+
+func newProtoKeyClicks(counts []pkgShortener.KeyClicks) []*shortener.KeyClicks {
+	res := make([]*shortener.KeyClicks, 0, len(counts))
+	for _, c := range counts {
+		res = append(res, &shortener.KeyClicks{Key: c.Key, Clicks: int64(c.Clicks)})
+	}
+	return res
+}
+
+func newKeyClicks(counts []*shortener.KeyClicks) []pkgShortener.KeyClicks {
+	res := make([]pkgShortener.KeyClicks, 0, len(counts))
+	for _, c := range counts {
+		res = append(res, pkgShortener.KeyClicks{Key: c.GetKey(), Clicks: int(c.GetClicks())})
+	}
+	return res
+}
+
+func NewProtoLinkStats(stats *pkgShortener.Stats) *shortener.LinkStats {
+	overTime := make([]*shortener.TimeClicks, 0, len(stats.OverTime))
+	for _, c := range stats.OverTime {
+		overTime = append(overTime, &shortener.TimeClicks{Time: c.Time.Unix(), Clicks: int64(c.Clicks)})
+	}
+	return &shortener.LinkStats{
+		URL:        stats.Link.OriginalURL,
+		ShortCode:  stats.Link.ShortCode,
+		CreatorId:  int64(stats.Link.CreatorId),
+		ExpiresAt:  unixTime(stats.Link.ExpiresAt),
+		MaxClicks:  int64(stats.Link.MaxClicks),
+		Clicks:     int64(stats.Clicks),
+		OverTime:   overTime,
+		Referrers:  newProtoKeyClicks(stats.Referrers),
+		UserAgents: newProtoKeyClicks(stats.UserAgents),
+	}
+}
+
+func NewLinkStats(stats *shortener.LinkStats) pkgShortener.Stats {
+	overTime := make([]pkgShortener.TimeClicks, 0, len(stats.GetOverTime()))
+	for _, c := range stats.GetOverTime() {
+		overTime = append(overTime, pkgShortener.TimeClicks{Time: time.Unix(c.GetTime(), 0).UTC(), Clicks: int(c.GetClicks())})
+	}
+	return pkgShortener.Stats{
+		Link: pkgShortener.Link{
+			OriginalURL: stats.GetURL(),
+			ShortCode:   stats.GetShortCode(),
+			CreatorId:   int(stats.GetCreatorId()),
+			ExpiresAt:   fromUnixTime(stats.GetExpiresAt()),
+			MaxClicks:   int(stats.GetMaxClicks()),
+		},
+		Clicks:     int(stats.GetClicks()),
+		OverTime:   overTime,
+		Referrers:  newKeyClicks(stats.GetReferrers()),
+		UserAgents: newKeyClicks(stats.GetUserAgents()),
+	}
+}
@@ -21,6 +21,8 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	Shortener_Get_FullMethodName    = "/shortener.Shortener/Get"
 	Shortener_Create_FullMethodName = "/shortener.Shortener/Create"
+	Shortener_Visit_FullMethodName  = "/shortener.Shortener/Visit"
+	Shortener_Stats_FullMethodName  = "/shortener.Shortener/Stats"
 )
 
 // ShortenerClient is the client API for Shortener service.
@@ -28,7 +30,9 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ShortenerClient interface {
 	Get(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*StringMessage, error)
-	Create(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*StringMessage, error)
+	Create(ctx context.Context, in *NewLink, opts ...grpc.CallOption) (*StringMessage, error)
+	Visit(ctx context.Context, in *Click, opts ...grpc.CallOption) (*StringMessage, error)
+	Stats(ctx context.Context, in *StatsQuery, opts ...grpc.CallOption) (*LinkStats, error)
 }
 
 type shortenerClient struct {
@@ -48,7 +52,7 @@ func (c *shortenerClient) Get(ctx context.Context, in *StringMessage, opts ...gr
 	return out, nil
 }
 
-func (c *shortenerClient) Create(ctx context.Context, in *StringMessage, opts ...grpc.CallOption) (*StringMessage, error) {
+func (c *shortenerClient) Create(ctx context.Context, in *NewLink, opts ...grpc.CallOption) (*StringMessage, error) {
 	out := new(StringMessage)
 	err := c.cc.Invoke(ctx, Shortener_Create_FullMethodName, in, out, opts...)
 	if err != nil {
@@ -57,12 +61,32 @@ func (c *shortenerClient) Create(ctx context.Context, in *StringMessage, opts ..
 	return out, nil
 }
 
+func (c *shortenerClient) Visit(ctx context.Context, in *Click, opts ...grpc.CallOption) (*StringMessage, error) {
+	out := new(StringMessage)
+	err := c.cc.Invoke(ctx, Shortener_Visit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shortenerClient) Stats(ctx context.Context, in *StatsQuery, opts ...grpc.CallOption) (*LinkStats, error) {
+	out := new(LinkStats)
+	err := c.cc.Invoke(ctx, Shortener_Stats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ShortenerServer is the server API for Shortener service.
 // All implementations must embed UnimplementedShortenerServer
 // for forward compatibility
 type ShortenerServer interface {
 	Get(context.Context, *StringMessage) (*StringMessage, error)
-	Create(context.Context, *StringMessage) (*StringMessage, error)
+	Create(context.Context, *NewLink) (*StringMessage, error)
+	Visit(context.Context, *Click) (*StringMessage, error)
+	Stats(context.Context, *StatsQuery) (*LinkStats, error)
 	mustEmbedUnimplementedShortenerServer()
 }
 
@@ -73,9 +97,15 @@ type UnimplementedShortenerServer struct {
 func (UnimplementedShortenerServer) Get(context.Context, *StringMessage) (*StringMessage, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
-func (UnimplementedShortenerServer) Create(context.Context, *StringMessage) (*StringMessage, error) {
+func (UnimplementedShortenerServer) Create(context.Context, *NewLink) (*StringMessage, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
 }
+func (UnimplementedShortenerServer) Visit(context.Context, *Click) (*StringMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Visit not implemented")
+}
+func (UnimplementedShortenerServer) Stats(context.Context, *StatsQuery) (*LinkStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
 func (UnimplementedShortenerServer) mustEmbedUnimplementedShortenerServer() {}
 
 // UnsafeShortenerServer may be embedded to opt out of forward compatibility for this service.
@@ -108,7 +138,7 @@ func _Shortener_Get_Handler(srv interface{}, ctx context.Context, dec func(inter
 }
 
 func _Shortener_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StringMessage)
+	in := new(NewLink)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -120,7 +150,43 @@ func _Shortener_Create_Handler(srv interface{}, ctx context.Context, dec func(in
 		FullMethod: Shortener_Create_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShortenerServer).Create(ctx, req.(*StringMessage))
+		return srv.(ShortenerServer).Create(ctx, req.(*NewLink))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shortener_Visit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Click)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServer).Visit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shortener_Visit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServer).Visit(ctx, req.(*Click))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shortener_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShortenerServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Shortener_Stats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShortenerServer).Stats(ctx, req.(*StatsQuery))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -140,6 +206,14 @@ var Shortener_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Create",
 			Handler:    _Shortener_Create_Handler,
 		},
+		{
+			MethodName: "Visit",
+			Handler:    _Shortener_Visit_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _Shortener_Stats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "shortener.proto",
@@ -2,6 +2,9 @@ package server
 
 import (
 	"context"
+	"time"
+
+	"go.uber.org/zap"
 
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/shortener"
@@ -13,11 +16,13 @@ type server struct {
 	proto.UnimplementedShortenerServer
 
 	rep shortener.ShortenerRepository
+	log *zap.Logger
 }
 
-func NewShortenerServer(rep shortener.ShortenerRepository) proto.ShortenerServer {
+func NewShortenerServer(rep shortener.ShortenerRepository, log *zap.Logger) proto.ShortenerServer {
 	return &server{
 		rep: rep,
+		log: log,
 	}
 }
 
@@ -30,11 +35,56 @@ func (s *server) Get(ctx context.Context, url *proto.StringMessage) (*proto.Stri
 	return models.NewProtoStringMessage(res), err
 }
 
-func (s *server) Create(ctx context.Context, url *proto.StringMessage) (*proto.StringMessage, error) {
-	msg := models.NewStringMessage(url)
-	hash, err := s.rep.Create(msg)
+func (s *server) Create(ctx context.Context, link *proto.NewLink) (*proto.StringMessage, error) {
+	opts := models.NewLinkOptions(link)
+	if err := shortener.CheckLinkOptions(&opts, time.Now()); err != nil {
+		return &proto.StringMessage{}, errors.GRPCWrapper(err)
+	}
+
+	hash, err := s.rep.Create(link.GetURL(), int(link.GetUserId()), opts)
 	if err != nil {
 		return &proto.StringMessage{}, errors.GRPCWrapper(err)
 	}
 	return models.NewProtoStringMessage(hash), err
 }
+
+func (s *server) Visit(ctx context.Context, c *proto.Click) (*proto.StringMessage, error) {
+	hash, click := models.NewClick(c)
+	link, err := s.rep.GetLink(hash)
+	if err != nil {
+		return &proto.StringMessage{}, errors.GRPCWrapper(err)
+	}
+	if link.Expired(time.Now()) {
+		return &proto.StringMessage{}, errors.GRPCWrapper(errors.ErrLinkExpired)
+	}
+	if err = s.rep.AddVisit(link); err != nil {
+		return &proto.StringMessage{}, errors.GRPCWrapper(err)
+	}
+
+	// the hash may differ in case from the alias of the link, clicks are counted by its short code
+	if err = s.rep.AddClick(link.ShortCode, click); err != nil {
+		s.log.Error("Failed to record click", zap.String("ShortCode", link.ShortCode), zap.Error(err))
+	}
+	return models.NewProtoStringMessage(link.OriginalURL), nil
+}
+
+func (s *server) Stats(ctx context.Context, q *proto.StatsQuery) (*proto.LinkStats, error) {
+	interval := q.GetInterval()
+	if err := shortener.CheckInterval(&interval); err != nil {
+		return &proto.LinkStats{}, errors.GRPCWrapper(err)
+	}
+
+	link, err := s.rep.GetLink(q.GetLink())
+	if err != nil {
+		return &proto.LinkStats{}, errors.GRPCWrapper(err)
+	}
+	if link.CreatorId == 0 || link.CreatorId != int(q.GetUserId()) {
+		return &proto.LinkStats{}, errors.GRPCWrapper(errors.ErrForbidden)
+	}
+
+	stats, err := s.rep.Stats(link, interval)
+	if err != nil {
+		return &proto.LinkStats{}, errors.GRPCWrapper(err)
+	}
+	return models.NewProtoLinkStats(&stats), nil
+}
@@ -1,7 +1,83 @@
 package http
 
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/shortener"
+)
+
 //go:generate easyjson -all -snake_case api_models.go
 
 type url struct {
 	URL string `json:"url"`
 }
+
+// linkRequest creates a link to the URL, the URL is not set for links to pins.
+type linkRequest struct {
+	URL       string     `json:"url"`
+	Alias     string     `json:"alias"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	MaxClicks int        `json:"max_clicks"`
+}
+
+func (req *linkRequest) options() shortener.LinkOptions {
+	opts := shortener.LinkOptions{
+		Alias:     req.Alias,
+		MaxClicks: req.MaxClicks,
+	}
+	if req.ExpiresAt != nil {
+		opts.ExpiresAt = *req.ExpiresAt
+	}
+	return opts
+}
+
+type timeClicks struct {
+	Time   time.Time `json:"time"`
+	Clicks int       `json:"clicks"`
+}
+
+type referrerClicks struct {
+	Referrer string `json:"referrer"`
+	Clicks   int    `json:"clicks"`
+}
+
+type userAgentClicks struct {
+	Class  string `json:"class"`
+	Clicks int    `json:"clicks"`
+}
+
+type statsResponse struct {
+	Link       string            `json:"link"`
+	URL        string            `json:"url"`
+	ExpiresAt  *time.Time        `json:"expires_at,omitempty"`
+	MaxClicks  int               `json:"max_clicks,omitempty"`
+	Clicks     int               `json:"clicks"`
+	OverTime   []timeClicks      `json:"over_time"`
+	Referrers  []referrerClicks  `json:"referrers"`
+	UserAgents []userAgentClicks `json:"user_agents"`
+}
+
+func newStatsResponse(stats *shortener.Stats) *statsResponse {
+	res := &statsResponse{
+		Link:       shortHost + "/" + stats.Link.ShortCode,
+		URL:        stats.Link.OriginalURL,
+		Clicks:     stats.Clicks,
+		OverTime:   make([]timeClicks, 0, len(stats.OverTime)),
+		Referrers:  make([]referrerClicks, 0, len(stats.Referrers)),
+		UserAgents: make([]userAgentClicks, 0, len(stats.UserAgents)),
+		MaxClicks:  stats.Link.MaxClicks,
+	}
+	if !stats.Link.ExpiresAt.IsZero() {
+		res.ExpiresAt = &stats.Link.ExpiresAt
+	}
+	for _, c := range stats.OverTime {
+		res.OverTime = append(res.OverTime, timeClicks{Time: c.Time, Clicks: c.Clicks})
+	}
+	for _, c := range stats.Referrers {
+		res.Referrers = append(res.Referrers, referrerClicks{Referrer: c.Key, Clicks: c.Clicks})
+	}
+	for _, c := range stats.UserAgents {
+		res.UserAgents = append(res.UserAgents, userAgentClicks{Class: c.Key, Clicks: c.Clicks})
+	}
+	return res
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 
 	mw "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/middleware"
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
@@ -24,6 +25,7 @@ func RegisterPostHandler(mux *httprouter.Router, logger *zap.Logger, authorizer
 	del := delivery{serv, logger}
 
 	mux.POST("/share/pin/:id", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(csrf(del.createPin))), logger), logger), logger))
+	mux.GET("/share/stats/:link", mw.HandleLogger(mw.ErrorHandler(m.MetricsMiddleware(mw.Cors(authorizer(del.stats)), logger), logger), logger))
 }
 
 type delivery struct {
@@ -39,13 +41,19 @@ func (del *delivery) create(w http.ResponseWriter, r *http.Request, p httprouter
 		return err
 	}
 
-	var request url
+	var request linkRequest
 	err = request.UnmarshalJSON(body)
 	if err != nil {
 		return errors.Wrap(pkgErrors.ErrParseJson, err.Error())
 	}
 
-	hash, err := del.serv.Create(request.URL)
+	strUserId := p.ByName("user-id")
+	userId, err := strconv.Atoi(strUserId)
+	if err != nil {
+		return pkgErrors.ErrInvalidUserIdParam
+	}
+
+	hash, err := del.serv.Create(request.URL, userId, request.options())
 	if err != nil {
 		return err
 	}
@@ -72,7 +80,25 @@ func (del *delivery) createPin(w http.ResponseWriter, r *http.Request, p httprou
 		return pkgErrors.ErrInvalidLinkIDParam
 	}
 
-	hash, err := del.serv.CreatePinLink(id)
+	strUserId := p.ByName("user-id")
+	userId, err := strconv.Atoi(strUserId)
+	if err != nil {
+		return pkgErrors.ErrInvalidUserIdParam
+	}
+
+	// the options of the link are optional, links without them are shared by the requests of the user
+	body, err := utils.ReadBody(r, del.log)
+	if err != nil {
+		return err
+	}
+	var request linkRequest
+	if len(body) > 0 {
+		if err = request.UnmarshalJSON(body); err != nil {
+			return errors.Wrap(pkgErrors.ErrParseJson, err.Error())
+		}
+	}
+
+	hash, err := del.serv.CreatePinLink(id, userId, request.options())
 	if err != nil {
 		return err
 	}
@@ -97,8 +123,8 @@ func (del *delivery) get(w http.ResponseWriter, r *http.Request, p httprouter.Pa
 		return pkgErrors.ErrInvalidLinkIDParam
 	}
 
-	res, err := del.serv.Get(link)
-	if err == pkgErrors.ErrLinkNotFound {
+	res, err := del.serv.Visit(link, shortener.NewClick(time.Now(), r.Referer(), r.UserAgent()))
+	if err != nil {
 		return err
 	}
 
@@ -106,3 +132,34 @@ func (del *delivery) get(w http.ResponseWriter, r *http.Request, p httprouter.Pa
 
 	return nil
 }
+
+func (del *delivery) stats(w http.ResponseWriter, r *http.Request, p httprouter.Params) error {
+	link := p.ByName("link")
+	if link == "" {
+		return pkgErrors.ErrInvalidLinkIDParam
+	}
+
+	strUserId := p.ByName("user-id")
+	userId, err := strconv.Atoi(strUserId)
+	if err != nil {
+		return pkgErrors.ErrInvalidUserIdParam
+	}
+
+	stats, err := del.serv.Stats(link, userId, r.URL.Query().Get("interval"))
+	if err != nil {
+		return err
+	}
+
+	response := newStatsResponse(&stats)
+	dt, err := response.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(dt)
+	if err != nil {
+		return errors.Wrap(pkgErrors.ErrCreateResponse, err.Error())
+	}
+	return nil
+}
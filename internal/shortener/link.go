@@ -0,0 +1,56 @@
+package shortener
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+// LinkOptions are the options of a link chosen by its creator, zero options are the ones
+// of generated links that never expire.
+type LinkOptions struct {
+	Alias     string    // short code chosen instead of a generated one
+	ExpiresAt time.Time // zero for links that never expire
+	MaxClicks int       // 0 for links with unlimited clicks
+}
+
+func (o LinkOptions) IsZero() bool {
+	return o.Alias == "" && o.ExpiresAt.IsZero() && o.MaxClicks == 0
+}
+
+var aliasRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{4,32}$`)
+
+// Lowercased aliases that are taken by pages of the site or could be mistaken for them.
+var reservedAliases = map[string]struct{}{
+	"share": {}, "stats": {}, "api": {}, "admin": {}, "metrics": {}, "health": {}, "static": {},
+	"login": {}, "logout": {}, "signup": {}, "auth": {}, "csrf": {}, "oauth": {},
+	"pin": {}, "pins": {}, "board": {}, "boards": {}, "profile": {}, "user": {}, "users": {},
+	"search": {}, "feed": {}, "chats": {}, "notifications": {}, "settings": {}, "help": {}, "about": {},
+	"pickpin": {},
+}
+
+// CheckLinkOptions validates the options of a link created at now. Aliases are lowercased so that
+// aliases differing in case only are taken by the same link.
+func CheckLinkOptions(opts *LinkOptions, now time.Time) error {
+	if opts.Alias != "" {
+		if !aliasRegexp.MatchString(opts.Alias) {
+			return pkgErrors.ErrInvalidAlias
+		}
+		opts.Alias = strings.ToLower(opts.Alias)
+		if _, ok := reservedAliases[opts.Alias]; ok {
+			return pkgErrors.ErrReservedAlias
+		}
+	}
+	if !opts.ExpiresAt.IsZero() && !opts.ExpiresAt.After(now) || opts.MaxClicks < 0 {
+		return pkgErrors.ErrInvalidLinkLimits
+	}
+	opts.ExpiresAt = opts.ExpiresAt.UTC()
+	return nil
+}
+
+// Expired reports whether the link expired by now or has no clicks left.
+func (l Link) Expired(now time.Time) bool {
+	return !l.ExpiresAt.IsZero() && !now.Before(l.ExpiresAt) || l.MaxClicks > 0 && l.Visits >= l.MaxClicks
+}
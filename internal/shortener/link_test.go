@@ -0,0 +1,116 @@
+package shortener
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+)
+
+func TestCheckLinkOptions(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	now := time.Date(2023, 5, 20, 12, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		opts    LinkOptions
+		checked LinkOptions
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"no options": {opts: LinkOptions{}, checked: LinkOptions{}},
+		"alias": {
+			opts:    LinkOptions{Alias: "my_pins-2023"},
+			checked: LinkOptions{Alias: "my_pins-2023"},
+		},
+		"alias lowercased": {
+			opts:    LinkOptions{Alias: "My_Pins"},
+			checked: LinkOptions{Alias: "my_pins"},
+		},
+		"shortest alias": {opts: LinkOptions{Alias: "abcd"}, checked: LinkOptions{Alias: "abcd"}},
+		"longest alias": {
+			opts:    LinkOptions{Alias: strings.Repeat("a", 32)},
+			checked: LinkOptions{Alias: strings.Repeat("a", 32)},
+		},
+		"expiry in UTC": {
+			opts:    LinkOptions{ExpiresAt: time.Date(2023, 5, 21, 15, 0, 0, 0, msk)},
+			checked: LinkOptions{ExpiresAt: time.Date(2023, 5, 21, 12, 0, 0, 0, time.UTC)},
+		},
+		"max clicks": {opts: LinkOptions{MaxClicks: 10}, checked: LinkOptions{MaxClicks: 10}},
+		"all options": {
+			opts:    LinkOptions{Alias: "Summer", ExpiresAt: now.Add(time.Hour), MaxClicks: 1},
+			checked: LinkOptions{Alias: "summer", ExpiresAt: now.Add(time.Hour), MaxClicks: 1},
+		},
+		"too short alias":      {opts: LinkOptions{Alias: "abc"}, err: pkgErrors.ErrInvalidAlias},
+		"too long alias":       {opts: LinkOptions{Alias: strings.Repeat("a", 33)}, err: pkgErrors.ErrInvalidAlias},
+		"alias with slash":     {opts: LinkOptions{Alias: "my/pins"}, err: pkgErrors.ErrInvalidAlias},
+		"alias with dot":       {opts: LinkOptions{Alias: "my.pins"}, err: pkgErrors.ErrInvalidAlias},
+		"alias with space":     {opts: LinkOptions{Alias: "my pins"}, err: pkgErrors.ErrInvalidAlias},
+		"non-latin alias":      {opts: LinkOptions{Alias: "пинчики"}, err: pkgErrors.ErrInvalidAlias},
+		"reserved alias":       {opts: LinkOptions{Alias: "share"}, err: pkgErrors.ErrReservedAlias},
+		"reserved in any case": {opts: LinkOptions{Alias: "Stats"}, err: pkgErrors.ErrReservedAlias},
+		"reserved site name":   {opts: LinkOptions{Alias: "PICKPIN"}, err: pkgErrors.ErrReservedAlias},
+		"past expiry":          {opts: LinkOptions{ExpiresAt: now.Add(-time.Minute)}, err: pkgErrors.ErrInvalidLinkLimits},
+		"expiry now":           {opts: LinkOptions{ExpiresAt: now}, err: pkgErrors.ErrInvalidLinkLimits},
+		"negative max clicks":  {opts: LinkOptions{MaxClicks: -1}, err: pkgErrors.ErrInvalidLinkLimits},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := test.opts
+			err := CheckLinkOptions(&opts, now)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if opts.Alias != test.checked.Alias || !opts.ExpiresAt.Equal(test.checked.ExpiresAt) ||
+				opts.MaxClicks != test.checked.MaxClicks {
+				t.Errorf("\nExpected: %+v\nGot: %+v", test.checked, opts)
+			}
+			if !opts.ExpiresAt.IsZero() && opts.ExpiresAt.Location() != time.UTC {
+				t.Errorf("expiry is in %v, expected UTC", opts.ExpiresAt.Location())
+			}
+		})
+	}
+}
+
+func TestLinkExpired(t *testing.T) {
+	now := time.Date(2023, 5, 20, 12, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		link    Link
+		expired bool
+	}
+
+	tests := map[string]testCase{
+		"no limits":            {link: Link{}, expired: false},
+		"before expiry":        {link: Link{ExpiresAt: now.Add(time.Second)}, expired: false},
+		"at expiry":            {link: Link{ExpiresAt: now}, expired: true},
+		"after expiry":         {link: Link{ExpiresAt: now.Add(-time.Hour)}, expired: true},
+		"clicks left":          {link: Link{MaxClicks: 3, Visits: 2}, expired: false},
+		"no clicks left":       {link: Link{MaxClicks: 3, Visits: 3}, expired: true},
+		"over the limit":       {link: Link{MaxClicks: 3, Visits: 5}, expired: true},
+		"unlimited clicks":     {link: Link{Visits: 100}, expired: false},
+		"clicks left, expired": {link: Link{ExpiresAt: now, MaxClicks: 3}, expired: true},
+		"no clicks, in time":   {link: Link{ExpiresAt: now.Add(time.Hour), MaxClicks: 1, Visits: 1}, expired: true},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if expired := test.link.Expired(now); expired != test.expired {
+				t.Errorf("Expired = %v, expected %v", expired, test.expired)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/shortener/repository.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	shortener "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/shortener"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockShortenerRepository is a mock of ShortenerRepository interface.
+type MockShortenerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShortenerRepositoryMockRecorder
+}
+
+// MockShortenerRepositoryMockRecorder is the mock recorder for MockShortenerRepository.
+type MockShortenerRepositoryMockRecorder struct {
+	mock *MockShortenerRepository
+}
+
+// NewMockShortenerRepository creates a new mock instance.
+func NewMockShortenerRepository(ctrl *gomock.Controller) *MockShortenerRepository {
+	mock := &MockShortenerRepository{ctrl: ctrl}
+	mock.recorder = &MockShortenerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShortenerRepository) EXPECT() *MockShortenerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddClick mocks base method.
+func (m *MockShortenerRepository) AddClick(hash string, click shortener.Click) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddClick", hash, click)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddClick indicates an expected call of AddClick.
+func (mr *MockShortenerRepositoryMockRecorder) AddClick(hash, click interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddClick", reflect.TypeOf((*MockShortenerRepository)(nil).AddClick), hash, click)
+}
+
+// AddVisit mocks base method.
+func (m *MockShortenerRepository) AddVisit(link shortener.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVisit", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddVisit indicates an expected call of AddVisit.
+func (mr *MockShortenerRepositoryMockRecorder) AddVisit(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVisit", reflect.TypeOf((*MockShortenerRepository)(nil).AddVisit), link)
+}
+
+// Create mocks base method.
+func (m *MockShortenerRepository) Create(url string, userId int, opts shortener.LinkOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", url, userId, opts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShortenerRepositoryMockRecorder) Create(url, userId, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShortenerRepository)(nil).Create), url, userId, opts)
+}
+
+// Get mocks base method.
+func (m *MockShortenerRepository) Get(hash string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", hash)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockShortenerRepositoryMockRecorder) Get(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockShortenerRepository)(nil).Get), hash)
+}
+
+// GetLink mocks base method.
+func (m *MockShortenerRepository) GetLink(hash string) (shortener.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLink", hash)
+	ret0, _ := ret[0].(shortener.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLink indicates an expected call of GetLink.
+func (mr *MockShortenerRepositoryMockRecorder) GetLink(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLink", reflect.TypeOf((*MockShortenerRepository)(nil).GetLink), hash)
+}
+
+// Stats mocks base method.
+func (m *MockShortenerRepository) Stats(link shortener.Link, interval string) (shortener.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", link, interval)
+	ret0, _ := ret[0].(shortener.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockShortenerRepositoryMockRecorder) Stats(link, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockShortenerRepository)(nil).Stats), link, interval)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/shortener/service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	shortener "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/shortener"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockShortenerService is a mock of ShortenerService interface.
+type MockShortenerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockShortenerServiceMockRecorder
+}
+
+// MockShortenerServiceMockRecorder is the mock recorder for MockShortenerService.
+type MockShortenerServiceMockRecorder struct {
+	mock *MockShortenerService
+}
+
+// NewMockShortenerService creates a new mock instance.
+func NewMockShortenerService(ctrl *gomock.Controller) *MockShortenerService {
+	mock := &MockShortenerService{ctrl: ctrl}
+	mock.recorder = &MockShortenerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShortenerService) EXPECT() *MockShortenerServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShortenerService) Create(url string, userId int, opts shortener.LinkOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", url, userId, opts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShortenerServiceMockRecorder) Create(url, userId, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShortenerService)(nil).Create), url, userId, opts)
+}
+
+// CreatePinLink mocks base method.
+func (m *MockShortenerService) CreatePinLink(id, userId int, opts shortener.LinkOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePinLink", id, userId, opts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePinLink indicates an expected call of CreatePinLink.
+func (mr *MockShortenerServiceMockRecorder) CreatePinLink(id, userId, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePinLink", reflect.TypeOf((*MockShortenerService)(nil).CreatePinLink), id, userId, opts)
+}
+
+// Get mocks base method.
+func (m *MockShortenerService) Get(hash string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", hash)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockShortenerServiceMockRecorder) Get(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockShortenerService)(nil).Get), hash)
+}
+
+// Stats mocks base method.
+func (m *MockShortenerService) Stats(hash string, userId int, interval string) (shortener.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", hash, userId, interval)
+	ret0, _ := ret[0].(shortener.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockShortenerServiceMockRecorder) Stats(hash, userId, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockShortenerService)(nil).Stats), hash, userId, interval)
+}
+
+// Visit mocks base method.
+func (m *MockShortenerService) Visit(hash string, click shortener.Click) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Visit", hash, click)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Visit indicates an expected call of Visit.
+func (mr *MockShortenerServiceMockRecorder) Visit(hash, click interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Visit", reflect.TypeOf((*MockShortenerService)(nil).Visit), hash, click)
+}
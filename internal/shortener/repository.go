@@ -1,6 +1,64 @@
 package shortener
 
+import "time"
+
+// Link is a short link, links are created for each user apart so that their clicks are counted apart.
+type Link struct {
+	OriginalURL string
+	ShortCode   string
+	CreatorId   int       // 0 for links created before the creators were kept
+	ExpiresAt   time.Time // zero for links that never expire
+	MaxClicks   int       // 0 for links with unlimited clicks
+	Visits      int       // redirects through the link, counted for links with MaxClicks only
+}
+
+// Click is a redirect through a short link. Neither the address of the client nor its location is kept.
+type Click struct {
+	Time      time.Time
+	Referrer  string // host of the referring page, empty for direct visits
+	UserAgent string // one of the user agent classes
+}
+
+// Intervals clicks are counted over time by
+const (
+	IntervalHour = "hour"
+	IntervalDay  = "day"
+)
+
+// TimeClicks is the number of clicks in the interval starting at Time.
+type TimeClicks struct {
+	Time   time.Time
+	Clicks int
+}
+
+// KeyClicks is the number of clicks from a referrer or of a user agent class.
+type KeyClicks struct {
+	Key    string
+	Clicks int
+}
+
+// Stats are the clicks of a link, the most frequent referrers and user agent classes first.
+type Stats struct {
+	Link       Link
+	Clicks     int
+	OverTime   []TimeClicks // intervals with clicks only, the earliest first
+	Referrers  []KeyClicks  // direct visits are counted with an empty referrer
+	UserAgents []KeyClicks
+}
+
 type ShortenerRepository interface {
+	// Get returns pkgErrors.ErrLinkExpired for expired links.
 	Get(hash string) (string, error)
-	Create(url string) (string, error)
+	// GetLink returns pkgErrors.ErrLinkNotFound if there is no link with the hash. Aliases match the hash
+	// in any case.
+	GetLink(hash string) (Link, error)
+	// Create returns the link of the user to the url, creating it if there is none. Links with options
+	// are always created, pkgErrors.ErrAliasTaken is returned if the alias is a short code of another link.
+	Create(url string, userId int, opts LinkOptions) (string, error)
+	// AddVisit counts a redirect through the link, pkgErrors.ErrLinkExpired is returned if the link
+	// has no clicks left.
+	AddVisit(link Link) error
+	AddClick(hash string, click Click) error
+	// Stats counts the clicks of the link, over time by the interval.
+	Stats(link Link, interval string) (Stats, error)
 }
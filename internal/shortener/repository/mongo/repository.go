@@ -3,12 +3,15 @@ package mongo
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 	short "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/shortener"
 	gen "github.com/go-park-mail-ru/2023_1_PracticalDev/pkg/shortener"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
@@ -18,8 +21,20 @@ type shortener struct {
 }
 
 type shortURL struct {
-	OriginalURL string `bson:"original_url"`
-	ShortCode   string `bson:"short_code"`
+	OriginalURL string     `bson:"original_url"`
+	ShortCode   string     `bson:"short_code"`
+	CreatorId   int        `bson:"creator_id"`
+	Custom      bool       `bson:"custom,omitempty"`
+	ExpiresAt   *time.Time `bson:"expires_at,omitempty"`
+	MaxClicks   int        `bson:"max_clicks,omitempty"`
+	Visits      int        `bson:"visits,omitempty"`
+}
+
+type click struct {
+	ShortCode string    `bson:"short_code"`
+	Time      time.Time `bson:"time"`
+	Referrer  string    `bson:"referrer"`
+	UserAgent string    `bson:"user_agent"`
 }
 
 var (
@@ -33,36 +48,115 @@ func NewShortenerRepository(db *mongo.Database, log *zap.Logger) short.Shortener
 	}
 }
 
+// CreateIndexes creates the indexes links are looked up and clicks are counted with.
+func CreateIndexes(db *mongo.Database) error {
+	_, err := db.Collection("urls").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "short_code", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "original_url", Value: 1}, {Key: "creator_id", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection("clicks").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: "short_code", Value: 1}, {Key: "time", Value: 1}},
+	})
+	return err
+}
+
 func (s *shortener) Get(hash string) (string, error) {
+	link, err := s.GetLink(hash)
+	if err != nil {
+		return "", err
+	}
+	if link.Expired(time.Now()) {
+		s.log.Debug("short link expired", zap.String("ShortCode", hash))
+		return "", pkgErrors.ErrLinkExpired
+	}
+	return link.OriginalURL, nil
+}
+
+func (s *shortener) GetLink(hash string) (short.Link, error) {
 	res := &shortURL{}
 	collection := s.db.Collection("urls")
 	err := collection.FindOne(context.Background(), bson.M{"short_code": hash}).Decode(res)
+	if lower := strings.ToLower(hash); err == mongo.ErrNoDocuments && lower != hash {
+		// aliases are stored lowercased and match in any case, generated short codes match exactly
+		err = collection.FindOne(context.Background(), bson.M{"short_code": lower, "custom": true}).Decode(res)
+	}
 	if err == mongo.ErrNoDocuments {
 		s.log.Error("failed to find short link", zap.String("ShortCode", hash), zap.Error(err))
-		return "", pkgErrors.ErrLinkNotFound
+		return short.Link{}, pkgErrors.ErrLinkNotFound
+	}
+	if err != nil {
+		s.log.Error("failed to lookup short link", zap.String("ShortCode", hash), zap.Error(err))
+		return short.Link{}, err
 	}
 
 	s.log.Debug("found short link", zap.String("OriginalURL", res.OriginalURL), zap.String("ShortCode", hash))
-	return res.OriginalURL, nil
+	link := short.Link{
+		OriginalURL: res.OriginalURL,
+		ShortCode:   res.ShortCode,
+		CreatorId:   res.CreatorId,
+		MaxClicks:   res.MaxClicks,
+		Visits:      res.Visits,
+	}
+	if res.ExpiresAt != nil {
+		link.ExpiresAt = res.ExpiresAt.UTC()
+	}
+	return link, nil
+}
+
+func newShortURL(url string, sc string, userId int, opts short.LinkOptions) *shortURL {
+	res := &shortURL{
+		OriginalURL: url,
+		ShortCode:   sc,
+		CreatorId:   userId,
+		Custom:      opts.Alias != "",
+		MaxClicks:   opts.MaxClicks,
+	}
+	if !opts.ExpiresAt.IsZero() {
+		res.ExpiresAt = &opts.ExpiresAt
+	}
+	return res
 }
 
-func (s *shortener) Create(url string) (string, error) {
+func (s *shortener) Create(url string, userId int, opts short.LinkOptions) (string, error) {
 	collection := s.db.Collection("urls")
+	if opts.Alias != "" {
+		_, err := collection.InsertOne(context.Background(), newShortURL(url, opts.Alias, userId, opts))
+		if mongo.IsDuplicateKeyError(err) {
+			s.log.Debug("alias already taken", zap.String("ShortCode", opts.Alias))
+			return "", pkgErrors.ErrAliasTaken
+		}
+		if err != nil {
+			s.log.Error("failed to insert short link", zap.String("OriginalURL", url), zap.Error(err))
+			return "", err
+		}
+		s.log.Debug("created aliased short link", zap.String("OriginalURL", url), zap.String("ShortCode", opts.Alias))
+		return opts.Alias, nil
+	}
+
+	// only the links without options are shared by the requests of the user
 	res := &shortURL{}
-	err := collection.FindOne(context.Background(), bson.M{"original_url": url}).Decode(res)
-	if err == nil {
-		s.log.Debug("short link already exists", zap.String("OriginalURL", url), zap.String("ShortCode", res.ShortCode))
-		return res.ShortCode, nil
+	if opts.IsZero() {
+		err := collection.FindOne(context.Background(), bson.M{
+			"original_url": url,
+			"creator_id":   userId,
+			"custom":       bson.M{"$exists": false},
+			"expires_at":   bson.M{"$exists": false},
+			"max_clicks":   bson.M{"$exists": false},
+		}).Decode(res)
+		if err == nil {
+			s.log.Debug("short link already exists", zap.String("OriginalURL", url), zap.String("ShortCode", res.ShortCode))
+			return res.ShortCode, nil
+		}
 	}
 	count := 10
 	for count > 0 {
 		sc := gen.GenerateShortCode()
 		err := collection.FindOne(context.Background(), bson.M{"short_code": sc}).Decode(res)
 		if err == mongo.ErrNoDocuments {
-			_, err := collection.InsertOne(context.Background(), &shortURL{
-				OriginalURL: url,
-				ShortCode:   sc,
-			})
+			_, err := collection.InsertOne(context.Background(), newShortURL(url, sc, userId, opts))
 			if err != nil {
 				s.log.Error("failed to insert short link", zap.String("OriginalURL", url), zap.Error(err))
 				return "", err
@@ -78,3 +172,110 @@ func (s *shortener) Create(url string) (string, error) {
 	s.log.Error("generate attempts limit exceeded")
 	return "", ErrGenerateAttemptsLimitExceeded
 }
+
+func (s *shortener) AddVisit(link short.Link) error {
+	if link.MaxClicks == 0 {
+		return nil
+	}
+
+	// the visits are counted only while there are clicks left so that concurrent visits do not exceed the limit
+	res, err := s.db.Collection("urls").UpdateOne(context.Background(),
+		bson.M{"short_code": link.ShortCode, "visits": bson.M{"$not": bson.M{"$gte": link.MaxClicks}}},
+		bson.M{"$inc": bson.M{"visits": 1}})
+	if err != nil {
+		s.log.Error("failed to count visit", zap.String("ShortCode", link.ShortCode), zap.Error(err))
+		return err
+	}
+	if res.MatchedCount == 0 {
+		s.log.Debug("short link has no clicks left", zap.String("ShortCode", link.ShortCode))
+		return pkgErrors.ErrLinkExpired
+	}
+	return nil
+}
+
+func (s *shortener) AddClick(hash string, c short.Click) error {
+	_, err := s.db.Collection("clicks").InsertOne(context.Background(), &click{
+		ShortCode: hash,
+		Time:      c.Time,
+		Referrer:  c.Referrer,
+		UserAgent: c.UserAgent,
+	})
+	if err != nil {
+		s.log.Error("failed to insert click", zap.String("ShortCode", hash), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Formats of the starts of intervals clicks are grouped by, in UTC
+var intervalFormats = map[string]string{
+	short.IntervalHour: "%Y-%m-%dT%H:00:00Z",
+	short.IntervalDay:  "%Y-%m-%dT00:00:00Z",
+}
+
+type count struct {
+	Key    string `bson:"_id"`
+	Clicks int    `bson:"clicks"`
+}
+
+// countClicks counts the clicks of the link grouped by the expression, ordered by sort.
+func (s *shortener) countClicks(hash string, group any, sort bson.D) ([]count, error) {
+	cursor, err := s.db.Collection("clicks").Aggregate(context.Background(), mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"short_code": hash}}},
+		{{Key: "$group", Value: bson.M{"_id": group, "clicks": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: sort}},
+	})
+	if err != nil {
+		s.log.Error("failed to count clicks", zap.String("ShortCode", hash), zap.Error(err))
+		return nil, err
+	}
+
+	var counts []count
+	if err = cursor.All(context.Background(), &counts); err != nil {
+		s.log.Error("failed to decode clicks", zap.String("ShortCode", hash), zap.Error(err))
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (s *shortener) Stats(link short.Link, interval string) (short.Stats, error) {
+	stats := short.Stats{Link: link, OverTime: []short.TimeClicks{}}
+
+	overTime, err := s.countClicks(link.ShortCode,
+		bson.M{"$dateToString": bson.M{"format": intervalFormats[interval], "date": "$time"}},
+		bson.D{{Key: "_id", Value: 1}})
+	if err != nil {
+		return short.Stats{}, err
+	}
+	for _, c := range overTime {
+		t, err := time.Parse(time.RFC3339, c.Key)
+		if err != nil {
+			s.log.Error("failed to parse clicks interval", zap.String("Interval", c.Key), zap.Error(err))
+			return short.Stats{}, err
+		}
+		stats.OverTime = append(stats.OverTime, short.TimeClicks{Time: t, Clicks: c.Clicks})
+		stats.Clicks += c.Clicks
+	}
+
+	byCount := bson.D{{Key: "clicks", Value: -1}, {Key: "_id", Value: 1}}
+	referrers, err := s.countClicks(link.ShortCode, "$referrer", byCount)
+	if err != nil {
+		return short.Stats{}, err
+	}
+	userAgents, err := s.countClicks(link.ShortCode, "$user_agent", byCount)
+	if err != nil {
+		return short.Stats{}, err
+	}
+
+	stats.Referrers = keyClicks(referrers)
+	stats.UserAgents = keyClicks(userAgents)
+	return stats, nil
+}
+
+func keyClicks(counts []count) []short.KeyClicks {
+	res := make([]short.KeyClicks, 0, len(counts))
+	for _, c := range counts {
+		res = append(res, short.KeyClicks{Key: c.Key, Clicks: c.Clicks})
+	}
+	return res
+}
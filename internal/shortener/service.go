@@ -2,6 +2,11 @@ package shortener
 
 type ShortenerService interface {
 	Get(hash string) (string, error)
-	Create(url string) (string, error)
-	CreatePinLink(id int) (string, error)
+	// Visit returns the original URL of the link and records the click, the redirect is not failed
+	// if the click is not recorded. pkgErrors.ErrLinkExpired is returned for expired links.
+	Visit(hash string, click Click) (string, error)
+	Create(url string, userId int, opts LinkOptions) (string, error)
+	CreatePinLink(id int, userId int, opts LinkOptions) (string, error)
+	// Stats returns the clicks of the link to its creator, pkgErrors.ErrForbidden to other users.
+	Stats(hash string, userId int, interval string) (Stats, error)
 }
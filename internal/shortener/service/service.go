@@ -3,17 +3,23 @@ package service
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"go.uber.org/zap"
+
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
 	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/shortener"
 )
 
 type service struct {
 	rep shortener.ShortenerRepository
+	log *zap.Logger
 }
 
-func NewShortenerService(rep shortener.ShortenerRepository) shortener.ShortenerService {
+func NewShortenerService(rep shortener.ShortenerRepository, log *zap.Logger) shortener.ShortenerService {
 	return &service{
 		rep: rep,
+		log: log,
 	}
 }
 
@@ -21,13 +27,50 @@ func (serv *service) Get(hash string) (string, error) {
 	return serv.rep.Get(hash)
 }
 
-func (serv *service) Create(url string) (string, error) {
-	return serv.rep.Create(url)
+func (serv *service) Visit(hash string, click shortener.Click) (string, error) {
+	link, err := serv.rep.GetLink(hash)
+	if err != nil {
+		return "", err
+	}
+	if link.Expired(time.Now()) {
+		return "", pkgErrors.ErrLinkExpired
+	}
+	if err = serv.rep.AddVisit(link); err != nil {
+		return "", err
+	}
+
+	// the hash may differ in case from the alias of the link, clicks are counted by its short code
+	if err = serv.rep.AddClick(link.ShortCode, click); err != nil {
+		serv.log.Error("Failed to record click", zap.String("ShortCode", link.ShortCode), zap.Error(err))
+	}
+	return link.OriginalURL, nil
 }
 
-func (serv *service) CreatePinLink(id int) (string, error) {
+func (serv *service) Create(url string, userId int, opts shortener.LinkOptions) (string, error) {
+	if err := shortener.CheckLinkOptions(&opts, time.Now()); err != nil {
+		return "", err
+	}
+	return serv.rep.Create(url, userId, opts)
+}
+
+func (serv *service) CreatePinLink(id int, userId int, opts shortener.LinkOptions) (string, error) {
 	if os.Getenv("SHORT_HOST") == "localhost:8091" {
-		return serv.Create(fmt.Sprintf("http://localhost/pin/%d", id))
+		return serv.Create(fmt.Sprintf("http://localhost/pin/%d", id), userId, opts)
+	}
+	return serv.Create(fmt.Sprintf("https://pickpin.ru/pin/%d", id), userId, opts)
+}
+
+func (serv *service) Stats(hash string, userId int, interval string) (shortener.Stats, error) {
+	if err := shortener.CheckInterval(&interval); err != nil {
+		return shortener.Stats{}, err
+	}
+
+	link, err := serv.rep.GetLink(hash)
+	if err != nil {
+		return shortener.Stats{}, err
+	}
+	if link.CreatorId == 0 || link.CreatorId != userId {
+		return shortener.Stats{}, pkgErrors.ErrForbidden
 	}
-	return serv.Create(fmt.Sprintf("https://pickpin.ru/pin/%d", id))
+	return serv.rep.Stats(link, interval)
 }
@@ -0,0 +1,336 @@
+package service
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	pkgErrors "github.com/go-park-mail-ru/2023_1_PracticalDev/internal/pkg/errors"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/shortener"
+	"github.com/go-park-mail-ru/2023_1_PracticalDev/internal/shortener/mocks"
+)
+
+func TestStats(t *testing.T) {
+	type fields struct {
+		repo *mocks.MockShortenerRepository
+	}
+
+	type testCase struct {
+		prepare  func(f *fields)
+		hash     string
+		userId   int
+		interval string
+		stats    shortener.Stats
+		err      error
+	}
+
+	link := shortener.Link{OriginalURL: "https://pickpin.ru/pin/3", ShortCode: "abcdefg", CreatorId: 12}
+	stats := shortener.Stats{
+		Link:       link,
+		Clicks:     3,
+		OverTime:   []shortener.TimeClicks{{Time: time.Date(2023, 5, 20, 0, 0, 0, 0, time.UTC), Clicks: 3}},
+		Referrers:  []shortener.KeyClicks{{Key: "vk.com", Clicks: 2}, {Key: "", Clicks: 1}},
+		UserAgents: []shortener.KeyClicks{{Key: shortener.UserAgentMobile, Clicks: 3}},
+	}
+
+	tests := map[string]testCase{
+		"owner": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().GetLink("abcdefg").Return(link, nil),
+					f.repo.EXPECT().Stats(link, shortener.IntervalHour).Return(stats, nil),
+				)
+			},
+			hash:     "abcdefg",
+			userId:   12,
+			interval: shortener.IntervalHour,
+			stats:    stats,
+			err:      nil,
+		},
+		"default interval": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().GetLink("abcdefg").Return(link, nil),
+					f.repo.EXPECT().Stats(link, shortener.IntervalDay).Return(stats, nil),
+				)
+			},
+			hash:   "abcdefg",
+			userId: 12,
+			stats:  stats,
+			err:    nil,
+		},
+		"another user": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().GetLink("abcdefg").Return(link, nil)
+			},
+			hash:   "abcdefg",
+			userId: 13,
+			err:    pkgErrors.ErrForbidden,
+		},
+		"link without creator": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().GetLink("abcdefg").Return(shortener.Link{OriginalURL: link.OriginalURL, ShortCode: "abcdefg"}, nil)
+			},
+			hash:   "abcdefg",
+			userId: 0,
+			err:    pkgErrors.ErrForbidden,
+		},
+		"link not found": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().GetLink("abcdefg").Return(shortener.Link{}, pkgErrors.ErrLinkNotFound)
+			},
+			hash:   "abcdefg",
+			userId: 12,
+			err:    pkgErrors.ErrLinkNotFound,
+		},
+		"invalid interval": {
+			prepare:  func(f *fields) {},
+			hash:     "abcdefg",
+			userId:   12,
+			interval: "week",
+			err:      pkgErrors.ErrInvalidIntervalParam,
+		},
+		"db error": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().GetLink("abcdefg").Return(link, nil),
+					f.repo.EXPECT().Stats(link, shortener.IntervalDay).Return(shortener.Stats{}, pkgErrors.ErrDb),
+				)
+			},
+			hash:   "abcdefg",
+			userId: 12,
+			err:    pkgErrors.ErrDb,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{repo: mocks.NewMockShortenerRepository(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			serv := NewShortenerService(f.repo, zap.NewNop())
+			stats, err := serv.Stats(test.hash, test.userId, test.interval)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if !reflect.DeepEqual(stats, test.stats) {
+				t.Errorf("\nExpected: %v\nGot: %v", test.stats, stats)
+			}
+		})
+	}
+}
+
+func TestVisit(t *testing.T) {
+	type fields struct {
+		repo *mocks.MockShortenerRepository
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		hash    string
+		url     string
+		err     error
+	}
+
+	click := shortener.Click{Time: time.Now().UTC(), Referrer: "vk.com", UserAgent: shortener.UserAgentMobile}
+	link := shortener.Link{OriginalURL: "https://pickpin.ru/pin/3", ShortCode: "abcdefg", CreatorId: 12}
+	limited := shortener.Link{OriginalURL: "https://pickpin.ru/pin/3", ShortCode: "summer", CreatorId: 12,
+		ExpiresAt: time.Now().Add(time.Hour), MaxClicks: 3, Visits: 2}
+
+	tests := map[string]testCase{
+		"usual": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().GetLink("abcdefg").Return(link, nil),
+					f.repo.EXPECT().AddVisit(link).Return(nil),
+					f.repo.EXPECT().AddClick("abcdefg", click).Return(nil),
+				)
+			},
+			hash: "abcdefg",
+			url:  "https://pickpin.ru/pin/3",
+		},
+		"last click": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().GetLink("summer").Return(limited, nil),
+					f.repo.EXPECT().AddVisit(limited).Return(nil),
+					f.repo.EXPECT().AddClick("summer", click).Return(nil),
+				)
+			},
+			hash: "summer",
+			url:  "https://pickpin.ru/pin/3",
+		},
+		"alias in another case": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().GetLink("Summer").Return(limited, nil),
+					f.repo.EXPECT().AddVisit(limited).Return(nil),
+					f.repo.EXPECT().AddClick("summer", click).Return(nil),
+				)
+			},
+			hash: "Summer",
+			url:  "https://pickpin.ru/pin/3",
+		},
+		"click not recorded": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().GetLink("abcdefg").Return(link, nil),
+					f.repo.EXPECT().AddVisit(link).Return(nil),
+					f.repo.EXPECT().AddClick("abcdefg", click).Return(pkgErrors.ErrDb),
+				)
+			},
+			hash: "abcdefg",
+			url:  "https://pickpin.ru/pin/3",
+		},
+		"past expiry": {
+			prepare: func(f *fields) {
+				expired := limited
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				f.repo.EXPECT().GetLink("summer").Return(expired, nil)
+			},
+			hash: "summer",
+			err:  pkgErrors.ErrLinkExpired,
+		},
+		"no clicks left": {
+			prepare: func(f *fields) {
+				used := limited
+				used.Visits = used.MaxClicks
+				f.repo.EXPECT().GetLink("summer").Return(used, nil)
+			},
+			hash: "summer",
+			err:  pkgErrors.ErrLinkExpired,
+		},
+		"concurrent last click": {
+			prepare: func(f *fields) {
+				gomock.InOrder(
+					f.repo.EXPECT().GetLink("summer").Return(limited, nil),
+					f.repo.EXPECT().AddVisit(limited).Return(pkgErrors.ErrLinkExpired),
+				)
+			},
+			hash: "summer",
+			err:  pkgErrors.ErrLinkExpired,
+		},
+		"link not found": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().GetLink("abcdefg").Return(shortener.Link{}, pkgErrors.ErrLinkNotFound)
+			},
+			hash: "abcdefg",
+			err:  pkgErrors.ErrLinkNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{repo: mocks.NewMockShortenerRepository(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			serv := NewShortenerService(f.repo, zap.NewNop())
+			url, err := serv.Visit(test.hash, click)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if url != test.url {
+				t.Errorf("\nExpected: %s\nGot: %s", test.url, url)
+			}
+			// expired links are answered with 410 Gone instead of a redirect
+			if errors.Is(test.err, pkgErrors.ErrLinkExpired) {
+				if code, _ := pkgErrors.GetHTTPCodeByError(err); code != http.StatusGone {
+					t.Errorf("\nExpected: %d\nGot: %d", http.StatusGone, code)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type fields struct {
+		repo *mocks.MockShortenerRepository
+	}
+
+	type testCase struct {
+		prepare func(f *fields)
+		opts    shortener.LinkOptions
+		hash    string
+		err     error
+	}
+
+	tests := map[string]testCase{
+		"generated": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().Create("https://pickpin.ru/pin/3", 12, shortener.LinkOptions{}).Return("abcdefg", nil)
+			},
+			hash: "abcdefg",
+		},
+		"alias stored lowercased": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().Create("https://pickpin.ru/pin/3", 12, shortener.LinkOptions{Alias: "summer"}).
+					Return("summer", nil)
+			},
+			opts: shortener.LinkOptions{Alias: "SUMMER"},
+			hash: "summer",
+		},
+		"alias taken": {
+			prepare: func(f *fields) {
+				f.repo.EXPECT().Create("https://pickpin.ru/pin/3", 12, shortener.LinkOptions{Alias: "summer"}).
+					Return("", pkgErrors.ErrAliasTaken)
+			},
+			opts: shortener.LinkOptions{Alias: "Summer"},
+			err:  pkgErrors.ErrAliasTaken,
+		},
+		"reserved alias": {
+			prepare: func(f *fields) {},
+			opts:    shortener.LinkOptions{Alias: "Search"},
+			err:     pkgErrors.ErrReservedAlias,
+		},
+		"negative max clicks": {
+			prepare: func(f *fields) {},
+			opts:    shortener.LinkOptions{MaxClicks: -1},
+			err:     pkgErrors.ErrInvalidLinkLimits,
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			f := fields{repo: mocks.NewMockShortenerRepository(ctrl)}
+			if test.prepare != nil {
+				test.prepare(&f)
+			}
+
+			serv := NewShortenerService(f.repo, zap.NewNop())
+			hash, err := serv.Create("https://pickpin.ru/pin/3", 12, test.opts)
+			if !errors.Is(err, test.err) {
+				t.Errorf("\nExpected: %s\nGot: %s", test.err, err)
+			}
+			if hash != test.hash {
+				t.Errorf("\nExpected: %s\nGot: %s", test.hash, hash)
+			}
+		})
+	}
+}
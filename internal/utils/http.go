@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"mime/multipart"
+	"net"
 	"net/http"
 
 	"github.com/pkg/errors"
@@ -33,6 +34,19 @@ func ReadBody(r *http.Request, log *zap.Logger) ([]byte, error) {
 	return body, nil
 }
 
+// GetRealIP returns the client address set by nginx in X-Real-IP, falling back to the connection address.
+func GetRealIP(r *http.Request) string {
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 func CreateMultipartFormBody(values map[string]string,
 	files map[string]File) (body *bytes.Buffer, contentType string, err error) {
 	body = new(bytes.Buffer)
@@ -22,6 +22,8 @@ interface_files=(
   internal/comments/repository.go
   internal/notifications/service.go
   internal/notifications/repository.go
+  internal/accesstokens/service.go
+  internal/accesstokens/repository.go
 )
 
 echo "Generating mocks..."
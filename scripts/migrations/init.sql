@@ -2,6 +2,23 @@ CREATE TYPE account_type AS ENUM ('personal', 'business');
 CREATE TYPE privacy AS ENUM ('public', 'secret');
 CREATE TYPE notification_type AS ENUM ('new_pin', 'new_like', 'new_comment', 'new_follower');
 
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Цвет в формате rgb(r, g, b), в котором хранятся цвета пинов, в виде числа 0xRRGGBB
+CREATE OR REPLACE FUNCTION css_rgb(color text) RETURNS int AS
+$$
+SELECT (c[1]::int << 16) | (c[2]::int << 8) | c[3]::int
+FROM regexp_match(color, 'rgb\((\d+), (\d+), (\d+)\)') AS c
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Евклидово расстояние между цветами 0xRRGGBB в пространстве RGB
+CREATE OR REPLACE FUNCTION rgb_distance(a int, b int) RETURNS float8 AS
+$$
+SELECT sqrt(power(((a >> 16) & 255) - ((b >> 16) & 255), 2) +
+            power(((a >> 8) & 255) - ((b >> 8) & 255), 2) +
+            power((a & 255) - (b & 255), 2))
+$$ LANGUAGE sql IMMUTABLE;
+
 CREATE TABLE IF NOT EXISTS users
 (
     id              serial       NOT NULL PRIMARY KEY,
@@ -11,7 +28,9 @@ CREATE TABLE IF NOT EXISTS users
     name            varchar(256) NOT NULL,
     profile_image   varchar,
     website_url     varchar,
-    account_type    account_type NOT NULL
+    account_type    account_type NOT NULL,
+    search_vector   tsvector GENERATED ALWAYS AS (to_tsvector('russian', username || ' ' || name) ||
+                                                  to_tsvector('simple', username || ' ' || name)) STORED
 );
 
 CREATE TABLE IF NOT EXISTS boards
@@ -20,7 +39,12 @@ CREATE TABLE IF NOT EXISTS boards
     name        varchar(256) NOT NULL,
     description varchar(500),
     privacy     privacy      NOT NULL,
-    user_id     int          NOT NULL REFERENCES users (id) ON DELETE CASCADE
+    user_id     int          NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    search_vector tsvector GENERATED ALWAYS AS (
+                setweight(to_tsvector('russian', name), 'A') ||
+                setweight(to_tsvector('simple', name), 'A') ||
+                setweight(to_tsvector('russian', coalesce(description, '')), 'B') ||
+                setweight(to_tsvector('simple', coalesce(description, '')), 'B')) STORED
 );
 
 CREATE TABLE IF NOT EXISTS pins
@@ -32,8 +56,23 @@ CREATE TABLE IF NOT EXISTS pins
     created_at         timestamp NOT NULL DEFAULT now(),
     media_source       varchar   NOT NULL,
     media_source_color varchar   NOT NULL DEFAULT 'rgb(39, 102, 120)',
+    media_renditions   jsonb     NOT NULL DEFAULT '{}',
+    media_hash         bigint,
+    media_palette      jsonb     NOT NULL DEFAULT '[]',
+    media_color        int GENERATED ALWAYS AS (css_rgb(media_palette ->> 0)) STORED,
+    media_blurhash     varchar   NOT NULL DEFAULT '',
+    media_type         varchar   NOT NULL DEFAULT 'image',
+    media_width        int       NOT NULL DEFAULT 0,
+    media_height       int       NOT NULL DEFAULT 0,
+    media_duration_ms  int       NOT NULL DEFAULT 0,
     n_likes            int       NOT NULL DEFAULT 0,
-    author_id          int       NOT NULL REFERENCES users (id) ON DELETE CASCADE
+    promoted           boolean   NOT NULL DEFAULT false,
+    author_id          int       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    search_vector      tsvector GENERATED ALWAYS AS (
+                setweight(to_tsvector('russian', coalesce(title, '')), 'A') ||
+                setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+                setweight(to_tsvector('russian', coalesce(description, '')), 'B') ||
+                setweight(to_tsvector('simple', coalesce(description, '')), 'B')) STORED
 );
 
 CREATE TABLE IF NOT EXISTS pin_likes
@@ -54,7 +93,7 @@ CREATE TABLE IF NOT EXISTS boards_pins
 CREATE TABLE IF NOT EXISTS comments
 (
     id         serial    NOT NULL PRIMARY KEY,
-    author_id  int       NOT NULL REFERENCES users (id),
+    author_id  int       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
     pin_id     int       NOT NULL REFERENCES pins (id) ON DELETE CASCADE,
     text       text      NOT NULL,
     created_at timestamp NOT NULL DEFAULT now()
@@ -89,7 +128,7 @@ CREATE TABLE IF NOT EXISTS chats
 CREATE TABLE IF NOT EXISTS messages
 (
     id         serial    NOT NULL PRIMARY KEY,
-    author_id  int       NOT NULL REFERENCES users (id),
+    author_id  int       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
     chat_id    int       NOT NULL REFERENCES chats (id) ON DELETE CASCADE,
     text       text      NOT NULL,
     created_at timestamp NOT NULL DEFAULT now()
@@ -98,7 +137,7 @@ CREATE TABLE IF NOT EXISTS messages
 CREATE TABLE IF NOT EXISTS notifications
 (
     id         serial            NOT NULL PRIMARY KEY,
-    user_id    int               NOT NULL REFERENCES users (id),
+    user_id    int               NOT NULL REFERENCES users (id) ON DELETE CASCADE,
     type       notification_type NOT NULL,
     is_read    boolean           NOT NULL DEFAULT false,
     created_at timestamp         NOT NULL DEFAULT now()
@@ -131,6 +170,235 @@ CREATE TABLE IF NOT EXISTS new_follower_notifications
     follower_id     int NOT NULL REFERENCES users (id) ON DELETE CASCADE
 );
 
+CREATE TABLE IF NOT EXISTS access_tokens
+(
+    id           serial      NOT NULL PRIMARY KEY,
+    user_id      int         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name         varchar(64) NOT NULL,
+    token_hash   char(64)    NOT NULL UNIQUE,
+    scopes       text[]      NOT NULL,
+    created_at   timestamp   NOT NULL DEFAULT now(),
+    last_used_at timestamp
+);
+
+CREATE TABLE IF NOT EXISTS account_deletions
+(
+    user_id      int       NOT NULL PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    requested_at timestamp NOT NULL DEFAULT now(),
+    delete_after timestamp NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS business_profiles
+(
+    user_id            int          NOT NULL PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    company_name       varchar(256) NOT NULL,
+    website_url        varchar,
+    website_verified   boolean      NOT NULL DEFAULT false,
+    verification_token varchar(64)  NOT NULL,
+    contact_email      text,
+    contact_phone      varchar(32),
+    created_at         timestamp    NOT NULL DEFAULT now()
+);
+
+-- Загруженные изображения, сборщик мусора удаляет те, на которые не ссылаются пины и профили
+CREATE TABLE IF NOT EXISTS images
+(
+    url         text      NOT NULL PRIMARY KEY,
+    original    text,
+    uploaded_at timestamp NOT NULL DEFAULT now()
+);
+
+-- Хештеги из названий и описаний пинов, заполняются триггером
+CREATE TABLE IF NOT EXISTS pin_tags
+(
+    pin_id int     NOT NULL REFERENCES pins (id) ON DELETE CASCADE,
+    tag    varchar NOT NULL,
+    PRIMARY KEY (pin_id, tag)
+);
+
+-- Число поисков по каждому запросу для подсказок, без пользователей, которые искали
+CREATE TABLE IF NOT EXISTS search_queries
+(
+    query            varchar   NOT NULL PRIMARY KEY,
+    n_searches       int       NOT NULL DEFAULT 1,
+    last_searched_at timestamp NOT NULL DEFAULT now()
+);
+
+-- Журнал поисков для популярных запросов за период, без пользователей; хранится за самый длинный период
+CREATE TABLE IF NOT EXISTS search_query_log
+(
+    query       varchar   NOT NULL,
+    searched_at timestamp NOT NULL DEFAULT now()
+);
+
+-- Недавние поиски пользователя, которые он может очистить
+CREATE TABLE IF NOT EXISTS recent_searches
+(
+    user_id     int       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    query       varchar   NOT NULL,
+    searched_at timestamp NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, query)
+);
+
+CREATE INDEX IF NOT EXISTS images_uploaded_at_idx ON images (uploaded_at);
+CREATE INDEX IF NOT EXISTS users_profile_image_idx ON users (profile_image);
+CREATE INDEX IF NOT EXISTS pins_media_source_idx ON pins (media_source);
+
+-- Продвигаемые пины (для баз, созданных до появления бизнес-аккаунтов)
+ALTER TABLE pins
+    ADD COLUMN IF NOT EXISTS promoted boolean NOT NULL DEFAULT false;
+
+-- Уменьшенные копии изображений пинов (у старых пинов только оригинал в media_source)
+ALTER TABLE pins
+    ADD COLUMN IF NOT EXISTS media_renditions jsonb NOT NULL DEFAULT '{}';
+
+-- Перцептивный хеш изображения пина для поиска дубликатов (у старых пинов отсутствует)
+ALTER TABLE pins
+    ADD COLUMN IF NOT EXISTS media_hash bigint;
+
+-- Поиск дубликатов сравнивает хеш только с последними изображениями
+CREATE INDEX IF NOT EXISTS pins_media_hash_created_at_idx ON pins (created_at DESC) INCLUDE (media_hash)
+    WHERE media_hash IS NOT NULL;
+
+-- Основные цвета и BlurHash-заглушка изображения пина (у старых пинов пустые)
+ALTER TABLE pins
+    ADD COLUMN IF NOT EXISTS media_palette jsonb NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS media_blurhash varchar NOT NULL DEFAULT '';
+
+-- Основной цвет изображения пина для поиска по цвету (первый цвет палитры)
+ALTER TABLE pins
+    ADD COLUMN IF NOT EXISTS media_color int GENERATED ALWAYS AS (css_rgb(media_palette ->> 0)) STORED;
+
+-- Тип медиа пина (image, gif, video), его размеры и длительность (у старых пинов размеры неизвестны)
+ALTER TABLE pins
+    ADD COLUMN IF NOT EXISTS media_type varchar NOT NULL DEFAULT 'image',
+    ADD COLUMN IF NOT EXISTS media_width int NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS media_height int NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS media_duration_ms int NOT NULL DEFAULT 0;
+
+-- Поисковые векторы: русская конфигурация нормализует русские и английские слова,
+-- simple сохраняет слова как есть (имена пользователей, стоп-слова)
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        to_tsvector('russian', username || ' ' || name) ||
+        to_tsvector('simple', username || ' ' || name)) STORED;
+
+ALTER TABLE boards
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', name), 'A') ||
+        setweight(to_tsvector('simple', name), 'A') ||
+        setweight(to_tsvector('russian', coalesce(description, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(description, '')), 'B')) STORED;
+
+ALTER TABLE pins
+    ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(description, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(description, '')), 'B')) STORED;
+
+-- Индексы полнотекстового поиска и поиска по подстроке
+CREATE INDEX IF NOT EXISTS users_search_vector_idx ON users USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS users_username_trgm_idx ON users USING gin (lower(username) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS users_name_trgm_idx ON users USING gin (lower(name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS boards_search_vector_idx ON boards USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS boards_name_trgm_idx ON boards USING gin (lower(name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS pins_search_vector_idx ON pins USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS pins_title_trgm_idx ON pins USING gin (lower(title) gin_trgm_ops);
+
+-- Индексы подсказок поиска по префиксу
+CREATE INDEX IF NOT EXISTS users_username_prefix_idx ON users (lower(username) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS boards_name_prefix_idx ON boards (lower(name) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS pin_tags_tag_idx ON pin_tags (tag text_pattern_ops);
+CREATE INDEX IF NOT EXISTS search_queries_query_idx ON search_queries (query text_pattern_ops);
+CREATE INDEX IF NOT EXISTS search_query_log_searched_at_idx ON search_query_log (searched_at);
+CREATE INDEX IF NOT EXISTS recent_searches_user_id_searched_at_idx ON recent_searches (user_id, searched_at);
+
+-- Словарь слов для исправления опечаток в запросах: слова пинов, досок и пользователей, доступных
+-- анонимным пользователям, чтобы не подсказывать слова секретных досок. Обновляется сервисом поиска
+CREATE MATERIALIZED VIEW IF NOT EXISTS search_words AS
+SELECT word
+FROM ts_stat($q$
+    SELECT to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(description, ''))
+    FROM pins
+    WHERE NOT EXISTS (SELECT 1
+                      FROM boards_pins bp
+                          JOIN boards b ON b.id = bp.board_id
+                      WHERE bp.pin_id = pins.id AND b.privacy = 'secret')
+       OR EXISTS (SELECT 1
+                  FROM boards_pins bp
+                      JOIN boards b ON b.id = bp.board_id
+                  WHERE bp.pin_id = pins.id AND b.privacy = 'public')
+    UNION ALL
+    SELECT to_tsvector('simple', name || ' ' || coalesce(description, ''))
+    FROM boards
+    WHERE privacy = 'public'
+    UNION ALL
+    SELECT to_tsvector('simple', username || ' ' || name)
+    FROM users
+$q$);
+
+CREATE UNIQUE INDEX IF NOT EXISTS search_words_word_idx ON search_words (word);
+CREATE INDEX IF NOT EXISTS search_words_word_trgm_idx ON search_words USING gin (word gin_trgm_ops);
+
+-- Удаление пользователя удаляет все его данные (для баз, созданных до появления ON DELETE CASCADE)
+ALTER TABLE comments
+    DROP CONSTRAINT IF EXISTS comments_author_id_fkey,
+    ADD CONSTRAINT comments_author_id_fkey FOREIGN KEY (author_id) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE messages
+    DROP CONSTRAINT IF EXISTS messages_author_id_fkey,
+    ADD CONSTRAINT messages_author_id_fkey FOREIGN KEY (author_id) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE notifications
+    DROP CONSTRAINT IF EXISTS notifications_user_id_fkey,
+    ADD CONSTRAINT notifications_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+
+-- Учёт изображений, загруженных до появления сборщика мусора
+INSERT INTO images (url)
+SELECT profile_image
+FROM users
+WHERE profile_image IS NOT NULL
+  AND profile_image <> ''
+  AND profile_image <> 'https://pickpin.hb.bizmrg.com/default-user-icon-8-4024862977'
+UNION
+SELECT media_source
+FROM pins
+ON CONFLICT (url) DO NOTHING;
+
+INSERT INTO images (url, original)
+SELECT rendition.value, pins.media_source
+FROM pins,
+     jsonb_each_text(pins.media_renditions) AS rendition
+ON CONFLICT (url) DO NOTHING;
+
+-- Пины секретных досок, сохранённые до появления подписанных ссылок: их изображения ещё публичные.
+-- Очередь заполняется один раз, при создании таблицы, и разбирается сервисом пинов при запуске
+DO
+$$
+BEGIN
+    IF to_regclass('media_privacy_backfill') IS NULL THEN
+        CREATE TABLE media_privacy_backfill
+        (
+            pin_id int NOT NULL PRIMARY KEY REFERENCES pins (id) ON DELETE CASCADE
+        );
+
+        INSERT INTO media_privacy_backfill (pin_id)
+        SELECT DISTINCT bp.pin_id
+        FROM boards_pins bp
+            JOIN boards b ON b.id = bp.board_id
+        WHERE b.privacy = 'secret';
+    END IF;
+END
+$$;
+
+-- Хештеги пинов, созданных до появления подсказок
+INSERT INTO pin_tags (pin_id, tag)
+SELECT DISTINCT pins.id, lower(tag[1])
+FROM pins,
+     regexp_matches(coalesce(pins.title, '') || ' ' || coalesce(pins.description, ''), '#(\w+)', 'g') AS tag
+ON CONFLICT DO NOTHING;
+
 -- Обработка создания лайка
 CREATE OR REPLACE FUNCTION on_pin_like() RETURNS TRIGGER AS
 $$
@@ -202,3 +470,79 @@ CREATE OR REPLACE TRIGGER new_follower_notification_delete
     ON new_follower_notifications
     FOR EACH ROW
 EXECUTE PROCEDURE on_specific_notification_delete();
+
+-- Обновление хештегов пина при изменении названия или описания
+CREATE OR REPLACE FUNCTION on_pin_text_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    DELETE
+    FROM pin_tags
+    WHERE pin_id = new.id;
+
+    INSERT INTO pin_tags (pin_id, tag)
+    SELECT DISTINCT new.id, lower(tag[1])
+    FROM regexp_matches(coalesce(new.title, '') || ' ' || coalesce(new.description, ''), '#(\w+)', 'g') AS tag;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER pin_text_change
+    AFTER INSERT OR UPDATE OF title, description
+    ON pins
+    FOR EACH ROW
+EXECUTE PROCEDURE on_pin_text_change();
+
+-- Оповещение встроенного поискового индекса об изменении пинов, досок и пользователей.
+-- Аргументы триггера: вид документа и столбец с его id
+CREATE OR REPLACE FUNCTION notify_search_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF tg_op <> 'INSERT' THEN
+        PERFORM pg_notify('search_changes', tg_argv[0] || ':' || (to_jsonb(old) ->> tg_argv[1]));
+    END IF;
+    IF tg_op <> 'DELETE' THEN
+        PERFORM pg_notify('search_changes', tg_argv[0] || ':' || (to_jsonb(new) ->> tg_argv[1]));
+    END IF;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER pin_search_change
+    AFTER INSERT OR UPDATE OF title, description, media_source, media_hash, media_palette, n_likes OR DELETE
+    ON pins
+    FOR EACH ROW
+EXECUTE PROCEDURE notify_search_change('pin', 'id');
+
+CREATE OR REPLACE TRIGGER board_search_change
+    AFTER INSERT OR UPDATE OF name, description, privacy OR DELETE
+    ON boards
+    FOR EACH ROW
+EXECUTE PROCEDURE notify_search_change('board', 'id');
+
+CREATE OR REPLACE TRIGGER user_search_change
+    AFTER INSERT OR UPDATE OF username, name, profile_image, website_url, account_type OR DELETE
+    ON users
+    FOR EACH ROW
+EXECUTE PROCEDURE notify_search_change('user', 'id');
+
+-- Доски пина определяют, кому он виден, а число пинов доски - её популярность
+CREATE OR REPLACE TRIGGER board_pin_search_change
+    AFTER INSERT OR DELETE
+    ON boards_pins
+    FOR EACH ROW
+EXECUTE PROCEDURE notify_search_change('pin', 'pin_id');
+
+CREATE OR REPLACE TRIGGER pin_board_search_change
+    AFTER INSERT OR DELETE
+    ON boards_pins
+    FOR EACH ROW
+EXECUTE PROCEDURE notify_search_change('board', 'board_id');
+
+-- Число подписчиков определяет популярность пользователя
+CREATE OR REPLACE TRIGGER following_search_change
+    AFTER INSERT OR DELETE
+    ON followings
+    FOR EACH ROW
+EXECUTE PROCEDURE notify_search_change('user', 'followee_id');